@@ -74,6 +74,22 @@ const docTemplate = `{
                 "summary": "Get audit records",
                 "operationId": "GetAllAudit",
                 "parameters": [
+                    {
+                        "enum": [
+                            "user",
+                            "service_account"
+                        ],
+                        "type": "string",
+                        "description": "Only changes made by users or by service accounts",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only changes made while a super admin impersonated the user",
+                        "name": "impersonated",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Page number",
@@ -85,6 +101,12 @@ const docTemplate = `{
                         "description": "Number of records per page",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, empty for the first page, to paginate by cursor instead of page number",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -305,6 +327,244 @@ const docTemplate = `{
                 }
             }
         },
+        "/dashboard/overdue-reviews": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [],
+                        "{}": []
+                    }
+                ],
+                "description": "Lists the active licenses and obligations whose review interval has passed since their last review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "Lists the licenses and obligations which are due for review",
+                "operationId": "GetOverdueReviews",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OverdueReviewResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Something went wrong",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/external-ref-fields": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [],
+                        "{}": []
+                    }
+                ],
+                "description": "Get the built in and runtime defined fields of the external_ref of licenses and obligations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "External Ref Fields"
+                ],
+                "summary": "Get external_ref fields",
+                "operationId": "GetExternalRefFields",
+                "parameters": [
+                    {
+                        "enum": [
+                            "LICENSE",
+                            "OBLIGATION"
+                        ],
+                        "type": "string",
+                        "description": "Only fields of licenses or obligations",
+                        "name": "entity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ExternalRefFieldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Unable to fetch external_ref fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Define a field of the external_ref of licenses or obligations. Enum values and a regex only apply to\nstring fields. Values written to the external_ref are validated against its fields.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "External Ref Fields"
+                ],
+                "summary": "Create an external_ref field",
+                "operationId": "CreateExternalRefField",
+                "parameters": [
+                    {
+                        "description": "Field to define",
+                        "name": "field",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ExternalRefFieldCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ExternalRefFieldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Field already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/external-ref-fields/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a runtime defined external_ref field. Values already stored under its key are kept, but can no\nlonger be written. The field is kept for its audits.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "External Ref Fields"
+                ],
+                "summary": "Delete an external_ref field",
+                "operationId": "DeleteExternalRefField",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "No field with given id found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the label, required flag, enum values or regex of a runtime defined external_ref field. Values\nalready stored are not revalidated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "External Ref Fields"
+                ],
+                "summary": "Update an external_ref field",
+                "operationId": "UpdateExternalRefField",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "field",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ExternalRefFieldUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ExternalRefFieldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No field with given id found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
         "/health": {
             "get": {
                 "description": "Check health of the service",
@@ -394,10 +654,22 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "External reference parameters",
+                        "description": "Cursor of the page, empty for the first page, to paginate by cursor instead of page number",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object of external_ref keys and their value or conditions on it (eq, ne, lt, lte, gt, gte, in, contains, exists)",
                         "name": "externalRef",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. risk=ge=3;(source=in=(spdx,fossology),fullname=like=GNU)",
+                        "name": "filter",
+                        "in": "query"
+                    },
                     {
                         "enum": [
                             "spdx_id",
@@ -420,11 +692,24 @@ const docTemplate = `{
                         "description": "Asc or desc ordering",
                         "name": "order_by",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated keys of the licenses to respond with, e.g. shortname,spdx_id,risk",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "user",
+                        "description": "Comma separated relations to include, obligations and user",
+                        "name": "expand",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Filtered licenses",
+                        "description": "Filtered licenses, with only the requested keys if fields or expand is given",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseResponse"
                         }
@@ -443,7 +728,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new license in the service",
+                "description": "Create a new license in the service. Licenses whose text is similar to the text of an active license,\nas set by DUPLICATE_CHECK_THRESHOLD, are only created with force=true.",
                 "consumes": [
                     "application/json"
                 ],
@@ -464,6 +749,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.LicenseCreateDTO"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Create the license even if its text duplicates an active license",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -479,6 +770,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
+                    "404": {
+                        "description": "Obligations not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Text duplicates active licenses",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseDuplicateError"
+                        }
+                    },
                     "500": {
                         "description": "Failed to create license",
                         "schema": {
@@ -488,35 +791,175 @@ const docTemplate = `{
                 }
             }
         },
-        "/licenses/export": {
+        "/licenses/duplicates": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": [],
-                        "{}": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Export all licenses as a json file",
+                "description": "Cluster the active licenses whose texts have at least the given trigram similarity, directly or through\nother licenses of the cluster. Each cluster suggests the license to merge the others into.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Licenses"
                 ],
-                "summary": "Export all licenses as a json file",
-                "operationId": "ExportLicenses",
+                "summary": "Report duplicate licenses",
+                "operationId": "GetDuplicateLicenses",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Minimum similarity of two texts, between 0 and 1, defaults to 0.9",
+                        "name": "threshold",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.LicenseResponseDTO"
-                            }
+                            "$ref": "#/definitions/models.DuplicateClustersResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to fetch Licenses",
+                    "400": {
+                        "description": "Invalid threshold",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to find duplicates",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/licenses/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [],
+                        "{}": []
+                    }
+                ],
+                "description": "Export all licenses as a json file",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licenses"
+                ],
+                "summary": "Export all licenses as a json file",
+                "operationId": "ExportLicenses",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LicenseResponseDTO"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch Licenses",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/licenses/external-ref-schema": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [],
+                        "{}": []
+                    }
+                ],
+                "description": "Get the JSON Schema of the external_ref of licenses, for rendering forms of its fields",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licenses"
+                ],
+                "summary": "Get the external_ref schema of licenses",
+                "operationId": "GetLicenseExternalRefSchema",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ExternalRefSchema"
+                        }
+                    },
+                    "500": {
+                        "description": "Unable to fetch external_ref fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/licenses/identify": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Identifies the licenses in a text following the SPDX License Matching Guidelines. Case, punctuation,\nwhitespace, bullets, copyright notices, license titles and varietal spellings are ignored, and the\nreplaceable and optional sections of SPDX license templates are honored. Returns the licenses\nwhose text is found exactly, and the ones whose text is covered at least min_coverage percent,\nalong with the portions of the input text which matched them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licenses"
+                ],
+                "summary": "Identify licenses in a text",
+                "operationId": "IdentifyLicense",
+                "parameters": [
+                    {
+                        "description": "Text to identify the licenses in",
+                        "name": "text",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.IdentifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseIdentificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "413": {
+                        "description": "Text is too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Unable to identify licenses",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -640,6 +1083,67 @@ const docTemplate = `{
                 }
             }
         },
+        "/licenses/resolve": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [],
+                        "{}": []
+                    }
+                ],
+                "description": "Find the license known under a name. Active licenses are matched by SPDX id, then by shortname and\nthen by alias, ignoring case. Inactive licenses are matched by SPDX id and shortname last. The\nnamespace restricts the aliases considered.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licenses"
+                ],
+                "summary": "Resolve a license name",
+                "operationId": "ResolveLicense",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "License name, SPDX id or alias",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "SPDX",
+                            "FOSSOLOGY",
+                            "SCANCODE",
+                            "VENDOR",
+                            "MERGED"
+                        ],
+                        "type": "string",
+                        "description": "Namespace of the alias",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseResolutionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid name or namespace",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No license known under the name",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
         "/licenses/similarity": {
             "post": {
                 "security": [
@@ -647,7 +1151,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Compares input license text with existing ones using pg_trgm similarity",
+                "description": "Returns the licenses whose text is similar to the input using pg_trgm, the most similar first.\nThe threshold defaults to SIMILARITY_THRESHOLD and all matches are returned unless a limit is given.",
                 "consumes": [
                     "application/json"
                 ],
@@ -657,7 +1161,7 @@ const docTemplate = `{
                 "tags": [
                     "Licenses"
                 ],
-                "summary": "Find similar license texts",
+                "summary": "Find similar licenses",
                 "operationId": "getSimilarLicense",
                 "parameters": [
                     {
@@ -722,6 +1226,12 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a version of the license the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -729,8 +1239,17 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the license"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "License not modified"
+                    },
                     "404": {
                         "description": "License with id not found",
                         "schema": {
@@ -773,6 +1292,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.LicenseUpdateDTO"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the license was read with, required if REQUIRE_IF_MATCH is set",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -780,6 +1305,12 @@ const docTemplate = `{
                         "description": "License updated successfully",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the license"
+                            }
                         }
                     },
                     "400": {
@@ -788,12 +1319,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
+                    "403": {
+                        "description": "License is stewarded by a team of other users",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
                     "404": {
                         "description": "License with id not found",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
+                    "412": {
+                        "description": "License was changed since it was read",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
                     "500": {
                         "description": "Failed to update license",
                         "schema": {
@@ -803,56 +1352,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Login to get JWT token",
-                "consumes": [
-                    "application/json"
+        "/licenses/{id}/aliases": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [],
+                        "{}": []
+                    }
                 ],
+                "description": "Get the alternative names under which a license is known",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Licenses"
                 ],
-                "summary": "Login",
-                "operationId": "Login",
+                "summary": "Get the aliases of a license",
+                "operationId": "GetLicenseAliases",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserLogin"
-                        }
+                        "type": "string",
+                        "description": "Id of the license",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "JWT token",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TokenResponse"
+                            "$ref": "#/definitions/models.LicenseAliasesResponse"
                         }
                     },
-                    "401": {
-                        "description": "Incorrect username or password",
+                    "400": {
+                        "description": "Invalid license id",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No license found",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            }
-        },
-        "/obligations": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
-                        "ApiKeyAuth": [],
-                        "{}": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get all active obligations from the service",
+                "description": "Add an alternative name of a license. An alias names a single license within its namespace.",
                 "consumes": [
                     "application/json"
                 ],
@@ -860,123 +1413,125 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Obligations"
+                    "Licenses"
                 ],
-                "summary": "Get all active obligations",
-                "operationId": "GetAllObligation",
+                "summary": "Add an alias to a license",
+                "operationId": "CreateLicenseAlias",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Active obligation only",
-                        "name": "active",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Id of the license",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of records per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "default": "asc",
-                        "description": "Asc or desc ordering",
-                        "name": "order_by",
-                        "in": "query"
+                        "description": "Alias to add",
+                        "name": "alias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseAliasInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationResponse"
+                            "$ref": "#/definitions/models.LicenseAliasesResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid active value",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "License is stewarded by another team",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No license found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Alias already names a license",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/licenses/{id}/aliases/{alias_id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create an obligation and associate it with licenses",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove an alternative name of a license",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Obligations"
+                    "Licenses"
                 ],
-                "summary": "Create an obligation",
-                "operationId": "CreateObligation",
+                "summary": "Remove an alias of a license",
+                "operationId": "DeleteLicenseAlias",
                 "parameters": [
                     {
-                        "description": "Obligation to create",
-                        "name": "obligation",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ObligationCreateDTO"
-                        }
+                        "type": "string",
+                        "description": "Id of the license",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the alias",
+                        "name": "alias_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid license id",
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationResponse"
+                            "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "400": {
-                        "description": "Bad request body",
+                    "403": {
+                        "description": "License is stewarded by another team",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "500": {
-                        "description": "Unable to create obligation",
+                    "404": {
+                        "description": "No license or alias found",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            }
-        },
-        "/obligations/categories": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
-                        "ApiKeyAuth": [],
-                        "{}": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get all active obligation categories from the service",
+                "description": "Update the name or namespace of an alias of a license",
                 "consumes": [
                     "application/json"
                 ],
@@ -984,47 +1539,77 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Obligations"
+                    "Licenses"
                 ],
-                "summary": "Get all active obligation categories",
-                "operationId": "GetAllObligationCategories",
+                "summary": "Update an alias of a license",
+                "operationId": "UpdateLicenseAlias",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Active obligation categories only",
-                        "name": "active",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Id of the license",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the alias",
+                        "name": "alias_id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Alias fields to update",
+                        "name": "alias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseAliasUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationCategoryResponse"
+                            "$ref": "#/definitions/models.LicenseAliasesResponse"
                         }
                     },
                     "400": {
-                        "description": "Unable to fetch obligation categories",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "500": {
-                        "description": "Unable to fetch obligation categories",
+                    "403": {
+                        "description": "License is stewarded by another team",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No license or alias found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Alias already names a license",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/licenses/{id}/merge": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create an obligation category",
+                "description": "Merge a duplicate into the license, which survives. The obligations of the duplicate are linked to the\nsurvivor, the audits and aliases of the duplicate are moved to it, the shortname and SPDX id of the\nduplicate are recorded as aliases of the survivor in the MERGED namespace and the duplicate is deactivated.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1032,42 +1617,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Obligations"
+                    "Licenses"
                 ],
-                "summary": "Create an obligation category",
-                "operationId": "CreateObligationCategory",
+                "summary": "Merge a duplicate into a license",
+                "operationId": "MergeLicense",
                 "parameters": [
                     {
-                        "description": "Obligation category to create",
-                        "name": "obligation_category",
+                        "type": "string",
+                        "description": "Id of the surviving license",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Duplicate to merge",
+                        "name": "merge",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationCategory"
+                            "$ref": "#/definitions/models.LicenseMergeInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationCategoryResponse"
+                            "$ref": "#/definitions/models.LicenseResponse"
                         }
                     },
                     "400": {
-                        "description": "invalid json body",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "409": {
-                        "description": "obligation category already exists",
+                    "403": {
+                        "description": "License is stewarded by another team",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No license found",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
                     "500": {
-                        "description": "something went wrong while creating new obligation category",
+                        "description": "Failed to merge licenses",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1075,14 +1673,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/obligations/categories/{category}": {
-            "delete": {
+        "/licenses/{id}/review": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deactivate an obligation category",
+                "description": "Record that a license was reviewed, which restarts its review interval",
                 "consumes": [
                     "application/json"
                 ],
@@ -1090,37 +1688,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Obligations"
+                    "Licenses"
                 ],
-                "summary": "Deactivate obligation category",
-                "operationId": "DeleteObligationCategory",
+                "summary": "Review a license",
+                "operationId": "ReviewLicense",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Obligation Category",
-                        "name": "category",
+                        "description": "Id of the license",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Review comment",
+                        "name": "review",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseResponse"
+                        }
                     },
                     "400": {
-                        "description": "cannot delete obligation category 'DISTRIBUTION' as it's still referenced by some obligations",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "404": {
-                        "description": "obligation category 'DISTRIBUTION' not found",
+                    "403": {
+                        "description": "License is stewarded by another team",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "500": {
-                        "description": "something went wrong while deleting obligation category",
+                    "404": {
+                        "description": "No license found",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1128,15 +1737,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/obligations/classifications": {
-            "get": {
+        "/licenses/{id}/steward": {
+            "put": {
                 "security": [
                     {
-                        "ApiKeyAuth": [],
-                        "{}": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get all active obligation classifications from the service",
+                "description": "Assign the team stewarding a license, or remove it with a null team",
                 "consumes": [
                     "application/json"
                 ],
@@ -1144,41 +1752,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Obligations"
+                    "Licenses"
                 ],
-                "summary": "Get all active obligation classifications",
-                "operationId": "GetAllObligationClassification",
+                "summary": "Assign the steward team of a license",
+                "operationId": "SetLicenseSteward",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Active obligation classification only",
-                        "name": "active",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Id of the license",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Steward team",
+                        "name": "steward",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StewardAssignment"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationClassificationResponse"
+                            "$ref": "#/definitions/models.LicenseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
                         }
                     },
                     "404": {
-                        "description": "No obligation classifications in DB",
+                        "description": "No license or team found",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/login": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Create an obligation classification",
+                "description": "Login to get JWT token. If the account has two-factor authentication enabled, or its user level\nrequires it, an mfa token is returned instead which has to be completed at /login/2fa.\nIf LDAP login is enabled, users without a local password log in with their directory credentials.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1186,42 +1806,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Obligations"
+                    "Users"
                 ],
-                "summary": "Create an obligation classification",
-                "operationId": "CreateObligationClassification",
+                "summary": "Login",
+                "operationId": "Login",
                 "parameters": [
                     {
-                        "description": "Obligation classification to create",
-                        "name": "obligation_classification",
+                        "description": "Login credentials",
+                        "name": "user",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationClassification"
+                            "$ref": "#/definitions/models.UserLogin"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "JWT token",
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationClassificationResponse"
+                            "$ref": "#/definitions/models.TokenResponse"
                         }
                     },
-                    "400": {
-                        "description": "invalid json body",
+                    "202": {
+                        "description": "Second factor required",
                         "schema": {
-                            "$ref": "#/definitions/models.LicenseError"
+                            "$ref": "#/definitions/models.TwoFactorChallengeResponse"
                         }
                     },
-                    "409": {
-                        "description": "obligation classification already exists",
+                    "401": {
+                        "description": "Incorrect username or password",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
                     "500": {
-                        "description": "something went wrong while creating new obligation classification",
+                        "description": "Failed to authenticate with the ldap directory",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1229,14 +1849,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/obligations/classifications/{classification}": {
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Deactivate an obligation classification",
+        "/login/2fa": {
+            "post": {
+                "description": "Exchange the mfa token returned by login and a TOTP or recovery code for JWT tokens.\nIf the login required an enrollment, the code must come from the newly enrolled secret\nand the response additionally lists the recovery codes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1244,73 +1859,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Obligations"
+                    "Users"
                 ],
-                "summary": "Deactivate obligation classification",
-                "operationId": "DeleteObligationClassification",
+                "summary": "Complete login with second factor",
+                "operationId": "LoginTwoFactor",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Obligation Classification",
-                        "name": "classification",
-                        "in": "path",
-                        "required": true
+                        "description": "MFA token and code",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorLogin"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
-                    },
-                    "400": {
-                        "description": "cannot delete obligation classification 'GREEN' as it's still referenced by some obligations",
-                        "schema": {
-                            "$ref": "#/definitions/models.LicenseError"
-                        }
-                    },
-                    "404": {
-                        "description": "obligation classification 'GREEN' not found",
+                        "description": "JWT token",
                         "schema": {
-                            "$ref": "#/definitions/models.LicenseError"
+                            "$ref": "#/definitions/models.TwoFactorLoginResponse"
                         }
                     },
-                    "500": {
-                        "description": "something went wrong while deleting obligation classification",
+                    "400": {
+                        "description": "Invalid json body",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
-                    }
-                }
-            }
-        },
-        "/obligations/export": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": [],
-                        "{}": []
-                    }
-                ],
-                "description": "Export all obligations as a json file",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Obligations"
-                ],
-                "summary": "Export all obligations as a json file",
-                "operationId": "ExportObligations",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ObligationResponseDTO"
-                            }
-                        }
                     },
-                    "500": {
-                        "description": "Failed to fetch obligations",
+                    "401": {
+                        "description": "Invalid token or code",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1318,64 +1896,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/obligations/import": {
+        "/login/2fa/enroll": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Import obligations by uploading a json file",
+                "description": "Generate a TOTP secret for a user whose login requires an enrollment. Complete the login at /login/2fa.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Obligations"
+                    "Users"
                 ],
-                "summary": "Import obligations by uploading a json file",
-                "operationId": "ImportObligations",
+                "summary": "Enroll second factor during login",
+                "operationId": "LoginTwoFactorEnroll",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "obligations json file list",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "MFA token",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorLoginEnroll"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "TOTP secret",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.ImportObligationsResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ObligationImportStatus"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.TwoFactorEnrollmentResponse"
                         }
                     },
                     "400": {
-                        "description": "input file must be present",
+                        "description": "Invalid json body",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Invalid token",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1383,7 +1943,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/obligations/preview": {
+        "/obligations": {
             "get": {
                 "security": [
                     {
@@ -1391,7 +1951,7 @@ const docTemplate = `{
                         "{}": []
                     }
                 ],
-                "description": "Get topic and type of all active obligations from the service",
+                "description": "Get all active obligations from the service",
                 "consumes": [
                     "application/json"
                 ],
@@ -1401,35 +1961,97 @@ const docTemplate = `{
                 "tags": [
                     "Obligations"
                 ],
-                "summary": "Get topic and types of all active obligations",
-                "operationId": "GetAllObligationPreviews",
+                "summary": "Get all active obligations",
+                "operationId": "GetAllObligation",
                 "parameters": [
                     {
                         "type": "boolean",
-                        "description": "Active obligation only",
+                        "description": "Active obligations only, true unless given empty or the filter has a condition on active",
                         "name": "active",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. classification=in=(RED,ORANGE);license.spdx_id==MIT",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object of external_ref keys and their value or conditions on it (eq, ne, lt, lte, gt, gte, in, contains, exists)",
+                        "name": "externalRef",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, empty for the first page, to paginate by cursor instead of page number",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Asc or desc ordering",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated keys of the obligations to respond with, e.g. topic,classification",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated relations to include, licenses",
+                        "name": "expand",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Obligations, with only the requested keys if fields or expand is given",
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationPreviewResponse"
+                            "$ref": "#/definitions/models.ObligationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid active value",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            }
-        },
-        "/obligations/similarity": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns the top 5 obligations with text similar to the input using pg_trgm",
+                "description": "Create an obligation and associate it with licenses. Obligations whose text is similar to the text of an\nactive obligation, as set by DUPLICATE_CHECK_THRESHOLD, are only created with force=true.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1439,37 +2061,52 @@ const docTemplate = `{
                 "tags": [
                     "Obligations"
                 ],
-                "summary": "Find similar obligations",
-                "operationId": "getSimilarObligation",
+                "summary": "Create an obligation",
+                "operationId": "CreateObligation",
                 "parameters": [
                     {
-                        "description": "Text to compare against stored obligations",
+                        "description": "Obligation to create",
                         "name": "obligation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.SimilarityRequest"
+                            "$ref": "#/definitions/models.ObligationCreateDTO"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Create the obligation even if its text duplicates an active obligation",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Similar obligations found",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.SimilarObligation"
-                            }
+                            "$ref": "#/definitions/models.ObligationResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input or database query failure",
+                        "description": "Bad request body",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
+                    "404": {
+                        "description": "Licenses not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Text duplicates active obligations",
+                        "schema": {
+                            "$ref": "#/definitions/models.ObligationDuplicateError"
+                        }
+                    },
                     "500": {
-                        "description": "Unexpected server error",
+                        "description": "Unable to create obligation",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1477,7 +2114,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/obligations/types": {
+        "/obligations/categories": {
             "get": {
                 "security": [
                     {
@@ -1485,7 +2122,7 @@ const docTemplate = `{
                         "{}": []
                     }
                 ],
-                "description": "Get all active obligation types from the service",
+                "description": "Get all active obligation categories from the service",
                 "consumes": [
                     "application/json"
                 ],
@@ -1495,12 +2132,12 @@ const docTemplate = `{
                 "tags": [
                     "Obligations"
                 ],
-                "summary": "Get all active obligation types",
-                "operationId": "GetAllObligationType",
+                "summary": "Get all active obligation categories",
+                "operationId": "GetAllObligationCategories",
                 "parameters": [
                     {
                         "type": "boolean",
-                        "description": "Active obligation type only",
+                        "description": "Active obligation categories only",
                         "name": "active",
                         "in": "query",
                         "required": true
@@ -1510,11 +2147,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationTypeResponse"
+                            "$ref": "#/definitions/models.ObligationCategoryResponse"
                         }
                     },
-                    "404": {
-                        "description": "No obligation types in DB",
+                    "400": {
+                        "description": "Unable to fetch obligation categories",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Unable to fetch obligation categories",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1527,7 +2170,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create an obligation type",
+                "description": "Create an obligation category",
                 "consumes": [
                     "application/json"
                 ],
@@ -1537,16 +2180,16 @@ const docTemplate = `{
                 "tags": [
                     "Obligations"
                 ],
-                "summary": "Create an obligation type",
-                "operationId": "CreateObligationType",
+                "summary": "Create an obligation category",
+                "operationId": "CreateObligationCategory",
                 "parameters": [
                     {
-                        "description": "Obligation type to create",
-                        "name": "obligation_type",
+                        "description": "Obligation category to create",
+                        "name": "obligation_category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationType"
+                            "$ref": "#/definitions/models.ObligationCategory"
                         }
                     }
                 ],
@@ -1554,7 +2197,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationTypeResponse"
+                            "$ref": "#/definitions/models.ObligationCategoryResponse"
                         }
                     },
                     "400": {
@@ -1564,13 +2207,13 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "obligation type already exists",
+                        "description": "obligation category already exists",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
                     "500": {
-                        "description": "something went wrong while creating new obligation type",
+                        "description": "something went wrong while creating new obligation category",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1578,14 +2221,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/obligations/types/{type}": {
+        "/obligations/categories/{category}": {
             "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deactivate an obligation type",
+                "description": "Deactivate an obligation category",
                 "consumes": [
                     "application/json"
                 ],
@@ -1595,15 +2238,21 @@ const docTemplate = `{
                 "tags": [
                     "Obligations"
                 ],
-                "summary": "Deactivate obligation type",
-                "operationId": "DeleteObligationType",
+                "summary": "Deactivate obligation category",
+                "operationId": "DeleteObligationCategory",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Obligation Type",
-                        "name": "type",
+                        "description": "Obligation Category",
+                        "name": "category",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version of the obligation category in its listing, required if REQUIRE_IF_MATCH is set",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1611,19 +2260,31 @@ const docTemplate = `{
                         "description": "OK"
                     },
                     "400": {
-                        "description": "cannot delete obligation type 'RISK' as it's still referenced by some obligations",
+                        "description": "cannot delete obligation category 'DISTRIBUTION' as it's still referenced by some obligations",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
                     "404": {
-                        "description": "obligation type 'RISK' not found",
+                        "description": "obligation category 'DISTRIBUTION' not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "412": {
+                        "description": "obligation category was changed since it was read",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
                     "500": {
-                        "description": "something went wrong while deleting obligation type",
+                        "description": "something went wrong while deleting obligation category",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1631,7 +2292,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/obligations/{id}": {
+        "/obligations/classifications": {
             "get": {
                 "security": [
                     {
@@ -1639,7 +2300,7 @@ const docTemplate = `{
                         "{}": []
                     }
                 ],
-                "description": "Get an active based on given id",
+                "description": "Get all active obligation classifications from the service",
                 "consumes": [
                     "application/json"
                 ],
@@ -1649,14 +2310,14 @@ const docTemplate = `{
                 "tags": [
                     "Obligations"
                 ],
-                "summary": "Get an obligation",
-                "operationId": "GetObligation",
+                "summary": "Get all active obligation classifications",
+                "operationId": "GetAllObligationClassification",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Id of the obligation",
-                        "name": "id",
-                        "in": "path",
+                        "type": "boolean",
+                        "description": "Active obligation classification only",
+                        "name": "active",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -1664,24 +2325,24 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ObligationResponse"
+                            "$ref": "#/definitions/models.ObligationClassificationResponse"
                         }
                     },
                     "404": {
-                        "description": "No obligation with given id found",
+                        "description": "No obligation classifications in DB",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deactivate an obligation",
+                "description": "Create an obligation classification",
                 "consumes": [
                     "application/json"
                 ],
@@ -1691,36 +2352,55 @@ const docTemplate = `{
                 "tags": [
                     "Obligations"
                 ],
-                "summary": "Deactivate obligation",
-                "operationId": "DeleteObligation",
+                "summary": "Create an obligation classification",
+                "operationId": "CreateObligationClassification",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Id of the obligation to be updated",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Obligation classification to create",
+                        "name": "obligation_classification",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ObligationClassification"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ObligationClassificationResponse"
+                        }
                     },
-                    "404": {
-                        "description": "No obligation with given id found",
+                    "400": {
+                        "description": "invalid json body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "obligation classification already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "something went wrong while creating new obligation classification",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/obligations/classifications/{classification}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing obligation record",
+                "description": "Deactivate an obligation classification",
                 "consumes": [
                     "application/json"
                 ],
@@ -1730,47 +2410,53 @@ const docTemplate = `{
                 "tags": [
                     "Obligations"
                 ],
-                "summary": "Update obligation",
-                "operationId": "UpdateObligation",
+                "summary": "Deactivate obligation classification",
+                "operationId": "DeleteObligationClassification",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of the obligation to be updated",
-                        "name": "id",
+                        "description": "Obligation Classification",
+                        "name": "classification",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Obligation to be updated",
-                        "name": "obligation",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ObligationUpdateDTO"
-                        }
+                        "type": "string",
+                        "description": "ETag of the version of the obligation classification in its listing, required if REQUIRE_IF_MATCH is set",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.ObligationResponse"
-                        }
+                        "description": "OK"
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "cannot delete obligation classification 'GREEN' as it's still referenced by some obligations",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
                     "404": {
-                        "description": "No obligation with given id found",
+                        "description": "obligation classification 'GREEN' not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "412": {
+                        "description": "obligation classification was changed since it was read",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
                     "500": {
-                        "description": "Unable to update obligation",
+                        "description": "something went wrong while deleting obligation classification",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1778,44 +2464,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/obligations/{id}/audits": {
+        "/obligations/duplicates": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": [],
-                        "{}": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Fetches audits corresponding to an obligation",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Cluster the active obligations whose texts have at least the given trigram similarity, directly or through\nother obligations of the cluster.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Obligations"
                 ],
-                "summary": "Fetches audits corresponding to an obligation",
-                "operationId": "GetObligationAudits",
+                "summary": "Report duplicate obligations",
+                "operationId": "GetDuplicateObligations",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Id of the obligation for which audits need to be fetched",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of records per page",
-                        "name": "limit",
+                        "type": "number",
+                        "description": "Minimum similarity of two texts, between 0 and 1, defaults to 0.9",
+                        "name": "threshold",
                         "in": "query"
                     }
                 ],
@@ -1823,17 +2492,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AuditResponse"
+                            "$ref": "#/definitions/models.DuplicateClustersResponse"
                         }
                     },
-                    "404": {
-                        "description": "No obligation with given id found",
+                    "400": {
+                        "description": "Invalid threshold",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
                     "500": {
-                        "description": "unable to find audits with such obligation id",
+                        "description": "Failed to find duplicates",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1841,44 +2510,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/oidcClients": {
+        "/obligations/export": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "ApiKeyAuth": [],
+                        "{}": []
                     }
                 ],
-                "description": "Get all oidc clients added by the user for initiating M2M flow with fossology",
+                "description": "Export all obligations as a json file",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OIDC Clients"
+                    "Obligations"
                 ],
-                "summary": "Get all oidc clients added by the user",
-                "operationId": "GetUserOidcClients",
+                "summary": "Export all obligations as a json file",
+                "operationId": "ExportObligations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.OidcClientsResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ObligationResponseDTO"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Something went wrong",
+                        "description": "Failed to fetch obligations",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/obligations/external-ref-schema": {
+            "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "ApiKeyAuth": [],
+                        "{}": []
                     }
                 ],
-                "description": "Add a new client for initiating M2M flow with fossology",
+                "description": "Get the JSON Schema of the external_ref of obligations, for rendering forms of its fields",
                 "consumes": [
                     "application/json"
                 ],
@@ -1886,83 +2562,84 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "OIDC Clients"
-                ],
-                "summary": "Adds a new oidc client",
-                "operationId": "CreateOidcClient",
-                "parameters": [
-                    {
-                        "description": "Oidc client to add",
-                        "name": "oidc_client",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateDeleteOidcClientDTO"
-                        }
-                    }
+                    "Obligations"
                 ],
+                "summary": "Get the external_ref schema of obligations",
+                "operationId": "GetObligationExternalRefSchema",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.OidcClientsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "invalid json body",
-                        "schema": {
-                            "$ref": "#/definitions/models.LicenseError"
-                        }
-                    },
-                    "409": {
-                        "description": "oidc client already exists",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.LicenseError"
+                            "$ref": "#/definitions/models.ExternalRefSchema"
                         }
                     },
                     "500": {
-                        "description": "something went wrong while adding new oidc client",
+                        "description": "Unable to fetch external_ref fields",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/obligations/import": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Remove an oidc client if it gets expired or is compromised",
+                "description": "Import obligations by uploading a json file",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OIDC Clients"
+                    "Obligations"
                 ],
-                "summary": "Remove an oidc client",
-                "operationId": "RevokeClient",
+                "summary": "Import obligations by uploading a json file",
+                "operationId": "ImportObligations",
                 "parameters": [
                     {
-                        "description": "Oidc client to add",
-                        "name": "oidc_client",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateDeleteOidcClientDTO"
-                        }
+                        "type": "file",
+                        "description": "obligations json file list",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ImportObligationsResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ObligationImportStatus"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    "404": {
-                        "description": "Oidc Client not found",
+                    "400": {
+                        "description": "input file must be present",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -1970,9 +2647,15 @@ const docTemplate = `{
                 }
             }
         },
-        "/refresh-token": {
-            "post": {
-                "description": "verify refresh token and get new access token",
+        "/obligations/preview": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [],
+                        "{}": []
+                    }
+                ],
+                "description": "Get topic and type of all active obligations from the service",
                 "consumes": [
                     "application/json"
                 ],
@@ -1980,46 +2663,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Obligations"
                 ],
-                "summary": "Verify refresh token",
-                "operationId": "RefreshToken",
+                "summary": "Get topic and types of all active obligations",
+                "operationId": "GetAllObligationPreviews",
                 "parameters": [
                     {
-                        "description": "Refresh token payload",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.RefreshToken"
-                        }
+                        "type": "boolean",
+                        "description": "Active obligation only",
+                        "name": "active",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "JWT token",
-                        "schema": {
-                            "$ref": "#/definitions/models.TokenResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Invalid or expired refresh token",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.LicenseError"
+                            "$ref": "#/definitions/models.ObligationPreviewResponse"
                         }
                     }
                 }
             }
         },
-        "/search": {
+        "/obligations/similarity": {
             "post": {
                 "security": [
                     {
-                        "ApiKeyAuth": [],
-                        "{}": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Search licenses on different filters and algorithms",
+                "description": "Returns the obligations whose text is similar to the input using pg_trgm, the most similar first.\nThe threshold defaults to SIMILARITY_THRESHOLD and all matches are returned unless a limit is given.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2027,36 +2701,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Licenses"
+                    "Obligations"
                 ],
-                "summary": "Search licenses",
-                "operationId": "SearchInLicense",
+                "summary": "Find similar obligations",
+                "operationId": "getSimilarObligation",
                 "parameters": [
                     {
-                        "description": "Search criteria",
-                        "name": "search",
+                        "description": "Text to compare against stored obligations",
+                        "name": "obligation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.SearchLicense"
+                            "$ref": "#/definitions/models.SimilarityRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Licenses matched",
+                        "description": "Similar obligations found",
                         "schema": {
-                            "$ref": "#/definitions/models.LicenseResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SimilarObligation"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid input or database query failure",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "404": {
-                        "description": "Search algorithm doesn't exist",
+                    "500": {
+                        "description": "Unexpected server error",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -2064,14 +2741,15 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/obligations/types": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "ApiKeyAuth": [],
+                        "{}": []
                     }
                 ],
-                "description": "Get all service users",
+                "description": "Get all active obligation types from the service",
                 "consumes": [
                     "application/json"
                 ],
@@ -2079,39 +2757,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Obligations"
                 ],
-                "summary": "Get users",
-                "operationId": "GetAllUsers",
+                "summary": "Get all active obligation types",
+                "operationId": "GetAllObligationType",
                 "parameters": [
                     {
                         "type": "boolean",
-                        "description": "Active user only",
+                        "description": "Active obligation type only",
                         "name": "active",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of records per page",
-                        "name": "limit",
-                        "in": "query"
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserResponse"
+                            "$ref": "#/definitions/models.ObligationTypeResponse"
                         }
                     },
                     "404": {
-                        "description": "Users not found",
+                        "description": "No obligation types in DB",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -2124,7 +2791,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new service user",
+                "description": "Create an obligation type",
                 "consumes": [
                     "application/json"
                 ],
@@ -2132,18 +2799,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Obligations"
                 ],
-                "summary": "Create new user",
-                "operationId": "CreateUser",
+                "summary": "Create an obligation type",
+                "operationId": "CreateObligationType",
                 "parameters": [
                     {
-                        "description": "User to create",
-                        "name": "user",
+                        "description": "Obligation type to create",
+                        "name": "obligation_type",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserCreate"
+                            "$ref": "#/definitions/models.ObligationType"
                         }
                     }
                 ],
@@ -2151,30 +2818,38 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.UserResponse"
+                            "$ref": "#/definitions/models.ObligationTypeResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid json body",
+                        "description": "invalid json body",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
                     "409": {
-                        "description": "User already exists",
+                        "description": "obligation type already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "something went wrong while creating new obligation type",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/obligations/types/{type}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Users can update their profile using this endpoint",
+                "description": "Deactivate an obligation type",
                 "consumes": [
                     "application/json"
                 ],
@@ -2182,66 +2857,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Obligations"
                 ],
-                "summary": "Users can update their profile using this endpoint",
-                "operationId": "UpdateProfile",
+                "summary": "Deactivate obligation type",
+                "operationId": "DeleteObligationType",
                 "parameters": [
                     {
-                        "description": "Profile fields to update",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ProfileUpdate"
-                        }
+                        "type": "string",
+                        "description": "Obligation Type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version of the obligation type in its listing, required if REQUIRE_IF_MATCH is set",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "cannot delete obligation type 'RISK' as it's still referenced by some obligations",
                         "schema": {
-                            "$ref": "#/definitions/models.UserResponse"
+                            "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "400": {
-                        "description": "Invalid json body",
+                    "404": {
+                        "description": "obligation type 'RISK' not found",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
-                    }
-                }
-            }
-        },
-        "/users/oidc": {
-            "post": {
-                "description": "Create a new service user via oidc id token",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Create new user via oidc id token",
-                "operationId": "CreateOidcUser",
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "412": {
+                        "description": "obligation type was changed since it was read",
                         "schema": {
-                            "$ref": "#/definitions/models.UserResponse"
+                            "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "400": {
-                        "description": "Invalid json body",
+                    "428": {
+                        "description": "If-Match header is required",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "409": {
-                        "description": "User already exists",
+                    "500": {
+                        "description": "something went wrong while deleting obligation type",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -2249,14 +2913,15 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/profile": {
+        "/obligations/{id}": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "ApiKeyAuth": [],
+                        "{}": []
                     }
                 ],
-                "description": "Get user's own profile",
+                "description": "Get an active based on given id",
                 "consumes": [
                     "application/json"
                 ],
@@ -2264,25 +2929,199 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Obligations"
                 ],
-                "summary": "Get user's own profile",
-                "operationId": "GetUserProfile",
-                "responses": {
+                "summary": "Get an obligation",
+                "operationId": "GetObligation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the obligation",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a version of the obligation the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserResponse"
+                            "$ref": "#/definitions/models.ObligationResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the obligation"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Obligation not modified"
+                    },
+                    "404": {
+                        "description": "No obligation with given id found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deactivate an obligation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Obligations"
+                ],
+                "summary": "Deactivate obligation",
+                "operationId": "DeleteObligation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the obligation to be updated",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the obligation was read with, required if REQUIRE_IF_MATCH is set",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Obligation is stewarded by a team of other users",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No obligation with given id found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "412": {
+                        "description": "Obligation was changed since it was read",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing obligation record",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Obligations"
+                ],
+                "summary": "Update obligation",
+                "operationId": "UpdateObligation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the obligation to be updated",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Obligation to be updated",
+                        "name": "obligation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ObligationUpdateDTO"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the obligation was read with, required if REQUIRE_IF_MATCH is set",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ObligationResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the obligation"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid user",
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Obligation is stewarded by a team of other users",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "No obligation with given id found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "412": {
+                        "description": "Obligation was changed since it was read",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Unable to update obligation",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
@@ -2290,14 +3129,15 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{username}": {
+        "/obligations/{id}/audits": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "ApiKeyAuth": [],
+                        "{}": []
                     }
                 ],
-                "description": "Get a single user by username",
+                "description": "Fetches audits corresponding to an obligation",
                 "consumes": [
                     "application/json"
                 ],
@@ -2305,47 +3145,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Obligations"
                 ],
-                "summary": "Get a user",
-                "operationId": "GetUser",
+                "summary": "Fetches audits corresponding to an obligation",
+                "operationId": "GetObligationAudits",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Username",
-                        "name": "username",
+                        "description": "Id of the obligation for which audits need to be fetched",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserResponse"
+                            "$ref": "#/definitions/models.AuditResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid user id",
+                    "404": {
+                        "description": "No obligation with given id found",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "500": {
+                        "description": "unable to find audits with such obligation id",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/obligations/{id}/review": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deactivate an user",
+                "description": "Record that an obligation was reviewed, which restarts its review interval",
                 "consumes": [
                     "application/json"
                 ],
@@ -2353,38 +3207,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Obligations"
                 ],
-                "summary": "Deactivate user",
-                "operationId": "DeleteUser",
+                "summary": "Review an obligation",
+                "operationId": "ReviewObligation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Username of the user to be marked as inactive",
-                        "name": "username",
+                        "description": "Id of the obligation",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Review comment",
+                        "name": "review",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ObligationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Obligation is stewarded by another team",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
                     },
                     "404": {
-                        "description": "No user with given username found",
+                        "description": "No obligation found",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/obligations/{id}/steward": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update a service user, requires admin rights",
+                "description": "Assign the team stewarding an obligation, or remove it with a null team",
                 "consumes": [
                     "application/json"
                 ],
@@ -2392,25 +3271,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Obligations"
                 ],
-                "summary": "Update user, requires admin rights",
-                "operationId": "UpdateUser",
+                "summary": "Assign the steward team of an obligation",
+                "operationId": "SetObligationSteward",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "username of the user to be updated",
-                        "name": "username",
+                        "description": "Id of the obligation",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User to update",
-                        "name": "user",
+                        "description": "Steward team",
+                        "name": "steward",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserUpdate"
+                            "$ref": "#/definitions/models.StewardAssignment"
                         }
                     }
                 ],
@@ -2418,42 +3297,4196 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserResponse"
+                            "$ref": "#/definitions/models.ObligationResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid json body",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     },
-                    "403": {
-                        "description": "This resource requires elevated access rights",
+                    "404": {
+                        "description": "No obligation or team found",
                         "schema": {
                             "$ref": "#/definitions/models.LicenseError"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.APICollection": {
+        },
+        "/oidcClients": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all oidc clients added by the user for initiating M2M flow with fossology",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OIDC Clients"
+                ],
+                "summary": "Get all oidc clients added by the user",
+                "operationId": "GetUserOidcClients",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OidcClientsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Something went wrong",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add a new client for initiating M2M flow with fossology",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OIDC Clients"
+                ],
+                "summary": "Adds a new oidc client",
+                "operationId": "CreateOidcClient",
+                "parameters": [
+                    {
+                        "description": "Oidc client to add",
+                        "name": "oidc_client",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateDeleteOidcClientDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.OidcClientsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid json body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "oidc client already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "something went wrong while adding new oidc client",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove an oidc client if it gets expired or is compromised",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OIDC Clients"
+                ],
+                "summary": "Remove an oidc client",
+                "operationId": "RevokeClient",
+                "parameters": [
+                    {
+                        "description": "Oidc client to add",
+                        "name": "oidc_client",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateDeleteOidcClientDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Oidc Client not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh-token": {
+            "post": {
+                "description": "verify refresh token and get new access token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Verify refresh token",
+                "operationId": "RefreshToken",
+                "parameters": [
+                    {
+                        "description": "Refresh token payload",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/models.TokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scan": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upload a source file or an archive (zip, jar, tar, tar.gz, tgz, tar.bz2 or gz) to detect the catalog\nlicenses in its files. SPDX-License-Identifier tags are resolved against the SPDX ids and shortnames of the\ncatalog, and license texts and headers are matched following the SPDX License Matching Guidelines.\nThe scan runs in the background, poll it until it is COMPLETED or FAILED.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scans"
+                ],
+                "summary": "Scan an archive for licenses",
+                "operationId": "CreateScan",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Source file or archive to scan",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum percentage of a license text a partial match must cover, defaults to 80",
+                        "name": "min_coverage",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScanResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "Url of the scan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "input file must be present",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "413": {
+                        "description": "Upload is too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to start scan",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many scans are waiting",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "string",
+                                "description": "Seconds to wait before uploading again"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/scan/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the status of a scan and, once it is COMPLETED, the licenses found in each file of the archive along\nwith all licenses found and their obligations. Users can only see their own scans, admins see all.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scans"
+                ],
+                "summary": "Get a scan",
+                "operationId": "GetScan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the scan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid scan id",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No scan found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Users": {
+            "get": {
+                "security": [
+                    {
+                        "ScimAuth": []
+                    }
+                ],
+                "description": "Query users as SCIM 2.0 resources. Supports filtering with ` + "`" + `userName eq \"...\"` + "`" + `, ` + "`" + `externalId eq \"...\"` + "`" + `\nor ` + "`" + `emails eq \"...\"` + "`" + ` and pagination with startIndex and count.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "List users (SCIM)",
+                "operationId": "ScimGetUsers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "userName eq \"jdoe\"",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1-based index of the first result",
+                        "name": "startIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid SCIM token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ScimAuth": []
+                    }
+                ],
+                "description": "Provision a user. The primary email is stored as user email, the role (USER or ADMIN) as user level.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "Create a user (SCIM)",
+                "operationId": "ScimCreateUser",
+                "parameters": [
+                    {
+                        "description": "User to create",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid SCIM token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ScimAuth": []
+                    }
+                ],
+                "description": "Get a user as SCIM 2.0 resource",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "Get a user (SCIM)",
+                "operationId": "ScimGetUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimUser"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid SCIM token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ScimAuth": []
+                    }
+                ],
+                "description": "Replace the attributes of a user. Setting active to false deactivates the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "Replace a user (SCIM)",
+                "operationId": "ScimReplaceUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User attributes",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid SCIM token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    },
+                    "409": {
+                        "description": "User with same userName or email exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ScimAuth": []
+                    }
+                ],
+                "description": "Deactivate a user. Users are never removed to keep their audits, and can be re-activated by setting active.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "Deactivate a user (SCIM)",
+                "operationId": "ScimDeleteUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Invalid SCIM token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ScimAuth": []
+                    }
+                ],
+                "description": "Apply add, replace and remove operations to the attributes of a user. Replacing active with false\ndeactivates the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "Patch a user (SCIM)",
+                "operationId": "ScimPatchUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid operation",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid SCIM token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    },
+                    "409": {
+                        "description": "User with same userName or email exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScimError"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [],
+                        "{}": []
+                    }
+                ],
+                "description": "Search the names, SPDX ids, texts and notes of licenses together with the topics, comments and texts of obligations.\nResults are ordered by relevance, names and ids weighing more than texts. Facet counts are over all results of the\nsearch term, regardless of the type, risk and classification filters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search licenses and obligations",
+                "operationId": "Search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search term, supports quoted phrases, or and -",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "LICENSE",
+                            "OBLIGATION"
+                        ],
+                        "type": "string",
+                        "description": "Type of the results",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Risk of the licenses",
+                        "name": "risk",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Classification of the obligations",
+                        "name": "classification",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid search",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Search failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [],
+                        "{}": []
+                    }
+                ],
+                "description": "Search licenses on different filters and algorithms",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licenses"
+                ],
+                "summary": "Search licenses",
+                "operationId": "SearchInLicense",
+                "parameters": [
+                    {
+                        "description": "Search criteria",
+                        "name": "search",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchLicense"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Licenses matched",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Search algorithm doesn't exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/service-accounts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all service accounts along with their oidc clients",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Get service accounts",
+                "operationId": "GetServiceAccounts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAccountResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Unable to fetch service accounts",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a service account for automation, owned by a team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Create a service account",
+                "operationId": "CreateServiceAccount",
+                "parameters": [
+                    {
+                        "description": "Service account to create",
+                        "name": "service_account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAccountCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Owner team not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Name already taken",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/service-accounts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a service account along with its oidc clients",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Get a service account",
+                "operationId": "GetServiceAccount",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAccountResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No service account with given id found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deactivate a service account and remove its oidc clients. The account is kept for its audits.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Deactivate a service account",
+                "operationId": "DeleteServiceAccount",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "No service account with given id found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update description, user level, owner team, expiry or active state of a service account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Update a service account",
+                "operationId": "UpdateServiceAccount",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "service_account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAccountUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No service account or owner team found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/service-accounts/{id}/audits": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the audits of all changes made by a service account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Get audits of a service account",
+                "operationId": "GetServiceAccountAudits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuditResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No service account with given id found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Unable to fetch audits",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/service-accounts/{id}/clients": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Tokens of the client credentials flow with this client id act as the service account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Add an oidc client to a service account",
+                "operationId": "AddServiceAccountClient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Oidc client to add",
+                        "name": "client",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAccountClient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No service account with given id found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Oidc client already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/service-accounts/{id}/clients/{client_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove an oidc client if it gets expired or is compromised",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Remove an oidc client of a service account",
+                "operationId": "RemoveServiceAccountClient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Oidc client id",
+                        "name": "client_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "No service account or oidc client found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all teams along with their members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get teams",
+                "operationId": "GetTeams",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Unable to fetch teams",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a team which can be assigned as steward of licenses and obligations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Create a team",
+                "operationId": "CreateTeam",
+                "parameters": [
+                    {
+                        "description": "Team to create",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Member not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Name already taken",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a team along with its members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get a team",
+                "operationId": "GetTeam",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No team with given name found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a team. Teams still stewarding licenses or obligations or owning service accounts can\nnot be deleted, these have to be assigned to another team first. The team is kept for its audits.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Delete a team",
+                "operationId": "DeleteTeam",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "No team with given name found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Team still stewards items or owns service accounts",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update description or steward-only edits of a team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Update a team",
+                "operationId": "UpdateTeam",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No team with given name found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{name}/members": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add an active user to a team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Add a team member",
+                "operationId": "AddTeamMember",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User to add",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamMemberInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "No team or user found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{name}/members/{username}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a user from a team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Remove a team member",
+                "operationId": "RemoveTeamMember",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username of the member",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No team or member found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all service users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get users",
+                "operationId": "GetAllUsers",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Active user only",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, empty for the first page, to paginate by cursor instead of page number",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Users not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new service user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create new user",
+                "operationId": "CreateUser",
+                "parameters": [
+                    {
+                        "description": "User to create",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid json body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Users can update their profile using this endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Users can update their profile using this endpoint",
+                "operationId": "UpdateProfile",
+                "parameters": [
+                    {
+                        "description": "Profile fields to update",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProfileUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid json body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Disable two-factor authentication for the logged in user. Not allowed when the policy of the user level requires it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Disable two-factor authentication",
+                "operationId": "DisableTwoFactor",
+                "parameters": [
+                    {
+                        "description": "TOTP or recovery code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid json body or two-factor authentication not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Two-factor authentication is mandatory",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Generate a TOTP secret and provisioning uri for the logged in user. The secret only becomes active\nafter a code generated from it is confirmed at /users/2fa/verify.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Start two-factor enrollment",
+                "operationId": "EnrollTwoFactor",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorEnrollmentResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa/policies": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the two-factor authentication policy of every user level",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get two-factor policies",
+                "operationId": "GetTwoFactorPolicies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorPolicyResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch policies",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Require or stop requiring two-factor authentication for local accounts of a user level.\nUsers of the level without a second factor are asked to enroll on their next login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update a two-factor policy",
+                "operationId": "UpdateTwoFactorPolicy",
+                "parameters": [
+                    {
+                        "description": "Policy of a user level",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorPolicy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorPolicyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid json body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Policy not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Invalidate all recovery codes of the logged in user and generate new ones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Regenerate recovery codes",
+                "operationId": "RegenerateRecoveryCodes",
+                "parameters": [
+                    {
+                        "description": "TOTP or recovery code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorRecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid json body or two-factor authentication not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa/verify": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Enable two-factor authentication by confirming a code generated from the pending secret.\nReturns the recovery codes, which are shown only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Confirm two-factor enrollment",
+                "operationId": "ConfirmTwoFactor",
+                "parameters": [
+                    {
+                        "description": "TOTP code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorRecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid json body or no pending enrollment",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/oidc": {
+            "post": {
+                "description": "Create a new service user via oidc id token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create new user via oidc id token",
+                "operationId": "CreateOidcUser",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid json body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "403": {
+                        "description": "No user level granted by the identity provider",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/profile": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get user's own profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user's own profile",
+                "operationId": "GetUserProfile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/profile/stewarded": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the licenses and obligations stewarded by the teams the user is a member of",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get my stewarded items",
+                "operationId": "GetStewardedItems",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StewardedItemsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Unable to fetch stewarded items",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a single user by username",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get a user",
+                "operationId": "GetUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deactivate an user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Deactivate user",
+                "operationId": "DeleteUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username of the user to be marked as inactive",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "No user with given username found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update a service user, requires admin rights",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user, requires admin rights",
+                "operationId": "UpdateUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username of the user to be updated",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User to update",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid json body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "403": {
+                        "description": "This resource requires elevated access rights",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "User is anonymised",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}/2fa": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove the TOTP secret and recovery codes of a user. If the policy requires two-factor authentication,\nthe user has to enroll again on the next login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Reset two-factor authentication of a user",
+                "operationId": "ResetUserTwoFactor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username of the user",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}/anonymise": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the name of the user by a pseudonym, remove its email, credentials and oidc clients and\ndeactivate it. The user row stays as pseudonymous placeholder, so audits and licenses keep\nreferencing it. Names and emails in the change logs of the user are scrubbed as well.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Anonymise user",
+                "operationId": "AnonymiseUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username of the user to anonymise",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Own user or service account",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already anonymised",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to anonymise user",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}/data-export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Export the profile of a user together with its oidc clients, owned service accounts, created\nlicenses, scans, teams and all audits made by or about the user. Admins can export any user, other users only\nthemselves.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Export user data",
+                "operationId": "ExportUserData",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username of the user",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserDataExport"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to export this user",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to export user data",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}/impersonate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a short-lived access token acting as the user, to reproduce what the user sees. The token names\nthe super admin in its \"act\" claim, can not be refreshed and is rejected by the user management\nendpoints. Every audit created with it records the super admin as impersonator.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Impersonate a user",
+                "operationId": "ImpersonateUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username of the user to impersonate",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the impersonation",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ImpersonationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or own user",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Super admins can not be impersonated",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to generate token",
+                        "schema": {
+                            "$ref": "#/definitions/models.LicenseError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.APICollection": {
+            "type": "object",
+            "properties": {
+                "authenticated": {
+                    "type": "object"
+                },
+                "unAuthenticated": {
+                    "type": "object"
+                }
+            }
+        },
+        "models.APICollectionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.APICollection"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.Audit": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "impersonator": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "impersonator_id": {
+                    "description": "ImpersonatorId is the super admin who made the change while\nimpersonating the user",
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "service_account": {
+                    "description": "ServiceAccount is set if the user is the identity of a service account",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ServiceAccount"
+                        }
+                    ]
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2023-12-01T18:10:25.00+05:30"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "OBLIGATION",
+                        "LICENSE",
+                        "USER",
+                        "TYPE",
+                        "CLASSIFICATION",
+                        "CATEGORY",
+                        "TWO_FACTOR_POLICY",
+                        "SERVICE_ACCOUNT",
+                        "IMPERSONATION",
+                        "TEAM"
+                    ],
+                    "example": "LICENSE"
+                },
+                "type_id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                }
+            }
+        },
+        "models.AuditResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Audit"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.CategoryObligationCount": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "GENERAL"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "models.ChangeLog": {
+            "type": "object",
+            "properties": {
+                "audit_id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "text"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "old_value": {
+                    "type": "string",
+                    "example": "Old license text"
+                },
+                "updated_value": {
+                    "type": "string",
+                    "example": "New license text"
+                }
+            }
+        },
+        "models.ChangeLogResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ChangeLog"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.CreateDeleteOidcClientDTO": {
+            "type": "object",
+            "required": [
+                "clientId"
+            ],
+            "properties": {
+                "clientId": {
+                    "type": "string",
+                    "example": "qwerty"
+                }
+            }
+        },
+        "models.Dashboard": {
+            "type": "object",
+            "properties": {
+                "category_obligation_frequency": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CategoryObligationCount"
+                    }
+                },
+                "licenses_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "monthly_license_changes_count": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "obligations_count": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "overdue_reviews_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "risk_license_frequency": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RiskLicenseCount"
+                    }
+                },
+                "users_count": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.DashboardResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Dashboard"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.DuplicateCluster": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DuplicateMember"
+                    }
+                },
+                "pairs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DuplicatePair"
+                    }
+                },
+                "suggested_survivor_id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                }
+            }
+        },
+        "models.DuplicateClustersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DuplicateCluster"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.DuplicateMember": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "MIT"
+                },
+                "spdx_id": {
+                    "type": "string",
+                    "example": "MIT"
+                }
+            }
+        },
+        "models.DuplicatePair": {
+            "type": "object",
+            "properties": {
+                "a": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "b": {
+                    "type": "string",
+                    "example": "a0c91e6b-7dec-11d0-a765-00f81d4fae6f"
+                },
+                "similarity": {
+                    "type": "number",
+                    "example": 0.97
+                }
+            }
+        },
+        "models.ExternalRefField": {
+            "type": "object",
+            "properties": {
+                "builtin": {
+                    "description": "Builtin fields are generated from external_ref_fields.yaml and cannot\nbe changed through the api",
+                    "type": "boolean",
+                    "example": false
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "LICENSE"
+                },
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "EAR99",
+                        "5D002"
+                    ]
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExternalRefField"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Export control classification"
+                },
+                "max": {
+                    "type": "number",
+                    "example": 10
+                },
+                "min": {
+                    "description": "Min and Max bound numbers, the length of strings and the number of\nitems of arrays. Fields are the fields of objects. They are only set on\nbuiltin fields",
+                    "type": "number",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "export_control"
+                },
+                "regex": {
+                    "type": "string",
+                    "example": "^[0-9A-Z]+$"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "type": {
+                    "type": "string",
+                    "example": "string"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.ExternalRefFieldCreate": {
+            "type": "object",
+            "required": [
+                "entity",
+                "enum",
+                "label",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "entity": {
+                    "type": "string",
+                    "enum": [
+                        "LICENSE",
+                        "OBLIGATION"
+                    ],
+                    "example": "LICENSE"
+                },
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "EAR99",
+                        "5D002"
+                    ]
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Export control classification"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "export_control"
+                },
+                "regex": {
+                    "type": "string",
+                    "example": "^[0-9A-Z]+$"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "int",
+                        "number",
+                        "boolean",
+                        "date"
+                    ],
+                    "example": "string"
+                }
+            }
+        },
+        "models.ExternalRefFieldResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExternalRefField"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.ExternalRefFieldUpdate": {
+            "type": "object",
+            "required": [
+                "enum"
+            ],
+            "properties": {
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "EAR99",
+                        "5D002"
+                    ]
+                },
+                "label": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Export control classification"
+                },
+                "regex": {
+                    "type": "string",
+                    "example": "^[0-9A-Z]+$"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ExternalRefSchema": {
+            "type": "object",
+            "properties": {
+                "$schema": {
+                    "type": "string",
+                    "example": "https://json-schema.org/draft/2020-12/schema"
+                },
+                "additionalProperties": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "properties": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.ExternalRefSchemaProperty"
+                    }
+                },
+                "required": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "License external_ref"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "object"
+                }
+            }
+        },
+        "models.ExternalRefSchemaProperty": {
+            "type": "object",
+            "properties": {
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "EAR99",
+                        "5D002"
+                    ]
+                },
+                "format": {
+                    "type": "string",
+                    "example": "date"
+                },
+                "items": {
+                    "description": "Items is the schema of the items of arrays",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ExternalRefSchemaProperty"
+                        }
+                    ]
+                },
+                "maxItems": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "maxLength": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "maximum": {
+                    "type": "number",
+                    "example": 10
+                },
+                "minItems": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "minLength": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "minimum": {
+                    "type": "number",
+                    "example": 0
+                },
+                "pattern": {
+                    "type": "string",
+                    "example": "^[0-9A-Z]+$"
+                },
+                "properties": {
+                    "description": "Properties and Required are the schema of the fields of objects",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.ExternalRefSchemaProperty"
+                    }
+                },
+                "required": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Export control classification"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "string"
+                }
+            }
+        },
+        "models.IdentifiedLicense": {
+            "type": "object",
+            "properties": {
+                "coverage": {
+                    "type": "number",
+                    "example": 98.8
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "portions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MatchedPortion"
+                    }
+                },
+                "shortname": {
+                    "type": "string",
+                    "example": "MIT"
+                },
+                "spdx_id": {
+                    "type": "string",
+                    "example": "MIT"
+                }
+            }
+        },
+        "models.IdentifyRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "min_coverage": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 50
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Permission is hereby granted, free of charge, to any person obtaining a copy"
+                }
+            }
+        },
+        "models.ImpersonationInput": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "User reports they can not edit obligation MIT-notice"
+                }
+            }
+        },
+        "models.ImportLicensesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "can be of type models.LicenseError or models.LicenseImportStatus",
+                    "type": "array",
+                    "items": {}
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.ImportObligationsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "can be of type models.LicenseError or models.ObligationImportStatus",
+                    "type": "array",
+                    "items": {}
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.LicenseAliasDTO": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string",
+                    "example": "GPL-2.0+"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "namespace": {
+                    "type": "string",
+                    "enum": [
+                        "SPDX",
+                        "FOSSOLOGY",
+                        "SCANCODE",
+                        "VENDOR",
+                        "MERGED"
+                    ],
+                    "example": "SPDX"
+                }
+            }
+        },
+        "models.LicenseAliasInput": {
+            "type": "object",
+            "required": [
+                "alias",
+                "namespace"
+            ],
+            "properties": {
+                "alias": {
+                    "type": "string",
+                    "example": "GPL-2.0+"
+                },
+                "namespace": {
+                    "type": "string",
+                    "enum": [
+                        "SPDX",
+                        "FOSSOLOGY",
+                        "SCANCODE",
+                        "VENDOR",
+                        "MERGED"
+                    ],
+                    "example": "SPDX"
+                }
+            }
+        },
+        "models.LicenseAliasUpdate": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "GPL-2.0+"
+                },
+                "namespace": {
+                    "type": "string",
+                    "enum": [
+                        "SPDX",
+                        "FOSSOLOGY",
+                        "SCANCODE",
+                        "VENDOR",
+                        "MERGED"
+                    ],
+                    "example": "SPDX"
+                }
+            }
+        },
+        "models.LicenseAliasesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LicenseAliasDTO"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.LicenseCreateDTO": {
+            "type": "object",
+            "required": [
+                "fullname",
+                "shortname",
+                "spdx_id",
+                "text"
+            ],
+            "properties": {
+                "OSIapproved": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "copyleft": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "external_ref": {
+                    "$ref": "#/definitions/models.LicenseDBSchemaExtension"
+                },
+                "fullname": {
+                    "type": "string",
+                    "example": "MIT License"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "This license has been superseded."
+                },
+                "obligation_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+                        "f812jfae-7dbc-11d0-a765-00a0hf06bf6"
+                    ]
+                },
+                "review_interval_days": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 365
+                },
+                "risk": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "shortname": {
+                    "type": "string",
+                    "example": "MIT"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "spdx"
+                },
+                "spdx_id": {
+                    "type": "string",
+                    "example": "MIT"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "MIT License Text here"
+                },
+                "text_updatable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://opensource.org/licenses/MIT"
+                }
+            }
+        },
+        "models.LicenseDBSchemaExtension": {
+            "type": "object",
+            "properties": {
+                "license_explanation": {
+                    "type": "string",
+                    "title": "License Explanation"
+                },
+                "license_suffix": {
+                    "type": "string",
+                    "title": "License Suffix"
+                }
+            }
+        },
+        "models.LicenseDuplicateError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid request body"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SimilarLicense"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid request body"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/v1/licenses"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2023-12-01T10:00:51+05:30"
+                }
+            }
+        },
+        "models.LicenseError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid request body"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid request body"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/v1/licenses"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2023-12-01T10:00:51+05:30"
+                }
+            }
+        },
+        "models.LicenseIdentification": {
+            "type": "object",
+            "properties": {
+                "exact_matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IdentifiedLicense"
+                    }
+                },
+                "near_matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IdentifiedLicense"
+                    }
+                }
+            }
+        },
+        "models.LicenseIdentificationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.LicenseIdentification"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.LicenseImportStatus": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "shortname": {
+                    "type": "string",
+                    "example": "MIT"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.LicenseMergeInput": {
+            "type": "object",
+            "required": [
+                "duplicate_id"
+            ],
+            "properties": {
+                "duplicate_id": {
+                    "type": "string",
+                    "example": "a0c91e6b-7dec-11d0-a765-00f81d4fae6f"
+                }
+            }
+        },
+        "models.LicensePreview": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "shortname": {
+                    "type": "string",
+                    "example": "GPL-2.0-or-later"
+                }
+            }
+        },
+        "models.LicensePreviewResponse": {
+            "type": "object",
+            "properties": {
+                "licenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LicensePreview"
+                    }
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.LicenseResolution": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "$ref": "#/definitions/models.LicenseAliasDTO"
+                },
+                "license": {
+                    "$ref": "#/definitions/models.LicenseResponseDTO"
+                },
+                "matched_by": {
+                    "type": "string",
+                    "enum": [
+                        "SPDX_ID",
+                        "SHORTNAME",
+                        "ALIAS"
+                    ],
+                    "example": "ALIAS"
+                }
+            }
+        },
+        "models.LicenseResolutionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.LicenseResolution"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.LicenseResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LicenseResponseDTO"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.LicenseResponseDTO": {
+            "type": "object",
+            "properties": {
+                "OSIapproved": {
+                    "type": "boolean"
+                },
+                "active": {
+                    "type": "boolean"
+                },
+                "add_date": {
+                    "type": "string"
+                },
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LicenseAliasDTO"
+                    }
+                },
+                "copyleft": {
+                    "type": "boolean"
+                },
+                "created_by": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "external_ref": {
+                    "$ref": "#/definitions/models.LicenseDBSchemaExtension"
+                },
+                "fullname": {
+                    "type": "string",
+                    "example": "MIT License"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "last_reviewed_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "This license has been superseded."
+                },
+                "obligation_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "review_due_at": {
+                    "type": "string",
+                    "example": "2027-01-01T00:00:00Z"
+                },
+                "review_interval_days": {
+                    "type": "integer",
+                    "example": 365
+                },
+                "risk": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "shortname": {
+                    "type": "string",
+                    "example": "MIT"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "spdx_id": {
+                    "type": "string",
+                    "example": "MIT"
+                },
+                "steward_team_id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "MIT License Text here"
+                },
+                "text_updatable": {
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://opensource.org/licenses/MIT"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.LicenseUpdateDTO": {
+            "type": "object",
+            "properties": {
+                "OSIapproved": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "copyleft": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "external_ref": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "fullname": {
+                    "type": "string",
+                    "example": "MIT License"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "This license has been superseded."
+                },
+                "obligation_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "review_interval_days": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 365
+                },
+                "risk": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "shortname": {
+                    "type": "string",
+                    "example": "MIT"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "Source"
+                },
+                "spdx_id": {
+                    "type": "string",
+                    "example": "MIT"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "MIT License Text here"
+                },
+                "text_updatable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://opensource.org/licenses/MIT"
+                }
+            }
+        },
+        "models.MatchedPortion": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer",
+                    "example": 1073
+                },
+                "start": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "models.ObligationCategory": {
+            "type": "object",
+            "required": [
+                "category"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "GENERAL"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.ObligationCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ObligationCategory"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.ObligationClassification": {
+            "type": "object",
+            "required": [
+                "classification",
+                "color"
+            ],
+            "properties": {
+                "classification": {
+                    "type": "string",
+                    "example": "GREEN"
+                },
+                "color": {
+                    "type": "string",
+                    "example": "#00FF00"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.ObligationClassificationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ObligationClassification"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.ObligationCreateDTO": {
+            "type": "object",
+            "required": [
+                "classification",
+                "license_ids",
+                "text",
+                "topic",
+                "type"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "DISTRIBUTION"
+                },
+                "classification": {
+                    "type": "string",
+                    "example": "GREEN"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "external_ref": {
+                    "$ref": "#/definitions/models.ObligationSchemaExtension"
+                },
+                "license_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+                        "f812jfae-7dbc-11d0-a765-00a0hf06bf6"
+                    ]
+                },
+                "review_interval_days": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 365
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Source code be made available when distributing the software."
+                },
+                "text_updatable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "copyleft"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "RISK"
+                }
+            }
+        },
+        "models.ObligationDuplicateError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid request body"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SimilarObligation"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid request body"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/v1/licenses"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2023-12-01T10:00:51+05:30"
+                }
+            }
+        },
+        "models.ObligationId": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "copyleft"
+                }
+            }
+        },
+        "models.ObligationImportStatus": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ObligationId"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.ObligationPreview": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Provide Copyright Notices"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "OBLIGATION",
+                        "RESTRICTION",
+                        "RISK",
+                        "RIGHT"
+                    ]
+                }
+            }
+        },
+        "models.ObligationPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ObligationPreview"
+                    }
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.ObligationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ObligationResponseDTO"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.ObligationResponseDTO": {
+            "type": "object",
+            "required": [
+                "category",
+                "classification",
+                "license_ids",
+                "text",
+                "topic",
+                "type"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "DISTRIBUTION"
+                },
+                "classification": {
+                    "type": "string",
+                    "example": "GREEN"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "external_ref": {
+                    "$ref": "#/definitions/models.ObligationSchemaExtension"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "last_reviewed_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "license_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+                        "f812jfae-7dbc-11d0-a765-00a0hf06bf6"
+                    ]
+                },
+                "review_due_at": {
+                    "type": "string",
+                    "example": "2027-01-01T00:00:00Z"
+                },
+                "review_interval_days": {
+                    "type": "integer",
+                    "example": 365
+                },
+                "steward_team_id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Source code be made available when distributing the software."
+                },
+                "text_updatable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "copyleft"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "RISK"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.ObligationSchemaExtension": {
+            "type": "object",
+            "properties": {
+                "obligation_explanation": {
+                    "type": "string",
+                    "title": "Obligation Explanation"
+                },
+                "obligation_suffix": {
+                    "type": "string",
+                    "title": "Obligation Suffix"
+                }
+            }
+        },
+        "models.ObligationType": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "example": "PERMISSION"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.ObligationTypeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ObligationType"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.ObligationUpdateDTO": {
+            "type": "object",
+            "required": [
+                "license_ids"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "DISTRIBUTION"
+                },
+                "classification": {
+                    "type": "string",
+                    "example": "GREEN"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "external_ref": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "license_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+                        "f812jfae-7dbc-11d0-a765-00a0hf06bf6"
+                    ]
+                },
+                "review_interval_days": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 365
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Source code be made available when distributing the software."
+                },
+                "text_updatable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "copyleft"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "RISK"
+                }
+            }
+        },
+        "models.OidcClientDTO": {
             "type": "object",
             "properties": {
-                "authenticated": {
-                    "type": "object"
+                "add_date": {
+                    "type": "string"
                 },
-                "unAuthenticated": {
-                    "type": "object"
+                "client_id": {
+                    "type": "string",
+                    "example": "qwerty"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 }
             }
         },
-        "models.APICollectionResponse": {
+        "models.OidcClientsResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/models.APICollection"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OidcClientDTO"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
                 },
                 "status": {
                     "type": "integer",
@@ -2461,49 +7494,50 @@ const docTemplate = `{
                 }
             }
         },
-        "models.Audit": {
+        "models.OverdueReview": {
             "type": "object",
             "properties": {
-                "entity": {
-                    "type": "object"
+                "due_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
                 },
                 "id": {
                     "type": "string",
                     "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "timestamp": {
+                "last_reviewed_at": {
                     "type": "string",
-                    "example": "2023-12-01T18:10:25.00+05:30"
+                    "example": "2025-01-01T00:00:00Z"
                 },
-                "type": {
+                "name": {
                     "type": "string",
-                    "enum": [
-                        "OBLIGATION",
-                        "LICENSE",
-                        "USER"
-                    ],
-                    "example": "LICENSE"
+                    "example": "GPL-2.0-only"
                 },
-                "type_id": {
+                "reminded_at": {
                     "type": "string",
-                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                    "example": "2026-01-02T00:00:00Z"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
+                "steward_team": {
+                    "type": "string",
+                    "example": "copyleft-reviewers"
                 },
-                "user_id": {
+                "type": {
                     "type": "string",
-                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                    "enum": [
+                        "LICENSE",
+                        "OBLIGATION"
+                    ],
+                    "example": "LICENSE"
                 }
             }
         },
-        "models.AuditResponse": {
+        "models.OverdueReviewResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Audit"
+                        "$ref": "#/definitions/models.OverdueReview"
                     }
                 },
                 "paginationmeta": {
@@ -2515,112 +7549,244 @@ const docTemplate = `{
                 }
             }
         },
-        "models.CategoryObligationCount": {
+        "models.PaginationMeta": {
             "type": "object",
             "properties": {
-                "category": {
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "next": {
                     "type": "string",
-                    "example": "GENERAL"
+                    "example": "/api/v1/licenses?limit=10\u0026page=11"
+                },
+                "next_cursor": {
+                    "description": "NextCursor is the cursor of the next page of listings paginated by cursor",
+                    "type": "string",
+                    "example": "eyJjIjoicmZfc2hvcnRuYW1lIn0"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "previous": {
+                    "type": "string",
+                    "example": "/api/v1/licenses?limit=10\u0026page=9"
+                },
+                "resource_count": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "models.ProfileUpdate": {
+            "type": "object",
+            "properties": {
+                "display_name": {
+                    "type": "string",
+                    "example": "fossy"
+                },
+                "subscribed": {
+                    "type": "boolean",
+                    "example": false
                 },
+                "user_email": {
+                    "type": "string"
+                },
+                "user_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RefreshToken": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "your_refresh_token_here"
+                }
+            }
+        },
+        "models.ReviewInput": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Checked against the SPDX text, no changes needed"
+                }
+            }
+        },
+        "models.RiskLicenseCount": {
+            "type": "object",
+            "properties": {
                 "count": {
                     "type": "integer",
                     "example": 6
+                },
+                "risk": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "models.ChangeLog": {
+        "models.ScanDTO": {
             "type": "object",
             "properties": {
-                "audit_id": {
+                "created_at": {
                     "type": "string",
-                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                    "example": "2026-01-01T00:00:00Z"
                 },
-                "field": {
+                "error": {
                     "type": "string",
-                    "example": "text"
+                    "example": "invalid zip file: zip: not a valid zip file"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "project-1.0.tar.gz"
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:05Z"
                 },
                 "id": {
                     "type": "string",
                     "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "old_value": {
+                "result": {
+                    "$ref": "#/definitions/models.ScanResult"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 524288
+                },
+                "started_at": {
                     "type": "string",
-                    "example": "Old license text"
+                    "example": "2026-01-01T00:00:01Z"
                 },
-                "updated_value": {
+                "status": {
                     "type": "string",
-                    "example": "New license text"
+                    "enum": [
+                        "QUEUED",
+                        "RUNNING",
+                        "COMPLETED",
+                        "FAILED"
+                    ],
+                    "example": "COMPLETED"
                 }
             }
         },
-        "models.ChangeLogResponse": {
+        "models.ScanFileFinding": {
             "type": "object",
             "properties": {
-                "data": {
+                "exact_matches": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.ChangeLog"
+                        "$ref": "#/definitions/models.IdentifiedLicense"
                     }
                 },
-                "paginationmeta": {
-                    "$ref": "#/definitions/models.PaginationMeta"
+                "identifiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScanIdentifier"
+                    }
                 },
-                "status": {
-                    "type": "integer",
-                    "example": 200
-                }
-            }
-        },
-        "models.CreateDeleteOidcClientDTO": {
-            "type": "object",
-            "required": [
-                "clientId"
-            ],
-            "properties": {
-                "clientId": {
+                "near_matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IdentifiedLicense"
+                    }
+                },
+                "path": {
                     "type": "string",
-                    "example": "qwerty"
+                    "example": "src/main.c"
                 }
             }
         },
-        "models.Dashboard": {
+        "models.ScanIdentifier": {
             "type": "object",
             "properties": {
-                "category_obligation_frequency": {
+                "expression": {
+                    "type": "string",
+                    "example": "MIT OR Apache-2.0"
+                },
+                "licenses": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.CategoryObligationCount"
+                        "$ref": "#/definitions/models.ScannedLicense"
                     }
                 },
-                "licenses_count": {
+                "line": {
                     "type": "integer",
-                    "example": 2
+                    "example": 1
                 },
-                "monthly_license_changes_count": {
+                "unknown_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "LicenseRef-Proprietary"
+                    ]
+                }
+            }
+        },
+        "models.ScanLicense": {
+            "type": "object",
+            "properties": {
+                "files": {
                     "type": "integer",
-                    "example": 6
+                    "example": 3
                 },
-                "obligations_count": {
-                    "type": "integer",
-                    "example": 7
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "risk_license_frequency": {
+                "obligations": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.RiskLicenseCount"
+                        "$ref": "#/definitions/models.ScanObligation"
                     }
                 },
-                "users_count": {
-                    "type": "integer",
-                    "example": 5
+                "shortname": {
+                    "type": "string",
+                    "example": "MIT"
+                },
+                "spdx_id": {
+                    "type": "string",
+                    "example": "MIT"
                 }
             }
         },
-        "models.DashboardResponse": {
+        "models.ScanObligation": {
+            "type": "object",
+            "properties": {
+                "classification": {
+                    "type": "string",
+                    "example": "GREEN"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Provide Copyright Notices"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "OBLIGATION"
+                }
+            }
+        },
+        "models.ScanResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/models.Dashboard"
+                    "$ref": "#/definitions/models.ScanDTO"
                 },
                 "status": {
                     "type": "integer",
@@ -2628,393 +7794,500 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ImportLicensesResponse": {
+        "models.ScanResult": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "can be of type models.LicenseError or models.LicenseImportStatus",
+                "files": {
                     "type": "array",
-                    "items": {}
+                    "items": {
+                        "$ref": "#/definitions/models.ScanFileFinding"
+                    }
                 },
-                "status": {
+                "files_scanned": {
                     "type": "integer",
-                    "example": 200
+                    "example": 120
+                },
+                "files_skipped": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "licenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScanLicense"
+                    }
+                },
+                "unknown_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "LicenseRef-Proprietary"
+                    ]
                 }
             }
         },
-        "models.ImportObligationsResponse": {
+        "models.ScannedLicense": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "can be of type models.LicenseError or models.ObligationImportStatus",
-                    "type": "array",
-                    "items": {}
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "status": {
-                    "type": "integer",
-                    "example": 200
+                "shortname": {
+                    "type": "string",
+                    "example": "MIT"
+                },
+                "spdx_id": {
+                    "type": "string",
+                    "example": "MIT"
                 }
             }
         },
-        "models.LicenseCreateDTO": {
+        "models.ScimError": {
             "type": "object",
-            "required": [
-                "fullname",
-                "shortname",
-                "spdx_id",
-                "text"
-            ],
             "properties": {
-                "OSIapproved": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "active": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "copyleft": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "external_ref": {
-                    "$ref": "#/definitions/models.LicenseDBSchemaExtension"
-                },
-                "fullname": {
-                    "type": "string",
-                    "example": "MIT License"
-                },
-                "notes": {
+                "detail": {
                     "type": "string",
-                    "example": "This license has been superseded."
+                    "example": "user not found"
                 },
-                "obligation_ids": {
+                "schemas": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     },
                     "example": [
-                        "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
-                        "f812jfae-7dbc-11d0-a765-00a0hf06bf6"
+                        "urn:ietf:params:scim:api:messages:2.0:Error"
                     ]
                 },
-                "risk": {
-                    "type": "integer",
-                    "maximum": 5,
-                    "minimum": 0,
-                    "example": 1
-                },
-                "shortname": {
+                "scimType": {
                     "type": "string",
-                    "example": "MIT"
+                    "example": "uniqueness"
                 },
-                "source": {
+                "status": {
                     "type": "string",
-                    "example": "spdx"
+                    "example": "404"
+                }
+            }
+        },
+        "models.ScimListResponse": {
+            "type": "object",
+            "properties": {
+                "Resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScimUser"
+                    }
                 },
-                "spdx_id": {
-                    "type": "string",
-                    "example": "MIT"
+                "itemsPerPage": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "text": {
-                    "type": "string",
-                    "example": "MIT License Text here"
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+                    ]
                 },
-                "text_updatable": {
-                    "type": "boolean",
-                    "example": false
+                "startIndex": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "url": {
-                    "type": "string",
-                    "example": "https://opensource.org/licenses/MIT"
+                "totalResults": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "models.LicenseDBSchemaExtension": {
+        "models.ScimMeta": {
             "type": "object",
             "properties": {
-                "license_explanation": {
-                    "type": "string"
+                "location": {
+                    "type": "string",
+                    "example": "/api/v1/scim/v2/Users/f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "license_suffix": {
-                    "type": "string"
+                "resourceType": {
+                    "type": "string",
+                    "example": "User"
                 }
             }
         },
-        "models.LicenseError": {
+        "models.ScimMultiValuedAttribute": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "invalid request body"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "invalid request body"
+                "primary": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "path": {
+                "type": {
                     "type": "string",
-                    "example": "/api/v1/licenses"
-                },
-                "status": {
-                    "type": "integer",
-                    "example": 400
+                    "example": "work"
                 },
-                "timestamp": {
+                "value": {
                     "type": "string",
-                    "example": "2023-12-01T10:00:51+05:30"
+                    "example": "jane.doe@example.com"
                 }
             }
         },
-        "models.LicenseImportStatus": {
+        "models.ScimName": {
             "type": "object",
             "properties": {
-                "id": {
+                "familyName": {
                     "type": "string",
-                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                    "example": "Doe"
                 },
-                "shortname": {
+                "formatted": {
                     "type": "string",
-                    "example": "MIT"
+                    "example": "Jane Doe"
                 },
-                "status": {
-                    "type": "integer",
-                    "example": 200
+                "givenName": {
+                    "type": "string",
+                    "example": "Jane"
                 }
             }
         },
-        "models.LicensePreview": {
+        "models.ScimPatchOperation": {
             "type": "object",
             "properties": {
-                "id": {
+                "op": {
                     "type": "string",
-                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                    "example": "replace"
                 },
-                "shortname": {
+                "path": {
                     "type": "string",
-                    "example": "GPL-2.0-or-later"
+                    "example": "active"
+                },
+                "value": {
+                    "type": "object"
                 }
             }
         },
-        "models.LicensePreviewResponse": {
+        "models.ScimPatchRequest": {
             "type": "object",
             "properties": {
-                "licenses": {
+                "Operations": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.LicensePreview"
+                        "$ref": "#/definitions/models.ScimPatchOperation"
                     }
                 },
-                "status": {
-                    "type": "integer",
-                    "example": 200
-                }
-            }
-        },
-        "models.LicenseResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
+                "schemas": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.LicenseResponseDTO"
-                    }
-                },
-                "paginationmeta": {
-                    "$ref": "#/definitions/models.PaginationMeta"
-                },
-                "status": {
-                    "type": "integer",
-                    "example": 200
+                        "type": "string"
+                    },
+                    "example": [
+                        "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+                    ]
                 }
             }
         },
-        "models.LicenseResponseDTO": {
+        "models.ScimUser": {
             "type": "object",
             "properties": {
-                "OSIapproved": {
-                    "type": "boolean"
-                },
                 "active": {
-                    "type": "boolean"
-                },
-                "add_date": {
-                    "type": "string"
-                },
-                "copyleft": {
-                    "type": "boolean"
+                    "type": "boolean",
+                    "example": true
                 },
-                "created_by": {
-                    "$ref": "#/definitions/models.User"
+                "displayName": {
+                    "type": "string",
+                    "example": "Jane Doe"
                 },
-                "external_ref": {
-                    "$ref": "#/definitions/models.LicenseDBSchemaExtension"
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScimMultiValuedAttribute"
+                    }
                 },
-                "fullname": {
+                "externalId": {
                     "type": "string",
-                    "example": "MIT License"
+                    "example": "00u1abcd"
                 },
                 "id": {
                     "type": "string",
                     "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "notes": {
-                    "type": "string",
-                    "example": "This license has been superseded."
+                "meta": {
+                    "$ref": "#/definitions/models.ScimMeta"
                 },
-                "obligation_ids": {
+                "name": {
+                    "$ref": "#/definitions/models.ScimName"
+                },
+                "roles": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/models.ScimMultiValuedAttribute"
                     }
                 },
-                "risk": {
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "urn:ietf:params:scim:schemas:core:2.0:User"
+                    ]
+                },
+                "userName": {
+                    "type": "string",
+                    "example": "jdoe"
+                }
+            }
+        },
+        "models.SearchFacet": {
+            "type": "object",
+            "properties": {
+                "count": {
                     "type": "integer",
-                    "example": 1
+                    "example": 12
                 },
-                "shortname": {
+                "value": {
                     "type": "string",
-                    "example": "MIT"
+                    "example": "LICENSE"
+                }
+            }
+        },
+        "models.SearchFacets": {
+            "type": "object",
+            "properties": {
+                "classification": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchFacet"
+                    }
                 },
-                "source": {
-                    "type": "string"
+                "risk": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchFacet"
+                    }
                 },
-                "spdx_id": {
+                "type": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchFacet"
+                    }
+                }
+            }
+        },
+        "models.SearchLicense": {
+            "type": "object",
+            "required": [
+                "field",
+                "search_term"
+            ],
+            "properties": {
+                "field": {
                     "type": "string",
-                    "example": "MIT"
+                    "example": "text"
                 },
-                "text": {
+                "search": {
                     "type": "string",
-                    "example": "MIT License Text here"
-                },
-                "text_updatable": {
-                    "type": "boolean"
+                    "enum": [
+                        "fuzzy",
+                        "full_text_search"
+                    ]
                 },
-                "url": {
+                "search_term": {
                     "type": "string",
-                    "example": "https://opensource.org/licenses/MIT"
+                    "example": "MIT License"
                 }
             }
         },
-        "models.LicenseUpdateDTO": {
+        "models.SearchResponse": {
             "type": "object",
             "properties": {
-                "OSIapproved": {
-                    "type": "boolean",
-                    "example": false
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchResult"
+                    }
                 },
-                "active": {
-                    "type": "boolean",
-                    "example": true
+                "facets": {
+                    "$ref": "#/definitions/models.SearchFacets"
                 },
-                "copyleft": {
-                    "type": "boolean",
-                    "example": false
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
                 },
-                "external_ref": {
-                    "type": "object",
-                    "additionalProperties": true
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.SearchResult": {
+            "type": "object",
+            "properties": {
+                "classification": {
+                    "type": "string",
+                    "example": "GREEN"
                 },
-                "fullname": {
+                "id": {
                     "type": "string",
-                    "example": "MIT License"
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "notes": {
+                "name": {
                     "type": "string",
-                    "example": "This license has been superseded."
+                    "example": "MIT"
                 },
-                "obligation_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "rank": {
+                    "type": "number",
+                    "example": 0.6079271
                 },
                 "risk": {
                     "type": "integer",
-                    "maximum": 5,
-                    "minimum": 0,
                     "example": 1
                 },
-                "shortname": {
+                "snippet": {
                     "type": "string",
-                    "example": "MIT"
+                    "example": "\u003cmark\u003eMIT\u003c/mark\u003e License Copyright (c) \u0026lt;year\u0026gt; \u0026lt;copyright holders\u0026gt;"
                 },
-                "source": {
+                "type": {
                     "type": "string",
-                    "example": "Source"
+                    "enum": [
+                        "LICENSE",
+                        "OBLIGATION"
+                    ],
+                    "example": "LICENSE"
+                }
+            }
+        },
+        "models.ServiceAccount": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
                 },
-                "spdx_id": {
+                "description": {
                     "type": "string",
-                    "example": "MIT"
+                    "example": "Imports licenses on every release"
                 },
-                "text": {
+                "expires_at": {
                     "type": "string",
-                    "example": "MIT License Text here"
+                    "example": "2027-01-01T00:00:00Z"
                 },
-                "text_updatable": {
-                    "type": "boolean",
-                    "example": false
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "url": {
+                "owner_team_id": {
                     "type": "string",
-                    "example": "https://opensource.org/licenses/MIT"
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 }
             }
         },
-        "models.ObligationCategory": {
+        "models.ServiceAccountClient": {
             "type": "object",
             "required": [
-                "category"
+                "client_id"
             ],
             "properties": {
-                "category": {
+                "client_id": {
                     "type": "string",
-                    "example": "GENERAL"
+                    "example": "release-pipeline"
                 }
             }
         },
-        "models.ObligationCategoryResponse": {
+        "models.ServiceAccountCreate": {
             "type": "object",
+            "required": [
+                "name",
+                "owner_team",
+                "user_level"
+            ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.ObligationCategory"
-                    }
+                "description": {
+                    "type": "string",
+                    "example": "Imports licenses on every release"
                 },
-                "paginationmeta": {
-                    "$ref": "#/definitions/models.PaginationMeta"
+                "expires_at": {
+                    "type": "string",
+                    "example": "2027-01-01T00:00:00Z"
                 },
-                "status": {
-                    "type": "integer",
-                    "example": 200
+                "name": {
+                    "type": "string",
+                    "example": "release-pipeline"
+                },
+                "owner_team": {
+                    "type": "string",
+                    "example": "release-engineering"
+                },
+                "user_level": {
+                    "type": "string",
+                    "enum": [
+                        "USER",
+                        "ADMIN"
+                    ],
+                    "example": "USER"
                 }
             }
         },
-        "models.ObligationClassification": {
+        "models.ServiceAccountDTO": {
             "type": "object",
-            "required": [
-                "classification",
-                "color"
-            ],
             "properties": {
-                "classification": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "client_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "release-pipeline"
+                    ]
+                },
+                "created_at": {
                     "type": "string",
-                    "example": "GREEN"
+                    "example": "2026-01-01T00:00:00Z"
                 },
-                "color": {
+                "description": {
                     "type": "string",
-                    "example": "#00FF00"
+                    "example": "Imports licenses on every release"
+                },
+                "expired": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2027-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "release-pipeline"
+                },
+                "owner_team": {
+                    "type": "string",
+                    "example": "release-engineering"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "user_level": {
+                    "type": "string",
+                    "example": "USER"
                 }
             }
         },
-        "models.ObligationClassificationResponse": {
+        "models.ServiceAccountResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.ObligationClassification"
+                        "$ref": "#/definitions/models.ServiceAccountDTO"
                     }
                 },
                 "paginationmeta": {
@@ -3026,137 +8299,141 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ObligationCreateDTO": {
+        "models.ServiceAccountUpdate": {
             "type": "object",
-            "required": [
-                "classification",
-                "license_ids",
-                "text",
-                "topic",
-                "type"
-            ],
             "properties": {
                 "active": {
-                    "type": "boolean"
+                    "type": "boolean",
+                    "example": true
                 },
-                "category": {
+                "description": {
                     "type": "string",
-                    "example": "DISTRIBUTION"
+                    "example": "Imports licenses on every release"
                 },
-                "classification": {
+                "expires_at": {
                     "type": "string",
-                    "example": "GREEN"
-                },
-                "comment": {
-                    "type": "string"
-                },
-                "external_ref": {
-                    "$ref": "#/definitions/models.ObligationSchemaExtension"
-                },
-                "license_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
-                        "f812jfae-7dbc-11d0-a765-00a0hf06bf6"
-                    ]
+                    "example": "2027-01-01T00:00:00Z"
                 },
-                "text": {
+                "owner_team": {
                     "type": "string",
-                    "example": "Source code be made available when distributing the software."
+                    "example": "release-engineering"
                 },
-                "text_updatable": {
-                    "type": "boolean",
-                    "example": true
+                "user_level": {
+                    "type": "string",
+                    "enum": [
+                        "USER",
+                        "ADMIN"
+                    ],
+                    "example": "ADMIN"
+                }
+            }
+        },
+        "models.SimilarLicense": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "topic": {
+                "shortname": {
                     "type": "string",
-                    "example": "copyleft"
+                    "example": "MIT"
                 },
-                "type": {
+                "similarity": {
+                    "type": "number"
+                },
+                "text": {
                     "type": "string",
-                    "example": "RISK"
+                    "example": "MIT License Text here"
                 }
             }
         },
-        "models.ObligationId": {
+        "models.SimilarObligation": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "string",
                     "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
+                "similarity": {
+                    "type": "number"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "obligation text here"
+                },
                 "topic": {
                     "type": "string",
-                    "example": "copyleft"
+                    "example": "MIT license"
                 }
             }
         },
-        "models.ObligationImportStatus": {
+        "models.SimilarityRequest": {
             "type": "object",
+            "required": [
+                "text"
+            ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.ObligationId"
+                "limit": {
+                    "description": "Limit is the maximum number of matches, all matches by default",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 10
                 },
-                "message": {
+                "min_length": {
+                    "description": "MinLength is the minimum length of the texts of matches, to skip short\ntexts which are similar to almost anything",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "omit_text": {
+                    "description": "OmitText leaves the texts of matches out of the response",
+                    "type": "boolean",
+                    "example": false
+                },
+                "text": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "integer",
-                    "example": 200
+                "threshold": {
+                    "description": "Threshold is the minimum trigram similarity of matches, defaulting to\nSIMILARITY_THRESHOLD",
+                    "type": "number",
+                    "maximum": 1,
+                    "example": 0.8
                 }
             }
         },
-        "models.ObligationPreview": {
+        "models.StewardAssignment": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
-                },
-                "topic": {
-                    "type": "string",
-                    "example": "Provide Copyright Notices"
-                },
-                "type": {
+                "team": {
                     "type": "string",
-                    "enum": [
-                        "OBLIGATION",
-                        "RESTRICTION",
-                        "RISK",
-                        "RIGHT"
-                    ]
+                    "example": "copyleft-reviewers"
                 }
             }
         },
-        "models.ObligationPreviewResponse": {
+        "models.StewardedItems": {
             "type": "object",
             "properties": {
-                "data": {
+                "licenses": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.ObligationPreview"
+                        "$ref": "#/definitions/models.StewardedLicense"
                     }
                 },
-                "status": {
-                    "type": "integer",
-                    "example": 200
+                "obligations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StewardedObligation"
+                    }
                 }
             }
         },
-        "models.ObligationResponse": {
+        "models.StewardedItemsResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.ObligationResponseDTO"
-                    }
-                },
-                "paginationmeta": {
-                    "$ref": "#/definitions/models.PaginationMeta"
+                    "$ref": "#/definitions/models.StewardedItems"
                 },
                 "status": {
                     "type": "integer",
@@ -3164,182 +8441,122 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ObligationResponseDTO": {
+        "models.StewardedLicense": {
             "type": "object",
-            "required": [
-                "category",
-                "classification",
-                "license_ids",
-                "text",
-                "topic",
-                "type"
-            ],
             "properties": {
-                "active": {
-                    "type": "boolean"
-                },
-                "category": {
-                    "type": "string",
-                    "example": "DISTRIBUTION"
-                },
-                "classification": {
-                    "type": "string",
-                    "example": "GREEN"
-                },
-                "comment": {
-                    "type": "string"
-                },
-                "external_ref": {
-                    "$ref": "#/definitions/models.ObligationSchemaExtension"
-                },
                 "id": {
                     "type": "string",
                     "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "license_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
-                        "f812jfae-7dbc-11d0-a765-00a0hf06bf6"
-                    ]
-                },
-                "text": {
-                    "type": "string",
-                    "example": "Source code be made available when distributing the software."
-                },
-                "text_updatable": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "topic": {
+                "shortname": {
                     "type": "string",
-                    "example": "copyleft"
+                    "example": "GPL-2.0-or-later"
                 },
-                "type": {
+                "team": {
                     "type": "string",
-                    "example": "RISK"
+                    "example": "copyleft-reviewers"
                 }
             }
         },
-        "models.ObligationSchemaExtension": {
+        "models.StewardedObligation": {
             "type": "object",
             "properties": {
-                "obligation_explanation": {
-                    "type": "string"
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "obligation_suffix": {
-                    "type": "string"
+                "team": {
+                    "type": "string",
+                    "example": "copyleft-reviewers"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Provide Copyright Notices"
                 }
             }
         },
-        "models.ObligationType": {
+        "models.TeamCreate": {
             "type": "object",
             "required": [
-                "type"
+                "name"
             ],
             "properties": {
-                "type": {
+                "description": {
                     "type": "string",
-                    "example": "PERMISSION"
-                }
-            }
-        },
-        "models.ObligationTypeResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
+                    "example": "Legal reviewers of copyleft licenses"
+                },
+                "members": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.ObligationType"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "fossy"
+                    ]
                 },
-                "paginationmeta": {
-                    "$ref": "#/definitions/models.PaginationMeta"
+                "name": {
+                    "type": "string",
+                    "example": "copyleft-reviewers"
                 },
-                "status": {
-                    "type": "integer",
-                    "example": 200
+                "steward_only_edits": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.ObligationUpdateDTO": {
+        "models.TeamDTO": {
             "type": "object",
-            "required": [
-                "license_ids"
-            ],
             "properties": {
-                "active": {
-                    "type": "boolean"
-                },
-                "category": {
+                "created_at": {
                     "type": "string",
-                    "example": "DISTRIBUTION"
+                    "example": "2026-01-01T00:00:00Z"
                 },
-                "classification": {
+                "description": {
                     "type": "string",
-                    "example": "GREEN"
+                    "example": "Legal reviewers of copyleft licenses"
                 },
-                "comment": {
-                    "type": "string"
-                },
-                "external_ref": {
-                    "type": "object",
-                    "additionalProperties": true
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
                 },
-                "license_ids": {
+                "members": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     },
                     "example": [
-                        "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
-                        "f812jfae-7dbc-11d0-a765-00a0hf06bf6"
+                        "fossy"
                     ]
                 },
-                "text": {
+                "name": {
                     "type": "string",
-                    "example": "Source code be made available when distributing the software."
+                    "example": "copyleft-reviewers"
                 },
-                "text_updatable": {
+                "steward_only_edits": {
                     "type": "boolean",
                     "example": true
-                },
-                "topic": {
-                    "type": "string",
-                    "example": "copyleft"
-                },
-                "type": {
-                    "type": "string",
-                    "example": "RISK"
                 }
             }
         },
-        "models.OidcClientDTO": {
+        "models.TeamMemberInput": {
             "type": "object",
+            "required": [
+                "username"
+            ],
             "properties": {
-                "add_date": {
-                    "type": "string"
-                },
-                "client_id": {
-                    "type": "string",
-                    "example": "qwerty"
-                },
-                "id": {
+                "username": {
                     "type": "string",
-                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                    "example": "fossy"
                 }
             }
         },
-        "models.OidcClientsResponse": {
+        "models.TeamResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.OidcClientDTO"
+                        "$ref": "#/definitions/models.TeamDTO"
                     }
                 },
                 "paginationmeta": {
@@ -3351,156 +8568,148 @@ const docTemplate = `{
                 }
             }
         },
-        "models.PaginationMeta": {
+        "models.TeamUpdate": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer",
-                    "example": 10
-                },
-                "next": {
-                    "type": "string",
-                    "example": "/api/v1/licenses?limit=10\u0026page=11"
-                },
-                "page": {
-                    "type": "integer",
-                    "example": 10
-                },
-                "previous": {
+                "description": {
                     "type": "string",
-                    "example": "/api/v1/licenses?limit=10\u0026page=9"
+                    "example": "Legal reviewers of copyleft licenses"
                 },
-                "resource_count": {
-                    "type": "integer",
-                    "example": 200
+                "steward_only_edits": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Tokens"
                 },
-                "total_pages": {
-                    "type": "integer",
-                    "example": 20
+                "meta": {},
+                "status": {
+                    "type": "integer"
                 }
             }
         },
-        "models.ProfileUpdate": {
+        "models.Tokens": {
             "type": "object",
             "properties": {
-                "display_name": {
+                "access_token": {
                     "type": "string",
-                    "example": "fossy"
-                },
-                "subscribed": {
-                    "type": "boolean",
-                    "example": false
+                    "example": "your_access_token_here"
                 },
-                "user_email": {
-                    "type": "string"
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-02-10T15:11:14Z"
                 },
-                "user_password": {
-                    "type": "string"
+                "refresh_token": {
+                    "type": "string",
+                    "example": "your_refresh_token_here"
                 }
             }
         },
-        "models.RefreshToken": {
+        "models.TwoFactorChallenge": {
             "type": "object",
             "properties": {
-                "refresh_token": {
+                "enrollment_required": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "expires_at": {
                     "type": "string",
-                    "example": "your_refresh_token_here"
+                    "example": "2026-02-10T15:11:14Z"
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "your_mfa_token_here"
                 }
             }
         },
-        "models.RiskLicenseCount": {
+        "models.TwoFactorChallengeResponse": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer",
-                    "example": 6
+                "data": {
+                    "$ref": "#/definitions/models.TwoFactorChallenge"
                 },
-                "risk": {
-                    "type": "integer",
-                    "example": 2
+                "meta": {},
+                "status": {
+                    "type": "integer"
                 }
             }
         },
-        "models.SearchLicense": {
+        "models.TwoFactorCode": {
             "type": "object",
             "required": [
-                "field",
-                "search_term"
+                "code"
             ],
             "properties": {
-                "field": {
+                "code": {
                     "type": "string",
-                    "example": "text"
-                },
-                "search": {
+                    "example": "123456"
+                }
+            }
+        },
+        "models.TwoFactorEnrollment": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
                     "type": "string",
-                    "enum": [
-                        "fuzzy",
-                        "full_text_search"
-                    ]
+                    "example": "otpauth://totp/LicenseDB:fossy?algorithm=SHA1\u0026digits=6\u0026issuer=LicenseDB\u0026period=30\u0026secret=JBSWY3DPEHPK3PXP"
                 },
-                "search_term": {
+                "secret": {
                     "type": "string",
-                    "example": "MIT License"
+                    "example": "JBSWY3DPEHPK3PXP"
                 }
             }
         },
-        "models.SimilarLicense": {
+        "models.TwoFactorEnrollmentResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
-                },
-                "shortname": {
-                    "type": "string",
-                    "example": "MIT"
-                },
-                "similarity": {
-                    "type": "number"
+                "data": {
+                    "$ref": "#/definitions/models.TwoFactorEnrollment"
                 },
-                "text": {
-                    "type": "string",
-                    "example": "MIT License Text here"
+                "meta": {},
+                "status": {
+                    "type": "integer"
                 }
             }
         },
-        "models.SimilarObligation": {
+        "models.TwoFactorLogin": {
             "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
-                },
-                "similarity": {
-                    "type": "number"
-                },
-                "text": {
+                "code": {
                     "type": "string",
-                    "example": "obligation text here"
+                    "example": "123456"
                 },
-                "topic": {
+                "mfa_token": {
                     "type": "string",
-                    "example": "MIT license"
+                    "example": "your_mfa_token_here"
                 }
             }
         },
-        "models.SimilarityRequest": {
+        "models.TwoFactorLoginEnroll": {
             "type": "object",
             "required": [
-                "text"
+                "mfa_token"
             ],
             "properties": {
-                "text": {
-                    "type": "string"
+                "mfa_token": {
+                    "type": "string",
+                    "example": "your_mfa_token_here"
                 }
             }
         },
-        "models.TokenResponse": {
+        "models.TwoFactorLoginResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/models.Tokens"
+                    "$ref": "#/definitions/models.TwoFactorLoginResult"
                 },
                 "meta": {},
                 "status": {
@@ -3508,7 +8717,7 @@ const docTemplate = `{
                 }
             }
         },
-        "models.Tokens": {
+        "models.TwoFactorLoginResult": {
             "type": "object",
             "properties": {
                 "access_token": {
@@ -3519,12 +8728,87 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2026-02-10T15:11:14Z"
                 },
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k3v9-2xq7"
+                    ]
+                },
                 "refresh_token": {
                     "type": "string",
                     "example": "your_refresh_token_here"
                 }
             }
         },
+        "models.TwoFactorPolicy": {
+            "type": "object",
+            "required": [
+                "required",
+                "user_level"
+            ],
+            "properties": {
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "user_level": {
+                    "type": "string",
+                    "enum": [
+                        "USER",
+                        "ADMIN",
+                        "SUPER_ADMIN"
+                    ],
+                    "example": "ADMIN"
+                }
+            }
+        },
+        "models.TwoFactorPolicyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TwoFactorPolicy"
+                    }
+                },
+                "paginationmeta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.TwoFactorRecoveryCodes": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k3v9-2xq7"
+                    ]
+                }
+            }
+        },
+        "models.TwoFactorRecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.TwoFactorRecoveryCodes"
+                },
+                "meta": {},
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.User": {
             "type": "object",
             "properties": {
@@ -3539,6 +8823,9 @@ const docTemplate = `{
                 "subscribed": {
                     "type": "boolean"
                 },
+                "totp_enabled": {
+                    "type": "boolean"
+                },
                 "user_email": {
                     "type": "string",
                     "example": "fossy@org.com"
@@ -3588,6 +8875,200 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UserDataExport": {
+            "type": "object",
+            "properties": {
+                "audits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserDataExportAudit"
+                    }
+                },
+                "created_licenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserDataExportLicense"
+                    }
+                },
+                "exported_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "oidc_clients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserDataExportClient"
+                    }
+                },
+                "owned_service_accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ServiceAccountDTO"
+                    }
+                },
+                "recovery_codes": {
+                    "$ref": "#/definitions/models.UserDataExportRecovery"
+                },
+                "scans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScanDTO"
+                    }
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserDataExportTeam"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/models.UserDataExportProfile"
+                }
+            }
+        },
+        "models.UserDataExportAudit": {
+            "type": "object",
+            "properties": {
+                "change_logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ChangeLog"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "relation": {
+                    "type": "string",
+                    "enum": [
+                        "ACTOR",
+                        "IMPERSONATOR",
+                        "SUBJECT"
+                    ],
+                    "example": "ACTOR"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2023-12-01T18:10:25.00+05:30"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "LICENSE"
+                },
+                "type_id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                }
+            }
+        },
+        "models.UserDataExportClient": {
+            "type": "object",
+            "properties": {
+                "add_date": {
+                    "type": "string",
+                    "example": "2023-12-01T18:10:25.00+05:30"
+                },
+                "client_id": {
+                    "type": "string",
+                    "example": "release-pipeline"
+                }
+            }
+        },
+        "models.UserDataExportLicense": {
+            "type": "object",
+            "properties": {
+                "add_date": {
+                    "type": "string",
+                    "example": "2023-12-01T18:10:25.00+05:30"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "shortname": {
+                    "type": "string",
+                    "example": "MIT"
+                }
+            }
+        },
+        "models.UserDataExportProfile": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "anonymised_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "display_name": {
+                    "type": "string",
+                    "example": "fossy"
+                },
+                "external_id": {
+                    "type": "string",
+                    "example": "00u1abcd"
+                },
+                "external_issuer": {
+                    "type": "string",
+                    "example": "https://login.example.com"
+                },
+                "external_subject": {
+                    "type": "string",
+                    "example": "248289761001"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "subscribed": {
+                    "type": "boolean"
+                },
+                "totp_enabled": {
+                    "type": "boolean"
+                },
+                "user_email": {
+                    "type": "string",
+                    "example": "fossy@org.com"
+                },
+                "user_level": {
+                    "type": "string",
+                    "example": "USER"
+                },
+                "user_name": {
+                    "type": "string",
+                    "example": "fossy"
+                }
+            }
+        },
+        "models.UserDataExportRecovery": {
+            "type": "object",
+            "properties": {
+                "total": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "unused": {
+                    "type": "integer",
+                    "example": 8
+                }
+            }
+        },
+        "models.UserDataExportTeam": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "copyleft-reviewers"
+                }
+            }
+        },
         "models.UserLogin": {
             "type": "object",
             "required": [
@@ -3660,6 +9141,12 @@ const docTemplate = `{
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
+        },
+        "ScimAuth": {
+            "description": "SCIM_BEARER_TOKEN of the server with the ` + "`" + `Bearer ` + "`" + ` prefix, used by SCIM provisioning clients.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
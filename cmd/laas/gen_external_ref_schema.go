@@ -9,9 +9,13 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"path/filepath"
 
@@ -29,6 +33,18 @@ type ExternalRefFieldMetaData struct {
 	StructFieldName string `yaml:"struct_field_name"`
 	Type            string `yaml:"type"`
 	Name            string `yaml:"name"`
+	Label           string `yaml:"label"`
+	// Values are the allowed values of enum fields and of the items of array fields
+	Values   []string `yaml:"values"`
+	Required bool     `yaml:"required"`
+	// Regex is matched by string, url and enum values and by the items of array fields
+	Regex string `yaml:"regex"`
+	// Min and Max bound the value of int and number fields, the length of
+	// string and url fields and the number of items of array fields
+	Min *float64 `yaml:"min"`
+	Max *float64 `yaml:"max"`
+	// Fields are the fields of object fields
+	Fields []ExternalRefFieldMetaData `yaml:"fields"`
 }
 
 // ExternalRefFields is the list of metadata of all extra fields
@@ -41,10 +57,30 @@ type ExternalRefYAML struct {
 	Obligation ExternalRefFields `yaml:"obligation"`
 }
 
+// externalRefTypes are the types of external_ref_fields.yaml and the types of
+// the ExternalRefField metadata generated for them. Enums are strings with
+// allowed values.
+var externalRefTypes = map[string]string{
+	"boolean":   "ExternalRefTypeBoolean",
+	"string":    "ExternalRefTypeString",
+	"int":       "ExternalRefTypeInt",
+	"number":    "ExternalRefTypeNumber",
+	"enum":      "ExternalRefTypeString",
+	"array":     "ExternalRefTypeArray",
+	"date":      "ExternalRefTypeDate",
+	"timestamp": "ExternalRefTypeTimestamp",
+	"url":       "ExternalRefTypeURL",
+	"object":    "ExternalRefTypeObject",
+}
+
 func main() {
+	configFile := flag.String("config", PATH_EXTERNAL_REF_CONFIG_FILE, "external_ref fields configuration to generate the structs of")
+	structFile := flag.String("out", PATH_EXTERNAL_REF_STRUCT_FILE, "file to write the generated structs to")
+	flag.Parse()
+
 	externalRefYAML := ExternalRefYAML{}
 
-	fieldsMetadata, err := os.ReadFile(PATH_EXTERNAL_REF_CONFIG_FILE)
+	fieldsMetadata, err := os.ReadFile(*configFile)
 	if err != nil {
 		log.Fatalf("Failed to instantiate json schema for external ref in license: %v", err)
 	}
@@ -61,56 +97,180 @@ func main() {
 
 	// REUSE-IgnoreStart
 
-	var licenseFields, obligationFields []jen.Code
+	licenseFields, err := generateStruct(f, "LicenseDBSchemaExtension", externalRefYAML.License.Fields, true)
+	if err != nil {
+		log.Fatalf("Failed to instantiate json schema for external ref in license: %v", err)
+	}
+	f.Comment("licenseExternalRefFields are the fields of LicenseDBSchemaExtension")
+	f.Var().Id("licenseExternalRefFields").Op("=").Index().Id("ExternalRefField").Values(licenseFields...)
+
+	obligationFields, err := generateStruct(f, "ObligationSchemaExtension", externalRefYAML.Obligation.Fields, true)
+	if err != nil {
+		log.Fatalf("Failed to instantiate json schema for external ref in obligation: %v", err)
+	}
+	f.Comment("obligationExternalRefFields are the fields of ObligationSchemaExtension")
+	f.Var().Id("obligationExternalRefFields").Op("=").Index().Id("ExternalRefField").Values(obligationFields...)
+
+	if err := f.Save(*structFile); err != nil {
+		log.Fatalf("Failed to save the external ref structs: %v", err)
+	}
+}
+
+// generateStruct adds the struct name with the given fields, and the structs
+// of its object fields, to f. It returns the ExternalRefField metadata of the
+// fields.
+func generateStruct(f *jen.File, name string, fieldsMetadata []ExternalRefFieldMetaData, extra bool) ([]jen.Code, error) {
+	var fields, metadata []jen.Code
+	for _, m := range fieldsMetadata {
+		if m.StructFieldName == "" {
+			return nil, errors.New("field struct_field_name is missing in external_ref_fields.yaml")
+		}
+		fieldType, ok := externalRefTypes[m.Type]
+		if !ok {
+			return nil, fmt.Errorf("type %s in external_ref_fields.yaml is not supported", m.Type)
+		}
+		if err := checkConstraints(m); err != nil {
+			return nil, fmt.Errorf("field %s in external_ref_fields.yaml: %v", m.Name, err)
+		}
 
-	for _, f := range externalRefYAML.License.Fields {
-		field := jen.Id(f.StructFieldName).Op("*")
-		if f.StructFieldName == "" {
-			err = errors.New("field struct_field_name is missing in external_ref_fields.yaml")
+		field := jen.Id(m.StructFieldName)
+		tags := map[string]string{"json": fmt.Sprintf("%s,omitempty", m.Name)}
+		validate := []string{"omitempty"}
+		if m.Required {
+			validate = []string{"required"}
 		}
-		switch f.Type {
+		var nested []jen.Code
+		switch m.Type {
 		case "boolean":
-			field = field.Bool()
-		case "string":
-			field = field.String()
+			field = field.Op("*").Bool()
+		case "string", "enum", "url", "date":
+			field = field.Op("*").String()
 		case "int":
-			field = field.Int64()
-		default:
-			err = fmt.Errorf("type %s in external_ref_fields.yaml is not supported", f.Type)
+			field = field.Op("*").Int64()
+		case "number":
+			field = field.Op("*").Float64()
+		case "timestamp":
+			field = field.Op("*").Qual("time", "Time")
+		case "array":
+			field = field.Index().String()
+		case "object":
+			nestedName := name + m.StructFieldName
+			var err error
+			if nested, err = generateStruct(f, nestedName, m.Fields, false); err != nil {
+				return nil, err
+			}
+			field = field.Op("*").Id(nestedName)
+		}
+		switch m.Type {
+		case "url":
+			validate = append(validate, "url")
+			tags["format"] = "uri"
+		case "date":
+			validate = append(validate, "datetime=2006-01-02")
+			tags["format"] = "date"
+		case "timestamp":
+			tags["format"] = "date-time"
+		case "array":
+			if len(m.Values) != 0 {
+				tags["enums"] = strings.Join(m.Values, ",")
+			}
+		}
+		if m.Min != nil {
+			validate = append(validate, "min="+strconv.FormatFloat(*m.Min, 'f', -1, 64))
+		}
+		if m.Max != nil {
+			validate = append(validate, "max="+strconv.FormatFloat(*m.Max, 'f', -1, 64))
 		}
-		if err != nil {
-			log.Fatalf("Failed to instantiate json schema for external ref in license: %v", err)
-			return
+		if m.Type == "array" && (len(m.Values) != 0 || m.Regex != "") {
+			validate = append(validate, "dive")
 		}
-		field = field.Tag(map[string]string{"json": fmt.Sprintf("%s,omitempty", f.Name)})
-		licenseFields = append(licenseFields, field)
+		if len(m.Values) != 0 {
+			validate = append(validate, "oneof="+strings.Join(m.Values, " "))
+		}
+		if m.Regex != "" {
+			escaped := strings.NewReplacer(",", "0x2C", "|", "0x7C").Replace(m.Regex)
+			validate = append(validate, "regexp="+escaped)
+		}
+		if len(validate) > 1 || m.Required {
+			tags["validate"] = strings.Join(validate, ",")
+		}
+		if m.Label != "" {
+			tags["title"] = m.Label
+		}
+		fields = append(fields, field.Tag(tags))
+
+		label := m.Label
+		if label == "" {
+			label = m.Name
+		}
+		dict := jen.Dict{
+			jen.Id("Name"):     jen.Lit(m.Name),
+			jen.Id("Type"):     jen.Id(fieldType),
+			jen.Id("Label"):    jen.Lit(label),
+			jen.Id("Required"): jen.Lit(m.Required),
+		}
+		if len(m.Values) != 0 {
+			var values []jen.Code
+			for _, value := range m.Values {
+				values = append(values, jen.Lit(value))
+			}
+			dict[jen.Id("Enum")] = jen.Qual("github.com/lib/pq", "StringArray").Values(values...)
+		}
+		if m.Regex != "" {
+			dict[jen.Id("Regex")] = jen.Id("ptr").Call(jen.Lit(m.Regex))
+		}
+		if m.Min != nil {
+			dict[jen.Id("Min")] = jen.Id("ptr").Call(jen.Lit(*m.Min))
+		}
+		if m.Max != nil {
+			dict[jen.Id("Max")] = jen.Id("ptr").Call(jen.Lit(*m.Max))
+		}
+		if len(nested) != 0 {
+			dict[jen.Id("Fields")] = jen.Index().Id("ExternalRefField").Values(nested...)
+		}
+		metadata = append(metadata, jen.Values(dict))
 	}
 
-	f.Type().Id("LicenseDBSchemaExtension").Struct(licenseFields...)
+	if extra {
+		fields = append(fields, extraField())
+	}
+	f.Type().Id(name).Struct(fields...)
+	return metadata, nil
+}
 
-	for _, f := range externalRefYAML.Obligation.Fields {
-		field := jen.Id(f.StructFieldName).Op("*")
-		if f.StructFieldName == "" {
-			err = errors.New("field struct_field_name is missing in external_ref_fields.yaml")
-		}
-		switch f.Type {
-		case "boolean":
-			field = field.Bool()
-		case "string":
-			field = field.String()
-		case "int":
-			field = field.Int64()
-		default:
-			err = fmt.Errorf("type %s in external_ref_fields.yaml is not supported", f.Type)
+// checkConstraints checks that the values, regex, bounds and fields of a
+// field apply to its type.
+func checkConstraints(m ExternalRefFieldMetaData) error {
+	switch {
+	case m.Type == "enum" && len(m.Values) == 0:
+		return errors.New("enum fields need values")
+	case len(m.Values) != 0 && m.Type != "enum" && m.Type != "array":
+		return fmt.Errorf("values do not apply to %s fields", m.Type)
+	case m.Regex != "" && m.Type != "string" && m.Type != "enum" && m.Type != "url" && m.Type != "array":
+		return fmt.Errorf("regex does not apply to %s fields", m.Type)
+	case (m.Min != nil || m.Max != nil) && m.Type != "int" && m.Type != "number" && m.Type != "string" && m.Type != "url" && m.Type != "array":
+		return fmt.Errorf("min and max do not apply to %s fields", m.Type)
+	case m.Type == "object" && len(m.Fields) == 0:
+		return errors.New("object fields need fields")
+	case len(m.Fields) != 0 && m.Type != "object":
+		return fmt.Errorf("fields do not apply to %s fields", m.Type)
+	}
+	for _, value := range m.Values {
+		if value == "" || strings.ContainsAny(value, " ,") {
+			return fmt.Errorf("enum value '%s' must not be empty or contain spaces or commas", value)
 		}
-		if err != nil {
-			log.Fatalf("Failed to instantiate json schema for external ref in license: %v", err)
-			return
+	}
+	if m.Regex != "" {
+		if _, err := regexp.Compile(m.Regex); err != nil {
+			return err
 		}
-		field = field.Tag(map[string]string{"json": fmt.Sprintf("%s,omitempty", f.Name)})
-		obligationFields = append(obligationFields, field)
 	}
+	return nil
+}
 
-	f.Type().Id("ObligationSchemaExtension").Struct(obligationFields...)
-	f.Save(PATH_EXTERNAL_REF_STRUCT_FILE)
+// extraField is the field holding the values of the keys of external_ref
+// which are defined at runtime rather than in external_ref_fields.yaml
+func extraField() jen.Code {
+	return jen.Comment("Extra holds the values of the fields defined at runtime").Line().
+		Id("Extra").Map(jen.String()).Any().Tag(map[string]string{"json": "-"})
 }
@@ -15,8 +15,6 @@ import (
 	"strconv"
 
 	"github.com/joho/godotenv"
-	"github.com/lestrrat-go/httprc/v3"
-	"github.com/lestrrat-go/jwx/v3/jwk"
 	"go.uber.org/zap"
 
 	_ "github.com/dave/jennifer/jen"
@@ -50,15 +48,19 @@ func main() {
 		logger.LogFatal("Mandatory environment variables not configured")
 	}
 
-	if os.Getenv("JWKS_URI") != "" {
-		cache, err := jwk.NewCache(context.Background(), httprc.NewClient())
-		if err != nil {
-			logger.LogFatal("Failed to create jwk.Cache", zap.Error(err))
-		}
-		if err := cache.Register(context.Background(), os.Getenv("JWKS_URI")); err != nil {
-			logger.LogFatal("Failed to register JWKS URI", zap.Error(err))
-		}
-		auth.Jwks = cache
+	if err := auth.LoadSigningKeys(); err != nil {
+		logger.LogFatal("Failed to load token signing keys", zap.Error(err))
+	}
+
+	if err := auth.LoadOidcIssuers(); err != nil {
+		logger.LogFatal("Failed to load oidc issuers", zap.Error(err))
+	}
+	if err := auth.RegisterOidcIssuers(context.Background()); err != nil {
+		logger.LogFatal("Failed to register oidc issuers", zap.Error(err))
+	}
+
+	if err := auth.LoadLdapDirectory(); err != nil {
+		logger.LogFatal("Failed to configure ldap login", zap.Error(err))
 	}
 
 	dbhost := os.Getenv("DB_HOST")
@@ -85,6 +87,14 @@ func main() {
 		utils.Populatedb(*datafile)
 	}
 
+	if err := api.StartReviewReminders(); err != nil {
+		logger.LogFatal("Failed to start review reminders", zap.Error(err))
+	}
+
+	if err := api.StartScans(); err != nil {
+		logger.LogFatal("Failed to start scans", zap.Error(err))
+	}
+
 	r := api.Router()
 	if err := r.Run(); err != nil {
 		logger.LogFatal("Error while running the server", zap.Error(err))
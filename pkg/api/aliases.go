@@ -0,0 +1,561 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+	"github.com/fossology/LicenseDb/pkg/validations"
+)
+
+// aliasNamespaces are the alias namespaces in the order in which aliases
+// are preferred when resolving a name.
+var aliasNamespaces = []string{
+	models.AliasNamespaceSpdx,
+	models.AliasNamespaceFossology,
+	models.AliasNamespaceScancode,
+	models.AliasNamespaceVendor,
+	models.AliasNamespaceMerged,
+}
+
+// GetLicenseAliases lists the aliases of a license.
+//
+//	@Summary		Get the aliases of a license
+//	@Description	Get the alternative names under which a license is known
+//	@Id				GetLicenseAliases
+//	@Tags			Licenses
+//	@Produce		json
+//	@Param			id	path		string	true	"Id of the license"
+//	@Success		200	{object}	models.LicenseAliasesResponse
+//	@Failure		400	{object}	models.LicenseError	"Invalid license id"
+//	@Failure		404	{object}	models.LicenseError	"No license found"
+//	@Security		ApiKeyAuth || {}
+//	@Router			/licenses/{id}/aliases [get]
+func GetLicenseAliases(c *gin.Context) {
+	licenseId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   fmt.Sprintf("no license with id '%s' exists", c.Param("id")),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	var license models.LicenseDB
+	if err := db.DB.Preload("Aliases", func(tx *gorm.DB) *gorm.DB { return tx.Order("namespace, alias") }).
+		Where(models.LicenseDB{Id: licenseId}).First(&license).Error; err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusNotFound,
+			Message:   fmt.Sprintf("no license with id '%s' exists", licenseId.String()),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusNotFound, er)
+		return
+	}
+
+	aliases := []models.LicenseAliasDTO{}
+	for _, a := range license.Aliases {
+		aliases = append(aliases, a.ConvertToLicenseAliasDTO())
+	}
+	c.JSON(http.StatusOK, models.LicenseAliasesResponse{
+		Status: http.StatusOK,
+		Data:   aliases,
+		Meta: &models.PaginationMeta{
+			ResourceCount: len(aliases),
+		},
+	})
+}
+
+// CreateLicenseAlias adds an alias to a license.
+//
+//	@Summary		Add an alias to a license
+//	@Description	Add an alternative name of a license. An alias names a single license within its namespace.
+//	@Id				CreateLicenseAlias
+//	@Tags			Licenses
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Id of the license"
+//	@Param			alias	body		models.LicenseAliasInput	true	"Alias to add"
+//	@Success		201		{object}	models.LicenseAliasesResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		403		{object}	models.LicenseError	"License is stewarded by another team"
+//	@Failure		404		{object}	models.LicenseError	"No license found"
+//	@Failure		409		{object}	models.LicenseError	"Alias already names a license"
+//	@Security		ApiKeyAuth
+//	@Router			/licenses/{id}/aliases [post]
+func CreateLicenseAlias(c *gin.Context) {
+	var input models.LicenseAliasInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	input.Alias = strings.TrimSpace(input.Alias)
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not create alias with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	editLicenseAliases(c, "add", http.StatusCreated, func(tx *gorm.DB, license *models.LicenseDB) (*models.LicenseAlias, bool, error) {
+		if !aliasAvailable(c, tx, input.Alias, input.Namespace, uuid.Nil) {
+			return nil, false, nil
+		}
+		alias := models.LicenseAlias{
+			LicenseId: license.Id,
+			Alias:     input.Alias,
+			Namespace: input.Namespace,
+		}
+		if err := tx.Create(&alias).Error; err != nil {
+			return nil, false, err
+		}
+		return &alias, true, nil
+	})
+}
+
+// UpdateLicenseAlias updates an alias of a license.
+//
+//	@Summary		Update an alias of a license
+//	@Description	Update the name or namespace of an alias of a license
+//	@Id				UpdateLicenseAlias
+//	@Tags			Licenses
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string						true	"Id of the license"
+//	@Param			alias_id	path		string						true	"Id of the alias"
+//	@Param			alias		body		models.LicenseAliasUpdate	true	"Alias fields to update"
+//	@Success		200			{object}	models.LicenseAliasesResponse
+//	@Failure		400			{object}	models.LicenseError	"Invalid request body"
+//	@Failure		403			{object}	models.LicenseError	"License is stewarded by another team"
+//	@Failure		404			{object}	models.LicenseError	"No license or alias found"
+//	@Failure		409			{object}	models.LicenseError	"Alias already names a license"
+//	@Security		ApiKeyAuth
+//	@Router			/licenses/{id}/aliases/{alias_id} [patch]
+func UpdateLicenseAlias(c *gin.Context) {
+	var input models.LicenseAliasUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	if input.Alias != nil {
+		*input.Alias = strings.TrimSpace(*input.Alias)
+	}
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not update alias with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	editLicenseAliases(c, "update", http.StatusOK, func(tx *gorm.DB, license *models.LicenseDB) (*models.LicenseAlias, bool, error) {
+		alias, ok := licenseAlias(c, license)
+		if !ok {
+			return nil, false, nil
+		}
+		if input.Alias != nil {
+			alias.Alias = *input.Alias
+		}
+		if input.Namespace != nil {
+			alias.Namespace = *input.Namespace
+		}
+		if !aliasAvailable(c, tx, alias.Alias, alias.Namespace, alias.Id) {
+			return nil, false, nil
+		}
+		if err := tx.Model(&models.LicenseAlias{}).Where(models.LicenseAlias{Id: alias.Id}).
+			UpdateColumns(map[string]interface{}{"alias": alias.Alias, "namespace": alias.Namespace}).Error; err != nil {
+			return nil, false, err
+		}
+		return &alias, true, nil
+	})
+}
+
+// DeleteLicenseAlias removes an alias from a license.
+//
+//	@Summary		Remove an alias of a license
+//	@Description	Remove an alternative name of a license
+//	@Id				DeleteLicenseAlias
+//	@Tags			Licenses
+//	@Produce		json
+//	@Param			id			path	string	true	"Id of the license"
+//	@Param			alias_id	path	string	true	"Id of the alias"
+//	@Success		204
+//	@Failure		400	{object}	models.LicenseError	"Invalid license id"
+//	@Failure		403	{object}	models.LicenseError	"License is stewarded by another team"
+//	@Failure		404	{object}	models.LicenseError	"No license or alias found"
+//	@Security		ApiKeyAuth
+//	@Router			/licenses/{id}/aliases/{alias_id} [delete]
+func DeleteLicenseAlias(c *gin.Context) {
+	editLicenseAliases(c, "remove", http.StatusNoContent, func(tx *gorm.DB, license *models.LicenseDB) (*models.LicenseAlias, bool, error) {
+		alias, ok := licenseAlias(c, license)
+		if !ok {
+			return nil, false, nil
+		}
+		if err := tx.Where(models.LicenseAlias{Id: alias.Id}).Delete(&models.LicenseAlias{}).Error; err != nil {
+			return nil, false, err
+		}
+		return &alias, true, nil
+	})
+}
+
+// ResolveLicense finds the canonical license of a license name.
+//
+//	@Summary		Resolve a license name
+//	@Description	Find the license known under a name. Active licenses are matched by SPDX id, then by shortname and
+//	@Description	then by alias, ignoring case. Inactive licenses are matched by SPDX id and shortname last. The
+//	@Description	namespace restricts the aliases considered.
+//	@Id				ResolveLicense
+//	@Tags			Licenses
+//	@Produce		json
+//	@Param			name		query		string	true	"License name, SPDX id or alias"
+//	@Param			namespace	query		string	false	"Namespace of the alias"	Enums(SPDX, FOSSOLOGY, SCANCODE, VENDOR, MERGED)
+//	@Success		200			{object}	models.LicenseResolutionResponse
+//	@Failure		400			{object}	models.LicenseError	"Invalid name or namespace"
+//	@Failure		404			{object}	models.LicenseError	"No license known under the name"
+//	@Security		ApiKeyAuth || {}
+//	@Router			/licenses/resolve [get]
+func ResolveLicense(c *gin.Context) {
+	name := strings.TrimSpace(c.Query("name"))
+	namespace := c.Query("namespace")
+	if name == "" || (namespace != "" && !slices.Contains(aliasNamespaces, namespace)) {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "name is required and namespace must be one of " + strings.Join(aliasNamespaces, ", "),
+			Error:     fmt.Sprintf("invalid name '%s' or namespace '%s'", name, namespace),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	resolution, err := resolveLicense(db.DB.WithContext(c), name, namespace)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to resolve license"
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			status = http.StatusNotFound
+			message = fmt.Sprintf("no license is known as '%s'", name)
+		}
+		er := models.LicenseError{
+			Status:    status,
+			Message:   message,
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(status, er)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.LicenseResolutionResponse{
+		Status: http.StatusOK,
+		Data:   *resolution,
+	})
+}
+
+// resolveLicense finds the license known under a name, returning
+// gorm.ErrRecordNotFound if there is none.
+func resolveLicense(tx *gorm.DB, name, namespace string) (*models.LicenseResolution, error) {
+	loadLicense := func() *gorm.DB {
+		return tx.Preload("User").Preload("Obligations").Preload("Aliases")
+	}
+
+	var license models.LicenseDB
+	for _, match := range []struct {
+		column    string
+		active    bool
+		matchedBy string
+	}{
+		{"rf_spdx_id", true, "SPDX_ID"},
+		{"rf_shortname", true, "SHORTNAME"},
+	} {
+		err := loadLicense().Where("lower("+match.column+") = lower(?) AND rf_active = ?", name, match.active).
+			Order("rf_add_date").First(&license).Error
+		if err == nil {
+			return &models.LicenseResolution{License: license.ConvertToLicenseResponseDTO(), MatchedBy: match.matchedBy}, nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	var aliases []models.LicenseAlias
+	query := tx.Where("lower(alias) = lower(?)", name)
+	if namespace != "" {
+		query = query.Where(models.LicenseAlias{Namespace: namespace})
+	}
+	if err := query.Find(&aliases).Error; err != nil {
+		return nil, err
+	}
+	// Aliases of active licenses are preferred, then by namespace
+	var licenses []models.LicenseDB
+	if len(aliases) != 0 {
+		ids := make([]uuid.UUID, 0, len(aliases))
+		for _, a := range aliases {
+			ids = append(ids, a.LicenseId)
+		}
+		if err := loadLicense().Where("rf_id IN ?", ids).Find(&licenses).Error; err != nil {
+			return nil, err
+		}
+	}
+	var best *models.LicenseResolution
+	bestRank := 0
+	for _, a := range aliases {
+		i := slices.IndexFunc(licenses, func(l models.LicenseDB) bool { return l.Id == a.LicenseId })
+		if i < 0 {
+			continue
+		}
+		rank := slices.Index(aliasNamespaces, a.Namespace)
+		if !*licenses[i].Active {
+			rank += len(aliasNamespaces)
+		}
+		if best == nil || rank < bestRank {
+			dto := a.ConvertToLicenseAliasDTO()
+			best = &models.LicenseResolution{License: licenses[i].ConvertToLicenseResponseDTO(), MatchedBy: "ALIAS", Alias: &dto}
+			bestRank = rank
+		}
+	}
+	if best != nil {
+		return best, nil
+	}
+
+	for _, match := range []struct {
+		column    string
+		matchedBy string
+	}{
+		{"rf_spdx_id", "SPDX_ID"},
+		{"rf_shortname", "SHORTNAME"},
+	} {
+		err := loadLicense().Where("lower("+match.column+") = lower(?)", name).
+			Order("rf_add_date").First(&license).Error
+		if err == nil {
+			return &models.LicenseResolution{License: license.ConvertToLicenseResponseDTO(), MatchedBy: match.matchedBy}, nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// editLicenseAliases loads the license of the request and applies an edit
+// of its aliases, auditing the change. The edit returns false if it wrote an
+// error response.
+func editLicenseAliases(c *gin.Context, action string, status int,
+	edit func(tx *gorm.DB, license *models.LicenseDB) (*models.LicenseAlias, bool, error)) {
+	licenseId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   fmt.Sprintf("no license with id '%s' exists", c.Param("id")),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var oldLicense models.LicenseDB
+		if err := tx.Preload("User").Preload("Obligations").Preload("Aliases").
+			Where(models.LicenseDB{Id: licenseId}).First(&oldLicense).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   fmt.Sprintf("license with id '%s' not found", licenseId.String()),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return nil
+		}
+
+		stewardTeam, err := stewardTeamDenyingEdit(c, tx, oldLicense.StewardTeamId)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   fmt.Sprintf("Failed to %s alias", action),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if stewardTeam != nil {
+			er := models.LicenseError{
+				Status:    http.StatusForbidden,
+				Message:   "only members of the steward team can edit this license",
+				Error:     fmt.Sprintf("license is stewarded by team '%s'", stewardTeam.Name),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusForbidden, er)
+			return nil
+		}
+
+		alias, ok, err := edit(tx, &oldLicense)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				status = http.StatusConflict
+			}
+			er := models.LicenseError{
+				Status:    status,
+				Message:   fmt.Sprintf("Failed to %s alias", action),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(status, er)
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		if err := utils.IncreaseVersions(tx, &models.LicenseDB{}, "rf_id", []uuid.UUID{licenseId}); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   fmt.Sprintf("Failed to %s alias", action),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		var newLicense models.LicenseDB
+		if err := tx.Preload("User").Preload("Obligations").Preload("Aliases").
+			Where(models.LicenseDB{Id: licenseId}).First(&newLicense).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   fmt.Sprintf("Failed to %s alias", action),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if err := utils.AddChangelogsForLicense(tx, userId, &newLicense, &oldLicense); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   fmt.Sprintf("Failed to %s alias", action),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if status == http.StatusNoContent {
+			c.Status(http.StatusNoContent)
+			return nil
+		}
+		if i := slices.IndexFunc(newLicense.Aliases, func(a models.LicenseAlias) bool { return a.Id == alias.Id }); i >= 0 {
+			alias = &newLicense.Aliases[i]
+		}
+		c.JSON(status, models.LicenseAliasesResponse{
+			Status: status,
+			Data:   []models.LicenseAliasDTO{alias.ConvertToLicenseAliasDTO()},
+			Meta: &models.PaginationMeta{
+				ResourceCount: 1,
+			},
+		})
+		return nil
+	})
+}
+
+// licenseAlias finds the alias of the request among the aliases of a
+// license, writing the error response if there is none.
+func licenseAlias(c *gin.Context, license *models.LicenseDB) (models.LicenseAlias, bool) {
+	aliasId, err := uuid.Parse(c.Param("alias_id"))
+	if err == nil {
+		for _, a := range license.Aliases {
+			if a.Id == aliasId {
+				return a, true
+			}
+		}
+		err = fmt.Errorf("license '%s' has no alias with id '%s'", license.Id, aliasId)
+	}
+	er := models.LicenseError{
+		Status:    http.StatusNotFound,
+		Message:   fmt.Sprintf("no alias with id '%s' exists", c.Param("alias_id")),
+		Error:     err.Error(),
+		Path:      c.Request.URL.Path,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	c.JSON(http.StatusNotFound, er)
+	return models.LicenseAlias{}, false
+}
+
+// aliasAvailable checks that no other alias of the namespace has the name,
+// writing the conflict response if one does.
+func aliasAvailable(c *gin.Context, tx *gorm.DB, name, namespace string, aliasId uuid.UUID) bool {
+	var existing models.LicenseAlias
+	err := tx.Where("lower(alias) = lower(?) AND namespace = ? AND id <> ?", name, namespace, aliasId).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true
+	}
+	er := models.LicenseError{
+		Status:    http.StatusConflict,
+		Message:   fmt.Sprintf("alias '%s' already exists in namespace '%s'", name, namespace),
+		Error:     fmt.Sprintf("alias names license '%s'", existing.LicenseId),
+		Path:      c.Request.URL.Path,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	if err != nil {
+		er.Status = http.StatusInternalServerError
+		er.Error = err.Error()
+	}
+	c.JSON(er.Status, er)
+	return false
+}
@@ -47,6 +47,11 @@ import (
 //	@in							header
 //	@name						Authorization
 //	@description				Token from /login endpoint. Enter the token with the `Bearer ` prefix, e.g. \"Bearer eyJhbGciOiJ.....\"
+//
+//	@securityDefinitions.apikey	ScimAuth
+//	@in							header
+//	@name						Authorization
+//	@description				SCIM_BEARER_TOKEN of the server with the `Bearer ` prefix, used by SCIM provisioning clients.
 
 const (
 	DEFAULT_PORT                            = "8080"
@@ -83,6 +88,13 @@ func Router() *gin.Engine {
 	// Pagination middleware
 	r.Use(middleware.PaginationMiddleware())
 
+	// Keys and discovery document for verifying access tokens issued by LicenseDB
+	wellKnown := r.Group("/.well-known")
+	{
+		wellKnown.GET("/jwks.json", auth.GetJwks)
+		wellKnown.GET("/openid-configuration", auth.GetOpenIdConfiguration)
+	}
+
 	if authEnabled {
 		unAuthorizedv1 := r.Group("/api/v1")
 		{
@@ -93,6 +105,8 @@ func Router() *gin.Engine {
 			login := unAuthorizedv1.Group("/login")
 			{
 				login.POST("", auth.Login)
+				login.POST("/2fa", auth.LoginTwoFactor)
+				login.POST("/2fa/enroll", auth.LoginTwoFactorEnroll)
 			}
 			ref := unAuthorizedv1.Group("/refresh-token")
 			{
@@ -106,6 +120,16 @@ func Router() *gin.Engine {
 			{
 				oidc.POST("", auth.CreateOidcUser)
 			}
+			scim := unAuthorizedv1.Group("/scim/v2/Users")
+			scim.Use(middleware.ScimAuthenticationMiddleware())
+			{
+				scim.GET("", ScimGetUsers)
+				scim.POST("", ScimCreateUser)
+				scim.GET(":id", ScimGetUser)
+				scim.PUT(":id", ScimReplaceUser)
+				scim.PATCH(":id", ScimPatchUser)
+				scim.DELETE(":id", ScimDeleteUser)
+			}
 		}
 
 		authorizedv1 := r.Group("/api/v1")
@@ -117,25 +141,50 @@ func Router() *gin.Engine {
 				licenses.GET(":id", GetLicense)
 				licenses.GET("export", ExportLicenses)
 				licenses.GET("/preview", GetAllLicensePreviews)
+				licenses.GET("/resolve", ResolveLicense)
+				licenses.GET(":id/aliases", GetLicenseAliases)
+				licenses.GET("/external-ref-schema", GetLicenseExternalRefSchema)
 				licenses.POST("", CreateLicense)
 				licenses.PATCH(":id", UpdateLicense)
+				licenses.PUT(":id/steward", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), SetLicenseSteward)
+				licenses.POST(":id/review", ReviewLicense)
+				licenses.POST(":id/merge", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), MergeLicense)
+				licenses.POST(":id/aliases", CreateLicenseAlias)
+				licenses.PATCH(":id/aliases/:alias_id", UpdateLicenseAlias)
+				licenses.DELETE(":id/aliases/:alias_id", DeleteLicenseAlias)
+				licenses.GET("/duplicates", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), GetDuplicateLicenses)
 				licenses.POST("import", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), ImportLicenses)
 				licenses.POST("/similarity", getSimilarLicenses)
+				licenses.POST("/identify", IdentifyLicense)
 
 			}
 			search := authorizedv1.Group("/search")
 			{
+				search.GET("", Search)
 				search.POST("", SearchInLicense)
 			}
 			users := authorizedv1.Group("/users")
+			// Impersonation tokens may only read the profile of the impersonated user
+			users.GET("/profile", auth.GetUserProfile)
+			users.GET("/profile/stewarded", GetStewardedItems)
+			users.Use(middleware.DenyImpersonationMiddleware())
 			{
 				users.GET("", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.GetAllUser)
-				users.GET("/profile", auth.GetUserProfile)
 				users.GET(":username", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.GetUser)
 				users.POST("", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.CreateUser)
 				users.PATCH("", auth.UpdateProfile)
 				users.PATCH(":username", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.UpdateUser)
 				users.DELETE(":username", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.DeleteUser)
+				users.POST(":username/impersonate", middleware.RoleBasedAccessMiddleware([]string{"SUPER_ADMIN"}), auth.ImpersonateUser)
+				users.GET(":username/data-export", auth.ExportUserData)
+				users.POST(":username/anonymise", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.AnonymiseUser)
+				users.POST("/2fa/enroll", auth.EnrollTwoFactor)
+				users.POST("/2fa/verify", auth.ConfirmTwoFactor)
+				users.POST("/2fa/recovery-codes", auth.RegenerateRecoveryCodes)
+				users.DELETE("/2fa", auth.DisableTwoFactor)
+				users.DELETE(":username/2fa", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.ResetUserTwoFactor)
+				users.GET("/2fa/policies", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.GetTwoFactorPolicies)
+				users.PATCH("/2fa/policies", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.UpdateTwoFactorPolicy)
 			}
 			obligations := authorizedv1.Group("/obligations")
 			{
@@ -144,10 +193,14 @@ func Router() *gin.Engine {
 				obligations.GET(":id", GetObligation)
 				obligations.GET(":id/audits", GetObligationAudits)
 				obligations.GET("export", ExportObligations)
+				obligations.GET("/external-ref-schema", GetObligationExternalRefSchema)
 				obligations.POST("", CreateObligation)
 				obligations.POST("import", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), ImportObligations)
 				obligations.PATCH(":id", UpdateObligation)
 				obligations.DELETE(":id", DeleteObligation)
+				obligations.PUT(":id/steward", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), SetObligationSteward)
+				obligations.POST(":id/review", ReviewObligation)
+				obligations.GET("/duplicates", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), GetDuplicateObligations)
 				obligations.GET("/types", GetAllObligationType)
 				obligations.POST("/types", middleware.RoleBasedAccessMiddleware([]string{"ADMIN"}), CreateObligationType)
 				obligations.DELETE("/types/:type", middleware.RoleBasedAccessMiddleware([]string{"ADMIN"}), DeleteObligationType)
@@ -169,13 +222,49 @@ func Router() *gin.Engine {
 			dashboard := authorizedv1.Group("/dashboard")
 			{
 				dashboard.GET("", GetDashboardData)
+				dashboard.GET("/overdue-reviews", GetOverdueReviews)
+			}
+			scan := authorizedv1.Group("/scan")
+			{
+				scan.POST("", CreateScan)
+				scan.GET(":id", GetScan)
+			}
+			teams := authorizedv1.Group("/teams")
+			{
+				teams.GET("", GetTeams)
+				teams.GET(":name", GetTeam)
+				teams.POST("", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), CreateTeam)
+				teams.PATCH(":name", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), UpdateTeam)
+				teams.DELETE(":name", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), DeleteTeam)
+				teams.POST(":name/members", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), AddTeamMember)
+				teams.DELETE(":name/members/:username", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), RemoveTeamMember)
+			}
+			externalRefFields := authorizedv1.Group("/external-ref-fields")
+			{
+				externalRefFields.GET("", GetExternalRefFields)
+				externalRefFields.POST("", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), CreateExternalRefField)
+				externalRefFields.PATCH(":id", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), UpdateExternalRefField)
+				externalRefFields.DELETE(":id", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), DeleteExternalRefField)
 			}
 			oidcClient := authorizedv1.Group("/oidcClients")
+			oidcClient.Use(middleware.DenyImpersonationMiddleware())
 			{
 				oidcClient.GET("", GetUserOidcClients)
 				oidcClient.POST("", AddOidcClient)
 				oidcClient.DELETE("", RevokeClient)
 			}
+			serviceAccounts := authorizedv1.Group("/service-accounts")
+			serviceAccounts.Use(middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}))
+			{
+				serviceAccounts.GET("", GetServiceAccounts)
+				serviceAccounts.GET(":id", GetServiceAccount)
+				serviceAccounts.GET(":id/audits", GetServiceAccountAudits)
+				serviceAccounts.POST("", CreateServiceAccount)
+				serviceAccounts.PATCH(":id", UpdateServiceAccount)
+				serviceAccounts.DELETE(":id", DeleteServiceAccount)
+				serviceAccounts.POST(":id/clients", AddServiceAccountClient)
+				serviceAccounts.DELETE(":id/clients/:client_id", RemoveServiceAccountClient)
+			}
 		}
 	} else {
 		unAuthorizedv1 := r.Group("/api/v1")
@@ -186,9 +275,13 @@ func Router() *gin.Engine {
 				licenses.GET(":id", GetLicense)
 				licenses.GET("export", ExportLicenses)
 				licenses.GET("/preview", GetAllLicensePreviews)
+				licenses.GET("/resolve", ResolveLicense)
+				licenses.GET(":id/aliases", GetLicenseAliases)
+				licenses.GET("/external-ref-schema", GetLicenseExternalRefSchema)
 			}
 			search := unAuthorizedv1.Group("/search")
 			{
+				search.GET("", Search)
 				search.POST("", SearchInLicense)
 			}
 			obligations := unAuthorizedv1.Group("/obligations")
@@ -201,6 +294,7 @@ func Router() *gin.Engine {
 				obligations.GET("/types", GetAllObligationType)
 				obligations.GET("/classifications", GetAllObligationClassification)
 				obligations.GET("/categories", GetAllObligationCategories)
+				obligations.GET("/external-ref-schema", GetObligationExternalRefSchema)
 			}
 			audit := unAuthorizedv1.Group("/audits")
 			{
@@ -216,6 +310,8 @@ func Router() *gin.Engine {
 			login := unAuthorizedv1.Group("/login")
 			{
 				login.POST("", auth.Login)
+				login.POST("/2fa", auth.LoginTwoFactor)
+				login.POST("/2fa/enroll", auth.LoginTwoFactorEnroll)
 			}
 			ref := unAuthorizedv1.Group("/refresh-token")
 			{
@@ -229,9 +325,24 @@ func Router() *gin.Engine {
 			{
 				oidc.POST("", auth.CreateOidcUser)
 			}
+			scim := unAuthorizedv1.Group("/scim/v2/Users")
+			scim.Use(middleware.ScimAuthenticationMiddleware())
+			{
+				scim.GET("", ScimGetUsers)
+				scim.POST("", ScimCreateUser)
+				scim.GET(":id", ScimGetUser)
+				scim.PUT(":id", ScimReplaceUser)
+				scim.PATCH(":id", ScimPatchUser)
+				scim.DELETE(":id", ScimDeleteUser)
+			}
 			dashboard := unAuthorizedv1.Group("/dashboard")
 			{
 				dashboard.GET("", GetDashboardData)
+				dashboard.GET("/overdue-reviews", GetOverdueReviews)
+			}
+			externalRefFields := unAuthorizedv1.Group("/external-ref-fields")
+			{
+				externalRefFields.GET("", GetExternalRefFields)
 			}
 		}
 
@@ -242,19 +353,45 @@ func Router() *gin.Engine {
 			{
 				licenses.POST("", CreateLicense)
 				licenses.PATCH(":id", UpdateLicense)
+				licenses.PUT(":id/steward", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), SetLicenseSteward)
+				licenses.POST(":id/review", ReviewLicense)
+				licenses.POST(":id/merge", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), MergeLicense)
+				licenses.POST(":id/aliases", CreateLicenseAlias)
+				licenses.PATCH(":id/aliases/:alias_id", UpdateLicenseAlias)
+				licenses.DELETE(":id/aliases/:alias_id", DeleteLicenseAlias)
+				licenses.GET("/duplicates", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), GetDuplicateLicenses)
 				licenses.POST("import", ImportLicenses)
 				licenses.POST("/similarity", getSimilarLicenses)
+				licenses.POST("/identify", IdentifyLicense)
 
 			}
+			scan := authorizedv1.Group("/scan")
+			{
+				scan.POST("", CreateScan)
+				scan.GET(":id", GetScan)
+			}
 			users := authorizedv1.Group("/users")
+			// Impersonation tokens may only read the profile of the impersonated user
+			users.GET("/profile", auth.GetUserProfile)
+			users.GET("/profile/stewarded", GetStewardedItems)
+			users.Use(middleware.DenyImpersonationMiddleware())
 			{
 				users.GET("", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.GetAllUser)
-				users.GET("/profile", auth.GetUserProfile)
 				users.GET(":username", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.GetUser)
 				users.POST("", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.CreateUser)
 				users.PATCH(":username", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.UpdateUser)
 				users.PATCH("", auth.UpdateProfile)
 				users.DELETE(":username", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.DeleteUser)
+				users.POST(":username/impersonate", middleware.RoleBasedAccessMiddleware([]string{"SUPER_ADMIN"}), auth.ImpersonateUser)
+				users.GET(":username/data-export", auth.ExportUserData)
+				users.POST(":username/anonymise", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.AnonymiseUser)
+				users.POST("/2fa/enroll", auth.EnrollTwoFactor)
+				users.POST("/2fa/verify", auth.ConfirmTwoFactor)
+				users.POST("/2fa/recovery-codes", auth.RegenerateRecoveryCodes)
+				users.DELETE("/2fa", auth.DisableTwoFactor)
+				users.DELETE(":username/2fa", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.ResetUserTwoFactor)
+				users.GET("/2fa/policies", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.GetTwoFactorPolicies)
+				users.PATCH("/2fa/policies", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), auth.UpdateTwoFactorPolicy)
 			}
 			obligations := authorizedv1.Group("/obligations")
 			{
@@ -262,6 +399,9 @@ func Router() *gin.Engine {
 				obligations.POST("import", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), ImportObligations)
 				obligations.PATCH(":id", UpdateObligation)
 				obligations.DELETE(":id", DeleteObligation)
+				obligations.PUT(":id/steward", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), SetObligationSteward)
+				obligations.POST(":id/review", ReviewObligation)
+				obligations.GET("/duplicates", middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), GetDuplicateObligations)
 				obligations.POST("/types", middleware.RoleBasedAccessMiddleware([]string{"ADMIN"}), CreateObligationType)
 				obligations.DELETE("/types/:type", middleware.RoleBasedAccessMiddleware([]string{"ADMIN"}), DeleteObligationType)
 				obligations.POST("/classifications", middleware.RoleBasedAccessMiddleware([]string{"ADMIN"}), CreateObligationClassification)
@@ -270,12 +410,41 @@ func Router() *gin.Engine {
 				obligations.DELETE("/categories/:category", middleware.RoleBasedAccessMiddleware([]string{"ADMIN"}), DeleteObligationCategory)
 				obligations.POST("/similarity", getSimilarObligations)
 			}
+			teams := authorizedv1.Group("/teams")
+			{
+				teams.GET("", GetTeams)
+				teams.GET(":name", GetTeam)
+				teams.POST("", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), CreateTeam)
+				teams.PATCH(":name", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), UpdateTeam)
+				teams.DELETE(":name", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), DeleteTeam)
+				teams.POST(":name/members", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), AddTeamMember)
+				teams.DELETE(":name/members/:username", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), RemoveTeamMember)
+			}
+			externalRefFields := authorizedv1.Group("/external-ref-fields")
+			{
+				externalRefFields.POST("", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), CreateExternalRefField)
+				externalRefFields.PATCH(":id", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), UpdateExternalRefField)
+				externalRefFields.DELETE(":id", middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}), DeleteExternalRefField)
+			}
 			oidcClient := authorizedv1.Group("/oidcClients")
+			oidcClient.Use(middleware.DenyImpersonationMiddleware())
 			{
 				oidcClient.GET("", GetUserOidcClients)
 				oidcClient.POST("", AddOidcClient)
 				oidcClient.DELETE("", RevokeClient)
 			}
+			serviceAccounts := authorizedv1.Group("/service-accounts")
+			serviceAccounts.Use(middleware.DenyImpersonationMiddleware(), middleware.RoleBasedAccessMiddleware([]string{"ADMIN", "SUPER_ADMIN"}))
+			{
+				serviceAccounts.GET("", GetServiceAccounts)
+				serviceAccounts.GET(":id", GetServiceAccount)
+				serviceAccounts.GET(":id/audits", GetServiceAccountAudits)
+				serviceAccounts.POST("", CreateServiceAccount)
+				serviceAccounts.PATCH(":id", UpdateServiceAccount)
+				serviceAccounts.DELETE(":id", DeleteServiceAccount)
+				serviceAccounts.POST(":id/clients", AddServiceAccountClient)
+				serviceAccounts.DELETE(":id/clients/:client_id", RemoveServiceAccountClient)
+			}
 		}
 	}
 
@@ -9,6 +9,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/fossology/LicenseDb/pkg/db"
@@ -18,6 +19,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// auditKeyset is the order of audit listings, latest first.
+var auditKeyset = utils.Keyset{Column: "timestamp", IdColumn: "id", Desc: true}
+
 // GetAllAudit retrieves a list of all audit records from the database
 //
 //	@Summary		Get audit records
@@ -26,20 +30,37 @@ import (
 //	@Tags			Audits
 //	@Accept			json
 //	@Produce		json
-//	@Param			page	query		int						false	"Page number"
-//	@Param			limit	query		int						false	"Number of records per page"
-//	@Success		200		{object}	models.AuditResponse	"Audit records"
-//	@Failure		404		{object}	models.LicenseError		"Not changelogs in DB"
+//	@Param			actor			query		string					false	"Only changes made by users or by service accounts"	Enums(user, service_account)
+//	@Param			impersonated	query		bool					false	"Only changes made while a super admin impersonated the user"
+//	@Param			page			query		int						false	"Page number"
+//	@Param			limit			query		int						false	"Number of records per page"
+//	@Param			cursor			query		string					false	"Cursor of the page, empty for the first page, to paginate by cursor instead of page number"
+//	@Success		200				{object}	models.AuditResponse	"Audit records"
+//	@Failure		404				{object}	models.LicenseError		"Not changelogs in DB"
 //	@Security		ApiKeyAuth || {}
 //	@Router			/audits [get]
 func GetAllAudit(c *gin.Context) {
 	var audits []models.Audit
 
-	query := db.DB.Model(&models.Audit{}).Preload("User")
+	query := db.DB.Model(&models.Audit{}).Preload("User").Preload("ServiceAccount").Preload("Impersonator")
 
-	_ = utils.PreparePaginateResponse(c, query, &models.AuditResponse{})
+	serviceAccountUsers := db.DB.Model(&models.ServiceAccount{}).Select("user_id")
+	switch c.Query("actor") {
+	case "user":
+		query = query.Where("user_id NOT IN (?)", serviceAccountUsers)
+	case "service_account":
+		query = query.Where("user_id IN (?)", serviceAccountUsers)
+	}
+	if impersonated, _ := strconv.ParseBool(c.Query("impersonated")); impersonated {
+		query = query.Where("impersonator_id IS NOT NULL")
+	}
 
-	if err := query.Order("timestamp desc").Find(&audits).Error; err != nil {
+	pagination, err := utils.PreparePaginateResponse(c, query, &auditKeyset)
+	if err != nil {
+		return
+	}
+
+	if err := query.Find(&audits).Error; err != nil {
 		er := models.LicenseError{
 			Status:    http.StatusInternalServerError,
 			Message:   "unable to fetch audits",
@@ -67,9 +88,7 @@ func GetAllAudit(c *gin.Context) {
 	res := models.AuditResponse{
 		Data:   audits,
 		Status: http.StatusOK,
-		Meta: &models.PaginationMeta{
-			ResourceCount: len(audits),
-		},
+		Meta:   pagination.Meta(audits),
 	}
 
 	c.JSON(http.StatusOK, res)
@@ -105,7 +124,7 @@ func GetAudit(c *gin.Context) {
 		return
 	}
 
-	if err := db.DB.Preload("User").Where(&models.Audit{Id: parsedId}).First(&audit).Error; err != nil {
+	if err := db.DB.Preload("User").Preload("ServiceAccount").Preload("Impersonator").Where(&models.Audit{Id: parsedId}).First(&audit).Error; err != nil {
 		er := models.LicenseError{
 			Status:    http.StatusNotFound,
 			Message:   "no audit with such id exists",
@@ -12,6 +12,7 @@ import (
 	"github.com/fossology/LicenseDb/pkg/db"
 	logger "github.com/fossology/LicenseDb/pkg/log"
 	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -125,11 +126,26 @@ func GetDashboardData(c *gin.Context) {
 		return
 	}
 
+	overdueReviews, err := utils.OverdueReviews(db.DB, now)
+	if err != nil {
+		logger.LogError("error fetching overdue reviews", zap.Error(err))
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "something went wrong",
+			Error:     "error fetching overdue reviews",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
 	res := models.DashboardResponse{
 		Data: models.Dashboard{
 			LicensesCount:                licensesCount,
 			ObligationsCount:             obligationsCount,
 			LicenseChangesSinceLastMonth: licenseChangesSinceLastMonth,
+			OverdueReviewsCount:          int64(len(overdueReviews)),
 			UsersCount:                   usersCount,
 			RiskLicenseFrequency:         licenseFrequency,
 			CategoryObligationFrequency:  categoryFrequency,
@@ -139,3 +155,40 @@ func GetDashboardData(c *gin.Context) {
 
 	c.JSON(http.StatusOK, res)
 }
+
+// GetOverdueReviews lists the licenses and obligations which are due for review
+//
+//	@Summary		Lists the licenses and obligations which are due for review
+//	@Description	Lists the active licenses and obligations whose review interval has passed since their last review
+//	@Id				GetOverdueReviews
+//	@Tags			Dashboard
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.OverdueReviewResponse
+//	@Failure		500	{object}	models.LicenseError	"Something went wrong"
+//	@Security		ApiKeyAuth || {}
+//	@Router			/dashboard/overdue-reviews [get]
+func GetOverdueReviews(c *gin.Context) {
+	overdueReviews, err := utils.OverdueReviews(db.DB.WithContext(c), time.Now())
+	if err != nil {
+		logger.LogError("error fetching overdue reviews", zap.Error(err))
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "something went wrong",
+			Error:     "error fetching overdue reviews",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	res := models.OverdueReviewResponse{
+		Data:   overdueReviews,
+		Status: http.StatusOK,
+		Meta: &models.PaginationMeta{
+			ResourceCount: len(overdueReviews),
+		},
+	}
+	c.JSON(http.StatusOK, res)
+}
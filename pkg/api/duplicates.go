@@ -0,0 +1,568 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	logger "github.com/fossology/LicenseDb/pkg/log"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+	"github.com/fossology/LicenseDb/pkg/validations"
+)
+
+const (
+	defaultDuplicateThreshold = 0.9
+	// maxCreateDuplicates is the number of similar licenses or obligations
+	// reported when creating a duplicate
+	maxCreateDuplicates = 5
+)
+
+// duplicateTable describes the table of licenses or obligations whose texts
+// are compared for duplicates.
+type duplicateTable struct {
+	name, id, text, active string
+}
+
+var (
+	licenseDuplicates    = duplicateTable{name: "license_dbs", id: "rf_id", text: "rf_text", active: "rf_active"}
+	obligationDuplicates = duplicateTable{name: "obligations", id: "id", text: "text", active: "active"}
+)
+
+// GetDuplicateLicenses reports clusters of active licenses with similar texts.
+//
+//	@Summary		Report duplicate licenses
+//	@Description	Cluster the active licenses whose texts have at least the given trigram similarity, directly or through
+//	@Description	other licenses of the cluster. Each cluster suggests the license to merge the others into.
+//	@Id				GetDuplicateLicenses
+//	@Tags			Licenses
+//	@Produce		json
+//	@Param			threshold	query		number	false	"Minimum similarity of two texts, between 0 and 1, defaults to 0.9"
+//	@Success		200			{object}	models.DuplicateClustersResponse
+//	@Failure		400			{object}	models.LicenseError	"Invalid threshold"
+//	@Failure		500			{object}	models.LicenseError	"Failed to find duplicates"
+//	@Security		ApiKeyAuth
+//	@Router			/licenses/duplicates [get]
+func GetDuplicateLicenses(c *gin.Context) {
+	threshold, ok := duplicateThreshold(c)
+	if !ok {
+		return
+	}
+
+	tx := db.DB.WithContext(c)
+	pairs, err := duplicatePairs(tx, licenseDuplicates, threshold)
+	if err != nil {
+		duplicatesNotFound(c, err)
+		return
+	}
+	var members []models.DuplicateMember
+	if err := tx.Model(&models.LicenseDB{}).Select("rf_id AS id, rf_shortname AS name, rf_spdx_id AS spdx_id").
+		Where("rf_id IN ?", pairedIds(pairs)).Scan(&members).Error; err != nil {
+		duplicatesNotFound(c, err)
+		return
+	}
+
+	clusters := duplicateClusters(pairs, members)
+	for i := range clusters {
+		// Licenses with SPDX ids survive the LicenseRef ids of imports
+		survivor := slices.MinFunc(clusters[i].Members, func(a, b models.DuplicateMember) int {
+			if isLicenseRef(a.SpdxId) != isLicenseRef(b.SpdxId) {
+				if isLicenseRef(a.SpdxId) {
+					return 1
+				}
+				return -1
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+		clusters[i].SuggestedSurvivorId = &survivor.Id
+	}
+	writeDuplicateClusters(c, clusters)
+}
+
+// GetDuplicateObligations reports clusters of active obligations with similar
+// texts.
+//
+//	@Summary		Report duplicate obligations
+//	@Description	Cluster the active obligations whose texts have at least the given trigram similarity, directly or through
+//	@Description	other obligations of the cluster.
+//	@Id				GetDuplicateObligations
+//	@Tags			Obligations
+//	@Produce		json
+//	@Param			threshold	query		number	false	"Minimum similarity of two texts, between 0 and 1, defaults to 0.9"
+//	@Success		200			{object}	models.DuplicateClustersResponse
+//	@Failure		400			{object}	models.LicenseError	"Invalid threshold"
+//	@Failure		500			{object}	models.LicenseError	"Failed to find duplicates"
+//	@Security		ApiKeyAuth
+//	@Router			/obligations/duplicates [get]
+func GetDuplicateObligations(c *gin.Context) {
+	threshold, ok := duplicateThreshold(c)
+	if !ok {
+		return
+	}
+
+	tx := db.DB.WithContext(c)
+	pairs, err := duplicatePairs(tx, obligationDuplicates, threshold)
+	if err != nil {
+		duplicatesNotFound(c, err)
+		return
+	}
+	var members []models.DuplicateMember
+	if err := tx.Model(&models.Obligation{}).Select("id, topic AS name").
+		Where("id IN ?", pairedIds(pairs)).Scan(&members).Error; err != nil {
+		duplicatesNotFound(c, err)
+		return
+	}
+	writeDuplicateClusters(c, duplicateClusters(pairs, members))
+}
+
+// MergeLicense merges a duplicate license into a license.
+//
+//	@Summary		Merge a duplicate into a license
+//	@Description	Merge a duplicate into the license, which survives. The obligations of the duplicate are linked to the
+//	@Description	survivor, the audits and aliases of the duplicate are moved to it, the shortname and SPDX id of the
+//	@Description	duplicate are recorded as aliases of the survivor in the MERGED namespace and the duplicate is deactivated.
+//	@Id				MergeLicense
+//	@Tags			Licenses
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Id of the surviving license"
+//	@Param			merge	body		models.LicenseMergeInput	true	"Duplicate to merge"
+//	@Success		200		{object}	models.LicenseResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		403		{object}	models.LicenseError	"License is stewarded by another team"
+//	@Failure		404		{object}	models.LicenseError	"No license found"
+//	@Failure		500		{object}	models.LicenseError	"Failed to merge licenses"
+//	@Security		ApiKeyAuth
+//	@Router			/licenses/{id}/merge [post]
+func MergeLicense(c *gin.Context) {
+	var input models.LicenseMergeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not merge licenses with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	survivorId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   fmt.Sprintf("no license with id '%s' exists", c.Param("id")),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	if survivorId == input.DuplicateId {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "a license can not be merged into itself",
+			Error:     fmt.Sprintf("duplicate_id is the id of the license '%s'", survivorId),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var survivor, duplicate models.LicenseDB
+		for _, license := range []struct {
+			id     uuid.UUID
+			target *models.LicenseDB
+		}{{survivorId, &survivor}, {input.DuplicateId, &duplicate}} {
+			if err := tx.Preload("User").Preload("Obligations").Where(models.LicenseDB{Id: license.id}).
+				First(license.target).Error; err != nil {
+				er := models.LicenseError{
+					Status:    http.StatusNotFound,
+					Message:   fmt.Sprintf("license with id '%s' not found", license.id.String()),
+					Error:     err.Error(),
+					Path:      c.Request.URL.Path,
+					Timestamp: time.Now().Format(time.RFC3339),
+				}
+				c.JSON(http.StatusNotFound, er)
+				return nil
+			}
+
+			stewardTeam, err := stewardTeamDenyingEdit(c, tx, license.target.StewardTeamId)
+			if err != nil {
+				licensesNotMerged(c, err)
+				return err
+			}
+			if stewardTeam != nil {
+				er := models.LicenseError{
+					Status:    http.StatusForbidden,
+					Message:   "only members of the steward team can merge this license",
+					Error:     fmt.Sprintf("license '%s' is stewarded by team '%s'", *license.target.Shortname, stewardTeam.Name),
+					Path:      c.Request.URL.Path,
+					Timestamp: time.Now().Format(time.RFC3339),
+				}
+				c.JSON(http.StatusForbidden, er)
+				return nil
+			}
+		}
+		if survivor.Active == nil || !*survivor.Active {
+			er := models.LicenseError{
+				Status:    http.StatusBadRequest,
+				Message:   "licenses can only be merged into active licenses",
+				Error:     fmt.Sprintf("license '%s' is inactive", *survivor.Shortname),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusBadRequest, er)
+			return nil
+		}
+
+		if err := mergeLicense(tx, userId, &survivor, &duplicate); err != nil {
+			licensesNotMerged(c, err)
+			return err
+		}
+
+		var merged models.LicenseDB
+		if err := tx.Preload("User").Preload("Obligations").Preload("Aliases").Where(models.LicenseDB{Id: survivorId}).First(&merged).Error; err != nil {
+			licensesNotMerged(c, err)
+			return err
+		}
+		res := models.LicenseResponse{
+			Data:   []models.LicenseResponseDTO{merged.ConvertToLicenseResponseDTO()},
+			Status: http.StatusOK,
+			Meta: &models.PaginationMeta{
+				ResourceCount: 1,
+			},
+		}
+		c.JSON(http.StatusOK, res)
+		return nil
+	})
+}
+
+// mergeLicense moves the obligation links, audits and aliases of a duplicate
+// to the survivor, records the names of the duplicate as aliases and
+// deactivates it, auditing the changes of both licenses.
+func mergeLicense(tx *gorm.DB, userId uuid.UUID, survivor, duplicate *models.LicenseDB) error {
+	if err := tx.Exec("INSERT INTO obligation_licenses (obligation_id, license_db_id) "+
+		"SELECT obligation_id, ? FROM obligation_licenses WHERE license_db_id = ? ON CONFLICT DO NOTHING",
+		survivor.Id, duplicate.Id).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM obligation_licenses WHERE license_db_id = ?", duplicate.Id).Error; err != nil {
+		return err
+	}
+
+	// The history of the duplicate becomes part of the history of the survivor
+	if err := tx.Model(&models.Audit{}).Where(models.Audit{Type: "LICENSE", TypeId: duplicate.Id}).
+		UpdateColumn("type_id", survivor.Id).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&models.LicenseAlias{}).Where(models.LicenseAlias{LicenseId: duplicate.Id}).
+		UpdateColumn("license_id", survivor.Id).Error; err != nil {
+		return err
+	}
+	var aliases []models.LicenseAlias
+	for _, name := range []*string{duplicate.Shortname, duplicate.SpdxId} {
+		if name == nil || *name == "" || strings.EqualFold(*name, *survivor.Shortname) ||
+			(survivor.SpdxId != nil && strings.EqualFold(*name, *survivor.SpdxId)) ||
+			slices.ContainsFunc(aliases, func(alias models.LicenseAlias) bool { return strings.EqualFold(alias.Alias, *name) }) {
+			continue
+		}
+		aliases = append(aliases, models.LicenseAlias{LicenseId: survivor.Id, Alias: *name, Namespace: models.AliasNamespaceMerged})
+	}
+	if len(aliases) != 0 {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&aliases).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Model(&models.LicenseDB{}).Where(models.LicenseDB{Id: duplicate.Id}).
+		UpdateColumn("rf_active", false).Error; err != nil {
+		return err
+	}
+	if err := utils.IncreaseVersions(tx, &models.LicenseDB{}, "rf_id", []uuid.UUID{survivor.Id}); err != nil {
+		return err
+	}
+
+	var survivorObligations []models.Obligation
+	if err := tx.Model(&models.LicenseDB{Id: survivor.Id}).Association("Obligations").Find(&survivorObligations); err != nil {
+		return err
+	}
+	now := time.Now()
+
+	var survivorChanges []models.ChangeLog
+	oldObligations, newObligations := obligationIdList(survivor.Obligations), obligationIdList(survivorObligations)
+	utils.AddChangelog("Obligation Ids", &oldObligations, &newObligations, &survivorChanges)
+	mergedLicense := fmt.Sprintf("%s (%s)", *duplicate.Shortname, duplicate.Id)
+	utils.AddChangelog("Merged License", nil, &mergedLicense, &survivorChanges)
+	if err := tx.Create(&models.Audit{
+		UserId:     userId,
+		TypeId:     survivor.Id,
+		Timestamp:  now,
+		Type:       "LICENSE",
+		ChangeLogs: survivorChanges,
+	}).Error; err != nil {
+		return err
+	}
+
+	var duplicateChanges []models.ChangeLog
+	inactive := false
+	utils.AddChangelog("Active", duplicate.Active, &inactive, &duplicateChanges)
+	oldObligations, newObligations = obligationIdList(duplicate.Obligations), ""
+	utils.AddChangelog("Obligation Ids", &oldObligations, &newObligations, &duplicateChanges)
+	mergedInto := fmt.Sprintf("%s (%s)", *survivor.Shortname, survivor.Id)
+	utils.AddChangelog("Merged Into", nil, &mergedInto, &duplicateChanges)
+	return tx.Create(&models.Audit{
+		UserId:     userId,
+		TypeId:     duplicate.Id,
+		Timestamp:  now,
+		Type:       "LICENSE",
+		ChangeLogs: duplicateChanges,
+	}).Error
+}
+
+// duplicatePairs returns the pairs of active rows of a table whose texts
+// have at least the given trigram similarity.
+func duplicatePairs(tx *gorm.DB, table duplicateTable, threshold float64) ([]models.DuplicatePair, error) {
+	pairs := []models.DuplicatePair{}
+	err := utils.WithSimilarityThreshold(tx, threshold, func(tx *gorm.DB) error {
+		return tx.Raw(fmt.Sprintf(
+			"SELECT a.%[2]s AS a, b.%[2]s AS b, similarity(a.%[3]s, b.%[3]s) AS similarity "+
+				"FROM %[1]s a JOIN %[1]s b ON a.%[2]s < b.%[2]s AND a.%[3]s %% b.%[3]s "+
+				"WHERE a.%[4]s AND b.%[4]s ORDER BY similarity DESC",
+			table.name, table.id, table.text, table.active)).Scan(&pairs).Error
+	})
+	return pairs, err
+}
+
+// duplicateClusters groups the members of similar pairs into clusters, the
+// largest clusters first.
+func duplicateClusters(pairs []models.DuplicatePair, members []models.DuplicateMember) []models.DuplicateCluster {
+	parent := map[uuid.UUID]uuid.UUID{}
+	var root func(id uuid.UUID) uuid.UUID
+	root = func(id uuid.UUID) uuid.UUID {
+		if p, ok := parent[id]; ok && p != id {
+			parent[id] = root(p)
+			return parent[id]
+		}
+		parent[id] = id
+		return id
+	}
+	for _, pair := range pairs {
+		parent[root(pair.A)] = root(pair.B)
+	}
+
+	byRoot := map[uuid.UUID]*models.DuplicateCluster{}
+	clusters := []*models.DuplicateCluster{}
+	for _, member := range members {
+		r := root(member.Id)
+		if _, ok := byRoot[r]; !ok {
+			byRoot[r] = &models.DuplicateCluster{Members: []models.DuplicateMember{}, Pairs: []models.DuplicatePair{}}
+			clusters = append(clusters, byRoot[r])
+		}
+		byRoot[r].Members = append(byRoot[r].Members, member)
+	}
+	for _, pair := range pairs {
+		if cluster, ok := byRoot[root(pair.A)]; ok {
+			cluster.Pairs = append(cluster.Pairs, pair)
+		}
+	}
+
+	result := make([]models.DuplicateCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		sort.Slice(cluster.Members, func(i, j int) bool { return cluster.Members[i].Name < cluster.Members[j].Name })
+		result = append(result, *cluster)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if len(result[i].Members) != len(result[j].Members) {
+			return len(result[i].Members) > len(result[j].Members)
+		}
+		return result[i].Members[0].Name < result[j].Members[0].Name
+	})
+	return result
+}
+
+// duplicateThreshold parses the threshold query parameter, writing the error
+// response if it is invalid.
+func duplicateThreshold(c *gin.Context) (float64, bool) {
+	threshold := defaultDuplicateThreshold
+	if value := c.Query("threshold"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 || parsed > 1 {
+			er := models.LicenseError{
+				Status:    http.StatusBadRequest,
+				Message:   "threshold must be a number greater than 0 and at most 1",
+				Error:     fmt.Sprintf("invalid threshold: %s", value),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusBadRequest, er)
+			return 0, false
+		}
+		threshold = parsed
+	}
+	return threshold, true
+}
+
+func pairedIds(pairs []models.DuplicatePair) []uuid.UUID {
+	ids := []uuid.UUID{}
+	for _, pair := range pairs {
+		ids = append(ids, pair.A, pair.B)
+	}
+	return ids
+}
+
+func isLicenseRef(spdxId *string) bool {
+	return spdxId == nil || *spdxId == "" || strings.HasPrefix(*spdxId, "LicenseRef-")
+}
+
+// obligationIdList lists the sorted ids of obligations like the changelogs
+// of license updates do.
+func obligationIdList(obligations []models.Obligation) string {
+	ids := make([]string, 0, len(obligations))
+	for _, obligation := range obligations {
+		ids = append(ids, obligation.Id.String())
+	}
+	slices.Sort(ids)
+	return strings.Join(ids, ", ")
+}
+
+func writeDuplicateClusters(c *gin.Context, clusters []models.DuplicateCluster) {
+	c.JSON(http.StatusOK, models.DuplicateClustersResponse{
+		Status: http.StatusOK,
+		Data:   clusters,
+		Meta: &models.PaginationMeta{
+			ResourceCount: len(clusters),
+		},
+	})
+}
+
+func duplicatesNotFound(c *gin.Context, err error) {
+	er := models.LicenseError{
+		Status:    http.StatusInternalServerError,
+		Message:   "Failed to find duplicates",
+		Error:     err.Error(),
+		Path:      c.Request.URL.Path,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	c.JSON(http.StatusInternalServerError, er)
+}
+
+func licensesNotMerged(c *gin.Context, err error) {
+	er := models.LicenseError{
+		Status:    http.StatusInternalServerError,
+		Message:   "Failed to merge licenses",
+		Error:     err.Error(),
+		Path:      c.Request.URL.Path,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	c.JSON(http.StatusInternalServerError, er)
+}
+
+// createDuplicateThreshold is the similarity at which the text of a new
+// license or obligation duplicates an existing one, read from
+// DUPLICATE_CHECK_THRESHOLD.
+func createDuplicateThreshold() float64 {
+	if value := os.Getenv("DUPLICATE_CHECK_THRESHOLD"); value != "" {
+		threshold, err := strconv.ParseFloat(value, 64)
+		if err == nil && threshold > 0 && threshold <= 1 {
+			return threshold
+		}
+		logger.LogError("Invalid DUPLICATE_CHECK_THRESHOLD, using the default",
+			zap.String("value", value), zap.Float64("default", defaultDuplicateThreshold))
+	}
+	return defaultDuplicateThreshold
+}
+
+// forceCreate parses the force query parameter, which creates licenses and
+// obligations despite duplicates, writing the error response if it is
+// invalid.
+func forceCreate(c *gin.Context) (bool, bool) {
+	value := c.DefaultQuery("force", "false")
+	force, err := strconv.ParseBool(value)
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "force must be true or false",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return false, false
+	}
+	return force, true
+}
+
+// findCreateDuplicates scans the active rows of a table whose text duplicates
+// the text of a new row into results, the most similar first.
+func findCreateDuplicates(tx *gorm.DB, table duplicateTable, columns, text string, results any) error {
+	threshold, limit := createDuplicateThreshold(), maxCreateDuplicates
+	return findSimilar(tx, models.SimilarityRequest{Text: text, Threshold: &threshold, Limit: &limit, OmitText: true},
+		table.name, columns, table.text, results, func(tx *gorm.DB) *gorm.DB { return tx.Where(table.active) })
+}
+
+// missingIds returns the ids of which no row exists in the table. Creates
+// report references to missing rows before duplicates, as they fail anyway.
+func missingIds(tx *gorm.DB, table duplicateTable, ids []uuid.UUID) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var existing []uuid.UUID
+	if err := tx.Table(table.name).Where(table.id+" IN ?", ids).Pluck(table.id, &existing).Error; err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, id := range ids {
+		if !slices.Contains(existing, id) {
+			missing = append(missing, id.String())
+		}
+	}
+	return missing, nil
+}
+
+// forcedDuplicateChangelogs record in the audit of a new license or
+// obligation that it was created despite the listed duplicates.
+func forcedDuplicateChangelogs(duplicates []string) []models.ChangeLog {
+	var changes []models.ChangeLog
+	if len(duplicates) != 0 {
+		decision := "created despite similar texts of " + strings.Join(duplicates, ", ")
+		utils.AddChangelog("Duplicate Check", nil, &decision, &changes)
+	}
+	return changes
+}
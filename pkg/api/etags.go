@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errVersionChanged is returned by lockVersion if a resource was changed
+// after it was read.
+var errVersionChanged = errors.New("the resource was changed by another request")
+
+// requireIfMatch tells whether requests changing licenses, obligations and
+// obligation types, classifications and categories need an If-Match header,
+// read from REQUIRE_IF_MATCH.
+func requireIfMatch() bool {
+	require, _ := strconv.ParseBool(os.Getenv("REQUIRE_IF_MATCH"))
+	return require
+}
+
+// etag is the entity tag of a version of a resource.
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// etagsMatch tells whether an If-Match or If-None-Match header, a comma
+// separated list of entity tags or *, matches the version of a resource. Weak
+// tags only match with weak comparison.
+func etagsMatch(header string, version int64, weak bool) bool {
+	tag := etag(version)
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if weak {
+			t = strings.TrimPrefix(t, "W/")
+		}
+		if t == "*" || t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified sets the ETag of a resource read by a request. It responds
+// with 304 Not Modified and returns true if the If-None-Match header matches.
+func notModified(c *gin.Context, version int64) bool {
+	c.Header("ETag", etag(version))
+	if header := c.GetHeader("If-None-Match"); header != "" && etagsMatch(header, version, true) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// ifMatch checks the If-Match header of a request changing a resource
+// against its version. It responds with 412 Precondition Failed if they do
+// not match, or with 428 Precondition Required if the header is missing while
+// REQUIRE_IF_MATCH is set, and returns false.
+func ifMatch(c *gin.Context, version int64) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		if !requireIfMatch() {
+			return true
+		}
+		er := models.LicenseError{
+			Status:    http.StatusPreconditionRequired,
+			Message:   "If-Match header is required",
+			Error:     "changes need the ETag the resource was read with in If-Match",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusPreconditionRequired, er)
+		return false
+	}
+	if etagsMatch(header, version, false) {
+		return true
+	}
+	preconditionFailed(c, fmt.Errorf("the current ETag of the resource is %s", etag(version)))
+	return false
+}
+
+// lockVersion locks the row of a resource until the end of the transaction
+// and checks that its version is still the one it was read with, so that
+// concurrent changes are not overwritten.
+func lockVersion(tx *gorm.DB, model any, idColumn string, id uuid.UUID, version int64) error {
+	var current int64
+	if err := tx.Model(model).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(idColumn+" = ?", id).Select("version").Scan(&current).Error; err != nil {
+		return err
+	}
+	if current != version {
+		return errVersionChanged
+	}
+	return nil
+}
+
+func preconditionFailed(c *gin.Context, err error) {
+	er := models.LicenseError{
+		Status:    http.StatusPreconditionFailed,
+		Message:   "the resource was changed since it was read",
+		Error:     err.Error(),
+		Path:      c.Request.URL.Path,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	c.JSON(http.StatusPreconditionFailed, er)
+}
@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/filter"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// externalRefOperators maps the operators of externalRef queries to the
+// operators of filter expressions.
+var externalRefOperators = map[string]string{
+	"eq":       filter.OpEqual,
+	"ne":       filter.OpNotEqual,
+	"lt":       filter.OpLess,
+	"lte":      filter.OpLessEqual,
+	"gt":       filter.OpGreater,
+	"gte":      filter.OpGreaterEqual,
+	"in":       filter.OpIn,
+	"contains": filter.OpLike,
+	"exists":   filter.OpNull,
+}
+
+// externalRefKinds are the kinds of filter fields of the types of external_ref
+// fields. Arrays and objects cannot be filtered on.
+var externalRefKinds = map[string]filter.Kind{
+	models.ExternalRefTypeString:    filter.String,
+	models.ExternalRefTypeInt:       filter.Integer,
+	models.ExternalRefTypeNumber:    filter.Float,
+	models.ExternalRefTypeBoolean:   filter.Boolean,
+	models.ExternalRefTypeDate:      filter.Time,
+	models.ExternalRefTypeTimestamp: filter.Time,
+	models.ExternalRefTypeURL:       filter.String,
+}
+
+// externalRefFilterFields returns the filter fields of the external_ref keys
+// of entity, stored in the jsonb column document. It responds with an error
+// and returns false if they cannot be loaded.
+func externalRefFilterFields(c *gin.Context, entity, document string) (filter.Fields, bool) {
+	fields, err := utils.ExternalRefFields(db.DB, entity)
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "unable to fetch external_ref fields",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return nil, false
+	}
+	filterFields := filter.Fields{}
+	for _, field := range fields {
+		if kind, ok := externalRefKinds[field.Type]; ok {
+			filterFields[field.Name] = filter.JSONField(kind, document, field.Name)
+		}
+	}
+	return filterFields, true
+}
+
+// withExternalRefFields returns the fields of filter expressions with the
+// external_ref keys added as external_ref.<key>.
+func withExternalRefFields(fields, externalRefFields filter.Fields) filter.Fields {
+	fields = maps.Clone(fields)
+	for key, field := range externalRefFields {
+		fields["external_ref."+key] = field
+	}
+	return fields
+}
+
+// parseExternalRefQuery parses the externalRef query parameter, a JSON object
+// of external_ref keys with either the value they equal or an object of
+// operators and their operands, e.g.
+//
+//	{"license_suffix": "v2", "license_explanation": {"contains": "patent", "exists": true}}
+//
+// All conditions must hold.
+func parseExternalRefQuery(query string, fields filter.Fields) (*filter.Expr, error) {
+	decoder := json.NewDecoder(bytes.NewBufferString(query))
+	decoder.UseNumber()
+	var keys map[string]any
+	if err := decoder.Decode(&keys); err != nil {
+		return nil, err
+	}
+
+	expr := &filter.Expr{}
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		if _, ok := fields[key]; !ok {
+			return nil, fmt.Errorf("unknown key '%s', expected one of %s", key, strings.Join(slices.Sorted(maps.Keys(fields)), ", "))
+		}
+		operations, ok := keys[key].(map[string]any)
+		if !ok {
+			operations = map[string]any{"eq": keys[key]}
+		}
+		for _, op := range slices.Sorted(maps.Keys(operations)) {
+			operator, ok := externalRefOperators[op]
+			if !ok {
+				return nil, fmt.Errorf("key '%s': unknown operator '%s', expected one of eq, ne, lt, lte, gt, gte, in, contains, exists", key, op)
+			}
+			operand := operations[op]
+			var values []string
+			switch operator {
+			case filter.OpIn:
+				list, ok := operand.([]any)
+				if !ok || len(list) == 0 {
+					return nil, fmt.Errorf("key '%s': operator %s takes a non-empty array", key, op)
+				}
+				for _, item := range list {
+					value, err := externalRefValue(key, item)
+					if err != nil {
+						return nil, err
+					}
+					values = append(values, value)
+				}
+			case filter.OpNull:
+				exists, ok := operand.(bool)
+				if !ok {
+					return nil, fmt.Errorf("key '%s': operator %s takes true or false", key, op)
+				}
+				values = []string{strconv.FormatBool(!exists)}
+			default:
+				value, err := externalRefValue(key, operand)
+				if err != nil {
+					return nil, err
+				}
+				values = []string{value}
+			}
+			expr.Operands = append(expr.Operands, &filter.Expr{
+				Comparison: &filter.Comparison{Field: key, Operator: operator, Values: values, Pos: -1},
+			})
+		}
+	}
+	return expr, nil
+}
+
+// externalRefCondition compiles the externalRef query parameter to an SQL
+// condition on fields. It responds with 400 Bad Request and returns false if
+// the parameter is invalid.
+func externalRefCondition(c *gin.Context, fields filter.Fields) (string, []any, bool) {
+	query := c.Query("externalRef")
+	if query == "" {
+		return "", nil, true
+	}
+	expr, err := parseExternalRefQuery(query, fields)
+	if err == nil && len(expr.Operands) == 0 {
+		return "", nil, true
+	}
+	var condition string
+	var args []any
+	if err == nil {
+		condition, args, err = fields.CompileExpr(expr)
+	}
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid external ref type value",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return "", nil, false
+	}
+	return condition, args, true
+}
+
+func externalRefValue(key string, value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("key '%s': expected a string, number or boolean value", key)
+}
+
+// checkExternalRef validates the external_ref values written to an entity of
+// entity, with current the external_ref of the entity if it exists already.
+// It responds with an error and returns it if they are invalid.
+func checkExternalRef(c *gin.Context, tx *gorm.DB, entity string, written, current any) error {
+	err := utils.CheckExternalRef(tx, entity, written, current)
+	var invalid *utils.ExternalRefError
+	if errors.As(err, &invalid) {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid external_ref",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+	} else if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "unable to validate external_ref",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+	}
+	return err
+}
@@ -0,0 +1,579 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+	"github.com/fossology/LicenseDb/pkg/validations"
+)
+
+// externalRefFieldName restricts the names of external_ref fields to those
+// usable in filter expressions.
+var externalRefFieldName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// externalRefSchemaTypes are the JSON Schema types and formats of the types of
+// external_ref fields.
+var externalRefSchemaTypes = map[string][2]string{
+	models.ExternalRefTypeString:    {"string", ""},
+	models.ExternalRefTypeInt:       {"integer", ""},
+	models.ExternalRefTypeNumber:    {"number", ""},
+	models.ExternalRefTypeBoolean:   {"boolean", ""},
+	models.ExternalRefTypeDate:      {"string", "date"},
+	models.ExternalRefTypeTimestamp: {"string", "date-time"},
+	models.ExternalRefTypeURL:       {"string", "uri"},
+	models.ExternalRefTypeArray:     {"array", ""},
+	models.ExternalRefTypeObject:    {"object", ""},
+}
+
+// GetExternalRefFields retrieves the external_ref fields.
+//
+//	@Summary		Get external_ref fields
+//	@Description	Get the built in and runtime defined fields of the external_ref of licenses and obligations
+//	@Id				GetExternalRefFields
+//	@Tags			External Ref Fields
+//	@Accept			json
+//	@Produce		json
+//	@Param			entity	query		string	false	"Only fields of licenses or obligations"	Enums(LICENSE, OBLIGATION)
+//	@Success		200		{object}	models.ExternalRefFieldResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid entity"
+//	@Failure		500		{object}	models.LicenseError	"Unable to fetch external_ref fields"
+//	@Security		ApiKeyAuth || {}
+//	@Router			/external-ref-fields [get]
+func GetExternalRefFields(c *gin.Context) {
+	entities := []string{models.ExternalRefEntityLicense, models.ExternalRefEntityObligation}
+	if entity := c.Query("entity"); entity != "" {
+		if !slices.Contains(entities, entity) {
+			er := models.LicenseError{
+				Status:    http.StatusBadRequest,
+				Message:   "invalid entity",
+				Error:     fmt.Sprintf("entity must be one of %s", strings.Join(entities, ", ")),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusBadRequest, er)
+			return
+		}
+		entities = []string{entity}
+	}
+
+	fields := []models.ExternalRefField{}
+	for _, entity := range entities {
+		entityFields, err := utils.ExternalRefFields(db.DB, entity)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "unable to fetch external_ref fields",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return
+		}
+		fields = append(fields, entityFields...)
+	}
+
+	res := models.ExternalRefFieldResponse{
+		Status: http.StatusOK,
+		Data:   fields,
+		Meta: &models.PaginationMeta{
+			ResourceCount: len(fields),
+		},
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateExternalRefField defines a new external_ref field.
+//
+//	@Summary		Create an external_ref field
+//	@Description	Define a field of the external_ref of licenses or obligations. Enum values and a regex only apply to
+//	@Description	string fields. Values written to the external_ref are validated against its fields.
+//	@Id				CreateExternalRefField
+//	@Tags			External Ref Fields
+//	@Accept			json
+//	@Produce		json
+//	@Param			field	body		models.ExternalRefFieldCreate	true	"Field to define"
+//	@Success		201		{object}	models.ExternalRefFieldResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		409		{object}	models.LicenseError	"Field already exists"
+//	@Security		ApiKeyAuth
+//	@Router			/external-ref-fields [post]
+func CreateExternalRefField(c *gin.Context) {
+	var input models.ExternalRefFieldCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	input.Name = strings.TrimSpace(input.Name)
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not create external_ref field with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	field := models.ExternalRefField{
+		Entity:   input.Entity,
+		Name:     input.Name,
+		Type:     input.Type,
+		Label:    input.Label,
+		Required: input.Required,
+		Enum:     input.Enum,
+		Regex:    input.Regex,
+	}
+	if !externalRefFieldName.MatchString(field.Name) {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not create external_ref field with these field values",
+			Error:     fmt.Sprintf("name must match %s", externalRefFieldName),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	if !validExternalRefConstraints(c, &field) {
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		fields, err := utils.ExternalRefFields(tx, field.Entity)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to create the external_ref field",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if slices.ContainsFunc(fields, func(f models.ExternalRefField) bool { return f.Name == field.Name }) {
+			er := models.LicenseError{
+				Status:    http.StatusConflict,
+				Message:   "can not create external_ref field",
+				Error:     fmt.Sprintf("a %s external_ref field with name '%s' already exists", strings.ToLower(field.Entity), field.Name),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusConflict, er)
+			return nil
+		}
+
+		if err := tx.Create(&field).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to create the external_ref field",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := addChangelogsForExternalRefField(tx, userId, &field, &models.ExternalRefField{}); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		writeExternalRefField(c, &field, http.StatusCreated)
+		return nil
+	})
+}
+
+// UpdateExternalRefField updates an external_ref field.
+//
+//	@Summary		Update an external_ref field
+//	@Description	Update the label, required flag, enum values or regex of a runtime defined external_ref field. Values
+//	@Description	already stored are not revalidated.
+//	@Id				UpdateExternalRefField
+//	@Tags			External Ref Fields
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Field id"
+//	@Param			field	body		models.ExternalRefFieldUpdate	true	"Fields to update"
+//	@Success		200		{object}	models.ExternalRefFieldResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		404		{object}	models.LicenseError	"No field with given id found"
+//	@Security		ApiKeyAuth
+//	@Router			/external-ref-fields/{id} [patch]
+func UpdateExternalRefField(c *gin.Context) {
+	var input models.ExternalRefFieldUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not update external_ref field with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		oldField, ok := findExternalRefField(c, tx, c.Param("id"))
+		if !ok {
+			return nil
+		}
+
+		newField := *oldField
+		if input.Label != nil {
+			newField.Label = *input.Label
+		}
+		if input.Required != nil {
+			newField.Required = *input.Required
+		}
+		if input.Enum != nil {
+			newField.Enum = *input.Enum
+		}
+		if input.Regex != nil {
+			newField.Regex = input.Regex
+			if *input.Regex == "" {
+				newField.Regex = nil
+			}
+		}
+		if !validExternalRefConstraints(c, &newField) {
+			return nil
+		}
+		newField.UpdatedAt = time.Now()
+
+		if err := tx.Model(&newField).Select("label", "required", "enum", "regex", "updated_at").Updates(&newField).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update the external_ref field",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := addChangelogsForExternalRefField(tx, userId, &newField, oldField); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		writeExternalRefField(c, &newField, http.StatusOK)
+		return nil
+	})
+}
+
+// DeleteExternalRefField deletes an external_ref field.
+//
+//	@Summary		Delete an external_ref field
+//	@Description	Delete a runtime defined external_ref field. Values already stored under its key are kept, but can no
+//	@Description	longer be written. The field is kept for its audits.
+//	@Id				DeleteExternalRefField
+//	@Tags			External Ref Fields
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Field id"
+//	@Success		204
+//	@Failure		404	{object}	models.LicenseError	"No field with given id found"
+//	@Security		ApiKeyAuth
+//	@Router			/external-ref-fields/{id} [delete]
+func DeleteExternalRefField(c *gin.Context) {
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		field, ok := findExternalRefField(c, tx, c.Param("id"))
+		if !ok {
+			return nil
+		}
+
+		if err := tx.Delete(field).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "failed to delete external_ref field",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := addChangelogsForExternalRefField(tx, userId, &models.ExternalRefField{Id: field.Id}, field); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	})
+}
+
+// GetLicenseExternalRefSchema retrieves the JSON Schema of the external_ref of
+// licenses.
+//
+//	@Summary		Get the external_ref schema of licenses
+//	@Description	Get the JSON Schema of the external_ref of licenses, for rendering forms of its fields
+//	@Id				GetLicenseExternalRefSchema
+//	@Tags			Licenses
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.ExternalRefSchema
+//	@Failure		500	{object}	models.LicenseError	"Unable to fetch external_ref fields"
+//	@Security		ApiKeyAuth || {}
+//	@Router			/licenses/external-ref-schema [get]
+func GetLicenseExternalRefSchema(c *gin.Context) {
+	writeExternalRefSchema(c, models.ExternalRefEntityLicense, "License external_ref")
+}
+
+// GetObligationExternalRefSchema retrieves the JSON Schema of the external_ref
+// of obligations.
+//
+//	@Summary		Get the external_ref schema of obligations
+//	@Description	Get the JSON Schema of the external_ref of obligations, for rendering forms of its fields
+//	@Id				GetObligationExternalRefSchema
+//	@Tags			Obligations
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.ExternalRefSchema
+//	@Failure		500	{object}	models.LicenseError	"Unable to fetch external_ref fields"
+//	@Security		ApiKeyAuth || {}
+//	@Router			/obligations/external-ref-schema [get]
+func GetObligationExternalRefSchema(c *gin.Context) {
+	writeExternalRefSchema(c, models.ExternalRefEntityObligation, "Obligation external_ref")
+}
+
+func writeExternalRefSchema(c *gin.Context, entity, title string) {
+	fields, err := utils.ExternalRefFields(db.DB, entity)
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "unable to fetch external_ref fields",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	schema := models.ExternalRefSchema{
+		Schema:     "https://json-schema.org/draft/2020-12/schema",
+		Title:      title,
+		Type:       "object",
+		Properties: map[string]models.ExternalRefSchemaProperty{},
+		Required:   []string{},
+	}
+	for _, field := range fields {
+		schema.Properties[field.Name] = externalRefSchemaProperty(field)
+		if field.Required {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+	c.JSON(http.StatusOK, schema)
+}
+
+// externalRefSchemaProperty returns the JSON Schema of field. The enum values
+// and regex of arrays apply to their items.
+func externalRefSchemaProperty(field models.ExternalRefField) models.ExternalRefSchemaProperty {
+	property := models.ExternalRefSchemaProperty{
+		Type:   externalRefSchemaTypes[field.Type][0],
+		Format: externalRefSchemaTypes[field.Type][1],
+		Title:  field.Label,
+	}
+	constrained := &property
+	switch field.Type {
+	case models.ExternalRefTypeInt, models.ExternalRefTypeNumber:
+		property.Minimum, property.Maximum = field.Min, field.Max
+	case models.ExternalRefTypeString, models.ExternalRefTypeURL:
+		property.MinLength, property.MaxLength = toLength(field.Min), toLength(field.Max)
+	case models.ExternalRefTypeArray:
+		property.MinItems, property.MaxItems = toLength(field.Min), toLength(field.Max)
+		property.Items = &models.ExternalRefSchemaProperty{Type: "string"}
+		constrained = property.Items
+	case models.ExternalRefTypeObject:
+		property.Properties = map[string]models.ExternalRefSchemaProperty{}
+		property.Required = []string{}
+		for _, nested := range field.Fields {
+			property.Properties[nested.Name] = externalRefSchemaProperty(nested)
+			if nested.Required {
+				property.Required = append(property.Required, nested.Name)
+			}
+		}
+	}
+	constrained.Enum = field.Enum
+	if field.Regex != nil {
+		constrained.Pattern = *field.Regex
+	}
+	return property
+}
+
+func toLength(bound *float64) *int {
+	if bound == nil {
+		return nil
+	}
+	length := int(*bound)
+	return &length
+}
+
+// validExternalRefConstraints checks that the enum values and regex of a
+// field are valid. It writes the error response and returns false if not.
+func validExternalRefConstraints(c *gin.Context, field *models.ExternalRefField) bool {
+	var message string
+	if (len(field.Enum) != 0 || field.Regex != nil) && field.Type != models.ExternalRefTypeString {
+		message = "enum values and regex only apply to string fields"
+	} else if field.Regex != nil {
+		if _, err := regexp.Compile(*field.Regex); err != nil {
+			message = fmt.Sprintf("invalid regex: %s", err.Error())
+		}
+	}
+	if message == "" {
+		return true
+	}
+	er := models.LicenseError{
+		Status:    http.StatusBadRequest,
+		Message:   "invalid external_ref field",
+		Error:     message,
+		Path:      c.Request.URL.Path,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	c.JSON(http.StatusBadRequest, er)
+	return false
+}
+
+// findExternalRefField fetches the runtime defined external_ref field with the
+// given id. It writes the error response and returns false if there is no
+// such field.
+func findExternalRefField(c *gin.Context, tx *gorm.DB, id string) (*models.ExternalRefField, bool) {
+	var field models.ExternalRefField
+	fieldId, err := uuid.Parse(id)
+	if err == nil {
+		err = tx.Where(&models.ExternalRefField{Id: fieldId}).First(&field).Error
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) || fieldId == uuid.Nil {
+			status = http.StatusNotFound
+		}
+		er := models.LicenseError{
+			Status:    status,
+			Message:   fmt.Sprintf("no external_ref field with id '%s' exists", id),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(status, er)
+		return nil, false
+	}
+	return &field, true
+}
+
+func writeExternalRefField(c *gin.Context, field *models.ExternalRefField, status int) {
+	res := models.ExternalRefFieldResponse{
+		Data:   []models.ExternalRefField{*field},
+		Status: status,
+		Meta: &models.PaginationMeta{
+			ResourceCount: 1,
+		},
+	}
+	c.JSON(status, res)
+}
+
+// addChangelogsForExternalRefField adds changelogs for the updated fields of
+// an external_ref field.
+func addChangelogsForExternalRefField(tx *gorm.DB, userId uuid.UUID, newField, oldField *models.ExternalRefField) error {
+	var changes []models.ChangeLog
+
+	str := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+	utils.AddChangelog("Entity", str(oldField.Entity), str(newField.Entity), &changes)
+	utils.AddChangelog("Name", str(oldField.Name), str(newField.Name), &changes)
+	utils.AddChangelog("Type", str(oldField.Type), str(newField.Type), &changes)
+	utils.AddChangelog("Label", str(oldField.Label), str(newField.Label), &changes)
+	utils.AddChangelog("Required", &oldField.Required, &newField.Required, &changes)
+	utils.AddChangelog("Enum", str(strings.Join(oldField.Enum, ", ")), str(strings.Join(newField.Enum, ", ")), &changes)
+	utils.AddChangelog("Regex", oldField.Regex, newField.Regex, &changes)
+
+	if len(changes) != 0 {
+		audit := models.Audit{
+			UserId:     userId,
+			TypeId:     newField.Id,
+			Timestamp:  time.Now(),
+			Type:       "EXTERNAL_REF_FIELD",
+			ChangeLogs: changes,
+		}
+
+		if err := tx.Create(&audit).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
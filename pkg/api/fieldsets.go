@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// licenseFieldColumns are the columns of the keys of licenses in responses.
+// Keys of relations have no columns.
+var licenseFieldColumns = map[string][]string{
+	"shortname":            {"license_dbs.rf_shortname"},
+	"fullname":             {"license_dbs.rf_fullname"},
+	"text":                 {"license_dbs.rf_text"},
+	"url":                  {"license_dbs.rf_url"},
+	"copyleft":             {"license_dbs.rf_copyleft"},
+	"OSIapproved":          {"license_dbs.rf_osiapproved"},
+	"notes":                {"license_dbs.rf_notes"},
+	"text_updatable":       {"license_dbs.rf_text_updatable"},
+	"active":               {"license_dbs.rf_active"},
+	"source":               {"license_dbs.rf_source"},
+	"spdx_id":              {"license_dbs.rf_spdx_id"},
+	"risk":                 {"license_dbs.rf_risk"},
+	"external_ref":         {"license_dbs.external_ref"},
+	"obligation_ids":       nil,
+	"created_by":           {"license_dbs.user_id"},
+	"add_date":             {"license_dbs.rf_add_date"},
+	"steward_team_id":      {"license_dbs.steward_team_id"},
+	"review_interval_days": {"license_dbs.review_interval_days"},
+	"last_reviewed_at":     {"license_dbs.last_reviewed_at"},
+	"review_due_at":        {"license_dbs.last_reviewed_at", "license_dbs.review_interval_days"},
+	"aliases":              nil,
+	"version":              {"license_dbs.version"},
+}
+
+// obligationFieldColumns are the columns of the keys of obligations in
+// responses. The type, classification and category are always joined.
+var obligationFieldColumns = map[string][]string{
+	"topic":                {"obligations.topic"},
+	"type":                 nil,
+	"text":                 {"obligations.text"},
+	"classification":       nil,
+	"comment":              {"obligations.comment"},
+	"active":               {"obligations.active"},
+	"text_updatable":       {"obligations.text_updatable"},
+	"license_ids":          nil,
+	"category":             nil,
+	"external_ref":         {"obligations.external_ref"},
+	"steward_team_id":      {"obligations.steward_team_id"},
+	"review_interval_days": {"obligations.review_interval_days"},
+	"last_reviewed_at":     {"obligations.last_reviewed_at"},
+	"review_due_at":        {"obligations.last_reviewed_at", "obligations.review_interval_days"},
+	"version":              {"obligations.version"},
+}
+
+// fieldset is the set of keys of the objects in a response, parsed from the
+// fields query parameter. A nil fieldset has all keys. The id is always
+// included.
+type fieldset map[string]bool
+
+func (f fieldset) has(key string) bool {
+	return f == nil || key == "id" || f[key]
+}
+
+// columns returns the columns to select for the keys of f, with the columns
+// always needed, or nil if all columns are needed.
+func (f fieldset) columns(fieldColumns map[string][]string, needed ...string) []string {
+	if f == nil {
+		return nil
+	}
+	columns := needed
+	for key := range f {
+		for _, column := range fieldColumns[key] {
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+	return columns
+}
+
+// parseFieldset parses the fields query parameter, a comma separated list of
+// keys. It responds with 400 Bad Request and returns false if a key is not
+// one of the keys of fieldColumns.
+func parseFieldset(c *gin.Context, fieldColumns map[string][]string) (fieldset, bool) {
+	fields, ok := c.GetQuery("fields")
+	if !ok {
+		return nil, true
+	}
+	f := fieldset{}
+	for _, key := range strings.Split(fields, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" || key == "id" {
+			continue
+		}
+		if _, ok := fieldColumns[key]; !ok {
+			fieldsetError(c, "invalid fields", fmt.Errorf("unknown field '%s', expected one of id, %s", key, strings.Join(slices.Sorted(maps.Keys(fieldColumns)), ", ")))
+			return nil, false
+		}
+		f[key] = true
+	}
+	return f, true
+}
+
+// parseExpand parses the expand query parameter, a comma separated list of
+// relations to include, which defaults to defaults. It responds with 400 Bad
+// Request and returns false if a relation is not one of relations.
+func parseExpand(c *gin.Context, relations []string, defaults ...string) (map[string]bool, bool) {
+	expand := map[string]bool{}
+	value, ok := c.GetQuery("expand")
+	if !ok {
+		for _, relation := range defaults {
+			expand[relation] = true
+		}
+		return expand, true
+	}
+	for _, relation := range strings.Split(value, ",") {
+		relation = strings.TrimSpace(relation)
+		if relation == "" {
+			continue
+		}
+		if !slices.Contains(relations, relation) {
+			fieldsetError(c, "invalid expand", fmt.Errorf("unknown relation '%s', expected one of %s", relation, strings.Join(relations, ", ")))
+			return nil, false
+		}
+		expand[relation] = true
+	}
+	return expand, true
+}
+
+// sparseResponse tells whether a listing is shaped by the fields or expand
+// query parameters rather than responding with complete objects.
+func sparseResponse(c *gin.Context) bool {
+	_, fields := c.GetQuery("fields")
+	_, expand := c.GetQuery("expand")
+	return fields || expand
+}
+
+// sparseObject returns the JSON object of v with only the keys of f, and
+// without the omitted keys.
+func sparseObject(v any, f fieldset, omit ...string) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for key := range object {
+		if !f.has(key) || slices.Contains(omit, key) {
+			delete(object, key)
+		}
+	}
+	return object, nil
+}
+
+// sparseLicense returns the JSON object of a license with the keys of f. The
+// user who created it is only included if expanded, and the obligations
+// expanded leave out their licenses.
+func sparseLicense(l *models.LicenseDB, f fieldset, expand map[string]bool) (map[string]json.RawMessage, error) {
+	var omit []string
+	if !expand["user"] {
+		omit = append(omit, "created_by")
+	}
+	object, err := sparseObject(l.ConvertToLicenseResponseDTO(), f, omit...)
+	if err != nil || !expand["obligations"] {
+		return object, err
+	}
+	obligations := make([]map[string]json.RawMessage, 0, len(l.Obligations))
+	for i := range l.Obligations {
+		obligation, err := sparseObject(l.Obligations[i].ConvertToObligationResponseDTO(), nil, "license_ids")
+		if err != nil {
+			return nil, err
+		}
+		obligations = append(obligations, obligation)
+	}
+	object["obligations"], err = json.Marshal(obligations)
+	return object, err
+}
+
+// sparseObligation returns the JSON object of an obligation with the keys of
+// f. The licenses expanded leave out their relations.
+func sparseObligation(o *models.Obligation, f fieldset, expand map[string]bool) (map[string]json.RawMessage, error) {
+	object, err := sparseObject(o.ConvertToObligationResponseDTO(), f)
+	if err != nil || !expand["licenses"] {
+		return object, err
+	}
+	licenses := make([]map[string]json.RawMessage, 0, len(o.Licenses))
+	for i := range o.Licenses {
+		license, err := sparseObject(o.Licenses[i].ConvertToLicenseResponseDTO(), nil, "created_by", "obligation_ids", "aliases")
+		if err != nil {
+			return nil, err
+		}
+		licenses = append(licenses, license)
+	}
+	object["licenses"], err = json.Marshal(licenses)
+	return object, err
+}
+
+// selectIds preloads only the ids of related rows, for lists of their ids.
+func selectIds(column string) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Select(column)
+	}
+}
+
+func fieldsetError(c *gin.Context, message string, err error) {
+	er := models.LicenseError{
+		Status:    http.StatusBadRequest,
+		Message:   message,
+		Error:     err.Error(),
+		Path:      c.Request.URL.Path,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	c.JSON(http.StatusBadRequest, er)
+}
@@ -0,0 +1,292 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/matching"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/validations"
+)
+
+const (
+	defaultMinCoverage = 50
+	// Only the licenses sharing the most words with the input are compared
+	// with it for near matches
+	maxNearMatchCandidates = 20
+	maxNearMatches         = 10
+	// maxIdentifyBodyBytes bounds the request bodies of identifications, the
+	// matching takes time and memory proportional to the text
+	maxIdentifyBodyBytes = 1 << 20
+)
+
+// licenseTemplates caches the compiled texts of the active licenses by id,
+// along with the license they were compiled from, and the catalog of the
+// licenses last loaded.
+var licenseTemplates = struct {
+	sync.Mutex
+	byId    map[uuid.UUID]licenseTemplate
+	catalog *licenseCatalog
+}{byId: map[uuid.UUID]licenseTemplate{}}
+
+type licenseTemplate struct {
+	license  identifiableLicense
+	template *matching.Template
+}
+
+// identifiableLicense is an active license along with the md5 of its text.
+type identifiableLicense struct {
+	Id        uuid.UUID `gorm:"column:rf_id"`
+	Shortname string    `gorm:"column:rf_shortname"`
+	Fullname  string    `gorm:"column:rf_fullname"`
+	SpdxId    string    `gorm:"column:rf_spdx_id"`
+	TextMd5   string    `gorm:"column:text_md5"`
+}
+
+// IdentifyLicense identifies the licenses in a text.
+//
+//	@Summary		Identify licenses in a text
+//	@Description	Identifies the licenses in a text following the SPDX License Matching Guidelines. Case, punctuation,
+//	@Description	whitespace, bullets, copyright notices, license titles and varietal spellings are ignored, and the
+//	@Description	replaceable and optional sections of SPDX license templates are honored. Returns the licenses
+//	@Description	whose text is found exactly, and the ones whose text is covered at least min_coverage percent,
+//	@Description	along with the portions of the input text which matched them.
+//	@Id				IdentifyLicense
+//	@Tags			Licenses
+//	@Accept			json
+//	@Produce		json
+//	@Param			text	body		models.IdentifyRequest	true	"Text to identify the licenses in"
+//	@Success		200		{object}	models.LicenseIdentificationResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		413		{object}	models.LicenseError	"Text is too large"
+//	@Failure		500		{object}	models.LicenseError	"Unable to identify licenses"
+//	@Security		ApiKeyAuth
+//	@Router			/licenses/identify [post]
+func IdentifyLicense(c *gin.Context) {
+	var input models.IdentifyRequest
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxIdentifyBodyBytes)
+	if err := c.ShouldBindJSON(&input); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			er := models.LicenseError{
+				Status:    http.StatusRequestEntityTooLarge,
+				Message:   fmt.Sprintf("request bodies may be at most %d MB", maxIdentifyBodyBytes>>20),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusRequestEntityTooLarge, er)
+			return
+		}
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not identify licenses with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	minCoverage := float64(defaultMinCoverage)
+	if input.MinCoverage != nil {
+		minCoverage = *input.MinCoverage
+	}
+
+	catalog, err := loadLicenseTemplates(db.DB.WithContext(c))
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Unable to identify licenses",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	identification := catalog.identify(matching.Words(input.Text), minCoverage)
+
+	c.JSON(http.StatusOK, models.LicenseIdentificationResponse{
+		Status: http.StatusOK,
+		Data:   identification,
+	})
+}
+
+// licenseCatalog are the active licenses along with their compiled texts.
+type licenseCatalog struct {
+	licenses  []identifiableLicense
+	templates []*matching.Template
+	index     *matching.Index
+}
+
+// identify returns the licenses whose texts are found exactly in a text, and
+// the ones whose texts are covered at least minCoverage percent.
+func (catalog *licenseCatalog) identify(text []matching.Word, minCoverage float64) models.LicenseIdentification {
+	identification := models.LicenseIdentification{
+		ExactMatches: []models.IdentifiedLicense{},
+		NearMatches:  []models.IdentifiedLicense{},
+	}
+	words := map[string]struct{}{}
+	for _, word := range text {
+		words[word.Text] = struct{}{}
+	}
+
+	type candidate struct {
+		license     identifiableLicense
+		template    *matching.Template
+		containment float64
+	}
+	var candidates []candidate
+	for i, containment := range catalog.index.Containments(words) {
+		template := catalog.templates[i]
+		if template == nil {
+			continue
+		}
+		if containment == 1 {
+			// Only texts with all words of a license can contain it exactly
+			result := template.Match(text)
+			if result.Exact {
+				identification.ExactMatches = append(identification.ExactMatches, identifiedLicense(catalog.licenses[i], result))
+				continue
+			}
+		}
+		if 100*containment >= minCoverage {
+			candidates = append(candidates, candidate{license: catalog.licenses[i], template: template, containment: containment})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].containment > candidates[j].containment })
+	if len(candidates) > maxNearMatchCandidates {
+		candidates = candidates[:maxNearMatchCandidates]
+	}
+	for _, candidate := range candidates {
+		result := candidate.template.Match(text)
+		if !result.Exact && result.Coverage >= minCoverage && len(result.Portions) != 0 {
+			identification.NearMatches = append(identification.NearMatches, identifiedLicense(candidate.license, result))
+		}
+	}
+	sort.SliceStable(identification.NearMatches, func(i, j int) bool {
+		return identification.NearMatches[i].Coverage > identification.NearMatches[j].Coverage
+	})
+	if len(identification.NearMatches) > maxNearMatches {
+		identification.NearMatches = identification.NearMatches[:maxNearMatches]
+	}
+	return identification
+}
+
+// loadLicenseTemplates returns the active licenses along with their compiled
+// texts, compiling the texts which changed since they were last compiled. The
+// catalog is reused as long as the active licenses do not change.
+func loadLicenseTemplates(tx *gorm.DB) (*licenseCatalog, error) {
+	var licenses []identifiableLicense
+	if err := tx.Model(&models.LicenseDB{}).
+		Select("rf_id, rf_shortname, rf_fullname, rf_spdx_id, md5(rf_text) AS text_md5").
+		Where("rf_active").
+		Order("rf_shortname").
+		Scan(&licenses).Error; err != nil {
+		return nil, err
+	}
+
+	licenseTemplates.Lock()
+	defer licenseTemplates.Unlock()
+
+	if catalog := licenseTemplates.catalog; catalog != nil && slices.Equal(catalog.licenses, licenses) {
+		return catalog, nil
+	}
+
+	// The titles are compiled into the templates along with the texts
+	var stale []uuid.UUID
+	for _, license := range licenses {
+		if cached, ok := licenseTemplates.byId[license.Id]; !ok || cached.license != license {
+			stale = append(stale, license.Id)
+		}
+	}
+	if len(stale) != 0 {
+		var texts []struct {
+			Id   uuid.UUID `gorm:"column:rf_id"`
+			Text string    `gorm:"column:rf_text"`
+		}
+		if err := tx.Model(&models.LicenseDB{}).Select("rf_id, rf_text").Where("rf_id IN ?", stale).
+			Scan(&texts).Error; err != nil {
+			return nil, err
+		}
+		byId := map[uuid.UUID]identifiableLicense{}
+		for _, license := range licenses {
+			byId[license.Id] = license
+		}
+		for _, text := range texts {
+			license := byId[text.Id]
+			licenseTemplates.byId[text.Id] = licenseTemplate{
+				license:  license,
+				template: matching.Compile(text.Text, license.Fullname, license.Shortname, license.SpdxId),
+			}
+		}
+	}
+
+	active := map[uuid.UUID]struct{}{}
+	templates := make([]*matching.Template, len(licenses))
+	complete := true
+	for i, license := range licenses {
+		active[license.Id] = struct{}{}
+		templates[i] = licenseTemplates.byId[license.Id].template
+		// Licenses deleted since they were listed have no template
+		complete = complete && templates[i] != nil
+	}
+	// Deleted and deactivated licenses are no longer identified
+	maps.DeleteFunc(licenseTemplates.byId, func(id uuid.UUID, _ licenseTemplate) bool {
+		_, ok := active[id]
+		return !ok
+	})
+
+	catalog := &licenseCatalog{licenses: licenses, templates: templates, index: matching.NewIndex(templates)}
+	licenseTemplates.catalog = nil
+	if complete {
+		licenseTemplates.catalog = catalog
+	}
+	return catalog, nil
+}
+
+func identifiedLicense(license identifiableLicense, result matching.Result) models.IdentifiedLicense {
+	identified := models.IdentifiedLicense{
+		Id:        license.Id,
+		Shortname: license.Shortname,
+		SpdxId:    license.SpdxId,
+		Coverage:  result.Coverage,
+		Portions:  make([]models.MatchedPortion, 0, len(result.Portions)),
+	}
+	for _, portion := range result.Portions {
+		identified.Portions = append(identified.Portions, models.MatchedPortion{Start: portion.Start, End: portion.End})
+	}
+	return identified
+}
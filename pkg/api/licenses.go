@@ -20,6 +20,7 @@ import (
 
 	"github.com/fossology/LicenseDb/pkg/db"
 	"github.com/fossology/LicenseDb/pkg/email"
+	"github.com/fossology/LicenseDb/pkg/filter"
 	logger "github.com/fossology/LicenseDb/pkg/log"
 	"github.com/fossology/LicenseDb/pkg/models"
 	"github.com/fossology/LicenseDb/pkg/utils"
@@ -31,6 +32,38 @@ import (
 	"gorm.io/gorm"
 )
 
+// licenseObligations is the subquery of the obligations of a license, with
+// their type, classification and category, for filters on obligations.
+const licenseObligations = "EXISTS (SELECT 1 FROM obligation_licenses ol " +
+	"JOIN obligations o ON o.id = ol.obligation_id " +
+	"LEFT JOIN obligation_types ot ON ot.id = o.obligation_type_id " +
+	"LEFT JOIN obligation_classifications oc ON oc.id = o.obligation_classification_id " +
+	"LEFT JOIN obligation_categories og ON og.id = o.obligation_category_id " +
+	"WHERE ol.license_db_id = license_dbs.rf_id AND %s)"
+
+// licenseFilterFields are the fields of the filter expressions of licenses.
+var licenseFilterFields = filter.Fields{
+	"shortname":                 {Kind: filter.String, Column: "license_dbs.rf_shortname"},
+	"fullname":                  {Kind: filter.String, Column: "license_dbs.rf_fullname"},
+	"spdx_id":                   {Kind: filter.String, Column: "license_dbs.rf_spdx_id"},
+	"text":                      {Kind: filter.String, Column: "license_dbs.rf_text"},
+	"url":                       {Kind: filter.String, Column: "license_dbs.rf_url"},
+	"notes":                     {Kind: filter.String, Column: "license_dbs.rf_notes"},
+	"source":                    {Kind: filter.String, Column: "license_dbs.rf_source"},
+	"risk":                      {Kind: filter.Integer, Column: "license_dbs.rf_risk"},
+	"active":                    {Kind: filter.Boolean, Column: "license_dbs.rf_active"},
+	"copyleft":                  {Kind: filter.Boolean, Column: "license_dbs.rf_copyleft"},
+	"osiapproved":               {Kind: filter.Boolean, Column: "license_dbs.rf_osiapproved"},
+	"text_updatable":            {Kind: filter.Boolean, Column: "license_dbs.rf_text_updatable"},
+	"add_date":                  {Kind: filter.Time, Column: "license_dbs.rf_add_date"},
+	"review_interval_days":      {Kind: filter.Integer, Column: "license_dbs.review_interval_days"},
+	"last_reviewed_at":          {Kind: filter.Time, Column: "license_dbs.last_reviewed_at"},
+	"obligation.topic":          {Kind: filter.String, Column: "o.topic", Exists: licenseObligations},
+	"obligation.type":           {Kind: filter.String, Column: "ot.type", Exists: licenseObligations},
+	"obligation.classification": {Kind: filter.String, Column: "oc.classification", Exists: licenseObligations},
+	"obligation.category":       {Kind: filter.String, Column: "og.category", Exists: licenseObligations},
+}
+
 // FilterLicense Get licenses from service based on different filters.
 //
 //	@Summary		Filter licenses
@@ -45,10 +78,14 @@ import (
 //	@Param			copyleft	query		bool					false	"Copyleft flag status of license"
 //	@Param			page		query		int						false	"Page number"
 //	@Param			limit		query		int						false	"Limit of responses per page"
-//	@Param			externalRef	query		string					false	"External reference parameters"
+//	@Param			cursor		query		string					false	"Cursor of the page, empty for the first page, to paginate by cursor instead of page number"
+//	@Param			externalRef	query		string					false	"JSON object of external_ref keys and their value or conditions on it (eq, ne, lt, lte, gt, gte, in, contains, exists)"
+//	@Param			filter		query		string					false	"Filter expression, e.g. risk=ge=3;(source=in=(spdx,fossology),fullname=like=GNU)"
 //	@Param			sort_by		query		string					false	"Sort by field"			Enums(spdx_id, shortname, fullname)	default(shortname)
 //	@Param			order_by	query		string					false	"Asc or desc ordering"	Enums(asc, desc)					default(asc)
-//	@Success		200			{object}	models.LicenseResponse	"Filtered licenses"
+//	@Param			fields		query		string					false	"Comma separated keys of the licenses to respond with, e.g. shortname,spdx_id,risk"
+//	@Param			expand		query		string					false	"Comma separated relations to include, obligations and user"	default(user)
+//	@Success		200			{object}	models.LicenseResponse	"Filtered licenses, with only the requested keys if fields or expand is given"
 //	@Failure		400			{object}	models.LicenseError		"Invalid value"
 //	@Security		ApiKeyAuth || {}
 //	@Router			/licenses [get]
@@ -57,16 +94,20 @@ func FilterLicense(c *gin.Context) {
 	active := c.Query("active")
 	OSIapproved := c.Query("osiapproved")
 	copyleft := c.Query("copyleft")
-	externalRef := c.Query("externalRef")
 
-	externalRefData := make(map[string]string)
+	externalRefFields, ok := externalRefFilterFields(c, models.ExternalRefEntityLicense, "license_dbs.external_ref")
+	if !ok {
+		return
+	}
 
-	if len(externalRef) > 0 {
-		err := json.Unmarshal([]byte(externalRef), &externalRefData)
-		if err != nil {
+	var filterCondition string
+	var filterArgs []any
+	if expression := c.Query("filter"); expression != "" {
+		var err error
+		if filterCondition, filterArgs, err = withExternalRefFields(licenseFilterFields, externalRefFields).Compile(expression); err != nil {
 			er := models.LicenseError{
 				Status:    http.StatusBadRequest,
-				Message:   "invalid external ref type value",
+				Message:   "invalid filter",
 				Error:     err.Error(),
 				Path:      c.Request.URL.Path,
 				Timestamp: time.Now().Format(time.RFC3339),
@@ -76,8 +117,33 @@ func FilterLicense(c *gin.Context) {
 		}
 	}
 
+	refCondition, refArgs, ok := externalRefCondition(c, externalRefFields)
+	if !ok {
+		return
+	}
+
+	fields, ok := parseFieldset(c, licenseFieldColumns)
+	if !ok {
+		return
+	}
+	expand, ok := parseExpand(c, []string{"obligations", "user"}, "user")
+	if !ok {
+		return
+	}
+
 	var licenses []models.LicenseDB
-	query := db.DB.Model(&licenses).Preload("User").Preload("Obligations")
+	query := db.DB.Model(&licenses)
+	if expand["user"] && fields.has("created_by") {
+		query = query.Preload("User")
+	}
+	if expand["obligations"] {
+		query = query.Preload("Obligations.Type").Preload("Obligations.Classification").Preload("Obligations.Category")
+	} else if fields.has("obligation_ids") {
+		query = query.Preload("Obligations", selectIds("obligations.id"))
+	}
+	if fields.has("aliases") {
+		query = query.Preload("Aliases")
+	}
 
 	if active != "" {
 		parsedActive, err := strconv.ParseBool(active)
@@ -107,26 +173,29 @@ func FilterLicense(c *gin.Context) {
 		query = query.Where(models.LicenseDB{SpdxId: &SpdxId})
 	}
 
-	for externalRefKey, externalRefValue := range externalRefData {
-		query = query.Where(fmt.Sprintf("external_ref->>'%s' = ?", externalRefKey), externalRefValue)
+	if refCondition != "" {
+		query = query.Where(refCondition, refArgs...)
+	}
+
+	if filterCondition != "" {
+		query = query.Where(filterCondition, filterArgs...)
 	}
 
 	sortBy := c.Query("sort_by")
 	orderBy := c.Query("order_by")
-	queryOrderString := ""
-	if sortBy != "" && (sortBy == "spdx_id" || sortBy == "shortname" || sortBy == "fullname") {
-		queryOrderString += "rf_" + sortBy
-	} else {
-		queryOrderString += "rf_shortname"
+	keyset := utils.Keyset{Table: "license_dbs", Column: "rf_shortname", IdColumn: "rf_id", Desc: orderBy == "desc"}
+	if sortBy == "spdx_id" || sortBy == "shortname" || sortBy == "fullname" {
+		keyset.Column = "rf_" + sortBy
 	}
 
-	if orderBy != "" && orderBy == "desc" {
-		queryOrderString += " desc"
+	pagination, err := utils.PreparePaginateResponse(c, query, &keyset)
+	if err != nil {
+		return
 	}
 
-	query.Order(queryOrderString)
-
-	_ = utils.PreparePaginateResponse(c, query, &models.LicenseResponse{})
+	if columns := fields.columns(licenseFieldColumns, "license_dbs.rf_id", "license_dbs."+keyset.Column); columns != nil {
+		query.Select(columns)
+	}
 
 	if err := query.Find(&licenses).Error; err != nil {
 		er := models.LicenseError{
@@ -140,6 +209,31 @@ func FilterLicense(c *gin.Context) {
 		return
 	}
 
+	if sparseResponse(c) {
+		data := make([]map[string]json.RawMessage, 0, len(licenses))
+		for i := range licenses {
+			object, err := sparseLicense(&licenses[i], fields, expand)
+			if err != nil {
+				er := models.LicenseError{
+					Status:    http.StatusInternalServerError,
+					Message:   "unable to build the response",
+					Error:     err.Error(),
+					Path:      c.Request.URL.Path,
+					Timestamp: time.Now().Format(time.RFC3339),
+				}
+				c.JSON(http.StatusInternalServerError, er)
+				return
+			}
+			data = append(data, object)
+		}
+		c.JSON(http.StatusOK, models.SparseResponse{
+			Data:   data,
+			Status: http.StatusOK,
+			Meta:   pagination.Meta(licenses),
+		})
+		return
+	}
+
 	var licensedtos []models.LicenseResponseDTO
 
 	for _, l := range licenses {
@@ -149,9 +243,7 @@ func FilterLicense(c *gin.Context) {
 	res := models.LicenseResponse{
 		Data:   licensedtos,
 		Status: http.StatusOK,
-		Meta: &models.PaginationMeta{
-			ResourceCount: len(licenses),
-		},
+		Meta:   pagination.Meta(licenses),
 	}
 	c.JSON(http.StatusOK, res)
 }
@@ -164,9 +256,12 @@ func FilterLicense(c *gin.Context) {
 //	@Tags			Licenses
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string	true	"Id of the license"
-//	@Success		200	{object}	models.LicenseResponse
-//	@Failure		404	{object}	models.LicenseError	"License with id not found"
+//	@Param			id				path		string	true	"Id of the license"
+//	@Param			If-None-Match	header		string	false	"ETag of a version of the license the client has"
+//	@Success		200				{object}	models.LicenseResponse
+//	@Header			200				{string}	ETag	"Version of the license"
+//	@Success		304				"License not modified"
+//	@Failure		404				{object}	models.LicenseError	"License with id not found"
 //	@Security		ApiKeyAuth || {}
 //	@Router			/licenses/{id} [get]
 func GetLicense(c *gin.Context) {
@@ -185,7 +280,7 @@ func GetLicense(c *gin.Context) {
 		return
 	}
 
-	err = db.DB.Where(models.LicenseDB{Id: licenseId}).Preload("User").Preload("Obligations").First(&license).Error
+	err = db.DB.Where(models.LicenseDB{Id: licenseId}).Preload("User").Preload("Obligations").Preload("Aliases").First(&license).Error
 	if err != nil {
 		er := models.LicenseError{
 			Status:    http.StatusNotFound,
@@ -198,6 +293,10 @@ func GetLicense(c *gin.Context) {
 		return
 	}
 
+	if notModified(c, license.Version) {
+		return
+	}
+
 	res := models.LicenseResponse{
 		Data:   []models.LicenseResponseDTO{license.ConvertToLicenseResponseDTO()},
 		Status: http.StatusOK,
@@ -212,15 +311,19 @@ func GetLicense(c *gin.Context) {
 // CreateLicense creates a new license in the database.
 //
 //	@Summary		Create a new license
-//	@Description	Create a new license in the service
+//	@Description	Create a new license in the service. Licenses whose text is similar to the text of an active license,
+//	@Description	as set by DUPLICATE_CHECK_THRESHOLD, are only created with force=true.
 //	@Id				CreateLicense
 //	@Tags			Licenses
 //	@Accept			json
 //	@Produce		json
-//	@Param			license	body		models.LicenseCreateDTO	true	"New license to be created"
-//	@Success		201		{object}	models.LicenseResponse	"New license created successfully"
-//	@Failure		400		{object}	models.LicenseError		"Invalid request body"
-//	@Failure		500		{object}	models.LicenseError		"Failed to create license"
+//	@Param			license	body		models.LicenseCreateDTO			true	"New license to be created"
+//	@Param			force	query		bool							false	"Create the license even if its text duplicates an active license"
+//	@Success		201		{object}	models.LicenseResponse			"New license created successfully"
+//	@Failure		400		{object}	models.LicenseError				"Invalid request body"
+//	@Failure		404		{object}	models.LicenseError				"Obligations not found"
+//	@Failure		409		{object}	models.LicenseDuplicateError	"Text duplicates active licenses"
+//	@Failure		500		{object}	models.LicenseError				"Failed to create license"
 //	@Security		ApiKeyAuth
 //	@Router			/licenses [post]
 func CreateLicense(c *gin.Context) {
@@ -252,11 +355,75 @@ func CreateLicense(c *gin.Context) {
 		return
 	}
 
+	force, ok := forceCreate(c)
+	if !ok {
+		return
+	}
+
 	lic := input.ConvertToLicenseDB()
 
 	lic.UserId = userId
 
-	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := checkExternalRef(c, tx, models.ExternalRefEntityLicense, input.ExternalRef, nil); err != nil {
+			return err
+		}
+
+		missing, err := missingIds(tx, obligationDuplicates, input.ObligationIds)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to create license",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if len(missing) != 0 {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   "Failed to create license",
+				Error:     fmt.Sprintf("no obligations with ids %s exist", strings.Join(missing, ", ")),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return nil
+		}
+
+		duplicates := []models.SimilarLicense{}
+		if err := findCreateDuplicates(tx, licenseDuplicates, "rf_id, rf_shortname", input.Text, &duplicates); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to create license",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if len(duplicates) != 0 && !force {
+			er := models.LicenseDuplicateError{
+				LicenseError: models.LicenseError{
+					Status:    http.StatusConflict,
+					Message:   "the text of the license duplicates active licenses, create it with force=true to proceed",
+					Error:     fmt.Sprintf("license text is similar to the text of '%s'", *duplicates[0].Shortname),
+					Path:      c.Request.URL.Path,
+					Timestamp: time.Now().Format(time.RFC3339),
+				},
+				Matches: duplicates,
+			}
+			c.JSON(http.StatusConflict, er)
+			return nil
+		}
+		var duplicateNames []string
+		for _, d := range duplicates {
+			duplicateNames = append(duplicateNames, fmt.Sprintf("%s (%s) %.2f", *d.Shortname, d.Id, d.Similarity))
+		}
+
 		if err := tx.Omit("Obligations").Create(&lic).Error; err != nil {
 			er := models.LicenseError{
 				Status:    http.StatusInternalServerError,
@@ -289,7 +456,7 @@ func CreateLicense(c *gin.Context) {
 			return errors.New(combinedMapErrors.String())
 		}
 
-		if err := tx.Preload("User").Preload("Obligations").First(&lic).Error; err != nil {
+		if err := tx.Preload("User").Preload("Obligations").Preload("Aliases").First(&lic).Error; err != nil {
 			er := models.LicenseError{
 				Status:    http.StatusNotFound,
 				Message:   "Failed to create license",
@@ -301,7 +468,7 @@ func CreateLicense(c *gin.Context) {
 			return err
 		}
 
-		if err := utils.AddChangelogsForLicense(tx, userId, &lic, &models.LicenseDB{}); err != nil {
+		if err := utils.AddChangelogsForLicense(tx, userId, &lic, &models.LicenseDB{}, forcedDuplicateChangelogs(duplicateNames)...); err != nil {
 			er := models.LicenseError{
 				Status:    http.StatusInternalServerError,
 				Message:   "Failed to create license",
@@ -314,7 +481,10 @@ func CreateLicense(c *gin.Context) {
 		}
 		// Send notification email about license creation
 		if email.Email != nil {
-			email.NotifyLicenseCreated(*lic.User.UserEmail, *lic.User.UserName, *lic.Shortname)
+			// Service accounts have no email
+			if lic.User.UserEmail != nil {
+				email.NotifyLicenseCreated(*lic.User.UserEmail, *lic.User.UserName, *lic.Shortname)
+			}
 		} else {
 			logger.LogInfo("Email service is not enabled; skipping notification email sending")
 		}
@@ -341,12 +511,17 @@ func CreateLicense(c *gin.Context) {
 //	@Tags			Licenses
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		string					true	"Id of the license to be updated"
-//	@Param			license	body		models.LicenseUpdateDTO	true	"Update license body (requires only the fields to be updated)"
-//	@Success		200		{object}	models.LicenseResponse	"License updated successfully"
-//	@Failure		400		{object}	models.LicenseError		"Invalid license body"
-//	@Failure		404		{object}	models.LicenseError		"License with id not found"
-//	@Failure		500		{object}	models.LicenseError		"Failed to update license"
+//	@Param			id			path		string					true	"Id of the license to be updated"
+//	@Param			license		body		models.LicenseUpdateDTO	true	"Update license body (requires only the fields to be updated)"
+//	@Param			If-Match	header		string					false	"ETag the license was read with, required if REQUIRE_IF_MATCH is set"
+//	@Success		200			{object}	models.LicenseResponse	"License updated successfully"
+//	@Header			200			{string}	ETag					"New version of the license"
+//	@Failure		400			{object}	models.LicenseError		"Invalid license body"
+//	@Failure		403			{object}	models.LicenseError		"License is stewarded by a team of other users"
+//	@Failure		404			{object}	models.LicenseError		"License with id not found"
+//	@Failure		412			{object}	models.LicenseError		"License was changed since it was read"
+//	@Failure		428			{object}	models.LicenseError		"If-Match header is required"
+//	@Failure		500			{object}	models.LicenseError		"Failed to update license"
 //	@Security		ApiKeyAuth
 //	@Router			/licenses/{id} [patch]
 func UpdateLicense(c *gin.Context) {
@@ -376,7 +551,7 @@ func UpdateLicense(c *gin.Context) {
 		return
 	}
 
-	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
 		var oldLicense models.LicenseDB
 		userId := c.MustGet("userId").(uuid.UUID)
 
@@ -392,7 +567,7 @@ func UpdateLicense(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, er)
 			return err
 		}
-		if err := tx.Preload("User").Preload("Obligations").First(&oldLicense, licenseId).Error; err != nil {
+		if err := tx.Preload("User").Preload("Obligations").Preload("Aliases").First(&oldLicense, licenseId).Error; err != nil {
 			er := models.LicenseError{
 				Status:    http.StatusNotFound,
 				Message:   fmt.Sprintf("license with id '%s' not found", licenseId.String()),
@@ -404,6 +579,49 @@ func UpdateLicense(c *gin.Context) {
 			return err
 		}
 
+		stewardTeam, err := stewardTeamDenyingEdit(c, tx, oldLicense.StewardTeamId)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update license",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if stewardTeam != nil {
+			er := models.LicenseError{
+				Status:    http.StatusForbidden,
+				Message:   "only members of the steward team can edit this license",
+				Error:     fmt.Sprintf("license is stewarded by team '%s'", stewardTeam.Name),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusForbidden, er)
+			return errors.New("license is stewarded by another team")
+		}
+
+		if !ifMatch(c, oldLicense.Version) {
+			return errVersionChanged
+		}
+		if err := lockVersion(tx, &models.LicenseDB{}, "rf_id", oldLicense.Id, oldLicense.Version); err != nil {
+			if errors.Is(err, errVersionChanged) {
+				preconditionFailed(c, err)
+				return err
+			}
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update license",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
 		newLicense := updates.ConvertToLicenseDB()
 		if newLicense.Text != nil && *oldLicense.Text != *newLicense.Text && !*oldLicense.TextUpdatable {
 			er := models.LicenseError{
@@ -417,6 +635,10 @@ func UpdateLicense(c *gin.Context) {
 			return errors.New("field `text_updatable` needs to be true to update the text")
 		}
 
+		if err := checkExternalRef(c, tx, models.ExternalRefEntityLicense, updates.ExternalRef, oldLicense.ExternalRef.Data()); err != nil {
+			return err
+		}
+
 		// Overwrite values of existing keys, add new key value pairs and remove keys with null values.
 		if err := tx.Model(models.LicenseDB{}).Where(models.LicenseDB{Id: oldLicense.Id}).UpdateColumn("external_ref", gorm.Expr("jsonb_strip_nulls(COALESCE(external_ref, '{}'::jsonb) || ?)", updates.ExternalRef)).Error; err != nil {
 			er := models.LicenseError{
@@ -463,7 +685,7 @@ func UpdateLicense(c *gin.Context) {
 			}
 		}
 
-		if err := tx.Preload("User").Preload("Obligations").Where(models.LicenseDB{Id: oldLicense.Id}).First(&newLicense).Error; err != nil {
+		if err := tx.Preload("User").Preload("Obligations").Preload("Aliases").Where(models.LicenseDB{Id: oldLicense.Id}).First(&newLicense).Error; err != nil {
 			er := models.LicenseError{
 				Status:    http.StatusInternalServerError,
 				Message:   "Failed to update license",
@@ -488,7 +710,10 @@ func UpdateLicense(c *gin.Context) {
 		}
 		// Send notification email about license update
 		if email.Email != nil {
-			email.NotifyLicenseUpdated(*newLicense.User.UserEmail, *newLicense.User.UserName, *newLicense.Shortname)
+			// Service accounts have no email
+			if newLicense.User.UserEmail != nil {
+				email.NotifyLicenseUpdated(*newLicense.User.UserEmail, *newLicense.User.UserName, *newLicense.Shortname)
+			}
 		} else {
 			logger.LogInfo("Email service is not enabled; skipping notification email sending")
 		}
@@ -501,6 +726,7 @@ func UpdateLicense(c *gin.Context) {
 			},
 		}
 
+		c.Header("ETag", etag(newLicense.Version))
 		c.JSON(http.StatusOK, res)
 
 		return nil
@@ -570,7 +796,7 @@ func SearchInLicense(c *gin.Context) {
 		c.JSON(http.StatusNotFound, er)
 		return
 	}
-	err := query.Preload("User").Preload("Obligations").Find(&licenses).Error
+	err := query.Preload("User").Preload("Obligations").Preload("Aliases").Find(&licenses).Error
 	if err != nil {
 		er := models.LicenseError{
 			Status:    http.StatusBadRequest,
@@ -663,7 +889,7 @@ func ImportLicenses(c *gin.Context) {
 	}
 
 	for i := range licenses {
-		errMessage, importStatus := utils.InsertOrUpdateLicenseOnImport(&licenses[i], userId)
+		errMessage, importStatus := utils.InsertOrUpdateLicenseOnImport(db.DB.WithContext(c), &licenses[i], userId)
 
 		switch importStatus {
 		case utils.IMPORT_FAILED:
@@ -739,7 +965,7 @@ func ImportLicenses(c *gin.Context) {
 //	@Router			/licenses/export [get]
 func ExportLicenses(c *gin.Context) {
 	var licenses []models.LicenseDB
-	query := db.DB.Model(&models.LicenseDB{}).Preload("User").Preload("Obligations")
+	query := db.DB.Model(&models.LicenseDB{}).Preload("User").Preload("Obligations").Preload("Aliases")
 	err := query.Find(&licenses).Error
 	if err != nil {
 		er := models.LicenseError{
@@ -830,8 +1056,9 @@ func GetAllLicensePreviews(c *gin.Context) {
 
 // getSimilarLicense finds similar license texts using trigram similarity
 //
-//	@Summary		Find similar license texts
-//	@Description	Compares input license text with existing ones using pg_trgm similarity
+//	@Summary		Find similar licenses
+//	@Description	Returns the licenses whose text is similar to the input using pg_trgm, the most similar first.
+//	@Description	The threshold defaults to SIMILARITY_THRESHOLD and all matches are returned unless a limit is given.
 //	@ID				getSimilarLicense
 //	@Tags			Licenses
 //	@Accept			json
@@ -847,22 +1074,15 @@ func getSimilarLicenses(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.LicenseError{
 			Status:    http.StatusBadRequest,
-			Message:   "Text field is required",
+			Message:   "Text field is required and threshold, limit and min_length must be valid",
 			Error:     err.Error(),
 			Path:      c.Request.URL.Path,
 			Timestamp: time.Now().Format(time.RFC3339),
 		})
 		return
 	}
-	var results []models.SimilarLicense
-	utils.SetSimilarityThreshold()
-	query := `
-		SELECT rf_id, rf_shortname, rf_text, similarity(rf_text, ?) AS similarity
-		FROM license_dbs
-		WHERE rf_text % ?
-		ORDER BY similarity DESC
-	`
-	if err := db.DB.Raw(query, req.Text, req.Text).Scan(&results).Error; err != nil {
+	results := []models.SimilarLicense{}
+	if err := findSimilar(db.DB.WithContext(c), req, "license_dbs", "rf_id, rf_shortname", "rf_text", &results); err != nil {
 		c.JSON(http.StatusBadRequest, models.LicenseError{
 			Status:    http.StatusBadRequest,
 			Message:   "Database query failed",
@@ -880,3 +1100,31 @@ func getSimilarLicenses(c *gin.Context) {
 		},
 	})
 }
+
+// findSimilar scans the rows of a table whose text is similar to the text of
+// a similarity request into results, applying the threshold of the request
+// within a single transaction. Scopes narrow down the rows compared.
+func findSimilar(tx *gorm.DB, req models.SimilarityRequest, table, columns, textColumn string, results any,
+	scopes ...func(*gorm.DB) *gorm.DB) error {
+	threshold := utils.DefaultSimilarityThreshold()
+	if req.Threshold != nil {
+		threshold = *req.Threshold
+	}
+	if !req.OmitText {
+		columns += ", " + textColumn
+	}
+
+	return utils.WithSimilarityThreshold(tx, threshold, func(tx *gorm.DB) error {
+		query := tx.Table(table).
+			Select(columns+", similarity("+textColumn+", ?) AS similarity", req.Text).
+			Where(textColumn+" % ?", req.Text).
+			Scopes(scopes...)
+		if req.MinLength != nil {
+			query = query.Where("char_length("+textColumn+") >= ?", *req.MinLength)
+		}
+		if req.Limit != nil {
+			query = query.Limit(*req.Limit)
+		}
+		return query.Order("similarity DESC").Scan(results).Error
+	})
+}
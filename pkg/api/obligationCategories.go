@@ -6,6 +6,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -109,7 +110,7 @@ func CreateObligationCategory(c *gin.Context) {
 		return
 	}
 
-	status, err := utils.CreateObCategory(&obCategory, userId)
+	status, err := utils.CreateObCategory(db.DB.WithContext(c), &obCategory, userId)
 
 	switch status {
 	case utils.CREATED:
@@ -174,9 +175,12 @@ func CreateObligationCategory(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			category	path	string	true	"Obligation Category"
+//	@Param			If-Match	header	string	false	"ETag of the version of the obligation category in its listing, required if REQUIRE_IF_MATCH is set"
 //	@Success		200
 //	@Failure		400	{object}	models.LicenseError	"cannot delete obligation category 'DISTRIBUTION' as it's still referenced by some obligations"
 //	@Failure		404	{object}	models.LicenseError	"obligation category 'DISTRIBUTION' not found"
+//	@Failure		412	{object}	models.LicenseError	"obligation category was changed since it was read"
+//	@Failure		428	{object}	models.LicenseError	"If-Match header is required"
 //	@Failure		500	{object}	models.LicenseError	"something went wrong while deleting obligation category"
 //	@Security		ApiKeyAuth
 //	@Router			/obligations/categories/{category} [delete]
@@ -226,9 +230,20 @@ func DeleteObligationCategory(c *gin.Context) {
 		return
 	}
 
-	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+	if !ifMatch(c, obCategory.Version) {
+		return
+	}
+
+	if err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := lockVersion(tx, &models.ObligationCategory{}, "id", obCategory.Id, obCategory.Version); err != nil {
+			return err
+		}
 		return utils.ToggleObligationCategoryActiveStatus(userId, tx, &obCategory)
 	}); err != nil {
+		if errors.Is(err, errVersionChanged) {
+			preconditionFailed(c, err)
+			return
+		}
 		er := models.LicenseError{
 			Status:    http.StatusInternalServerError,
 			Message:   "something went wrong while deleting obligation category",
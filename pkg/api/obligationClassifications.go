@@ -6,6 +6,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -108,7 +109,7 @@ func CreateObligationClassification(c *gin.Context) {
 		return
 	}
 
-	status, err := utils.CreateObClassification(&obClassification, userId)
+	status, err := utils.CreateObClassification(db.DB.WithContext(c), &obClassification, userId)
 
 	switch status {
 	case utils.CREATED:
@@ -173,9 +174,12 @@ func CreateObligationClassification(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			classification	path	string	true	"Obligation Classification"
+//	@Param			If-Match		header	string	false	"ETag of the version of the obligation classification in its listing, required if REQUIRE_IF_MATCH is set"
 //	@Success		200
 //	@Failure		400	{object}	models.LicenseError	"cannot delete obligation classification 'GREEN' as it's still referenced by some obligations"
 //	@Failure		404	{object}	models.LicenseError	"obligation classification 'GREEN' not found"
+//	@Failure		412	{object}	models.LicenseError	"obligation classification was changed since it was read"
+//	@Failure		428	{object}	models.LicenseError	"If-Match header is required"
 //	@Failure		500	{object}	models.LicenseError	"something went wrong while deleting obligation classification"
 //	@Security		ApiKeyAuth
 //	@Router			/obligations/classifications/{classification} [delete]
@@ -225,9 +229,20 @@ func DeleteObligationClassification(c *gin.Context) {
 		return
 	}
 
-	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+	if !ifMatch(c, obClassification.Version) {
+		return
+	}
+
+	if err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := lockVersion(tx, &models.ObligationClassification{}, "id", obClassification.Id, obClassification.Version); err != nil {
+			return err
+		}
 		return utils.ToggleObligationClassificationActiveStatus(userId, tx, &obClassification)
 	}); err != nil {
+		if errors.Is(err, errVersionChanged) {
+			preconditionFailed(c, err)
+			return
+		}
 		er := models.LicenseError{
 			Status:    http.StatusInternalServerError,
 			Message:   "something went wrong while deleting obligation classification",
@@ -6,6 +6,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -108,7 +109,7 @@ func CreateObligationType(c *gin.Context) {
 		return
 	}
 
-	status, err := utils.CreateObType(&obType, userId)
+	status, err := utils.CreateObType(db.DB.WithContext(c), &obType, userId)
 
 	switch status {
 	case utils.CREATED:
@@ -172,10 +173,13 @@ func CreateObligationType(c *gin.Context) {
 //	@Tags			Obligations
 //	@Accept			json
 //	@Produce		json
-//	@Param			type	path	string	true	"Obligation Type"
+//	@Param			type		path	string	true	"Obligation Type"
+//	@Param			If-Match	header	string	false	"ETag of the version of the obligation type in its listing, required if REQUIRE_IF_MATCH is set"
 //	@Success		200
 //	@Failure		400	{object}	models.LicenseError	"cannot delete obligation type 'RISK' as it's still referenced by some obligations"
 //	@Failure		404	{object}	models.LicenseError	"obligation type 'RISK' not found"
+//	@Failure		412	{object}	models.LicenseError	"obligation type was changed since it was read"
+//	@Failure		428	{object}	models.LicenseError	"If-Match header is required"
 //	@Failure		500	{object}	models.LicenseError	"something went wrong while deleting obligation type"
 //	@Security		ApiKeyAuth
 //	@Router			/obligations/types/{type} [delete]
@@ -225,9 +229,20 @@ func DeleteObligationType(c *gin.Context) {
 		return
 	}
 
-	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+	if !ifMatch(c, obType.Version) {
+		return
+	}
+
+	if err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := lockVersion(tx, &models.ObligationType{}, "id", obType.Id, obType.Version); err != nil {
+			return err
+		}
 		return utils.ToggleObligationTypeActiveStatus(userId, tx, &obType)
 	}); err != nil {
+		if errors.Is(err, errVersionChanged) {
+			preconditionFailed(c, err)
+			return
+		}
 		er := models.LicenseError{
 			Status:    http.StatusInternalServerError,
 			Message:   "something went wrong while deleting obligation type",
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/filter"
 	"github.com/fossology/LicenseDb/pkg/models"
 	"github.com/fossology/LicenseDb/pkg/utils"
 	"github.com/gin-gonic/gin"
@@ -28,6 +29,31 @@ import (
 	"gorm.io/gorm"
 )
 
+// obligationLicenses is the subquery of the licenses of an obligation, for
+// filters on licenses.
+const obligationLicenses = "EXISTS (SELECT 1 FROM obligation_licenses ol " +
+	"JOIN license_dbs l ON l.rf_id = ol.license_db_id " +
+	"WHERE ol.obligation_id = obligations.id AND %s)"
+
+// obligationFilterFields are the fields of the filter expressions of
+// obligations.
+var obligationFilterFields = filter.Fields{
+	"topic":                {Kind: filter.String, Column: "obligations.topic"},
+	"text":                 {Kind: filter.String, Column: "obligations.text"},
+	"comment":              {Kind: filter.String, Column: "obligations.comment"},
+	"active":               {Kind: filter.Boolean, Column: "obligations.active"},
+	"text_updatable":       {Kind: filter.Boolean, Column: "obligations.text_updatable"},
+	"type":                 {Kind: filter.String, Column: "(SELECT type FROM obligation_types WHERE id = obligations.obligation_type_id)"},
+	"classification":       {Kind: filter.String, Column: "(SELECT classification FROM obligation_classifications WHERE id = obligations.obligation_classification_id)"},
+	"category":             {Kind: filter.String, Column: "(SELECT category FROM obligation_categories WHERE id = obligations.obligation_category_id)"},
+	"review_interval_days": {Kind: filter.Integer, Column: "obligations.review_interval_days"},
+	"last_reviewed_at":     {Kind: filter.Time, Column: "obligations.last_reviewed_at"},
+	"license.shortname":    {Kind: filter.String, Column: "l.rf_shortname", Exists: obligationLicenses},
+	"license.spdx_id":      {Kind: filter.String, Column: "l.rf_spdx_id", Exists: obligationLicenses},
+	"license.risk":         {Kind: filter.Integer, Column: "l.rf_risk", Exists: obligationLicenses},
+	"license.active":       {Kind: filter.Boolean, Column: "l.rf_active", Exists: obligationLicenses},
+}
+
 // GetAllObligation retrieves a list of all obligation records
 //
 //	@Summary		Get all active obligations
@@ -36,49 +62,105 @@ import (
 //	@Tags			Obligations
 //	@Accept			json
 //	@Produce		json
-//	@Param			active		query		bool	true	"Active obligation only"
-//	@Param			page		query		int		false	"Page number"
-//	@Param			limit		query		int		false	"Number of records per page"
-//	@Param			order_by	query		string	false	"Asc or desc ordering"	Enums(asc, desc)	default(asc)
-//	@Success		200			{object}	models.ObligationResponse
-//	@Failure		400			{object}	models.LicenseError	"Invalid active value"
-//	@Failure		500			{object}	models.LicenseError	"Internal server error"
+//	@Param			active		query		bool						false	"Active obligations only, true unless given empty or the filter has a condition on active"
+//	@Param			filter		query		string						false	"Filter expression, e.g. classification=in=(RED,ORANGE);license.spdx_id==MIT"
+//	@Param			externalRef	query		string						false	"JSON object of external_ref keys and their value or conditions on it (eq, ne, lt, lte, gt, gte, in, contains, exists)"
+//	@Param			page		query		int							false	"Page number"
+//	@Param			limit		query		int							false	"Number of records per page"
+//	@Param			cursor		query		string						false	"Cursor of the page, empty for the first page, to paginate by cursor instead of page number"
+//	@Param			order_by	query		string						false	"Asc or desc ordering"	Enums(asc, desc)	default(asc)
+//	@Param			fields		query		string						false	"Comma separated keys of the obligations to respond with, e.g. topic,classification"
+//	@Param			expand		query		string						false	"Comma separated relations to include, licenses"
+//	@Success		200			{object}	models.ObligationResponse	"Obligations, with only the requested keys if fields or expand is given"
+//	@Failure		400			{object}	models.LicenseError			"Invalid active value"
+//	@Failure		500			{object}	models.LicenseError			"Internal server error"
 //	@Security		ApiKeyAuth || {}
 //	@Router			/obligations [get]
 func GetAllObligation(c *gin.Context) {
 	var obligations []models.Obligation
-	active := c.Query("active")
-	if active == "" {
+	fields, ok := parseFieldset(c, obligationFieldColumns)
+	if !ok {
+		return
+	}
+	expand, ok := parseExpand(c, []string{"licenses"})
+	if !ok {
+		return
+	}
+	externalRefFields, ok := externalRefFilterFields(c, models.ExternalRefEntityObligation, "obligations.external_ref")
+	if !ok {
+		return
+	}
+	var condition string
+	var args []any
+	var expr *filter.Expr
+	if expression := c.Query("filter"); expression != "" {
+		var err error
+		expr, err = filter.Parse(expression)
+		if err == nil {
+			condition, args, err = withExternalRefFields(obligationFilterFields, externalRefFields).CompileExpr(expr)
+		}
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusBadRequest,
+				Message:   "invalid filter",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusBadRequest, er)
+			return
+		}
+	}
+	// Only active obligations are listed unless active is given, even if
+	// empty, or the filter has a condition on it.
+	active, given := c.GetQuery("active")
+	if !given && !expr.References("active") {
 		active = "true"
 	}
-	var parsedActive bool
-	parsedActive, err := strconv.ParseBool(active)
-	if err != nil {
-		er := models.LicenseError{
-			Status:    http.StatusBadRequest,
-			Message:   "Invalid active value",
-			Error:     fmt.Sprintf("Parsing failed for value '%s'", active),
-			Path:      c.Request.URL.Path,
-			Timestamp: time.Now().Format(time.RFC3339),
+
+	query := db.DB.Model(&models.Obligation{})
+	if expand["licenses"] {
+		query = query.Preload("Licenses")
+	} else if fields.has("license_ids") {
+		query = query.Preload("Licenses", selectIds("license_dbs.rf_id"))
+	}
+	if active != "" {
+		parsedActive, err := strconv.ParseBool(active)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusBadRequest,
+				Message:   "Invalid active value",
+				Error:     fmt.Sprintf("Parsing failed for value '%s'", active),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusBadRequest, er)
+			return
 		}
-		c.JSON(http.StatusBadRequest, er)
+		query.Where(&models.Obligation{Active: &parsedActive})
+	}
+	if condition != "" {
+		query.Where(condition, args...)
+	}
+	refCondition, refArgs, ok := externalRefCondition(c, externalRefFields)
+	if !ok {
 		return
 	}
-	query := db.DB.Model(&models.Obligation{})
-	query.Where(&models.Obligation{Active: &parsedActive})
-
-	_ = utils.PreparePaginateResponse(c, query, &models.ObligationResponse{})
-
-	orderBy := c.Query("order_by")
-	queryOrderString := "topic"
+	if refCondition != "" {
+		query.Where(refCondition, refArgs...)
+	}
 
-	if orderBy != "" && orderBy == "desc" {
-		queryOrderString += " desc"
+	keyset := utils.Keyset{Table: "obligations", Column: "topic", IdColumn: "id", Desc: c.Query("order_by") == "desc"}
+	pagination, err := utils.PreparePaginateResponse(c, query, &keyset)
+	if err != nil {
+		return
 	}
 
-	query.Order(queryOrderString)
+	if columns := fields.columns(obligationFieldColumns, "obligations.id", "obligations."+keyset.Column); columns != nil {
+		query.Select(columns)
+	}
 
-	if err = query.Joins("Type").Joins("Classification").Joins("Category").Preload("Licenses").Find(&obligations).Error; err != nil {
+	if err := query.Joins("Type").Joins("Classification").Joins("Category").Find(&obligations).Error; err != nil {
 		er := models.LicenseError{
 			Status:    http.StatusInternalServerError,
 			Message:   "Unable to fetch obligations",
@@ -90,6 +172,31 @@ func GetAllObligation(c *gin.Context) {
 		return
 	}
 
+	if sparseResponse(c) {
+		data := make([]map[string]json.RawMessage, 0, len(obligations))
+		for i := range obligations {
+			object, err := sparseObligation(&obligations[i], fields, expand)
+			if err != nil {
+				er := models.LicenseError{
+					Status:    http.StatusInternalServerError,
+					Message:   "unable to build the response",
+					Error:     err.Error(),
+					Path:      c.Request.URL.Path,
+					Timestamp: time.Now().Format(time.RFC3339),
+				}
+				c.JSON(http.StatusInternalServerError, er)
+				return
+			}
+			data = append(data, object)
+		}
+		c.JSON(http.StatusOK, models.SparseResponse{
+			Data:   data,
+			Status: http.StatusOK,
+			Meta:   pagination.Meta(obligations),
+		})
+		return
+	}
+
 	var obligationDtos []models.ObligationResponseDTO
 
 	for _, o := range obligations {
@@ -100,9 +207,7 @@ func GetAllObligation(c *gin.Context) {
 	res := models.ObligationResponse{
 		Data:   obligationDtos,
 		Status: http.StatusOK,
-		Meta: models.PaginationMeta{
-			ResourceCount: len(obligations),
-		},
+		Meta:   *pagination.Meta(obligations),
 	}
 
 	c.JSON(http.StatusOK, res)
@@ -116,9 +221,12 @@ func GetAllObligation(c *gin.Context) {
 //	@Tags			Obligations
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string	true	"Id of the obligation"
-//	@Success		200	{object}	models.ObligationResponse
-//	@Failure		404	{object}	models.LicenseError	"No obligation with given id found"
+//	@Param			id				path		string	true	"Id of the obligation"
+//	@Param			If-None-Match	header		string	false	"ETag of a version of the obligation the client has"
+//	@Success		200				{object}	models.ObligationResponse
+//	@Header			200				{string}	ETag	"Version of the obligation"
+//	@Success		304				"Obligation not modified"
+//	@Failure		404				{object}	models.LicenseError	"No obligation with given id found"
 //	@Security		ApiKeyAuth || {}
 //	@Router			/obligations/{id} [get]
 func GetObligation(c *gin.Context) {
@@ -150,6 +258,10 @@ func GetObligation(c *gin.Context) {
 		return
 	}
 
+	if notModified(c, obligation.Version) {
+		return
+	}
+
 	obDto := obligation.ConvertToObligationResponseDTO()
 
 	res := models.ObligationResponse{
@@ -165,15 +277,19 @@ func GetObligation(c *gin.Context) {
 // CreateObligation creates a new obligation record and associates it with relevant licenses.
 //
 //	@Summary		Create an obligation
-//	@Description	Create an obligation and associate it with licenses
+//	@Description	Create an obligation and associate it with licenses. Obligations whose text is similar to the text of an
+//	@Description	active obligation, as set by DUPLICATE_CHECK_THRESHOLD, are only created with force=true.
 //	@Id				CreateObligation
 //	@Tags			Obligations
 //	@Accept			json
 //	@Produce		json
 //	@Param			obligation	body		models.ObligationCreateDTO	true	"Obligation to create"
+//	@Param			force		query		bool						false	"Create the obligation even if its text duplicates an active obligation"
 //	@Success		201			{object}	models.ObligationResponse
-//	@Failure		400			{object}	models.LicenseError	"Bad request body"
-//	@Failure		500			{object}	models.LicenseError	"Unable to create obligation"
+//	@Failure		400			{object}	models.LicenseError				"Bad request body"
+//	@Failure		404			{object}	models.LicenseError				"Licenses not found"
+//	@Failure		409			{object}	models.ObligationDuplicateError	"Text duplicates active obligations"
+//	@Failure		500			{object}	models.LicenseError				"Unable to create obligation"
 //	@Security		ApiKeyAuth
 //	@Router			/obligations [post]
 func CreateObligation(c *gin.Context) {
@@ -192,7 +308,71 @@ func CreateObligation(c *gin.Context) {
 		return
 	}
 
-	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+	force, ok := forceCreate(c)
+	if !ok {
+		return
+	}
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := checkExternalRef(c, tx, models.ExternalRefEntityObligation, obligation.ExternalRef, nil); err != nil {
+			return err
+		}
+
+		missing, err := missingIds(tx, licenseDuplicates, obligation.LicenseIds)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to create obligation",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if len(missing) != 0 {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   "Failed to create obligation",
+				Error:     fmt.Sprintf("no licenses with ids %s exist", strings.Join(missing, ", ")),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return nil
+		}
+
+		duplicates := []models.SimilarObligation{}
+		if err := findCreateDuplicates(tx, obligationDuplicates, "id, topic", obligation.Text, &duplicates); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to create obligation",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if len(duplicates) != 0 && !force {
+			er := models.ObligationDuplicateError{
+				LicenseError: models.LicenseError{
+					Status:    http.StatusConflict,
+					Message:   "the text of the obligation duplicates active obligations, create it with force=true to proceed",
+					Error:     fmt.Sprintf("obligation text is similar to the text of '%s'", *duplicates[0].Topic),
+					Path:      c.Request.URL.Path,
+					Timestamp: time.Now().Format(time.RFC3339),
+				},
+				Matches: duplicates,
+			}
+			c.JSON(http.StatusConflict, er)
+			return nil
+		}
+		var duplicateNames []string
+		for _, d := range duplicates {
+			duplicateNames = append(duplicateNames, fmt.Sprintf("%s (%s) %.2f", *d.Topic, d.Id, d.Similarity))
+		}
+
 		ob := obligation.ConvertToObligation()
 		if err := tx.Omit("Licenses").Create(&ob).Error; err != nil {
 			er := models.LicenseError{
@@ -238,7 +418,7 @@ func CreateObligation(c *gin.Context) {
 			return err
 		}
 
-		if err := addChangelogsForObligation(tx, userId, &ob, &models.Obligation{}); err != nil {
+		if err := addChangelogsForObligation(tx, userId, &ob, &models.Obligation{}, forcedDuplicateChangelogs(duplicateNames)...); err != nil {
 			er := models.LicenseError{
 				Status:    http.StatusBadRequest,
 				Message:   "Failed to create obligation",
@@ -275,9 +455,14 @@ func CreateObligation(c *gin.Context) {
 //	@Produce		json
 //	@Param			id			path		string						true	"Id of the obligation to be updated"
 //	@Param			obligation	body		models.ObligationUpdateDTO	true	"Obligation to be updated"
+//	@Param			If-Match	header		string						false	"ETag the obligation was read with, required if REQUIRE_IF_MATCH is set"
 //	@Success		200			{object}	models.ObligationResponse
+//	@Header			200			{string}	ETag				"New version of the obligation"
 //	@Failure		400			{object}	models.LicenseError	"Invalid request"
+//	@Failure		403			{object}	models.LicenseError	"Obligation is stewarded by a team of other users"
 //	@Failure		404			{object}	models.LicenseError	"No obligation with given id found"
+//	@Failure		412			{object}	models.LicenseError	"Obligation was changed since it was read"
+//	@Failure		428			{object}	models.LicenseError	"If-Match header is required"
 //	@Failure		500			{object}	models.LicenseError	"Unable to update obligation"
 //	@Security		ApiKeyAuth
 //	@Router			/obligations/{id} [patch]
@@ -322,6 +507,34 @@ func UpdateObligation(c *gin.Context) {
 		return
 	}
 
+	stewardTeam, err := stewardTeamDenyingEdit(c, db.DB, oldObligation.StewardTeamId)
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Unable to update obligation",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+	if stewardTeam != nil {
+		er := models.LicenseError{
+			Status:    http.StatusForbidden,
+			Message:   "only members of the steward team can edit this obligation",
+			Error:     fmt.Sprintf("obligation is stewarded by team '%s'", stewardTeam.Name),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusForbidden, er)
+		return
+	}
+
+	if !ifMatch(c, oldObligation.Version) {
+		return
+	}
+
 	newObligation := updates.ConvertToObligation()
 	if newObligation.Text != nil && *oldObligation.Text != *newObligation.Text && !*oldObligation.TextUpdatable {
 		er := models.LicenseError{
@@ -336,7 +549,15 @@ func UpdateObligation(c *gin.Context) {
 	}
 	newObligation.Id = oldObligation.Id
 
-	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+	if err := checkExternalRef(c, db.DB, models.ExternalRefEntityObligation, updates.ExternalRef, oldObligation.ExternalRef.Data()); err != nil {
+		return
+	}
+
+	if err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := lockVersion(tx, &models.Obligation{}, "id", oldObligation.Id, oldObligation.Version); err != nil {
+			return err
+		}
+
 		// Overwrite values of existing keys, add new key value pairs and remove keys with null values.
 		if err := tx.Model(&models.Obligation{}).Where(models.Obligation{Id: oldObligation.Id}).UpdateColumn("external_ref", gorm.Expr("jsonb_strip_nulls(COALESCE(external_ref, '{}'::jsonb) || ?)", updates.ExternalRef)).Error; err != nil {
 			return err
@@ -370,6 +591,10 @@ func UpdateObligation(c *gin.Context) {
 
 		return nil
 	}); err != nil {
+		if errors.Is(err, errVersionChanged) {
+			preconditionFailed(c, err)
+			return
+		}
 		er := models.LicenseError{
 			Status:    http.StatusBadRequest,
 			Message:   "Failed to update obligation",
@@ -389,6 +614,7 @@ func UpdateObligation(c *gin.Context) {
 			ResourceCount: 1,
 		},
 	}
+	c.Header("ETag", etag(newObligation.Version))
 	c.JSON(http.StatusOK, res)
 }
 
@@ -400,9 +626,13 @@ func UpdateObligation(c *gin.Context) {
 //	@Tags			Obligations
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path	string	true	"Id of the obligation to be updated"
+//	@Param			id			path	string	true	"Id of the obligation to be updated"
+//	@Param			If-Match	header	string	false	"ETag the obligation was read with, required if REQUIRE_IF_MATCH is set"
 //	@Success		204
+//	@Failure		403	{object}	models.LicenseError	"Obligation is stewarded by a team of other users"
 //	@Failure		404	{object}	models.LicenseError	"No obligation with given id found"
+//	@Failure		412	{object}	models.LicenseError	"Obligation was changed since it was read"
+//	@Failure		428	{object}	models.LicenseError	"If-Match header is required"
 //	@Security		ApiKeyAuth
 //	@Router			/obligations/{id} [delete]
 func DeleteObligation(c *gin.Context) {
@@ -432,8 +662,9 @@ func DeleteObligation(c *gin.Context) {
 		c.JSON(http.StatusNotFound, er)
 		return
 	}
-	*obligation.Active = false
-	if err := db.DB.Updates(&obligation).Error; err != nil {
+
+	stewardTeam, err := stewardTeamDenyingEdit(c, db.DB, obligation.StewardTeamId)
+	if err != nil {
 		er := models.LicenseError{
 			Status:    http.StatusInternalServerError,
 			Message:   "failed to delete obligation",
@@ -444,6 +675,38 @@ func DeleteObligation(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, er)
 		return
 	}
+	if stewardTeam != nil {
+		er := models.LicenseError{
+			Status:    http.StatusForbidden,
+			Message:   "only members of the steward team can edit this obligation",
+			Error:     fmt.Sprintf("obligation is stewarded by team '%s'", stewardTeam.Name),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusForbidden, er)
+		return
+	}
+	if !ifMatch(c, obligation.Version) {
+		return
+	}
+	*obligation.Active = false
+	// Only deactivate the version that was read
+	result := db.DB.Where("version = ?", obligation.Version).Updates(&obligation)
+	if result.Error != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "failed to delete obligation",
+			Error:     result.Error.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+	if result.RowsAffected == 0 {
+		preconditionFailed(c, errVersionChanged)
+		return
+	}
 	c.Status(http.StatusNoContent)
 }
 
@@ -478,9 +741,12 @@ func GetObligationAudits(c *gin.Context) {
 	}
 
 	var audits []models.Audit
-	query := db.DB.Model(&models.Audit{}).Preload("User")
-	query.Where(models.Audit{TypeId: obligationId, Type: "OBLIGATION"}).Order("timestamp desc")
-	_ = utils.PreparePaginateResponse(c, query, &models.AuditResponse{})
+	query := db.DB.Model(&models.Audit{}).Preload("User").Preload("Impersonator")
+	query.Where(models.Audit{TypeId: obligationId, Type: "OBLIGATION"})
+	pagination, err := utils.PreparePaginateResponse(c, query, &auditKeyset)
+	if err != nil {
+		return
+	}
 
 	res := query.Find(&audits)
 	if res.Error != nil {
@@ -512,9 +778,7 @@ func GetObligationAudits(c *gin.Context) {
 	response := models.AuditResponse{
 		Data:   audits,
 		Status: http.StatusOK,
-		Meta: &models.PaginationMeta{
-			ResourceCount: len(audits),
-		},
+		Meta:   pagination.Meta(audits),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -585,7 +849,23 @@ func ImportObligations(c *gin.Context) {
 	for _, ob := range obligations {
 		oldObligation := ob.ConvertToObligation()
 		newObligation := oldObligation
-		_ = db.DB.Transaction(func(tx *gorm.DB) error {
+		_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+			// invalidExternalRef validates the external_ref of the imported
+			// obligation against that of the existing one, if any
+			invalidExternalRef := func(current any) bool {
+				if err := utils.CheckExternalRef(tx, models.ExternalRefEntityObligation, ob.ExternalRef, current); err != nil {
+					res.Data = append(res.Data, models.LicenseError{
+						Status:    http.StatusBadRequest,
+						Message:   fmt.Sprintf("Failed to create/update obligation: %s", err.Error()),
+						Error:     *ob.Topic,
+						Path:      c.Request.URL.Path,
+						Timestamp: time.Now().Format(time.RFC3339),
+					})
+					return true
+				}
+				return false
+			}
+
 			// (a) If id not present in json object, create a new one.
 			//
 			// (b) If id present in json object, but entry not found in database (can arise in cases when
@@ -596,6 +876,9 @@ func ImportObligations(c *gin.Context) {
 			// of the json object
 			if ob.Id == nil {
 				// Case (a)
+				if invalidExternalRef(nil) {
+					return nil
+				}
 				result := tx.Omit("Licenses").Create(&oldObligation)
 				if result.Error != nil {
 					res.Data = append(res.Data, models.LicenseError{
@@ -656,6 +939,9 @@ func ImportObligations(c *gin.Context) {
 			} else {
 				if err := tx.Where(&models.Obligation{Id: oldObligation.Id}).First(&oldObligation).Error; err != nil {
 					if errors.Is(err, gorm.ErrRecordNotFound) {
+						if invalidExternalRef(nil) {
+							return nil
+						}
 						result := tx.Omit("Licenses").Create(&oldObligation)
 						if result.Error != nil {
 							res.Data = append(res.Data, models.LicenseError{
@@ -733,6 +1019,9 @@ func ImportObligations(c *gin.Context) {
 						})
 						return errors.New("field `text_updatable` needs to be true to update the text")
 					}
+					if invalidExternalRef(oldObligation.ExternalRef.Data()) {
+						return nil
+					}
 					if err := tx.Model(&models.Obligation{}).Where(models.Obligation{Id: oldObligation.Id}).UpdateColumn("external_ref", gorm.Expr("jsonb_strip_nulls(COALESCE(external_ref, '{}'::jsonb) || ?)", ob.ExternalRef)).Error; err != nil {
 						res.Data = append(res.Data, models.LicenseError{
 							Status:    http.StatusInternalServerError,
@@ -856,7 +1145,7 @@ func ExportObligations(c *gin.Context) {
 
 // addChangelogsForObligation adds changelogs for the updated fields on obligation update
 func addChangelogsForObligation(tx *gorm.DB, userId uuid.UUID,
-	newObligation, oldObligation *models.Obligation) error {
+	newObligation, oldObligation *models.Obligation, extraChanges ...models.ChangeLog) error {
 	uuidsToStr := func(ids []models.LicenseDB) string {
 		if len(ids) == 0 {
 			return ""
@@ -899,6 +1188,10 @@ func addChangelogsForObligation(tx *gorm.DB, userId uuid.UUID,
 
 	utils.AddChangelog("Text Updatable", oldObligation.TextUpdatable, newObligation.TextUpdatable, &changes)
 
+	utils.AddChangelog("Steward Team Id", oldObligation.StewardTeamId, newObligation.StewardTeamId, &changes)
+
+	utils.AddChangelog("Review Interval Days", oldObligation.ReviewIntervalDays, newObligation.ReviewIntervalDays, &changes)
+
 	oldObligationExternalRef := oldObligation.ExternalRef.Data()
 	oldExternalRefVal := reflect.ValueOf(oldObligationExternalRef)
 	typesOf := oldExternalRefVal.Type()
@@ -910,7 +1203,7 @@ func addChangelogsForObligation(tx *gorm.DB, userId uuid.UUID,
 		fieldName := typesOf.Field(i).Name
 
 		switch typesOf.Field(i).Type.String() {
-		case "*boolean":
+		case "*bool":
 			oldFieldPtr, _ := oldExternalRefVal.Field(i).Interface().(*bool)
 			newFieldPtr, _ := newExternalRefVal.Field(i).Interface().(*bool)
 			utils.AddChangelog(fmt.Sprintf("External Reference %s", fieldName), oldFieldPtr, newFieldPtr, &changes)
@@ -918,18 +1211,27 @@ func addChangelogsForObligation(tx *gorm.DB, userId uuid.UUID,
 			oldFieldPtr, _ := oldExternalRefVal.Field(i).Interface().(*string)
 			newFieldPtr, _ := newExternalRefVal.Field(i).Interface().(*string)
 			utils.AddChangelog(fmt.Sprintf("External Reference %s", fieldName), oldFieldPtr, newFieldPtr, &changes)
-		case "*int":
-			oldFieldPtr, _ := oldExternalRefVal.Field(i).Interface().(*int)
-			newFieldPtr, _ := newExternalRefVal.Field(i).Interface().(*int)
+		case "*int64":
+			oldFieldPtr, _ := oldExternalRefVal.Field(i).Interface().(*int64)
+			newFieldPtr, _ := newExternalRefVal.Field(i).Interface().(*int64)
 			utils.AddChangelog(fmt.Sprintf("External Reference %s", fieldName), oldFieldPtr, newFieldPtr, &changes)
+		default:
+			if typesOf.Field(i).Tag.Get("json") == "-" {
+				continue
+			}
+			utils.AddChangelog(fmt.Sprintf("External Reference %s", fieldName), utils.ExternalRefJSON(oldExternalRefVal.Field(i)), utils.ExternalRefJSON(newExternalRefVal.Field(i)), &changes)
 		}
 	}
 
+	utils.AddExternalRefChangelogs(oldObligationExternalRef.Extra, newObligationExternalRef.Extra, &changes)
+
 	oldVal := uuidsToStr(oldObligation.Licenses)
 	newVal := uuidsToStr(newObligation.Licenses)
 
 	utils.AddChangelog("Licenses", &oldVal, &newVal, &changes)
 
+	changes = append(changes, extraChanges...)
+
 	if len(changes) != 0 {
 		audit := models.Audit{
 			UserId:     userId,
@@ -1011,7 +1313,8 @@ func GetAllObligationPreviews(c *gin.Context) {
 // getSimilarObligation finds similar obligation texts using trigram similarity
 //
 //	@Summary		Find similar obligations
-//	@Description	Returns the top 5 obligations with text similar to the input using pg_trgm
+//	@Description	Returns the obligations whose text is similar to the input using pg_trgm, the most similar first.
+//	@Description	The threshold defaults to SIMILARITY_THRESHOLD and all matches are returned unless a limit is given.
 //	@ID				getSimilarObligation
 //	@Tags			Obligations
 //	@Accept			json
@@ -1027,7 +1330,7 @@ func getSimilarObligations(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		er := models.LicenseError{
 			Status:    http.StatusBadRequest,
-			Message:   "Text field is required",
+			Message:   "Text field is required and threshold, limit and min_length must be valid",
 			Error:     err.Error(),
 			Path:      c.Request.URL.Path,
 			Timestamp: time.Now().Format(time.RFC3339),
@@ -1035,15 +1338,8 @@ func getSimilarObligations(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, er)
 		return
 	}
-	var results []models.SimilarObligation
-	utils.SetSimilarityThreshold()
-	rawQuery := `
-		SELECT id, topic,text, similarity(text, ?) AS similarity
-		FROM obligations
-		WHERE text % ?
-		ORDER BY similarity DESC
-	`
-	if err := db.DB.Raw(rawQuery, req.Text, req.Text).Scan(&results).Error; err != nil {
+	results := []models.SimilarObligation{}
+	if err := findSimilar(db.DB.WithContext(c), req, "obligations", "id, topic", "text", &results); err != nil {
 		er := models.LicenseError{
 			Status:    http.StatusBadRequest,
 			Message:   "Database query failed",
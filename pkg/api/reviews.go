@@ -0,0 +1,411 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/email"
+	logger "github.com/fossology/LicenseDb/pkg/log"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+)
+
+const defaultReviewReminderInterval = 24 * time.Hour
+
+// ReviewLicense records the periodic review of a license.
+//
+//	@Summary		Review a license
+//	@Description	Record that a license was reviewed, which restarts its review interval
+//	@Id				ReviewLicense
+//	@Tags			Licenses
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"Id of the license"
+//	@Param			review	body		models.ReviewInput	false	"Review comment"
+//	@Success		200		{object}	models.LicenseResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		403		{object}	models.LicenseError	"License is stewarded by another team"
+//	@Failure		404		{object}	models.LicenseError	"No license found"
+//	@Security		ApiKeyAuth
+//	@Router			/licenses/{id}/review [post]
+func ReviewLicense(c *gin.Context) {
+	input, ok := bindReviewInput(c)
+	if !ok {
+		return
+	}
+
+	licenseId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   fmt.Sprintf("no license with id '%s' exists", c.Param("id")),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var license models.LicenseDB
+		if err := tx.Preload("User").Preload("Obligations").Where(models.LicenseDB{Id: licenseId}).First(&license).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   fmt.Sprintf("license with id '%s' not found", licenseId.String()),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return nil
+		}
+
+		stewardTeam, err := stewardTeamDenyingEdit(c, tx, license.StewardTeamId)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to review license",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if stewardTeam != nil {
+			er := models.LicenseError{
+				Status:    http.StatusForbidden,
+				Message:   "only members of the steward team can review this license",
+				Error:     fmt.Sprintf("license is stewarded by team '%s'", stewardTeam.Name),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusForbidden, er)
+			return nil
+		}
+
+		reviewedAt := time.Now()
+		if err := tx.Model(&models.LicenseDB{}).Where(models.LicenseDB{Id: licenseId}).
+			UpdateColumns(map[string]interface{}{"last_reviewed_at": reviewedAt, "review_reminded_at": nil}).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to review license",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := addReviewAudit(tx, userId, "LICENSE", licenseId, license.LastReviewedAt, reviewedAt, input.Comment); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to review license",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		license.LastReviewedAt = &reviewedAt
+		license.ReviewRemindedAt = nil
+		res := models.LicenseResponse{
+			Data:   []models.LicenseResponseDTO{license.ConvertToLicenseResponseDTO()},
+			Status: http.StatusOK,
+			Meta: &models.PaginationMeta{
+				ResourceCount: 1,
+			},
+		}
+		c.JSON(http.StatusOK, res)
+		return nil
+	})
+}
+
+// ReviewObligation records the periodic review of an obligation.
+//
+//	@Summary		Review an obligation
+//	@Description	Record that an obligation was reviewed, which restarts its review interval
+//	@Id				ReviewObligation
+//	@Tags			Obligations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"Id of the obligation"
+//	@Param			review	body		models.ReviewInput	false	"Review comment"
+//	@Success		200		{object}	models.ObligationResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		403		{object}	models.LicenseError	"Obligation is stewarded by another team"
+//	@Failure		404		{object}	models.LicenseError	"No obligation found"
+//	@Security		ApiKeyAuth
+//	@Router			/obligations/{id}/review [post]
+func ReviewObligation(c *gin.Context) {
+	input, ok := bindReviewInput(c)
+	if !ok {
+		return
+	}
+
+	obligationId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   fmt.Sprintf("no obligation with id '%s' exists", c.Param("id")),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var obligation models.Obligation
+		if err := tx.Joins("Type").Joins("Classification").Joins("Category").Preload("Licenses").
+			Where(models.Obligation{Id: obligationId}).First(&obligation).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   fmt.Sprintf("obligation with id '%s' not found", obligationId.String()),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return nil
+		}
+
+		stewardTeam, err := stewardTeamDenyingEdit(c, tx, obligation.StewardTeamId)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to review obligation",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if stewardTeam != nil {
+			er := models.LicenseError{
+				Status:    http.StatusForbidden,
+				Message:   "only members of the steward team can review this obligation",
+				Error:     fmt.Sprintf("obligation is stewarded by team '%s'", stewardTeam.Name),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusForbidden, er)
+			return nil
+		}
+
+		reviewedAt := time.Now()
+		if err := tx.Model(&models.Obligation{}).Where(models.Obligation{Id: obligationId}).
+			UpdateColumns(map[string]interface{}{"last_reviewed_at": reviewedAt, "review_reminded_at": nil}).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to review obligation",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := addReviewAudit(tx, userId, "OBLIGATION", obligationId, obligation.LastReviewedAt, reviewedAt, input.Comment); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to review obligation",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		obligation.LastReviewedAt = &reviewedAt
+		obligation.ReviewRemindedAt = nil
+		res := models.ObligationResponse{
+			Data:   []models.ObligationResponseDTO{obligation.ConvertToObligationResponseDTO()},
+			Status: http.StatusOK,
+			Meta: models.PaginationMeta{
+				ResourceCount: 1,
+			},
+		}
+		c.JSON(http.StatusOK, res)
+		return nil
+	})
+}
+
+// StartReviewReminders periodically emails the stewards of overdue licenses
+// and obligations, or the admins if an item has no steward. Each item is only
+// reminded about once until it is reviewed again.
+func StartReviewReminders() error {
+	interval := defaultReviewReminderInterval
+	if value := os.Getenv("REVIEW_REMINDER_INTERVAL_HOURS"); value != "" {
+		hours, err := strconv.Atoi(value)
+		if err != nil || hours <= 0 {
+			return fmt.Errorf("invalid review reminder interval: %s", value)
+		}
+		interval = time.Duration(hours) * time.Hour
+	}
+
+	if email.Email == nil {
+		logger.LogInfo("Email service disabled, not sending review reminders")
+		return nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := sendReviewReminders(time.Now()); err != nil {
+				logger.LogError("Failed to send review reminders", zap.Error(err))
+			}
+			<-ticker.C
+		}
+	}()
+	return nil
+}
+
+// sendReviewReminders emails one reminder per steward team, and one to the
+// admins for items without steward, about items not reminded about yet.
+func sendReviewReminders(now time.Time) error {
+	overdue, err := utils.OverdueReviews(db.DB, now)
+	if err != nil {
+		return err
+	}
+
+	type reminder struct {
+		recipient     string
+		items         []string
+		licenseIds    []uuid.UUID
+		obligationIds []uuid.UUID
+	}
+	reminders := map[uuid.UUID]*reminder{}
+	for _, item := range overdue {
+		if item.RemindedAt != nil {
+			continue
+		}
+		var teamId uuid.UUID
+		recipient := "Admins"
+		if item.StewardTeamId != nil && item.StewardTeam != nil {
+			teamId = *item.StewardTeamId
+			recipient = *item.StewardTeam
+		}
+		r, ok := reminders[teamId]
+		if !ok {
+			r = &reminder{recipient: recipient}
+			reminders[teamId] = r
+		}
+		if item.Type == "LICENSE" {
+			r.items = append(r.items, "License "+item.Name)
+			r.licenseIds = append(r.licenseIds, item.Id)
+		} else {
+			r.items = append(r.items, "Obligation "+item.Name)
+			r.obligationIds = append(r.obligationIds, item.Id)
+		}
+	}
+
+	for teamId, r := range reminders {
+		var emails []string
+		if teamId != uuid.Nil {
+			active := true
+			if err := db.DB.Model(&models.User{}).
+				Joins("JOIN team_members ON team_members.user_id = users.id").
+				Where("team_members.team_id = ? AND users.user_email IS NOT NULL", teamId).
+				Where(&models.User{Active: &active}).
+				Pluck("users.user_email", &emails).Error; err != nil {
+				return err
+			}
+		}
+		if len(emails) == 0 {
+			if emails, err = email.FetchAdminEmails(); err != nil {
+				return err
+			}
+		}
+		if len(emails) == 0 {
+			continue
+		}
+
+		email.NotifyOverdueReviews(emails, r.recipient, r.items)
+
+		if len(r.licenseIds) != 0 {
+			if err := db.DB.Model(&models.LicenseDB{}).Where("rf_id IN ?", r.licenseIds).
+				UpdateColumn("review_reminded_at", now).Error; err != nil {
+				return err
+			}
+		}
+		if len(r.obligationIds) != 0 {
+			if err := db.DB.Model(&models.Obligation{}).Where("id IN ?", r.obligationIds).
+				UpdateColumn("review_reminded_at", now).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bindReviewInput binds the optional body of a review request.
+func bindReviewInput(c *gin.Context) (models.ReviewInput, bool) {
+	var input models.ReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return input, false
+	}
+	return input, true
+}
+
+// addReviewAudit records the review of a license or obligation.
+func addReviewAudit(tx *gorm.DB, userId uuid.UUID, entityType string, entityId uuid.UUID,
+	oldReviewedAt *time.Time, reviewedAt time.Time, comment *string) error {
+	var changes []models.ChangeLog
+
+	var oldValue *string
+	if oldReviewedAt != nil {
+		old := oldReviewedAt.Format(time.RFC3339)
+		oldValue = &old
+	}
+	newValue := reviewedAt.Format(time.RFC3339)
+	utils.AddChangelog("Last Reviewed At", oldValue, &newValue, &changes)
+	if comment != nil && *comment != "" {
+		utils.AddChangelog("Review Comment", nil, comment, &changes)
+	}
+
+	audit := models.Audit{
+		UserId:     userId,
+		TypeId:     entityId,
+		Timestamp:  reviewedAt,
+		Type:       entityType,
+		ChangeLogs: changes,
+	}
+	return tx.Create(&audit).Error
+}
@@ -0,0 +1,481 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/datatypes"
+
+	"github.com/fossology/LicenseDb/pkg/archive"
+	"github.com/fossology/LicenseDb/pkg/db"
+	logger "github.com/fossology/LicenseDb/pkg/log"
+	"github.com/fossology/LicenseDb/pkg/matching"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+const (
+	defaultScanWorkers        = 2
+	defaultScanQueueSize      = 20
+	defaultScanMaxUploadMB    = 100
+	defaultScanMaxExtractedMB = 1024
+	defaultScanMinCoverage    = 80
+	maxScanFiles              = 50000
+	maxScanFileBytes          = 1 << 20
+	// License texts are looked for at the beginning of files, where license
+	// headers and the texts of license files are
+	maxScanTextBytes = 64 << 10
+	// Files with a NUL byte at their beginning are considered binary
+	binaryProbeBytes = 8000
+)
+
+var (
+	// scanSlots limits the number of scans running at the same time
+	scanSlots = make(chan struct{}, defaultScanWorkers)
+	// scanQueue limits the number of scans waiting or running, further scans
+	// are rejected
+	scanQueue             = make(chan struct{}, defaultScanQueueSize)
+	scanMaxUploadBytes    = int64(defaultScanMaxUploadMB) << 20
+	scanMaxExtractedBytes = int64(defaultScanMaxExtractedMB) << 20
+)
+
+// CreateScan starts a scan of an uploaded archive.
+//
+//	@Summary		Scan an archive for licenses
+//	@Description	Upload a source file or an archive (zip, jar, tar, tar.gz, tgz, tar.bz2 or gz) to detect the catalog
+//	@Description	licenses in its files. SPDX-License-Identifier tags are resolved against the SPDX ids and shortnames of the
+//	@Description	catalog, and license texts and headers are matched following the SPDX License Matching Guidelines.
+//	@Description	The scan runs in the background, poll it until it is COMPLETED or FAILED.
+//	@Id				CreateScan
+//	@Tags			Scans
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file			formData	file	true	"Source file or archive to scan"
+//	@Param			min_coverage	formData	number	false	"Minimum percentage of a license text a partial match must cover, defaults to 80"
+//	@Success		202				{object}	models.ScanResponse
+//	@Header			202				{string}	Location			"Url of the scan"
+//	@Failure		400				{object}	models.LicenseError	"input file must be present"
+//	@Failure		413				{object}	models.LicenseError	"Upload is too large"
+//	@Failure		500				{object}	models.LicenseError	"Failed to start scan"
+//	@Failure		503				{object}	models.LicenseError	"Too many scans are waiting"
+//	@Header			503				{string}	Retry-After			"Seconds to wait before uploading again"
+//	@Security		ApiKeyAuth
+//	@Router			/scan [post]
+func CreateScan(c *gin.Context) {
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	queue := scanQueue
+	select {
+	case queue <- struct{}{}:
+	default:
+		c.Header("Retry-After", "60")
+		er := models.LicenseError{
+			Status:    http.StatusServiceUnavailable,
+			Message:   "too many scans are waiting, try again later",
+			Error:     fmt.Sprintf("%d scans are waiting or running", cap(queue)),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusServiceUnavailable, er)
+		return
+	}
+	queued := false
+	defer func() {
+		if !queued {
+			<-queue
+		}
+	}()
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, scanMaxUploadBytes)
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			er := models.LicenseError{
+				Status:    http.StatusRequestEntityTooLarge,
+				Message:   fmt.Sprintf("uploads may be at most %d MB", scanMaxUploadBytes>>20),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusRequestEntityTooLarge, er)
+			return
+		}
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "input file must be present",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	minCoverage := float64(defaultScanMinCoverage)
+	if value := c.PostForm("min_coverage"); value != "" {
+		minCoverage, err = strconv.ParseFloat(value, 64)
+		if err != nil || minCoverage < 0 || minCoverage > 100 {
+			er := models.LicenseError{
+				Status:    http.StatusBadRequest,
+				Message:   "min_coverage must be a number between 0 and 100",
+				Error:     fmt.Sprintf("invalid min_coverage: %s", value),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusBadRequest, er)
+			return
+		}
+	}
+
+	// The upload is kept until the scan finished
+	upload, err := os.CreateTemp("", "licensedb-scan-*")
+	if err != nil {
+		scanNotStarted(c, err)
+		return
+	}
+	size, err := io.Copy(upload, file)
+	if closeErr := upload.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(upload.Name())
+		scanNotStarted(c, err)
+		return
+	}
+
+	scan := models.Scan{
+		Status:    models.ScanQueued,
+		FileName:  path.Base(header.Filename),
+		Size:      size,
+		CreatedBy: userId,
+	}
+	if err := db.DB.WithContext(c).Create(&scan).Error; err != nil {
+		_ = os.Remove(upload.Name())
+		scanNotStarted(c, err)
+		return
+	}
+
+	queued = true
+	go runScan(queue, scan.Id, upload.Name(), scan.FileName, minCoverage)
+
+	c.Header("Location", fmt.Sprintf("%s/%s", strings.TrimSuffix(c.Request.URL.Path, "/"), scan.Id))
+	c.JSON(http.StatusAccepted, models.ScanResponse{
+		Status: http.StatusAccepted,
+		Data:   scan.ConvertToScanDTO(),
+	})
+}
+
+// GetScan returns the status of a scan, and its findings once it completed.
+//
+//	@Summary		Get a scan
+//	@Description	Get the status of a scan and, once it is COMPLETED, the licenses found in each file of the archive along
+//	@Description	with all licenses found and their obligations. Users can only see their own scans, admins see all.
+//	@Id				GetScan
+//	@Tags			Scans
+//	@Produce		json
+//	@Param			id	path		string	true	"Id of the scan"
+//	@Success		200	{object}	models.ScanResponse
+//	@Failure		400	{object}	models.LicenseError	"Invalid scan id"
+//	@Failure		404	{object}	models.LicenseError	"No scan found"
+//	@Security		ApiKeyAuth
+//	@Router			/scan/{id} [get]
+func GetScan(c *gin.Context) {
+	userId := c.MustGet("userId").(uuid.UUID)
+	scanId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   fmt.Sprintf("no scan with id '%s' exists", c.Param("id")),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	query := db.DB.WithContext(c).Where(models.Scan{Id: scanId})
+	if !slices.Contains([]string{"ADMIN", "SUPER_ADMIN"}, c.GetString("role")) {
+		query = query.Where(models.Scan{CreatedBy: userId})
+	}
+	var scan models.Scan
+	if err := query.First(&scan).Error; err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusNotFound,
+			Message:   fmt.Sprintf("scan with id '%s' not found", scanId.String()),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusNotFound, er)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ScanResponse{
+		Status: http.StatusOK,
+		Data:   scan.ConvertToScanDTO(),
+	})
+}
+
+// StartScans configures the scans and fails the scans which were interrupted
+// by a restart, as their uploads are gone.
+func StartScans() error {
+	if value := os.Getenv("SCAN_WORKERS"); value != "" {
+		workers, err := strconv.Atoi(value)
+		if err != nil || workers <= 0 {
+			return fmt.Errorf("invalid number of scan workers: %s", value)
+		}
+		scanSlots = make(chan struct{}, workers)
+	}
+	if value := os.Getenv("SCAN_QUEUE_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			return fmt.Errorf("invalid scan queue size: %s", value)
+		}
+		scanQueue = make(chan struct{}, size)
+	}
+	if value := os.Getenv("SCAN_MAX_UPLOAD_MB"); value != "" {
+		megabytes, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || megabytes <= 0 {
+			return fmt.Errorf("invalid maximum scan upload size: %s", value)
+		}
+		scanMaxUploadBytes = megabytes << 20
+	}
+	if value := os.Getenv("SCAN_MAX_EXTRACTED_MB"); value != "" {
+		megabytes, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || megabytes <= 0 {
+			return fmt.Errorf("invalid maximum extracted scan size: %s", value)
+		}
+		scanMaxExtractedBytes = megabytes << 20
+	}
+
+	interrupted := "scan was interrupted by a restart of the server"
+	return db.DB.Model(&models.Scan{}).Where("finished_at IS NULL").
+		UpdateColumns(map[string]interface{}{"status": models.ScanFailed, "error": interrupted, "finished_at": time.Now()}).Error
+}
+
+// runScan scans an upload in the background, removes it afterwards and frees
+// its place in the queue.
+func runScan(queue chan struct{}, scanId uuid.UUID, uploadPath, fileName string, minCoverage float64) {
+	slots := scanSlots
+	slots <- struct{}{}
+	defer func() {
+		_ = os.Remove(uploadPath)
+		<-slots
+		<-queue
+	}()
+
+	if err := db.DB.Model(&models.Scan{}).Where(models.Scan{Id: scanId}).
+		UpdateColumns(map[string]interface{}{"status": models.ScanRunning, "started_at": time.Now()}).Error; err != nil {
+		logger.LogError("Failed to start scan", zap.String("scan", scanId.String()), zap.Error(err))
+	}
+
+	columns := map[string]interface{}{"status": models.ScanCompleted}
+	result, err := scanArchive(uploadPath, fileName, minCoverage)
+	if err == nil {
+		var data []byte
+		data, err = json.Marshal(result)
+		columns["result"] = datatypes.JSON(data)
+	}
+	if err != nil {
+		logger.LogError("Scan failed", zap.String("scan", scanId.String()), zap.Error(err))
+		columns = map[string]interface{}{"status": models.ScanFailed, "error": err.Error()}
+	}
+	columns["finished_at"] = time.Now()
+	if err := db.DB.Model(&models.Scan{}).Where(models.Scan{Id: scanId}).UpdateColumns(columns).Error; err != nil {
+		logger.LogError("Failed to save scan", zap.String("scan", scanId.String()), zap.Error(err))
+	}
+}
+
+// scanArchive finds the SPDX-License-Identifier tags and the license texts in
+// the files of an archive.
+func scanArchive(uploadPath, fileName string, minCoverage float64) (result *models.ScanResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("scan failed: %v", r)
+		}
+	}()
+
+	catalog, err := loadLicenseTemplates(db.DB)
+	if err != nil {
+		return nil, err
+	}
+	byIdentifier := map[string]identifiableLicense{}
+	var aliases []models.LicenseAlias
+	if err := db.DB.Find(&aliases).Error; err != nil {
+		return nil, err
+	}
+	// Aliases of preferred namespaces take precedence over other aliases
+	sort.SliceStable(aliases, func(i, j int) bool {
+		return slices.Index(aliasNamespaces, aliases[i].Namespace) > slices.Index(aliasNamespaces, aliases[j].Namespace)
+	})
+	byId := map[uuid.UUID]identifiableLicense{}
+	for _, license := range catalog.licenses {
+		byId[license.Id] = license
+	}
+	for _, alias := range aliases {
+		if license, ok := byId[alias.LicenseId]; ok {
+			byIdentifier[strings.ToLower(alias.Alias)] = license
+		}
+	}
+	// Shortnames take precedence over aliases
+	for _, license := range catalog.licenses {
+		byIdentifier[strings.ToLower(license.Shortname)] = license
+	}
+	// SPDX ids take precedence over shortnames
+	for _, license := range catalog.licenses {
+		if license.SpdxId != "" {
+			byIdentifier[strings.ToLower(license.SpdxId)] = license
+		}
+	}
+
+	result = &models.ScanResult{
+		Files:      []models.ScanFileFinding{},
+		Licenses:   []models.ScanLicense{},
+		UnknownIds: []string{},
+	}
+	found := map[uuid.UUID]*models.ScanLicense{}
+	unknownIds := map[string]struct{}{}
+	err = archive.Walk(uploadPath, fileName, maxScanFiles, scanMaxExtractedBytes, func(name string, contents io.Reader) error {
+		data, err := io.ReadAll(io.LimitReader(contents, maxScanFileBytes))
+		if err != nil {
+			return err
+		}
+		if archive.IsArchive(name) || bytes.IndexByte(data[:min(len(data), binaryProbeBytes)], 0) != -1 {
+			result.FilesSkipped++
+			return nil
+		}
+		result.FilesScanned++
+
+		text := string(data)
+		finding := models.ScanFileFinding{Path: name, Identifiers: []models.ScanIdentifier{}}
+		fileLicenses := map[uuid.UUID]identifiableLicense{}
+		for _, identifier := range matching.Identifiers(text) {
+			scanIdentifier := models.ScanIdentifier{
+				Line:       identifier.Line,
+				Expression: identifier.Expression,
+				Licenses:   []models.ScannedLicense{},
+				UnknownIds: []string{},
+			}
+			for _, id := range identifier.Ids {
+				license, ok := byIdentifier[strings.ToLower(id)]
+				if !ok {
+					scanIdentifier.UnknownIds = append(scanIdentifier.UnknownIds, id)
+					unknownIds[id] = struct{}{}
+					continue
+				}
+				scanIdentifier.Licenses = append(scanIdentifier.Licenses, scannedLicense(license))
+				fileLicenses[license.Id] = license
+			}
+			finding.Identifiers = append(finding.Identifiers, scanIdentifier)
+		}
+
+		identification := catalog.identify(matching.Words(text[:min(len(text), maxScanTextBytes)]), minCoverage)
+		finding.ExactMatches = identification.ExactMatches
+		finding.NearMatches = identification.NearMatches
+		for _, identified := range slices.Concat(identification.ExactMatches, identification.NearMatches) {
+			fileLicenses[identified.Id] = identifiableLicense{Id: identified.Id, Shortname: identified.Shortname, SpdxId: identified.SpdxId}
+		}
+
+		if len(fileLicenses) == 0 && len(finding.Identifiers) == 0 {
+			return nil
+		}
+		result.Files = append(result.Files, finding)
+		for id, license := range fileLicenses {
+			if _, ok := found[id]; !ok {
+				found[id] = &models.ScanLicense{ScannedLicense: scannedLicense(license), Obligations: []models.ScanObligation{}}
+			}
+			found[id].Files++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := addScanObligations(found); err != nil {
+		return nil, err
+	}
+	for _, license := range found {
+		result.Licenses = append(result.Licenses, *license)
+	}
+	sort.Slice(result.Licenses, func(i, j int) bool {
+		if result.Licenses[i].Files != result.Licenses[j].Files {
+			return result.Licenses[i].Files > result.Licenses[j].Files
+		}
+		return result.Licenses[i].Shortname < result.Licenses[j].Shortname
+	})
+	for id := range unknownIds {
+		result.UnknownIds = append(result.UnknownIds, id)
+	}
+	sort.Strings(result.UnknownIds)
+	return result, nil
+}
+
+// addScanObligations adds the active obligations of the licenses found in a
+// scan.
+func addScanObligations(found map[uuid.UUID]*models.ScanLicense) error {
+	if len(found) == 0 {
+		return nil
+	}
+	licenseIds := make([]uuid.UUID, 0, len(found))
+	for id := range found {
+		licenseIds = append(licenseIds, id)
+	}
+
+	var obligations []struct {
+		LicenseId uuid.UUID `gorm:"column:license_db_id"`
+		models.ScanObligation
+	}
+	if err := db.DB.Model(&models.Obligation{}).
+		Select("obligation_licenses.license_db_id, obligations.id, obligations.topic, obligation_types.type, "+
+			"obligation_classifications.classification").
+		Joins("JOIN obligation_licenses ON obligation_licenses.obligation_id = obligations.id").
+		Joins("JOIN obligation_types ON obligation_types.id = obligations.obligation_type_id").
+		Joins("JOIN obligation_classifications ON obligation_classifications.id = obligations.obligation_classification_id").
+		Where("obligations.active AND obligation_licenses.license_db_id IN ?", licenseIds).
+		Order("obligations.topic").
+		Scan(&obligations).Error; err != nil {
+		return err
+	}
+	for _, obligation := range obligations {
+		found[obligation.LicenseId].Obligations = append(found[obligation.LicenseId].Obligations, obligation.ScanObligation)
+	}
+	return nil
+}
+
+func scannedLicense(license identifiableLicense) models.ScannedLicense {
+	return models.ScannedLicense{Id: license.Id, Shortname: license.Shortname, SpdxId: license.SpdxId}
+}
+
+func scanNotStarted(c *gin.Context, err error) {
+	er := models.LicenseError{
+		Status:    http.StatusInternalServerError,
+		Message:   "Failed to start scan",
+		Error:     err.Error(),
+		Path:      c.Request.URL.Path,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	c.JSON(http.StatusInternalServerError, er)
+}
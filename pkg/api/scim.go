@@ -0,0 +1,622 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+	"github.com/fossology/LicenseDb/pkg/validations"
+)
+
+const (
+	scimUsersLocation     = "/api/v1/scim/v2/Users"
+	scimDefaultCount      = 100
+	scimMaxCount          = 1000
+	scimContentType       = "application/scim+json"
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeUniqueness    = "uniqueness"
+)
+
+// scimFilterRegex matches the supported filter expressions, e.g. userName eq "jdoe"
+var scimFilterRegex = regexp.MustCompile(`(?i)^\s*([a-z.]+)\s+eq\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// ScimGetUsers lists users for SCIM provisioning clients.
+//
+//	@Summary		List users (SCIM)
+//	@Description	Query users as SCIM 2.0 resources. Supports filtering with `userName eq "..."`, `externalId eq "..."`
+//	@Description	or `emails eq "..."` and pagination with startIndex and count.
+//	@Id				ScimGetUsers
+//	@Tags			SCIM
+//	@Accept			json
+//	@Produce		json
+//	@Param			filter		query		string	false	"Filter expression"	example(userName eq "jdoe")
+//	@Param			startIndex	query		int		false	"1-based index of the first result"
+//	@Param			count		query		int		false	"Maximum number of results"
+//	@Success		200			{object}	models.ScimListResponse
+//	@Failure		400			{object}	models.ScimError	"Invalid filter"
+//	@Failure		401			{object}	models.ScimError	"Invalid SCIM token"
+//	@Security		ScimAuth
+//	@Router			/scim/v2/Users [get]
+func ScimGetUsers(c *gin.Context) {
+	query := db.DB.Model(&models.User{}).Where("id NOT IN (?)", db.DB.Model(&models.ServiceAccount{}).Select("user_id")).
+		Where("anonymised_at IS NULL AND id <> ?", models.ScimActorId)
+
+	if filter := c.Query("filter"); filter != "" {
+		match := scimFilterRegex.FindStringSubmatch(filter)
+		if match == nil {
+			scimError(c, http.StatusBadRequest, scimTypeInvalidFilter, "only filters of the form '<attribute> eq \"<value>\"' are supported")
+			return
+		}
+		value := strings.ReplaceAll(match[2], `\"`, `"`)
+		switch strings.ToLower(match[1]) {
+		case "username":
+			query = query.Where("LOWER(user_name) = LOWER(?)", value)
+		case "externalid":
+			query = query.Where("external_id = ?", value)
+		case "emails", "emails.value":
+			query = query.Where("LOWER(user_email) = LOWER(?)", value)
+		default:
+			scimError(c, http.StatusBadRequest, scimTypeInvalidFilter, fmt.Sprintf("filtering by '%s' is not supported", match[1]))
+			return
+		}
+	}
+
+	startIndex, err := strconv.Atoi(c.DefaultQuery("startIndex", "1"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(c.DefaultQuery("count", strconv.Itoa(scimDefaultCount)))
+	if err != nil || count < 0 {
+		count = scimDefaultCount
+	}
+	if count > scimMaxCount {
+		count = scimMaxCount
+	}
+
+	var totalResults int64
+	if err := query.Count(&totalResults).Error; err != nil {
+		scimError(c, http.StatusInternalServerError, "", err.Error())
+		return
+	}
+
+	var users []models.User
+	if err := query.Order("user_name").Offset(startIndex - 1).Limit(count).Find(&users).Error; err != nil {
+		scimError(c, http.StatusInternalServerError, "", err.Error())
+		return
+	}
+
+	res := models.ScimListResponse{
+		Schemas:      []string{models.ScimListResponseSchema},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(users),
+		Resources:    []models.ScimUser{},
+	}
+	for i := range users {
+		res.Resources = append(res.Resources, users[i].ConvertToScimUser(scimUsersLocation))
+	}
+
+	c.Header("Content-Type", scimContentType)
+	c.JSON(http.StatusOK, res)
+}
+
+// ScimGetUser returns a user for SCIM provisioning clients.
+//
+//	@Summary		Get a user (SCIM)
+//	@Description	Get a user as SCIM 2.0 resource
+//	@Id				ScimGetUser
+//	@Tags			SCIM
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"User id"
+//	@Success		200	{object}	models.ScimUser
+//	@Failure		401	{object}	models.ScimError	"Invalid SCIM token"
+//	@Failure		404	{object}	models.ScimError	"User not found"
+//	@Security		ScimAuth
+//	@Router			/scim/v2/Users/{id} [get]
+func ScimGetUser(c *gin.Context) {
+	user, ok := scimFindUser(c, db.DB)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", scimContentType)
+	c.JSON(http.StatusOK, user.ConvertToScimUser(scimUsersLocation))
+}
+
+// ScimCreateUser provisions a new user.
+//
+//	@Summary		Create a user (SCIM)
+//	@Description	Provision a user. The primary email is stored as user email, the role (USER or ADMIN) as user level.
+//	@Id				ScimCreateUser
+//	@Tags			SCIM
+//	@Accept			json
+//	@Produce		json
+//	@Param			user	body		models.ScimUser	true	"User to create"
+//	@Success		201		{object}	models.ScimUser
+//	@Failure		400		{object}	models.ScimError	"Invalid user"
+//	@Failure		401		{object}	models.ScimError	"Invalid SCIM token"
+//	@Failure		409		{object}	models.ScimError	"User already exists"
+//	@Security		ScimAuth
+//	@Router			/scim/v2/Users [post]
+func ScimCreateUser(c *gin.Context) {
+	var input models.ScimUser
+	if err := c.ShouldBindJSON(&input); err != nil {
+		scimError(c, http.StatusBadRequest, scimTypeInvalidSyntax, err.Error())
+		return
+	}
+
+	var user models.User
+	if err := applyScimUser(&user, input); err != nil {
+		scimError(c, http.StatusBadRequest, scimTypeInvalidValue, err.Error())
+		return
+	}
+	if user.Active == nil {
+		active := true
+		user.Active = &active
+	}
+
+	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&models.User{}).Where("LOWER(user_name) = LOWER(?)", *user.UserName).Count(&existing).Error; err != nil {
+			scimError(c, http.StatusInternalServerError, "", err.Error())
+			return err
+		}
+		if existing != 0 {
+			scimError(c, http.StatusConflict, scimTypeUniqueness, fmt.Sprintf("user with userName '%s' already exists", *user.UserName))
+			return nil
+		}
+
+		if err := tx.Create(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				scimError(c, http.StatusConflict, scimTypeUniqueness, "user with same userName or email already exists")
+				return err
+			}
+			scimError(c, http.StatusInternalServerError, "", err.Error())
+			return err
+		}
+
+		if err := utils.AddChangelogsForUser(tx, models.ScimActorId, &user, &models.User{}); err != nil {
+			scimError(c, http.StatusInternalServerError, "", err.Error())
+			return err
+		}
+
+		c.Header("Content-Type", scimContentType)
+		c.JSON(http.StatusCreated, user.ConvertToScimUser(scimUsersLocation))
+		return nil
+	})
+}
+
+// ScimReplaceUser replaces the attributes of a user.
+//
+//	@Summary		Replace a user (SCIM)
+//	@Description	Replace the attributes of a user. Setting active to false deactivates the user.
+//	@Id				ScimReplaceUser
+//	@Tags			SCIM
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"User id"
+//	@Param			user	body		models.ScimUser	true	"User attributes"
+//	@Success		200		{object}	models.ScimUser
+//	@Failure		400		{object}	models.ScimError	"Invalid user"
+//	@Failure		401		{object}	models.ScimError	"Invalid SCIM token"
+//	@Failure		404		{object}	models.ScimError	"User not found"
+//	@Failure		409		{object}	models.ScimError	"User with same userName or email exists"
+//	@Security		ScimAuth
+//	@Router			/scim/v2/Users/{id} [put]
+func ScimReplaceUser(c *gin.Context) {
+	var input models.ScimUser
+	if err := c.ShouldBindJSON(&input); err != nil {
+		scimError(c, http.StatusBadRequest, scimTypeInvalidSyntax, err.Error())
+		return
+	}
+
+	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+		oldUser, ok := scimFindUser(c, tx)
+		if !ok {
+			return nil
+		}
+
+		newUser := *oldUser
+		if err := applyScimUser(&newUser, input); err != nil {
+			scimError(c, http.StatusBadRequest, scimTypeInvalidValue, err.Error())
+			return nil
+		}
+
+		return scimSaveUser(c, tx, oldUser, &newUser)
+	})
+}
+
+// ScimPatchUser modifies attributes of a user.
+//
+//	@Summary		Patch a user (SCIM)
+//	@Description	Apply add, replace and remove operations to the attributes of a user. Replacing active with false
+//	@Description	deactivates the user.
+//	@Id				ScimPatchUser
+//	@Tags			SCIM
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"User id"
+//	@Param			patch	body		models.ScimPatchRequest	true	"Patch operations"
+//	@Success		200		{object}	models.ScimUser
+//	@Failure		400		{object}	models.ScimError	"Invalid operation"
+//	@Failure		401		{object}	models.ScimError	"Invalid SCIM token"
+//	@Failure		404		{object}	models.ScimError	"User not found"
+//	@Failure		409		{object}	models.ScimError	"User with same userName or email exists"
+//	@Security		ScimAuth
+//	@Router			/scim/v2/Users/{id} [patch]
+func ScimPatchUser(c *gin.Context) {
+	var input models.ScimPatchRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		scimError(c, http.StatusBadRequest, scimTypeInvalidSyntax, err.Error())
+		return
+	}
+
+	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+		oldUser, ok := scimFindUser(c, tx)
+		if !ok {
+			return nil
+		}
+
+		scimUser := oldUser.ConvertToScimUser(scimUsersLocation)
+		for _, operation := range input.Operations {
+			if err := applyScimPatchOperation(&scimUser, operation); err != nil {
+				scimError(c, http.StatusBadRequest, scimTypeInvalidValue, err.Error())
+				return nil
+			}
+		}
+
+		newUser := *oldUser
+		if err := applyScimUser(&newUser, scimUser); err != nil {
+			scimError(c, http.StatusBadRequest, scimTypeInvalidValue, err.Error())
+			return nil
+		}
+
+		return scimSaveUser(c, tx, oldUser, &newUser)
+	})
+}
+
+// ScimDeleteUser deactivates a user.
+//
+//	@Summary		Deactivate a user (SCIM)
+//	@Description	Deactivate a user. Users are never removed to keep their audits, and can be re-activated by setting active.
+//	@Id				ScimDeleteUser
+//	@Tags			SCIM
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"User id"
+//	@Success		204
+//	@Failure		401	{object}	models.ScimError	"Invalid SCIM token"
+//	@Failure		404	{object}	models.ScimError	"User not found"
+//	@Security		ScimAuth
+//	@Router			/scim/v2/Users/{id} [delete]
+func ScimDeleteUser(c *gin.Context) {
+	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+		oldUser, ok := scimFindUser(c, tx)
+		if !ok {
+			return nil
+		}
+
+		newUser := *oldUser
+		active := false
+		newUser.Active = &active
+		if err := tx.Model(&newUser).Update("active", false).Error; err != nil {
+			scimError(c, http.StatusInternalServerError, "", err.Error())
+			return err
+		}
+		if err := utils.AddChangelogsForUser(tx, models.ScimActorId, &newUser, oldUser); err != nil {
+			scimError(c, http.StatusInternalServerError, "", err.Error())
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	})
+}
+
+// scimFindUser fetches the user of the id path parameter. It writes the error
+// response and returns false if there is no such user.
+func scimFindUser(c *gin.Context, tx *gorm.DB) (*models.User, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		scimError(c, http.StatusNotFound, "", fmt.Sprintf("user '%s' not found", c.Param("id")))
+		return nil, false
+	}
+
+	var user models.User
+	err = tx.Where(models.User{Id: id}).
+		Where("id NOT IN (?)", tx.Model(&models.ServiceAccount{}).Select("user_id")).
+		Where("anonymised_at IS NULL AND id <> ?", models.ScimActorId).
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			scimError(c, http.StatusNotFound, "", fmt.Sprintf("user '%s' not found", c.Param("id")))
+		} else {
+			scimError(c, http.StatusInternalServerError, "", err.Error())
+		}
+		return nil, false
+	}
+	return &user, true
+}
+
+// scimSaveUser stores the replaced attributes of a user and writes the response.
+func scimSaveUser(c *gin.Context, tx *gorm.DB, oldUser, newUser *models.User) error {
+	err := tx.Model(newUser).
+		Select("user_name", "display_name", "user_email", "user_level", "active", "external_id", "external_issuer", "external_subject").
+		Updates(newUser).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			scimError(c, http.StatusConflict, scimTypeUniqueness, "user with same userName or email already exists")
+			return err
+		}
+		scimError(c, http.StatusInternalServerError, "", err.Error())
+		return err
+	}
+
+	if err := utils.AddChangelogsForUser(tx, models.ScimActorId, newUser, oldUser); err != nil {
+		scimError(c, http.StatusInternalServerError, "", err.Error())
+		return err
+	}
+
+	c.Header("Content-Type", scimContentType)
+	c.JSON(http.StatusOK, newUser.ConvertToScimUser(scimUsersLocation))
+	return nil
+}
+
+// applyScimUser sets the attributes of the SCIM user on the user. Roles only
+// change the user level between USER and ADMIN, super admins are kept. Users
+// are bound to the oidc identity of their externalId if SCIM_OIDC_ISSUER is
+// set, otherwise they are bound on their first oidc login.
+func applyScimUser(user *models.User, input models.ScimUser) error {
+	userName := strings.TrimSpace(input.UserName)
+	if userName == "" {
+		return errors.New("userName is required")
+	}
+
+	var email string
+	for _, entry := range input.Emails {
+		if email == "" || entry.Primary {
+			email = strings.TrimSpace(entry.Value)
+		}
+	}
+	if email == "" {
+		return errors.New("an email is required")
+	}
+	if err := validations.Validate.Var(email, "email"); err != nil {
+		return fmt.Errorf("'%s' is not a valid email", email)
+	}
+
+	displayName := strings.TrimSpace(input.DisplayName)
+	if displayName == "" && input.Name != nil {
+		displayName = strings.TrimSpace(input.Name.Formatted)
+		if displayName == "" {
+			displayName = strings.TrimSpace(input.Name.GivenName + " " + input.Name.FamilyName)
+		}
+	}
+	if displayName == "" {
+		displayName = userName
+	}
+
+	level := ""
+	for _, role := range input.Roles {
+		switch strings.ToUpper(strings.TrimSpace(role.Value)) {
+		case "ADMIN":
+			level = "ADMIN"
+		case "USER":
+			if level == "" {
+				level = "USER"
+			}
+		default:
+			return fmt.Errorf("role '%s' is not supported, use USER or ADMIN", role.Value)
+		}
+	}
+	if user.UserLevel != nil && *user.UserLevel == "SUPER_ADMIN" {
+		level = ""
+	}
+	if level == "" && user.UserLevel == nil {
+		level = "USER"
+	}
+
+	user.UserName = &userName
+	user.UserEmail = &email
+	user.DisplayName = &displayName
+	if level != "" {
+		user.UserLevel = &level
+	}
+	if input.ExternalId != "" {
+		externalId := input.ExternalId
+		user.ExternalId = &externalId
+		// The externalId is the sub claim of the users' tokens of the issuer in
+		// SCIM_OIDC_ISSUER, identities of other issuers are kept
+		issuer := os.Getenv("SCIM_OIDC_ISSUER")
+		if issuer != "" && (user.ExternalIssuer == nil || *user.ExternalIssuer == issuer) {
+			user.ExternalIssuer = &issuer
+			user.ExternalSubject = &externalId
+		}
+	} else {
+		user.ExternalId = nil
+	}
+	if input.Active != nil {
+		active := *input.Active
+		user.Active = &active
+	}
+	return nil
+}
+
+// applyScimPatchOperation applies a PATCH operation to the SCIM user.
+func applyScimPatchOperation(user *models.ScimUser, operation models.ScimPatchOperation) error {
+	op := strings.ToLower(operation.Op)
+	if op != "add" && op != "replace" && op != "remove" {
+		return fmt.Errorf("operation '%s' is not supported", operation.Op)
+	}
+
+	path := strings.ToLower(strings.TrimSpace(operation.Path))
+	path = strings.TrimPrefix(path, strings.ToLower(models.ScimUserSchema)+":")
+	if path == "" {
+		if op == "remove" {
+			return errors.New("remove operations require a path")
+		}
+		values, ok := operation.Value.(map[string]interface{})
+		if !ok {
+			return errors.New("operations without path require an object value")
+		}
+		for key, value := range values {
+			err := applyScimPatchOperation(user, models.ScimPatchOperation{Op: op, Path: key, Value: value})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if op == "remove" {
+		switch {
+		case path == "externalid":
+			user.ExternalId = ""
+		case path == "displayname":
+			user.DisplayName = ""
+		case path == "name" || strings.HasPrefix(path, "name."):
+			user.Name = nil
+		case strings.HasPrefix(path, "roles"):
+			user.Roles = nil
+		default:
+			return fmt.Errorf("attribute '%s' can not be removed", operation.Path)
+		}
+		return nil
+	}
+
+	switch {
+	case path == "active":
+		active, err := scimBool(operation.Value)
+		if err != nil {
+			return err
+		}
+		user.Active = &active
+	case path == "username":
+		value, ok := operation.Value.(string)
+		if !ok {
+			return errors.New("userName must be a string")
+		}
+		user.UserName = value
+	case path == "displayname":
+		value, ok := operation.Value.(string)
+		if !ok {
+			return errors.New("displayName must be a string")
+		}
+		user.DisplayName = value
+	case path == "externalid":
+		value, ok := operation.Value.(string)
+		if !ok {
+			return errors.New("externalId must be a string")
+		}
+		user.ExternalId = value
+	case path == "name" || strings.HasPrefix(path, "name."):
+		if user.Name == nil {
+			user.Name = &models.ScimName{}
+		}
+		values := map[string]interface{}{strings.TrimPrefix(path, "name."): operation.Value}
+		if path == "name" {
+			object, ok := operation.Value.(map[string]interface{})
+			if !ok {
+				return errors.New("name must be an object")
+			}
+			values = object
+		}
+		for key, value := range values {
+			str, _ := value.(string)
+			switch strings.ToLower(key) {
+			case "formatted":
+				user.Name.Formatted = str
+				user.DisplayName = ""
+			case "givenname":
+				user.Name.GivenName = str
+			case "familyname":
+				user.Name.FamilyName = str
+			}
+		}
+	case strings.HasPrefix(path, "emails"):
+		emails, err := scimMultiValued(operation.Value)
+		if err != nil {
+			return err
+		}
+		if len(emails) != 0 {
+			emails[0].Primary = true
+		}
+		user.Emails = emails
+	case strings.HasPrefix(path, "roles"):
+		roles, err := scimMultiValued(operation.Value)
+		if err != nil {
+			return err
+		}
+		user.Roles = roles
+	default:
+		return fmt.Errorf("attribute '%s' is not supported", operation.Path)
+	}
+	return nil
+}
+
+// scimBool accepts booleans as well as "True"/"False" strings sent by some
+// provisioning clients.
+func scimBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	}
+	return false, errors.New("active must be a boolean")
+}
+
+// scimMultiValued reads the value of a multi-valued attribute operation, which
+// can be a plain value, an entry or a list of entries.
+func scimMultiValued(value interface{}) ([]models.ScimMultiValuedAttribute, error) {
+	switch v := value.(type) {
+	case string:
+		return []models.ScimMultiValuedAttribute{{Value: v}}, nil
+	case map[string]interface{}:
+		entry := models.ScimMultiValuedAttribute{}
+		entry.Value, _ = v["value"].(string)
+		entry.Type, _ = v["type"].(string)
+		entry.Primary, _ = v["primary"].(bool)
+		return []models.ScimMultiValuedAttribute{entry}, nil
+	case []interface{}:
+		var entries []models.ScimMultiValuedAttribute
+		for _, item := range v {
+			itemEntries, err := scimMultiValued(item)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, itemEntries...)
+		}
+		return entries, nil
+	}
+	return nil, errors.New("invalid value of multi-valued attribute")
+}
+
+// scimError writes an error response in the SCIM format.
+func scimError(c *gin.Context, status int, scimType, detail string) {
+	c.Header("Content-Type", scimContentType)
+	c.JSON(status, models.ScimError{
+		Schemas:  []string{models.ScimErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+)
+
+// searchTsQuery parses the search term like web search engines do, supporting
+// quoted phrases, "or" and "-" for excluded terms.
+const searchTsQuery = "websearch_to_tsquery('english', ?)"
+
+// searchHeadlineText is the text of a result escaped for HTML, so that the
+// <mark> tags of ts_headline are the only markup in the snippet.
+const searchHeadlineText = "replace(replace(replace(replace(replace(text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '\"', '&quot;'), '''', '&#39;')"
+
+// searchHeadlineOptions configure the highlighted snippets of search results.
+const searchHeadlineOptions = "MaxFragments=2, MaxWords=20, MinWords=5, StartSel=<mark>, StopSel=</mark>"
+
+// searchFilter narrows down the results of a search.
+type searchFilter struct {
+	types          []string
+	risk           *int64
+	classification *string
+}
+
+// Search searches licenses and obligations.
+//
+//	@Summary		Search licenses and obligations
+//	@Description	Search the names, SPDX ids, texts and notes of licenses together with the topics, comments and texts of obligations.
+//	@Description	Results are ordered by relevance, names and ids weighing more than texts. Facet counts are over all results of the
+//	@Description	search term, regardless of the type, risk and classification filters.
+//	@Id				Search
+//	@Tags			Search
+//	@Accept			json
+//	@Produce		json
+//	@Param			q				query		string	true	"Search term, supports quoted phrases, or and -"
+//	@Param			type			query		string	false	"Type of the results"	Enums(LICENSE, OBLIGATION)
+//	@Param			risk			query		int		false	"Risk of the licenses"
+//	@Param			classification	query		string	false	"Classification of the obligations"
+//	@Param			page			query		int		false	"Page number"
+//	@Param			limit			query		int		false	"Number of records per page"
+//	@Success		200				{object}	models.SearchResponse
+//	@Failure		400				{object}	models.LicenseError	"Invalid search"
+//	@Failure		500				{object}	models.LicenseError	"Search failed"
+//	@Security		ApiKeyAuth || {}
+//	@Router			/search [get]
+func Search(c *gin.Context) {
+	term := strings.TrimSpace(c.Query("q"))
+	if term == "" {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "search term is required",
+			Error:     "query parameter 'q' is empty",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	filter := searchFilter{types: []string{"LICENSE", "OBLIGATION"}}
+	if entityType := c.Query("type"); entityType != "" {
+		entityType = strings.ToUpper(entityType)
+		if entityType != "LICENSE" && entityType != "OBLIGATION" {
+			er := models.LicenseError{
+				Status:    http.StatusBadRequest,
+				Message:   "invalid type",
+				Error:     "type must be one of LICENSE, OBLIGATION",
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusBadRequest, er)
+			return
+		}
+		filter.types = []string{entityType}
+	}
+	if risk := c.Query("risk"); risk != "" {
+		parsedRisk, err := strconv.ParseInt(risk, 10, 64)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusBadRequest,
+				Message:   "invalid risk",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusBadRequest, er)
+			return
+		}
+		filter.risk = &parsedRisk
+	}
+	if classification := c.Query("classification"); classification != "" {
+		classification = strings.ToUpper(classification)
+		filter.classification = &classification
+	}
+
+	tx := db.DB.WithContext(c)
+	pagination, err := utils.PreparePaginateResponse(c, tx.Table("(?) AS results", searchResults(tx, term, filter)), nil)
+	if err != nil {
+		return
+	}
+
+	// Only the snippets of the requested page are highlighted
+	page := tx.Table("(?) AS results", searchResults(tx, term, filter)).
+		Order("rank DESC, name").
+		Offset(int(pagination.Input.GetOffset())).
+		Limit(int(pagination.Input.GetLimit()))
+	results := []models.SearchResult{}
+	if err := tx.Table("(?) AS results", page).
+		Select("type, id, name, risk, classification, rank, ts_headline('english', "+searchHeadlineText+", "+searchTsQuery+", ?) AS snippet",
+			term, searchHeadlineOptions).
+		Order("rank DESC, name").
+		Scan(&results).Error; err != nil {
+		searchFailed(c, err)
+		return
+	}
+
+	facets, err := searchFacets(tx, term)
+	if err != nil {
+		searchFailed(c, err)
+		return
+	}
+
+	res := models.SearchResponse{
+		Data:   results,
+		Facets: facets,
+		Status: http.StatusOK,
+		Meta:   pagination.Meta(results),
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// searchResults builds the query for the active licenses and obligations
+// matching the search term and the filter.
+func searchResults(tx *gorm.DB, term string, filter searchFilter) *gorm.DB {
+	var parts []string
+	var subqueries []interface{}
+
+	if slices.Contains(filter.types, "LICENSE") && filter.classification == nil {
+		licenses := tx.Model(&models.LicenseDB{}).
+			Select("'LICENSE' AS type, rf_id AS id, rf_shortname AS name, rf_risk AS risk, NULL::text AS classification, "+
+				"ts_rank(search_vector, "+searchTsQuery+") AS rank, rf_text AS text", term).
+			Where("rf_active AND search_vector @@ "+searchTsQuery, term)
+		if filter.risk != nil {
+			licenses = licenses.Where("rf_risk = ?", *filter.risk)
+		}
+		parts = append(parts, "(?)")
+		subqueries = append(subqueries, licenses)
+	}
+
+	if slices.Contains(filter.types, "OBLIGATION") && filter.risk == nil {
+		obligations := tx.Model(&models.Obligation{}).
+			Select("'OBLIGATION' AS type, obligations.id, obligations.topic AS name, NULL::bigint AS risk, "+
+				"obligation_classifications.classification, ts_rank(obligations.search_vector, "+searchTsQuery+") AS rank, "+
+				"obligations.text", term).
+			Joins("JOIN obligation_classifications ON obligation_classifications.id = obligations.obligation_classification_id").
+			Where("obligations.active AND obligations.search_vector @@ "+searchTsQuery, term)
+		if filter.classification != nil {
+			obligations = obligations.Where("obligation_classifications.classification = ?", *filter.classification)
+		}
+		parts = append(parts, "(?)")
+		subqueries = append(subqueries, obligations)
+	}
+
+	if len(parts) == 0 {
+		// A risk and a classification filter exclude each other
+		return tx.Raw("SELECT NULL::text AS type, NULL::uuid AS id, NULL::text AS name, NULL::bigint AS risk, " +
+			"NULL::text AS classification, NULL::real AS rank, NULL::text AS text WHERE false")
+	}
+	return tx.Raw(strings.Join(parts, " UNION ALL "), subqueries...)
+}
+
+// searchFacets counts all results of the search term by type, risk and
+// classification.
+func searchFacets(tx *gorm.DB, term string) (models.SearchFacets, error) {
+	facets := models.SearchFacets{
+		Types:           []models.SearchFacet{},
+		Risks:           []models.SearchFacet{},
+		Classifications: []models.SearchFacet{},
+	}
+	all := searchFilter{types: []string{"LICENSE", "OBLIGATION"}}
+
+	if err := tx.Table("(?) AS results", searchResults(tx, term, all)).
+		Select("type AS value, count(*) AS count").
+		Group("type").Order("type").
+		Scan(&facets.Types).Error; err != nil {
+		return facets, err
+	}
+	if err := tx.Table("(?) AS results", searchResults(tx, term, all)).
+		Select("risk::text AS value, count(*) AS count").
+		Where("risk IS NOT NULL").
+		Group("risk").Order("risk").
+		Scan(&facets.Risks).Error; err != nil {
+		return facets, err
+	}
+	if err := tx.Table("(?) AS results", searchResults(tx, term, all)).
+		Select("classification AS value, count(*) AS count").
+		Where("classification IS NOT NULL").
+		Group("classification").Order("classification").
+		Scan(&facets.Classifications).Error; err != nil {
+		return facets, err
+	}
+	return facets, nil
+}
+
+func searchFailed(c *gin.Context, err error) {
+	er := models.LicenseError{
+		Status:    http.StatusInternalServerError,
+		Message:   "search failed",
+		Error:     err.Error(),
+		Path:      c.Request.URL.Path,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	c.JSON(http.StatusInternalServerError, er)
+}
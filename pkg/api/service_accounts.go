@@ -0,0 +1,683 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+	"github.com/fossology/LicenseDb/pkg/validations"
+)
+
+// GetServiceAccounts retrieves all service accounts.
+//
+//	@Summary		Get service accounts
+//	@Description	Get all service accounts along with their oidc clients
+//	@Id				GetServiceAccounts
+//	@Tags			Service Accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	false	"Page number"
+//	@Param			limit	query		int	false	"Number of records per page"
+//	@Success		200		{object}	models.ServiceAccountResponse
+//	@Failure		500		{object}	models.LicenseError	"Unable to fetch service accounts"
+//	@Security		ApiKeyAuth
+//	@Router			/service-accounts [get]
+func GetServiceAccounts(c *gin.Context) {
+	var serviceAccounts []models.ServiceAccount
+	query := db.DB.Model(&models.ServiceAccount{}).Preload("User").Preload("OwnerTeam").Order("created_at")
+	pagination, err := utils.PreparePaginateResponse(c, query, nil)
+	if err != nil {
+		return
+	}
+
+	if err := query.Find(&serviceAccounts).Error; err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Unable to fetch service accounts",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	dtos := make([]models.ServiceAccountDTO, 0, len(serviceAccounts))
+	for i := range serviceAccounts {
+		dto, err := serviceAccountDTO(db.DB, &serviceAccounts[i])
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Unable to fetch service accounts",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return
+		}
+		dtos = append(dtos, dto)
+	}
+
+	res := models.ServiceAccountResponse{
+		Data:   dtos,
+		Status: http.StatusOK,
+		Meta:   pagination.Meta(serviceAccounts),
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetServiceAccount retrieves a service account by its id.
+//
+//	@Summary		Get a service account
+//	@Description	Get a service account along with its oidc clients
+//	@Id				GetServiceAccount
+//	@Tags			Service Accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Service account id"
+//	@Success		200	{object}	models.ServiceAccountResponse
+//	@Failure		404	{object}	models.LicenseError	"No service account with given id found"
+//	@Security		ApiKeyAuth
+//	@Router			/service-accounts/{id} [get]
+func GetServiceAccount(c *gin.Context) {
+	serviceAccount, ok := findServiceAccount(c, db.DB)
+	if !ok {
+		return
+	}
+	writeServiceAccount(c, db.DB, serviceAccount, http.StatusOK)
+}
+
+// CreateServiceAccount creates a new service account.
+//
+//	@Summary		Create a service account
+//	@Description	Create a service account for automation, owned by a team
+//	@Id				CreateServiceAccount
+//	@Tags			Service Accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			service_account	body		models.ServiceAccountCreate	true	"Service account to create"
+//	@Success		201				{object}	models.ServiceAccountResponse
+//	@Failure		400				{object}	models.LicenseError	"Invalid request body"
+//	@Failure		404				{object}	models.LicenseError	"Owner team not found"
+//	@Failure		409				{object}	models.LicenseError	"Name already taken"
+//	@Security		ApiKeyAuth
+//	@Router			/service-accounts [post]
+func CreateServiceAccount(c *gin.Context) {
+	var input models.ServiceAccountCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	input.Name = strings.TrimSpace(input.Name)
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not create service account with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+		ownerTeam, ok := findTeam(c, tx, input.OwnerTeam)
+		if !ok {
+			return nil
+		}
+
+		active := true
+		user := models.User{
+			UserName:    &input.Name,
+			DisplayName: &input.Name,
+			UserLevel:   &input.UserLevel,
+			Active:      &active,
+		}
+		result := tx.Where(models.User{UserName: user.UserName}).FirstOrCreate(&user)
+		if result.Error != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to create the service account",
+				Error:     result.Error.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			er := models.LicenseError{
+				Status:    http.StatusConflict,
+				Message:   "can not create service account",
+				Error:     fmt.Sprintf("a user or service account with name '%s' already exists", input.Name),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusConflict, er)
+			return nil
+		}
+
+		serviceAccount := models.ServiceAccount{
+			UserId:      user.Id,
+			Description: input.Description,
+			OwnerTeamId: ownerTeam.Id,
+			ExpiresAt:   input.ExpiresAt,
+		}
+		if err := tx.Omit(clause.Associations).Create(&serviceAccount).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to create the service account",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := utils.AddChangelogsForUser(tx, userId, &user, &models.User{}); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if err := utils.AddChangelogsForServiceAccount(tx, userId, &serviceAccount, &models.ServiceAccount{}); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := tx.Preload("User").Preload("OwnerTeam").First(&serviceAccount).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to create the service account",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		writeServiceAccount(c, tx, &serviceAccount, http.StatusCreated)
+		return nil
+	})
+}
+
+// UpdateServiceAccount updates a service account.
+//
+//	@Summary		Update a service account
+//	@Description	Update description, user level, owner team, expiry or active state of a service account
+//	@Id				UpdateServiceAccount
+//	@Tags			Service Accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string						true	"Service account id"
+//	@Param			service_account	body		models.ServiceAccountUpdate	true	"Fields to update"
+//	@Success		200				{object}	models.ServiceAccountResponse
+//	@Failure		400				{object}	models.LicenseError	"Invalid request body"
+//	@Failure		404				{object}	models.LicenseError	"No service account or owner team found"
+//	@Security		ApiKeyAuth
+//	@Router			/service-accounts/{id} [patch]
+func UpdateServiceAccount(c *gin.Context) {
+	var input models.ServiceAccountUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not update service account with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+		oldServiceAccount, ok := findServiceAccount(c, tx)
+		if !ok {
+			return nil
+		}
+
+		newServiceAccount := *oldServiceAccount
+		if input.Description != nil {
+			newServiceAccount.Description = input.Description
+		}
+		if input.ExpiresAt != nil {
+			newServiceAccount.ExpiresAt = input.ExpiresAt
+		}
+		if input.OwnerTeam != nil {
+			ownerTeam, ok := findTeam(c, tx, *input.OwnerTeam)
+			if !ok {
+				return nil
+			}
+			newServiceAccount.OwnerTeamId = ownerTeam.Id
+			newServiceAccount.OwnerTeam = *ownerTeam
+		}
+
+		newUser := oldServiceAccount.User
+		if input.UserLevel != nil {
+			newUser.UserLevel = input.UserLevel
+		}
+		if input.Active != nil {
+			newUser.Active = input.Active
+		}
+		newServiceAccount.User = newUser
+
+		if err := tx.Model(&newServiceAccount).Omit(clause.Associations).
+			Select("description", "owner_team_id", "expires_at").Updates(&newServiceAccount).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update the service account",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if err := tx.Model(&newUser).Select("user_level", "active").Updates(&newUser).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update the service account",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		oldUser := oldServiceAccount.User
+		if err := utils.AddChangelogsForUser(tx, userId, &newUser, &oldUser); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if err := utils.AddChangelogsForServiceAccount(tx, userId, &newServiceAccount, oldServiceAccount); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		writeServiceAccount(c, tx, &newServiceAccount, http.StatusOK)
+		return nil
+	})
+}
+
+// DeleteServiceAccount deactivates a service account and revokes its clients.
+//
+//	@Summary		Deactivate a service account
+//	@Description	Deactivate a service account and remove its oidc clients. The account is kept for its audits.
+//	@Id				DeleteServiceAccount
+//	@Tags			Service Accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Service account id"
+//	@Success		204
+//	@Failure		404	{object}	models.LicenseError	"No service account with given id found"
+//	@Security		ApiKeyAuth
+//	@Router			/service-accounts/{id} [delete]
+func DeleteServiceAccount(c *gin.Context) {
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+		serviceAccount, ok := findServiceAccount(c, tx)
+		if !ok {
+			return nil
+		}
+
+		oldUser := serviceAccount.User
+		newUser := oldUser
+		active := false
+		newUser.Active = &active
+		if err := tx.Model(&newUser).Update("active", false).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "failed to delete service account",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := tx.Where(&models.OidcClient{UserId: serviceAccount.UserId}).Delete(&models.OidcClient{}).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "failed to delete service account",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := utils.AddChangelogsForUser(tx, userId, &newUser, &oldUser); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	})
+}
+
+// AddServiceAccountClient maps an oidc client to a service account.
+//
+//	@Summary		Add an oidc client to a service account
+//	@Description	Tokens of the client credentials flow with this client id act as the service account
+//	@Id				AddServiceAccountClient
+//	@Tags			Service Accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Service account id"
+//	@Param			client	body		models.ServiceAccountClient	true	"Oidc client to add"
+//	@Success		201		{object}	models.ServiceAccountResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		404		{object}	models.LicenseError	"No service account with given id found"
+//	@Failure		409		{object}	models.LicenseError	"Oidc client already exists"
+//	@Security		ApiKeyAuth
+//	@Router			/service-accounts/{id}/clients [post]
+func AddServiceAccountClient(c *gin.Context) {
+	var input models.ServiceAccountClient
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not add oidc client with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+		serviceAccount, ok := findServiceAccount(c, tx)
+		if !ok {
+			return nil
+		}
+
+		oidcClient := models.OidcClient{ClientId: input.ClientId, UserId: serviceAccount.UserId}
+		result := tx.Where(&models.OidcClient{ClientId: oidcClient.ClientId}).FirstOrCreate(&oidcClient)
+		if result.Error != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Unable to create oidc client",
+				Error:     result.Error.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			er := models.LicenseError{
+				Status:    http.StatusConflict,
+				Message:   "Unable to create oidc client",
+				Error:     "Oidc client already exists",
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusConflict, er)
+			return nil
+		}
+
+		writeServiceAccount(c, tx, serviceAccount, http.StatusCreated)
+		return nil
+	})
+}
+
+// RemoveServiceAccountClient removes an oidc client of a service account.
+//
+//	@Summary		Remove an oidc client of a service account
+//	@Description	Remove an oidc client if it gets expired or is compromised
+//	@Id				RemoveServiceAccountClient
+//	@Tags			Service Accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path	string	true	"Service account id"
+//	@Param			client_id	path	string	true	"Oidc client id"
+//	@Success		204
+//	@Failure		404	{object}	models.LicenseError	"No service account or oidc client found"
+//	@Security		ApiKeyAuth
+//	@Router			/service-accounts/{id}/clients/{client_id} [delete]
+func RemoveServiceAccountClient(c *gin.Context) {
+	serviceAccount, ok := findServiceAccount(c, db.DB)
+	if !ok {
+		return
+	}
+
+	result := db.DB.Where(&models.OidcClient{ClientId: c.Param("client_id"), UserId: serviceAccount.UserId}).Delete(&models.OidcClient{})
+	if result.Error != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Unable to delete oidc client",
+			Error:     result.Error.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	} else if result.RowsAffected == 0 {
+		er := models.LicenseError{
+			Status:    http.StatusNotFound,
+			Message:   "Unable to delete oidc client",
+			Error:     "Oidc client not found",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusNotFound, er)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetServiceAccountAudits lists the changes made by a service account.
+//
+//	@Summary		Get audits of a service account
+//	@Description	Get the audits of all changes made by a service account
+//	@Id				GetServiceAccountAudits
+//	@Tags			Service Accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Service account id"
+//	@Param			page	query		int		false	"Page number"
+//	@Param			limit	query		int		false	"Number of records per page"
+//	@Success		200		{object}	models.AuditResponse
+//	@Failure		404		{object}	models.LicenseError	"No service account with given id found"
+//	@Failure		500		{object}	models.LicenseError	"Unable to fetch audits"
+//	@Security		ApiKeyAuth
+//	@Router			/service-accounts/{id}/audits [get]
+func GetServiceAccountAudits(c *gin.Context) {
+	serviceAccount, ok := findServiceAccount(c, db.DB)
+	if !ok {
+		return
+	}
+
+	var audits []models.Audit
+	query := db.DB.Model(&models.Audit{}).Preload("User").Preload("ServiceAccount").Preload("Impersonator")
+	query.Where(models.Audit{UserId: serviceAccount.UserId})
+	pagination, err := utils.PreparePaginateResponse(c, query, &auditKeyset)
+	if err != nil {
+		return
+	}
+
+	if err := query.Find(&audits).Error; err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Unable to fetch audits",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	for i := 0; i < len(audits); i++ {
+		if err := utils.GetAuditEntity(c, &audits[i]); err != nil {
+			return
+		}
+	}
+
+	res := models.AuditResponse{
+		Data:   audits,
+		Status: http.StatusOK,
+		Meta:   pagination.Meta(audits),
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// findServiceAccount fetches the service account of the id path parameter with
+// its user and owner team. It writes the error response and returns false if
+// there is no such service account.
+func findServiceAccount(c *gin.Context, tx *gorm.DB) (*models.ServiceAccount, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   fmt.Sprintf("no service account with id '%s' exists", c.Param("id")),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return nil, false
+	}
+
+	var serviceAccount models.ServiceAccount
+	if err := tx.Preload("User").Preload("OwnerTeam").Where(&models.ServiceAccount{Id: id}).First(&serviceAccount).Error; err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			status = http.StatusNotFound
+		}
+		er := models.LicenseError{
+			Status:    status,
+			Message:   fmt.Sprintf("no service account with id '%s' exists", c.Param("id")),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(status, er)
+		return nil, false
+	}
+	return &serviceAccount, true
+}
+
+// serviceAccountDTO loads the oidc clients of a service account and converts
+// it to its api representation.
+func serviceAccountDTO(tx *gorm.DB, serviceAccount *models.ServiceAccount) (models.ServiceAccountDTO, error) {
+	var clients []models.OidcClient
+	if err := tx.Where(&models.OidcClient{UserId: serviceAccount.UserId}).Order("client_id").Find(&clients).Error; err != nil {
+		return models.ServiceAccountDTO{}, err
+	}
+	return serviceAccount.ConvertToServiceAccountDTO(clients), nil
+}
+
+// writeServiceAccount writes the response for a single service account.
+func writeServiceAccount(c *gin.Context, tx *gorm.DB, serviceAccount *models.ServiceAccount, status int) {
+	dto, err := serviceAccountDTO(tx, serviceAccount)
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Unable to fetch oidc clients",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	res := models.ServiceAccountResponse{
+		Data:   []models.ServiceAccountDTO{dto},
+		Status: status,
+		Meta: &models.PaginationMeta{
+			ResourceCount: 1,
+		},
+	}
+	c.JSON(status, res)
+}
@@ -0,0 +1,951 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+	"github.com/fossology/LicenseDb/pkg/validations"
+)
+
+// GetTeams retrieves all teams.
+//
+//	@Summary		Get teams
+//	@Description	Get all teams along with their members
+//	@Id				GetTeams
+//	@Tags			Teams
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	false	"Page number"
+//	@Param			limit	query		int	false	"Number of records per page"
+//	@Success		200		{object}	models.TeamResponse
+//	@Failure		500		{object}	models.LicenseError	"Unable to fetch teams"
+//	@Security		ApiKeyAuth
+//	@Router			/teams [get]
+func GetTeams(c *gin.Context) {
+	var teams []models.Team
+	query := db.DB.Model(&models.Team{}).Preload("Members").Order("name")
+	pagination, err := utils.PreparePaginateResponse(c, query, nil)
+	if err != nil {
+		return
+	}
+
+	if err := query.Find(&teams).Error; err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Unable to fetch teams",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	dtos := make([]models.TeamDTO, 0, len(teams))
+	for i := range teams {
+		dtos = append(dtos, teams[i].ConvertToTeamDTO())
+	}
+
+	res := models.TeamResponse{
+		Data:   dtos,
+		Status: http.StatusOK,
+		Meta:   pagination.Meta(teams),
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetTeam retrieves a team by its name.
+//
+//	@Summary		Get a team
+//	@Description	Get a team along with its members
+//	@Id				GetTeam
+//	@Tags			Teams
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string	true	"Team name"
+//	@Success		200		{object}	models.TeamResponse
+//	@Failure		404		{object}	models.LicenseError	"No team with given name found"
+//	@Security		ApiKeyAuth
+//	@Router			/teams/{name} [get]
+func GetTeam(c *gin.Context) {
+	team, ok := findTeam(c, db.DB, c.Param("name"))
+	if !ok {
+		return
+	}
+	writeTeam(c, team, http.StatusOK)
+}
+
+// CreateTeam creates a new team.
+//
+//	@Summary		Create a team
+//	@Description	Create a team which can be assigned as steward of licenses and obligations
+//	@Id				CreateTeam
+//	@Tags			Teams
+//	@Accept			json
+//	@Produce		json
+//	@Param			team	body		models.TeamCreate	true	"Team to create"
+//	@Success		201		{object}	models.TeamResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		404		{object}	models.LicenseError	"Member not found"
+//	@Failure		409		{object}	models.LicenseError	"Name already taken"
+//	@Security		ApiKeyAuth
+//	@Router			/teams [post]
+func CreateTeam(c *gin.Context) {
+	var input models.TeamCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	input.Name = strings.TrimSpace(input.Name)
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not create team with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&models.Team{}).Where(&models.Team{Name: input.Name}).Count(&existing).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to create the team",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if existing != 0 {
+			er := models.LicenseError{
+				Status:    http.StatusConflict,
+				Message:   "can not create team",
+				Error:     fmt.Sprintf("a team with name '%s' already exists", input.Name),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusConflict, er)
+			return nil
+		}
+
+		team := models.Team{
+			Name:             input.Name,
+			Description:      input.Description,
+			StewardOnlyEdits: input.StewardOnlyEdits,
+		}
+		for _, username := range input.Members {
+			member, ok := findTeamMember(c, tx, username)
+			if !ok {
+				return nil
+			}
+			team.Members = append(team.Members, *member)
+		}
+
+		if err := tx.Omit("Members.*").Create(&team).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to create the team",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := addChangelogsForTeam(tx, userId, &team, &models.Team{}); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		writeTeam(c, &team, http.StatusCreated)
+		return nil
+	})
+}
+
+// UpdateTeam updates a team.
+//
+//	@Summary		Update a team
+//	@Description	Update description or steward-only edits of a team
+//	@Id				UpdateTeam
+//	@Tags			Teams
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string				true	"Team name"
+//	@Param			team	body		models.TeamUpdate	true	"Fields to update"
+//	@Success		200		{object}	models.TeamResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		404		{object}	models.LicenseError	"No team with given name found"
+//	@Security		ApiKeyAuth
+//	@Router			/teams/{name} [patch]
+func UpdateTeam(c *gin.Context) {
+	var input models.TeamUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		oldTeam, ok := findTeam(c, tx, c.Param("name"))
+		if !ok {
+			return nil
+		}
+
+		newTeam := *oldTeam
+		if input.Description != nil {
+			newTeam.Description = input.Description
+		}
+		if input.StewardOnlyEdits != nil {
+			newTeam.StewardOnlyEdits = *input.StewardOnlyEdits
+		}
+
+		if err := tx.Model(&newTeam).Omit(clause.Associations).
+			Select("description", "steward_only_edits").Updates(&newTeam).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update the team",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := addChangelogsForTeam(tx, userId, &newTeam, oldTeam); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		writeTeam(c, &newTeam, http.StatusOK)
+		return nil
+	})
+}
+
+// DeleteTeam deletes a team.
+//
+//	@Summary		Delete a team
+//	@Description	Delete a team. Teams still stewarding licenses or obligations or owning service accounts can
+//	@Description	not be deleted, these have to be assigned to another team first. The team is kept for its audits.
+//	@Id				DeleteTeam
+//	@Tags			Teams
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path	string	true	"Team name"
+//	@Success		204
+//	@Failure		404	{object}	models.LicenseError	"No team with given name found"
+//	@Failure		409	{object}	models.LicenseError	"Team still stewards items or owns service accounts"
+//	@Security		ApiKeyAuth
+//	@Router			/teams/{name} [delete]
+func DeleteTeam(c *gin.Context) {
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		team, ok := findTeam(c, tx, c.Param("name"))
+		if !ok {
+			return nil
+		}
+
+		var licenses, obligations, serviceAccounts int64
+		if err := tx.Model(&models.LicenseDB{}).Where(&models.LicenseDB{StewardTeamId: &team.Id}).Count(&licenses).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "failed to delete team",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if err := tx.Model(&models.Obligation{}).Where(&models.Obligation{StewardTeamId: &team.Id}).Count(&obligations).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "failed to delete team",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if err := tx.Model(&models.ServiceAccount{}).Where(&models.ServiceAccount{OwnerTeamId: team.Id}).Count(&serviceAccounts).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "failed to delete team",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if serviceAccounts != 0 {
+			er := models.LicenseError{
+				Status:    http.StatusConflict,
+				Message:   "can not delete team",
+				Error:     fmt.Sprintf("team '%s' still owns %d service accounts", team.Name, serviceAccounts),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusConflict, er)
+			return nil
+		}
+		if licenses != 0 || obligations != 0 {
+			er := models.LicenseError{
+				Status:    http.StatusConflict,
+				Message:   "can not delete team",
+				Error:     fmt.Sprintf("team '%s' still stewards %d licenses and %d obligations", team.Name, licenses, obligations),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusConflict, er)
+			return nil
+		}
+
+		if err := tx.Model(team).Association("Members").Clear(); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "failed to delete team",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+		if err := tx.Delete(team).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "failed to delete team",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := addChangelogsForTeam(tx, userId, &models.Team{Id: team.Id}, team); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	})
+}
+
+// AddTeamMember adds a user to a team.
+//
+//	@Summary		Add a team member
+//	@Description	Add an active user to a team
+//	@Id				AddTeamMember
+//	@Tags			Teams
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string					true	"Team name"
+//	@Param			member	body		models.TeamMemberInput	true	"User to add"
+//	@Success		200		{object}	models.TeamResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		404		{object}	models.LicenseError	"No team or user found"
+//	@Security		ApiKeyAuth
+//	@Router			/teams/{name}/members [post]
+func AddTeamMember(c *gin.Context) {
+	var input models.TeamMemberInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not add team member with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		oldTeam, ok := findTeam(c, tx, c.Param("name"))
+		if !ok {
+			return nil
+		}
+		member, ok := findTeamMember(c, tx, input.Username)
+		if !ok {
+			return nil
+		}
+
+		newTeam := *oldTeam
+		newTeam.Members = slices.Clone(oldTeam.Members)
+		if !slices.ContainsFunc(newTeam.Members, func(u models.User) bool { return u.Id == member.Id }) {
+			if err := tx.Model(&newTeam).Association("Members").Append(member); err != nil {
+				er := models.LicenseError{
+					Status:    http.StatusInternalServerError,
+					Message:   "Failed to add team member",
+					Error:     err.Error(),
+					Path:      c.Request.URL.Path,
+					Timestamp: time.Now().Format(time.RFC3339),
+				}
+				c.JSON(http.StatusInternalServerError, er)
+				return err
+			}
+		}
+
+		if err := addChangelogsForTeam(tx, userId, &newTeam, oldTeam); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		writeTeam(c, &newTeam, http.StatusOK)
+		return nil
+	})
+}
+
+// RemoveTeamMember removes a user from a team.
+//
+//	@Summary		Remove a team member
+//	@Description	Remove a user from a team
+//	@Id				RemoveTeamMember
+//	@Tags			Teams
+//	@Accept			json
+//	@Produce		json
+//	@Param			name		path		string	true	"Team name"
+//	@Param			username	path		string	true	"Username of the member"
+//	@Success		200			{object}	models.TeamResponse
+//	@Failure		404			{object}	models.LicenseError	"No team or member found"
+//	@Security		ApiKeyAuth
+//	@Router			/teams/{name}/members/{username} [delete]
+func RemoveTeamMember(c *gin.Context) {
+	userId := c.MustGet("userId").(uuid.UUID)
+	username := c.Param("username")
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		oldTeam, ok := findTeam(c, tx, c.Param("name"))
+		if !ok {
+			return nil
+		}
+
+		index := slices.IndexFunc(oldTeam.Members, func(u models.User) bool {
+			return u.UserName != nil && *u.UserName == username
+		})
+		if index == -1 {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   "team member not found",
+				Error:     fmt.Sprintf("user '%s' is no member of team '%s'", username, oldTeam.Name),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return nil
+		}
+
+		newTeam := *oldTeam
+		newTeam.Members = slices.Delete(slices.Clone(oldTeam.Members), index, index+1)
+		if err := tx.Model(&newTeam).Association("Members").Delete(&oldTeam.Members[index]); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to remove team member",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		if err := addChangelogsForTeam(tx, userId, &newTeam, oldTeam); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update changelogs",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		writeTeam(c, &newTeam, http.StatusOK)
+		return nil
+	})
+}
+
+// SetLicenseSteward assigns the steward team of a license.
+//
+//	@Summary		Assign the steward team of a license
+//	@Description	Assign the team stewarding a license, or remove it with a null team
+//	@Id				SetLicenseSteward
+//	@Tags			Licenses
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Id of the license"
+//	@Param			steward	body		models.StewardAssignment	true	"Steward team"
+//	@Success		200		{object}	models.LicenseResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		404		{object}	models.LicenseError	"No license or team found"
+//	@Security		ApiKeyAuth
+//	@Router			/licenses/{id}/steward [put]
+func SetLicenseSteward(c *gin.Context) {
+	var input models.StewardAssignment
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	licenseId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   fmt.Sprintf("no license with id '%s' exists", c.Param("id")),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var oldLicense models.LicenseDB
+		if err := tx.Preload("User").Preload("Obligations").Where(models.LicenseDB{Id: licenseId}).First(&oldLicense).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   fmt.Sprintf("license with id '%s' not found", licenseId.String()),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return nil
+		}
+
+		stewardTeamId, ok := findStewardTeamId(c, tx, input.Team)
+		if !ok {
+			return nil
+		}
+
+		if err := tx.Model(&models.LicenseDB{}).Where(models.LicenseDB{Id: licenseId}).
+			UpdateColumn("steward_team_id", stewardTeamId).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update license",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		newLicense := oldLicense
+		newLicense.StewardTeamId = stewardTeamId
+		if err := utils.AddChangelogsForLicense(tx, userId, &newLicense, &oldLicense); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update license",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		res := models.LicenseResponse{
+			Data:   []models.LicenseResponseDTO{newLicense.ConvertToLicenseResponseDTO()},
+			Status: http.StatusOK,
+			Meta: &models.PaginationMeta{
+				ResourceCount: 1,
+			},
+		}
+		c.JSON(http.StatusOK, res)
+		return nil
+	})
+}
+
+// SetObligationSteward assigns the steward team of an obligation.
+//
+//	@Summary		Assign the steward team of an obligation
+//	@Description	Assign the team stewarding an obligation, or remove it with a null team
+//	@Id				SetObligationSteward
+//	@Tags			Obligations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Id of the obligation"
+//	@Param			steward	body		models.StewardAssignment	true	"Steward team"
+//	@Success		200		{object}	models.ObligationResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid request body"
+//	@Failure		404		{object}	models.LicenseError	"No obligation or team found"
+//	@Security		ApiKeyAuth
+//	@Router			/obligations/{id}/steward [put]
+func SetObligationSteward(c *gin.Context) {
+	var input models.StewardAssignment
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	obligationId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   fmt.Sprintf("no obligation with id '%s' exists", c.Param("id")),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	_ = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var oldObligation models.Obligation
+		if err := tx.Joins("Type").Joins("Classification").Joins("Category").Preload("Licenses").
+			Where(models.Obligation{Id: obligationId}).First(&oldObligation).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   fmt.Sprintf("obligation with id '%s' not found", obligationId.String()),
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return nil
+		}
+
+		stewardTeamId, ok := findStewardTeamId(c, tx, input.Team)
+		if !ok {
+			return nil
+		}
+
+		if err := tx.Model(&models.Obligation{}).Where(models.Obligation{Id: obligationId}).
+			UpdateColumn("steward_team_id", stewardTeamId).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update obligation",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		newObligation := oldObligation
+		newObligation.StewardTeamId = stewardTeamId
+		if err := addChangelogsForObligation(tx, userId, &newObligation, &oldObligation); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update obligation",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		res := models.ObligationResponse{
+			Data:   []models.ObligationResponseDTO{newObligation.ConvertToObligationResponseDTO()},
+			Status: http.StatusOK,
+			Meta: models.PaginationMeta{
+				ResourceCount: 1,
+			},
+		}
+		c.JSON(http.StatusOK, res)
+		return nil
+	})
+}
+
+// GetStewardedItems lists the items stewarded by the teams of the user.
+//
+//	@Summary		Get my stewarded items
+//	@Description	Get the licenses and obligations stewarded by the teams the user is a member of
+//	@Id				GetStewardedItems
+//	@Tags			Teams
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.StewardedItemsResponse
+//	@Failure		500	{object}	models.LicenseError	"Unable to fetch stewarded items"
+//	@Security		ApiKeyAuth
+//	@Router			/users/profile/stewarded [get]
+func GetStewardedItems(c *gin.Context) {
+	userId := c.MustGet("userId").(uuid.UUID)
+	memberTeams := db.DB.Table("team_members").Select("team_id").Where("user_id = ?", userId)
+
+	items := models.StewardedItems{
+		Licenses:    []models.StewardedLicense{},
+		Obligations: []models.StewardedObligation{},
+	}
+	err := db.DB.Model(&models.LicenseDB{}).
+		Select("license_dbs.rf_id AS id, license_dbs.rf_shortname AS shortname, teams.name AS team").
+		Joins("JOIN teams ON teams.id = license_dbs.steward_team_id AND teams.deleted_at IS NULL").
+		Where("license_dbs.steward_team_id IN (?)", memberTeams).
+		Order("license_dbs.rf_shortname").Scan(&items.Licenses).Error
+	if err == nil {
+		err = db.DB.Model(&models.Obligation{}).
+			Select("obligations.id AS id, obligations.topic AS topic, teams.name AS team").
+			Joins("JOIN teams ON teams.id = obligations.steward_team_id AND teams.deleted_at IS NULL").
+			Where("obligations.steward_team_id IN (?)", memberTeams).
+			Order("obligations.topic").Scan(&items.Obligations).Error
+	}
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Unable to fetch stewarded items",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.StewardedItemsResponse{
+		Status: http.StatusOK,
+		Data:   items,
+	})
+}
+
+// findTeam fetches the team with the given name along with its members. It
+// writes the error response and returns false if there is no such team.
+func findTeam(c *gin.Context, tx *gorm.DB, name string) (*models.Team, bool) {
+	var team models.Team
+	if err := tx.Preload("Members").Where(&models.Team{Name: name}).First(&team).Error; err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			status = http.StatusNotFound
+		}
+		er := models.LicenseError{
+			Status:    status,
+			Message:   fmt.Sprintf("no team with name '%s' exists", name),
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(status, er)
+		return nil, false
+	}
+	return &team, true
+}
+
+// findTeamMember fetches the active user to add to a team. It writes the
+// error response and returns false if there is no such user.
+func findTeamMember(c *gin.Context, tx *gorm.DB, username string) (*models.User, bool) {
+	active := true
+	var member models.User
+	if err := tx.Where(models.User{UserName: &username, Active: &active}).First(&member).Error; err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusNotFound,
+			Message:   "team member not found",
+			Error:     fmt.Sprintf("no active user with username '%s' exists", username),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusNotFound, er)
+		return nil, false
+	}
+	return &member, true
+}
+
+// findStewardTeamId resolves the team of a steward assignment. A nil name
+// resolves to no team. It writes the error response and returns false if
+// there is no such team.
+func findStewardTeamId(c *gin.Context, tx *gorm.DB, name *string) (*uuid.UUID, bool) {
+	if name == nil {
+		return nil, true
+	}
+	team, ok := findTeam(c, tx, *name)
+	if !ok {
+		return nil, false
+	}
+	return &team.Id, true
+}
+
+// stewardTeamDenyingEdit returns the steward team if it only allows its
+// members to edit its items and the user is none of them. Super admins can
+// always edit.
+func stewardTeamDenyingEdit(c *gin.Context, tx *gorm.DB, stewardTeamId *uuid.UUID) (*models.Team, error) {
+	if stewardTeamId == nil || c.GetString("role") == "SUPER_ADMIN" {
+		return nil, nil
+	}
+	var team models.Team
+	if err := tx.Where(&models.Team{Id: *stewardTeamId}).First(&team).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !team.StewardOnlyEdits {
+		return nil, nil
+	}
+	var members int64
+	if err := tx.Table("team_members").Where("team_id = ? AND user_id = ?", team.Id, c.MustGet("userId").(uuid.UUID)).
+		Count(&members).Error; err != nil {
+		return nil, err
+	}
+	if members != 0 {
+		return nil, nil
+	}
+	return &team, nil
+}
+
+// writeTeam writes the response for a single team.
+func writeTeam(c *gin.Context, team *models.Team, status int) {
+	res := models.TeamResponse{
+		Data:   []models.TeamDTO{team.ConvertToTeamDTO()},
+		Status: status,
+		Meta: &models.PaginationMeta{
+			ResourceCount: 1,
+		},
+	}
+	c.JSON(status, res)
+}
+
+// addChangelogsForTeam adds changelogs for the updated fields and members of a
+// team.
+func addChangelogsForTeam(tx *gorm.DB, userId uuid.UUID, newTeam, oldTeam *models.Team) error {
+	membersToStr := func(members []models.User) string {
+		s := make([]string, 0, len(members))
+		for _, member := range members {
+			if member.UserName != nil {
+				s = append(s, *member.UserName)
+			}
+		}
+		slices.Sort(s)
+		return strings.Join(s, ", ")
+	}
+	var changes []models.ChangeLog
+
+	var oldName, newName *string
+	if oldTeam.Name != "" {
+		oldName = &oldTeam.Name
+	}
+	if newTeam.Name != "" {
+		newName = &newTeam.Name
+	}
+	utils.AddChangelog("Name", oldName, newName, &changes)
+	utils.AddChangelog("Description", oldTeam.Description, newTeam.Description, &changes)
+	utils.AddChangelog("Steward Only Edits", &oldTeam.StewardOnlyEdits, &newTeam.StewardOnlyEdits, &changes)
+
+	oldMembers := membersToStr(oldTeam.Members)
+	newMembers := membersToStr(newTeam.Members)
+	utils.AddChangelog("Members", &oldMembers, &newMembers, &changes)
+
+	if len(changes) != 0 {
+		audit := models.Audit{
+			UserId:     userId,
+			TypeId:     newTeam.Id,
+			Timestamp:  time.Now(),
+			Type:       "TEAM",
+			ChangeLogs: changes,
+		}
+
+		if err := tx.Create(&audit).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+// Package archive walks the files of uploaded archives.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	formatZip      = "zip"
+	formatTar      = "tar"
+	formatTarGzip  = "tar.gz"
+	formatTarBzip2 = "tar.bz2"
+	formatGzip     = "gz"
+)
+
+// formats are the archive formats by file name suffix.
+var formats = map[string]string{
+	".zip":     formatZip,
+	".jar":     formatZip,
+	".war":     formatZip,
+	".ear":     formatZip,
+	".whl":     formatZip,
+	".nupkg":   formatZip,
+	".tar":     formatTar,
+	".tar.gz":  formatTarGzip,
+	".tgz":     formatTarGzip,
+	".crate":   formatTarGzip,
+	".tar.bz2": formatTarBzip2,
+	".tbz2":    formatTarBzip2,
+	".tbz":     formatTarBzip2,
+	".gz":      formatGzip,
+}
+
+var (
+	// ErrTooManyFiles is returned when an archive has more files than allowed.
+	ErrTooManyFiles = errors.New("archive has too many files")
+	// ErrTooLarge is returned when more bytes than allowed are extracted from
+	// an archive.
+	ErrTooLarge = errors.New("archive is too large when extracted")
+)
+
+// WalkFunc is called with the path and the contents of each regular file of
+// an archive.
+type WalkFunc func(name string, contents io.Reader) error
+
+// Walk calls fn for the regular files of the archive at path, detecting its
+// format from its name. Zip, tar, gzip and bzip2 compressed tar and gzip
+// files are extracted, any other file is passed to fn as it is. Archives
+// nested in the archive are not extracted. At most maxBytes are extracted in
+// total, including the parts of tar streams skipped by fn.
+func Walk(filePath, name string, maxFiles int, maxBytes int64, fn WalkFunc) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	limit := &byteLimit{remaining: maxBytes}
+	switch format(name) {
+	case formatZip:
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		return walkZip(file, info.Size(), maxFiles, limit, fn)
+	case formatTar:
+		return walkTar(limit.reader(file), maxFiles, fn)
+	case formatTarGzip:
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("invalid gzip file: %w", err)
+		}
+		return walkTar(limit.reader(gz), maxFiles, fn)
+	case formatTarBzip2:
+		return walkTar(limit.reader(bzip2.NewReader(file)), maxFiles, fn)
+	case formatGzip:
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("invalid gzip file: %w", err)
+		}
+		return fn(strings.TrimSuffix(path.Base(name), path.Ext(name)), limit.reader(gz))
+	default:
+		return fn(path.Base(name), limit.reader(file))
+	}
+}
+
+// IsArchive reports whether Walk extracts the files of a file with the given
+// name.
+func IsArchive(name string) bool {
+	return format(name) != ""
+}
+
+func walkZip(file io.ReaderAt, size int64, maxFiles int, limit *byteLimit, fn WalkFunc) error {
+	reader, err := zip.NewReader(file, size)
+	if err != nil {
+		return fmt.Errorf("invalid zip file: %w", err)
+	}
+	files := 0
+	for _, entry := range reader.File {
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		if files++; files > maxFiles {
+			return ErrTooManyFiles
+		}
+		contents, err := entry.Open()
+		if err != nil {
+			return fmt.Errorf("invalid zip file: %w", err)
+		}
+		err = fn(entry.Name, limit.reader(contents))
+		_ = contents.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkTar(file io.Reader, maxFiles int, fn WalkFunc) error {
+	reader := tar.NewReader(file)
+	files := 0
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if errors.Is(err, ErrTooLarge) {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("invalid tar file: %w", err)
+		}
+		if !header.FileInfo().Mode().IsRegular() {
+			continue
+		}
+		if files++; files > maxFiles {
+			return ErrTooManyFiles
+		}
+		if err := fn(strings.TrimPrefix(header.Name, "./"), reader); err != nil {
+			return err
+		}
+	}
+}
+
+// byteLimit is the number of bytes which may still be extracted from an
+// archive. It is shared by the readers of all its files.
+type byteLimit struct {
+	remaining int64
+}
+
+func (l *byteLimit) reader(r io.Reader) io.Reader {
+	return &limitedReader{reader: r, limit: l}
+}
+
+// limitedReader fails with ErrTooLarge once more bytes are read than its
+// limit allows.
+type limitedReader struct {
+	reader io.Reader
+	limit  *byteLimit
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	// One byte more than allowed is read to tell files ending right at the
+	// limit from larger ones
+	if int64(len(p)) > r.limit.remaining+1 {
+		p = p[:r.limit.remaining+1]
+	}
+	n, err := r.reader.Read(p)
+	r.limit.remaining -= int64(n)
+	if r.limit.remaining < 0 {
+		return n, ErrTooLarge
+	}
+	return n, err
+}
+
+// format detects the format of an archive from its name.
+func format(name string) string {
+	name = strings.ToLower(name)
+	// Longer suffixes take precedence, .tar.gz files are not plain gzip files
+	for _, suffix := range []string{".tar.gz", ".tar.bz2", ".tgz", ".tbz2", ".tbz", ".tar", ".gz"} {
+		if strings.HasSuffix(name, suffix) {
+			return formats[suffix]
+		}
+	}
+	return formats[path.Ext(name)]
+}
@@ -8,7 +8,6 @@
 package auth
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"html"
@@ -21,9 +20,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
-	"github.com/lestrrat-go/jwx/v3/jwa"
 	"github.com/lestrrat-go/jwx/v3/jwk"
-	"github.com/lestrrat-go/jwx/v3/jws"
 	"github.com/lestrrat-go/jwx/v3/jwt"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
@@ -163,23 +160,10 @@ func CreateUser(c *gin.Context) {
 //	@Produce		json
 //	@Success		201	{object}	models.UserResponse
 //	@Failure		400	{object}	models.LicenseError	"Invalid json body"
+//	@Failure		403	{object}	models.LicenseError	"No user level granted by the identity provider"
 //	@Failure		409	{object}	models.LicenseError	"User already exists"
 //	@Router			/users/oidc [post]
 func CreateOidcUser(c *gin.Context) {
-	if os.Getenv("OIDC_ISSUER") == "" || os.Getenv("OIDC_EMAIL_KEY") == "" || os.Getenv("OIDC_DISPLAYNAME_KEY") == "" ||
-		os.Getenv("OIDC_USERNAME_KEY") == "" || Jwks == nil {
-		er := models.LicenseError{
-			Status:    http.StatusInternalServerError,
-			Message:   "Something went wrong, try again",
-			Error:     "internal server error",
-			Path:      c.Request.URL.Path,
-			Timestamp: time.Now().Format(time.RFC3339),
-		}
-		c.JSON(http.StatusInternalServerError, er)
-		log.Print("\033[31mError: OIDC environment variables not configured properly\033[0m")
-		return
-	}
-
 	authHeader := c.GetHeader("Authorization")
 
 	if authHeader == "" {
@@ -211,42 +195,12 @@ func CreateOidcUser(c *gin.Context) {
 
 	tokenString := parts[1]
 
-	keyset, err := Jwks.Lookup(context.Background(), os.Getenv("JWKS_URI"))
+	unverifiedToken, err := jwt.Parse([]byte(tokenString), jwt.WithValidate(true), jwt.WithVerify(false))
 	if err != nil {
-		log.Print("\033[31mError: Failed jwk.Cache lookup from the oidc provider's URL\033[0m")
-		er := models.LicenseError{
-			Status:    http.StatusInternalServerError,
-			Message:   "Something went wrong",
-			Error:     "internal server error",
-			Path:      c.Request.URL.Path,
-			Timestamp: time.Now().Format(time.RFC3339),
-		}
-		c.JSON(http.StatusInternalServerError, er)
-		return
-	}
-
-	keyOptions := jws.WithKeySet(keyset)
-	keyError := true
-	if kid, err := utils.GetKid(tokenString); err == nil {
-		if key, ok := keyset.LookupKeyID(kid); ok {
-			if os.Getenv("OIDC_SIGNING_ALG") != "" {
-				if alg, ok := jwa.LookupSignatureAlgorithm(os.Getenv("OIDC_SIGNING_ALG")); ok {
-					if err = key.Set("alg", alg); err == nil {
-						keyError = false
-					}
-				}
-			} else if _, ok := key.Algorithm(); ok {
-				keyError = false
-			}
-		}
-	}
-
-	if keyError {
-		log.Printf("\033[31mError: Token verification failed due to invalid alg header key field \033[0m")
 		er := models.LicenseError{
 			Status:    http.StatusUnauthorized,
 			Message:   "Please check your credentials and try again",
-			Error:     "token verification failed",
+			Error:     "token parsing failed",
 			Path:      c.Request.URL.Path,
 			Timestamp: time.Now().Format(time.RFC3339),
 		}
@@ -254,56 +208,74 @@ func CreateOidcUser(c *gin.Context) {
 		return
 	}
 
-	if _, err = jws.Verify([]byte(tokenString), keyOptions); err != nil {
+	iss, _ := unverifiedToken.Issuer()
+	issuer, ok := LookupOidcIssuer(iss)
+	if !ok {
 		er := models.LicenseError{
 			Status:    http.StatusUnauthorized,
 			Message:   "Please check your credentials and try again",
-			Error:     "token verification failed",
+			Error:     "issuer not supported",
 			Path:      c.Request.URL.Path,
 			Timestamp: time.Now().Format(time.RFC3339),
 		}
 		c.JSON(http.StatusUnauthorized, er)
-		log.Printf("\033[31mError: Token verification failed \033[0m")
+		log.Printf("\033[31mError: Issuer '%s' not supported\033[0m", iss)
 		return
 	}
 
-	parsedToken, err := jwt.Parse([]byte(tokenString), jwt.WithValidate(true), jwt.WithVerify(false))
-	if err != nil {
+	if issuer.EmailClaim == "" || issuer.DisplayNameClaim == "" {
 		er := models.LicenseError{
-			Status:    http.StatusUnauthorized,
-			Message:   "Please check your credentials and try again",
-			Error:     "token parsing failed",
+			Status:    http.StatusInternalServerError,
+			Message:   "Something went wrong, try again",
+			Error:     "internal server error",
 			Path:      c.Request.URL.Path,
 			Timestamp: time.Now().Format(time.RFC3339),
 		}
-		c.JSON(http.StatusUnauthorized, er)
+		c.JSON(http.StatusInternalServerError, er)
+		log.Printf("\033[31mError: Email and display name claims of issuer '%s' not configured\033[0m", iss)
 		return
 	}
 
-	iss, _ := parsedToken.Issuer()
-	if iss != os.Getenv("OIDC_ISSUER") {
+	parsedToken, err := issuer.Verify(tokenString)
+	if err != nil {
+		if errors.Is(err, ErrOidcProviderUnavailable) {
+			log.Print("\033[31mError: Failed jwk.Cache lookup from the oidc provider's URL\033[0m")
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Something went wrong",
+				Error:     "internal server error",
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return
+		}
 		er := models.LicenseError{
 			Status:    http.StatusUnauthorized,
 			Message:   "Please check your credentials and try again",
-			Error:     "internal server error",
+			Error:     "token verification failed",
 			Path:      c.Request.URL.Path,
 			Timestamp: time.Now().Format(time.RFC3339),
 		}
 		c.JSON(http.StatusUnauthorized, er)
-		log.Printf("\033[31mError: Issuer '%s' not supported\033[0m", iss)
+		log.Printf("\033[31mError: Token verification failed: %s\033[0m", err.Error())
 		return
 	}
 
 	var email, username, displayname, errMessage string
-	if err = parsedToken.Get(os.Getenv("OIDC_EMAIL_KEY"), &email); err != nil {
+	if err = parsedToken.Get(issuer.EmailClaim, &email); err != nil {
 		errMessage = err.Error()
 	}
-	if err = parsedToken.Get(os.Getenv("OIDC_USERNAME_KEY"), &username); err != nil {
+	if err = parsedToken.Get(issuer.UsernameClaim, &username); err != nil {
 		errMessage = err.Error()
 	}
-	if err = parsedToken.Get(os.Getenv("OIDC_DISPLAYNAME_KEY"), &displayname); err != nil {
+	if err = parsedToken.Get(issuer.DisplayNameClaim, &displayname); err != nil {
 		errMessage = err.Error()
 	}
+	subject, ok := parsedToken.Subject()
+	if !ok || subject == "" {
+		errMessage = "token has no sub claim"
+	}
 	if errMessage != "" {
 		er := models.LicenseError{
 			Status:    http.StatusUnauthorized,
@@ -316,22 +288,53 @@ func CreateOidcUser(c *gin.Context) {
 		log.Printf("\033[31mError: %s\033[0m", errMessage)
 		return
 	}
-	level := "USER"
+	level, err := issuer.UserLevel(parsedToken)
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusForbidden,
+			Message:   "Failed to create user",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusForbidden, er)
+		return
+	}
 
 	user := models.User{
-		UserName:    &username,
-		UserEmail:   &email,
-		UserLevel:   &level,
-		DisplayName: &displayname,
+		UserName:        &username,
+		UserEmail:       &email,
+		UserLevel:       &level,
+		DisplayName:     &displayname,
+		ExternalIssuer:  &issuer.Issuer,
+		ExternalSubject: &subject,
 	}
 
-	result := db.DB.
-		Where(&models.User{UserName: user.UserName}).
-		FirstOrCreate(&user)
+	// Users are bound to the identity of the token, existing accounts with the
+	// same username are never taken over, unless they were created by an oidc
+	// token before users were bound to their identity
+	identity := &models.User{ExternalIssuer: user.ExternalIssuer, ExternalSubject: user.ExternalSubject}
+	err = db.DB.Where(identity).First(&models.User{}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = issuer.bindLegacyUser(db.DB, parsedToken, subject, &models.User{})
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to create the new user",
+			Error:     "Something went wrong. Try again.",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	result := db.DB.Where(identity).FirstOrCreate(&user)
 	if result.Error != nil {
 		errMessage := "Something went wrong. Try again."
 		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
-			errMessage = "User with same display name or email exists"
+			errMessage = "User with same username or email exists"
 			er := models.LicenseError{
 				Status:    http.StatusConflict,
 				Message:   "Failed to create user",
@@ -393,6 +396,7 @@ func CreateOidcUser(c *gin.Context) {
 //	@Success		200			{object}	models.UserResponse
 //	@Failure		400			{object}	models.LicenseError	"Invalid json body"
 //	@Failure		403			{object}	models.LicenseError	"This resource requires elevated access rights"
+//	@Failure		409			{object}	models.LicenseError	"User is anonymised"
 //	@Security		ApiKeyAuth
 //	@Router			/users/{username} [patch]
 func UpdateUser(c *gin.Context) {
@@ -413,6 +417,17 @@ func UpdateUser(c *gin.Context) {
 			c.JSON(http.StatusNotFound, er)
 			return nil
 		}
+		if olduser.AnonymisedAt != nil {
+			er := models.LicenseError{
+				Status:    http.StatusConflict,
+				Message:   "anonymised users can not be updated",
+				Error:     "user is anonymised",
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusConflict, er)
+			return nil
+		}
 
 		// var input models.UserUpdate
 		if err := c.ShouldBindJSON(&updates); err != nil {
@@ -711,6 +726,7 @@ func DeleteUser(c *gin.Context) {
 //	@Param			active	query		bool	false	"Active user only"
 //	@Param			page	query		int		false	"Page number"
 //	@Param			limit	query		int		false	"Number of records per page"
+//	@Param			cursor	query		string	false	"Cursor of the page, empty for the first page, to paginate by cursor instead of page number"
 //	@Success		200		{object}	models.UserResponse
 //	@Failure		404		{object}	models.LicenseError	"Users not found"
 //	@Security		ApiKeyAuth
@@ -722,9 +738,12 @@ func GetAllUser(c *gin.Context) {
 	}
 
 	var users []models.User
-	query := db.DB.Model(&models.User{})
-	_ = utils.PreparePaginateResponse(c, query, &models.UserResponse{})
-	if err := query.Where(&models.User{Active: &active}).Find(&users).Error; err != nil {
+	query := db.DB.Model(&models.User{}).Where(&models.User{Active: &active})
+	pagination, err := utils.PreparePaginateResponse(c, query, &utils.Keyset{Column: "user_name", IdColumn: "id"})
+	if err != nil {
+		return
+	}
+	if err := query.Find(&users).Error; err != nil {
 		er := models.LicenseError{
 			Status:    http.StatusNotFound,
 			Message:   "Users not found",
@@ -739,9 +758,7 @@ func GetAllUser(c *gin.Context) {
 	res := models.UserResponse{
 		Data:   users,
 		Status: http.StatusOK,
-		Meta: &models.PaginationMeta{
-			ResourceCount: len(users),
-		},
+		Meta:   pagination.Meta(users),
 	}
 
 	c.JSON(http.StatusOK, res)
@@ -792,14 +809,18 @@ func GetUser(c *gin.Context) {
 // Login user and get JWT tokens
 //
 //	@Summary		Login
-//	@Description	Login to get JWT token
+//	@Description	Login to get JWT token. If the account has two-factor authentication enabled, or its user level
+//	@Description	requires it, an mfa token is returned instead which has to be completed at /login/2fa.
+//	@Description	If LDAP login is enabled, users without a local password log in with their directory credentials.
 //	@Id				Login
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
-//	@Param			user	body		models.UserLogin		true	"Login credentials"
-//	@Success		200		{object}	models.TokenResponse	"JWT token"
-//	@Failure		401		{object}	models.LicenseError		"Incorrect username or password"
+//	@Param			user	body		models.UserLogin					true	"Login credentials"
+//	@Success		200		{object}	models.TokenResponse				"JWT token"
+//	@Success		202		{object}	models.TwoFactorChallengeResponse	"Second factor required"
+//	@Failure		401		{object}	models.LicenseError					"Incorrect username or password"
+//	@Failure		500		{object}	models.LicenseError					"Failed to authenticate with the ldap directory"
 //	@Router			/login [post]
 func Login(c *gin.Context) {
 	var input models.UserLogin
@@ -820,58 +841,106 @@ func Login(c *gin.Context) {
 	active := true
 	var user models.User
 	result := db.DB.Where(models.User{UserName: &username, Active: &active}).First(&user)
-	if result.Error != nil {
-		er := models.LicenseError{
-			Status:    http.StatusUnauthorized,
-			Message:   "Incorrect username or password",
-			Error:     "Incorrect username or password",
-			Path:      c.Request.URL.Path,
-			Timestamp: time.Now().Format(time.RFC3339),
+	if ldapDirectory != nil && (result.Error != nil || user.UserPassword == nil) {
+		// Users without a local password log in with the directory
+		ldapUser, err := ldapDirectory.Authenticate(db.DB, username, password)
+		if err != nil {
+			status, message := http.StatusInternalServerError, "Failed to authenticate with the ldap directory"
+			if errors.Is(err, ErrLdapInvalidCredentials) {
+				status, message = http.StatusUnauthorized, "Incorrect username or password"
+			} else if errors.Is(err, ErrLdapNoUserLevel) || errors.Is(err, ErrLdapUserDeactivated) || errors.Is(err, ErrLdapUserExists) {
+				status, message = http.StatusUnauthorized, err.Error()
+			} else {
+				logger.LogError("ldap login failed", zap.String("username", username), zap.Error(err))
+			}
+			er := models.LicenseError{
+				Status:    status,
+				Message:   message,
+				Error:     message,
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(status, er)
+			return
 		}
+		user = *ldapUser
+	} else {
+		if result.Error != nil {
+			er := models.LicenseError{
+				Status:    http.StatusUnauthorized,
+				Message:   "Incorrect username or password",
+				Error:     "Incorrect username or password",
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
 
-		c.JSON(http.StatusUnauthorized, er)
-		return
-	}
+			c.JSON(http.StatusUnauthorized, er)
+			return
+		}
 
-	if user.UserPassword == nil {
-		er := models.LicenseError{
-			Status:    http.StatusUnauthorized,
-			Message:   "Incorrect username or password",
-			Error:     "Incorrect username or password",
-			Path:      c.Request.URL.Path,
-			Timestamp: time.Now().Format(time.RFC3339),
+		if user.UserPassword == nil {
+			er := models.LicenseError{
+				Status:    http.StatusUnauthorized,
+				Message:   "Incorrect username or password",
+				Error:     "Incorrect username or password",
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+
+			c.JSON(http.StatusUnauthorized, er)
+			return
 		}
 
-		c.JSON(http.StatusUnauthorized, er)
-		return
+		err := EncryptUserPassword(&user)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to encrypt user password",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+
+			c.JSON(http.StatusInternalServerError, er)
+			return
+		}
+
+		// Check if the password matches
+		err = utils.VerifyPassword(password, *user.UserPassword)
+		if err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusUnauthorized,
+				Message:   "Incorrect username or password",
+				Error:     "Incorrect username or password",
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+
+			c.JSON(http.StatusUnauthorized, er)
+			return
+		}
 	}
 
-	err := EncryptUserPassword(&user)
+	// Users with a second factor, or whose level requires one, only get an
+	// mfa token here which is exchanged for the JWT tokens at /login/2fa.
+	if user.TotpEnabled != nil && *user.TotpEnabled {
+		issueTwoFactorChallenge(c, user, mfaTokenTypeVerify)
+		return
+	}
+	required, err := isTwoFactorRequired(db.DB, user.UserLevel)
 	if err != nil {
 		er := models.LicenseError{
 			Status:    http.StatusInternalServerError,
-			Message:   "Failed to encrypt user password",
+			Message:   "Failed to fetch two-factor policy",
 			Error:     err.Error(),
 			Path:      c.Request.URL.Path,
 			Timestamp: time.Now().Format(time.RFC3339),
 		}
-
 		c.JSON(http.StatusInternalServerError, er)
 		return
 	}
-
-	// Check if the password matches
-	err = utils.VerifyPassword(password, *user.UserPassword)
-	if err != nil {
-		er := models.LicenseError{
-			Status:    http.StatusUnauthorized,
-			Message:   "Incorrect username or password",
-			Error:     "Incorrect username or password",
-			Path:      c.Request.URL.Path,
-			Timestamp: time.Now().Format(time.RFC3339),
-		}
-
-		c.JSON(http.StatusUnauthorized, er)
+	if required {
+		issueTwoFactorChallenge(c, user, mfaTokenTypeEnroll)
 		return
 	}
 
@@ -984,10 +1053,7 @@ func VerifyRefreshToken(c *gin.Context) {
 		return
 	}
 
-	if _, err := jws.Verify(
-		[]byte(refreshToken),
-		jws.WithKey(jwa.HS256(), []byte(os.Getenv("REFRESH_TOKEN_SECRET"))),
-	); err != nil {
+	if err := verifyInternalToken(refreshToken, os.Getenv("REFRESH_TOKEN_SECRET")); err != nil {
 		logger.LogError("Token signature verification failed", zap.Error(err))
 		unauthorized(c, "token verification failed")
 		return
@@ -1075,8 +1141,6 @@ func generateToken(user models.User) (*models.Tokens, error) {
 	}
 
 	issuer := os.Getenv("DEFAULT_ISSUER")
-	accessSecret := []byte(os.Getenv("API_SECRET"))
-	refreshSecret := []byte(os.Getenv("REFRESH_TOKEN_SECRET"))
 	now := time.Now().UTC()
 	safeClaim := models.UserClaim{
 		Id:          user.Id,
@@ -1093,6 +1157,8 @@ func generateToken(user models.User) (*models.Tokens, error) {
 		IssuedAt(now).
 		NotBefore(now).
 		Expiration(AccessTokenExpiresAt).
+		Subject(user.Id.String()).
+		Audience([]string{issuer}).
 		Claim("user", safeClaim).
 		Build()
 	if err != nil {
@@ -1100,7 +1166,7 @@ func generateToken(user models.User) (*models.Tokens, error) {
 		return nil, fmt.Errorf("failed to build access token: %w", err)
 	}
 
-	signedAccessToken, err := jwt.Sign(accessToken, jwt.WithKey(jwa.HS256(), accessSecret))
+	signedAccessToken, err := signAccessToken(accessToken)
 	if err != nil {
 		logger.LogError("Failed to sign access token", zap.Error(err))
 		return nil, fmt.Errorf("failed to sign access token: %w", err)
@@ -1120,7 +1186,7 @@ func generateToken(user models.User) (*models.Tokens, error) {
 		return nil, fmt.Errorf("failed to build refresh token: %w", err)
 	}
 
-	signedRefreshToken, err := jwt.Sign(refreshToken, jwt.WithKey(jwa.HS256(), refreshSecret))
+	signedRefreshToken, err := signInternalToken(refreshToken, os.Getenv("REFRESH_TOKEN_SECRET"))
 	if err != nil {
 		logger.LogError("Failed to sign refresh token", zap.Error(err))
 		return nil, fmt.Errorf("failed to sign refresh token: %w", err)
@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	logger "github.com/fossology/LicenseDb/pkg/log"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/validations"
+)
+
+// defaultImpersonationLifespan is used if IMPERSONATION_TOKEN_MINUTES is not set.
+const defaultImpersonationLifespan = 15 * time.Minute
+
+// ImpersonateUser issues a short-lived token acting as another user.
+//
+//	@Summary		Impersonate a user
+//	@Description	Get a short-lived access token acting as the user, to reproduce what the user sees. The token names
+//	@Description	the super admin in its "act" claim, can not be refreshed and is rejected by the user management
+//	@Description	endpoints. Every audit created with it records the super admin as impersonator.
+//	@Id				ImpersonateUser
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string						true	"Username of the user to impersonate"
+//	@Param			reason		body		models.ImpersonationInput	true	"Reason for the impersonation"
+//	@Success		200			{object}	models.TokenResponse
+//	@Failure		400			{object}	models.LicenseError	"Invalid request body or own user"
+//	@Failure		403			{object}	models.LicenseError	"Super admins can not be impersonated"
+//	@Failure		404			{object}	models.LicenseError	"User not found"
+//	@Failure		500			{object}	models.LicenseError	"Failed to generate token"
+//	@Security		ApiKeyAuth
+//	@Router			/users/{username}/impersonate [post]
+func ImpersonateUser(c *gin.Context) {
+	var input models.ImpersonationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not impersonate user",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	impersonatorId := c.MustGet("userId").(uuid.UUID)
+	var impersonator models.User
+	if err := db.DB.Where(models.User{Id: impersonatorId}).First(&impersonator).Error; err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "failed to fetch user",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	username := c.Param("username")
+	active := true
+	var user models.User
+	if err := db.DB.Where(models.User{UserName: &username, Active: &active}).First(&user).Error; err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusNotFound,
+			Message:   "no user with such username exists",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusNotFound, er)
+		return
+	}
+	if user.Id == impersonator.Id {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not impersonate yourself",
+			Error:     "can not impersonate yourself",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+	if user.UserLevel != nil && *user.UserLevel == "SUPER_ADMIN" {
+		er := models.LicenseError{
+			Status:    http.StatusForbidden,
+			Message:   "super admins can not be impersonated",
+			Error:     "super admins can not be impersonated",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusForbidden, er)
+		return
+	}
+
+	var tokens *models.Tokens
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		var expiresAt time.Time
+		tokens, expiresAt, err = generateImpersonationToken(user, impersonator)
+		if err != nil {
+			return err
+		}
+
+		reason := input.Reason
+		expiry := expiresAt.Format(time.RFC3339)
+		audit := models.Audit{
+			UserId:    impersonator.Id,
+			TypeId:    user.Id,
+			Timestamp: time.Now(),
+			Type:      "IMPERSONATION",
+			ChangeLogs: []models.ChangeLog{
+				{Field: "Reason", UpdatedValue: &reason},
+				{Field: "ExpiresAt", UpdatedValue: &expiry},
+			},
+		}
+		return tx.Create(&audit).Error
+	})
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to generate token",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	logger.LogInfo("Issued impersonation token",
+		zap.String("impersonator", *impersonator.UserName),
+		zap.String("username", username),
+		zap.String("reason", input.Reason),
+	)
+	c.JSON(http.StatusOK, models.TokenResponse{
+		Status: http.StatusOK,
+		Data:   *tokens,
+	})
+}
+
+// generateImpersonationToken builds an access token of the user which names
+// the impersonator in its "act" claim (RFC 8693). No refresh token is issued.
+func generateImpersonationToken(user, impersonator models.User) (*models.Tokens, time.Time, error) {
+	lifespan := defaultImpersonationLifespan
+	if value := os.Getenv("IMPERSONATION_TOKEN_MINUTES"); value != "" {
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes <= 0 {
+			return nil, time.Time{}, fmt.Errorf("invalid impersonation token lifespan: %s", value)
+		}
+		lifespan = time.Duration(minutes) * time.Minute
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(lifespan)
+	token, err := jwt.NewBuilder().
+		Issuer(os.Getenv("DEFAULT_ISSUER")).
+		IssuedAt(now).
+		NotBefore(now).
+		Expiration(expiresAt).
+		Subject(user.Id.String()).
+		Audience([]string{os.Getenv("DEFAULT_ISSUER")}).
+		Claim("user", models.UserClaim{
+			Id:          user.Id,
+			UserName:    user.UserName,
+			DisplayName: user.DisplayName,
+			UserEmail:   user.UserEmail,
+			UserLevel:   user.UserLevel,
+		}).
+		Claim("act", map[string]interface{}{
+			"sub":       impersonator.Id.String(),
+			"user_name": impersonator.UserName,
+		}).
+		Build()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to build impersonation token: %w", err)
+	}
+
+	signed, err := signAccessToken(token)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to sign impersonation token: %w", err)
+	}
+	return &models.Tokens{
+		AccessToken:          string(signed),
+		AccessTokenExpiresAt: expiresAt.Format(time.RFC3339),
+	}, expiresAt, nil
+}
@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package auth
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	logger "github.com/fossology/LicenseDb/pkg/log"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+)
+
+// LdapDirectory is an LDAP server (OpenLDAP, Active Directory) users log in
+// with. Users bind with their own password, so no service credentials are
+// stored in LicenseDB.
+type LdapDirectory struct {
+	// Url of the server, ldap:// or ldaps://
+	Url string
+	// StartTls upgrades ldap:// connections to TLS before binding
+	StartTls bool
+	// UserDn is the DN users bind as, {username} is replaced by the login
+	// name, e.g. uid={username},ou=people,dc=example,dc=org or
+	// {username}@corp.example.com for Active Directory
+	UserDn string
+	// BaseDn and UserFilter locate the entry of the bound user, {username} is
+	// replaced in the filter
+	BaseDn     string
+	UserFilter string
+	// EmailAttribute, DisplayNameAttribute and GroupAttribute name the
+	// attributes mapped to the user
+	EmailAttribute       string
+	DisplayNameAttribute string
+	GroupAttribute       string
+	// RoleMapping maps a user level to the group DNs granting it. The highest
+	// granted level wins and is re-synced on every login.
+	RoleMapping map[string][]string
+	// DefaultRole is the level of users in none of the groups. If empty, such
+	// users are rejected while a role mapping is configured.
+	DefaultRole string
+}
+
+var (
+	// ErrLdapInvalidCredentials is returned if the bind fails or the user has
+	// no entry in the directory.
+	ErrLdapInvalidCredentials = errors.New("invalid credentials")
+	// ErrLdapUnavailable is returned when the directory can not be reached.
+	ErrLdapUnavailable = errors.New("ldap directory unavailable")
+	// ErrLdapNoUserLevel is returned if the groups of the user grant no level.
+	ErrLdapNoUserLevel = errors.New("no user level granted by the ldap groups")
+	// ErrLdapUserDeactivated is returned for users deactivated in LicenseDB.
+	ErrLdapUserDeactivated = errors.New("user is deactivated")
+	// ErrLdapUserExists is returned if the username belongs to an account
+	// not bound to the directory, e.g. an oidc or scim user.
+	ErrLdapUserExists = errors.New("username is taken by an account not bound to the ldap directory")
+)
+
+// ldapDirectory is the directory users without a local password log in with,
+// nil if LDAP login is disabled.
+var ldapDirectory *LdapDirectory
+
+// LoadLdapDirectory configures LDAP login from the LDAP_* environment
+// variables. LDAP login is disabled if LDAP_URL is empty.
+func LoadLdapDirectory() error {
+	ldapDirectory = nil
+	if os.Getenv("LDAP_URL") == "" {
+		return nil
+	}
+
+	directory := LdapDirectory{
+		Url:                  os.Getenv("LDAP_URL"),
+		UserDn:               os.Getenv("LDAP_USER_DN"),
+		BaseDn:               os.Getenv("LDAP_BASE_DN"),
+		UserFilter:           envOrDefault("LDAP_USER_FILTER", "(uid={username})"),
+		EmailAttribute:       envOrDefault("LDAP_EMAIL_ATTRIBUTE", "mail"),
+		DisplayNameAttribute: envOrDefault("LDAP_DISPLAY_NAME_ATTRIBUTE", "displayName"),
+		GroupAttribute:       envOrDefault("LDAP_GROUP_ATTRIBUTE", "memberOf"),
+		DefaultRole:          os.Getenv("LDAP_DEFAULT_ROLE"),
+	}
+	directory.StartTls, _ = strconv.ParseBool(os.Getenv("LDAP_START_TLS"))
+	if os.Getenv("LDAP_ROLE_MAPPING") != "" {
+		if err := json.Unmarshal([]byte(os.Getenv("LDAP_ROLE_MAPPING")), &directory.RoleMapping); err != nil {
+			return fmt.Errorf("failed to parse LDAP_ROLE_MAPPING: %w", err)
+		}
+	}
+
+	if !strings.Contains(directory.UserDn, "{username}") {
+		return errors.New("LDAP_USER_DN must contain {username}")
+	}
+	if directory.BaseDn == "" {
+		return errors.New("LDAP_BASE_DN is required")
+	}
+	// DNs are compared case-insensitively
+	for level, groups := range directory.RoleMapping {
+		if _, ok := userLevelRank[level]; !ok {
+			return fmt.Errorf("LDAP_ROLE_MAPPING maps to unknown user level %q", level)
+		}
+		for i := range groups {
+			groups[i] = strings.ToLower(groups[i])
+		}
+	}
+	if _, ok := userLevelRank[directory.DefaultRole]; directory.DefaultRole != "" && !ok {
+		return fmt.Errorf("LDAP_DEFAULT_ROLE has unknown user level %q", directory.DefaultRole)
+	}
+
+	ldapDirectory = &directory
+	logger.LogInfo("LDAP login enabled", zap.String("url", directory.Url))
+	return nil
+}
+
+// Authenticate binds as the user and creates or updates the local user from
+// the attributes of its directory entry.
+func (d *LdapDirectory) Authenticate(tx *gorm.DB, username, password string) (*models.User, error) {
+	// An empty password would be an anonymous bind which always succeeds
+	if username == "" || password == "" {
+		return nil, ErrLdapInvalidCredentials
+	}
+
+	conn, err := ldap.DialURL(d.Url, ldap.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLdapUnavailable, err)
+	}
+	defer conn.Close()
+	conn.SetTimeout(10 * time.Second)
+
+	if d.StartTls {
+		serverUrl, err := url.Parse(d.Url)
+		if err != nil {
+			return nil, err
+		}
+		if err := conn.StartTLS(&tls.Config{ServerName: serverUrl.Hostname()}); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrLdapUnavailable, err)
+		}
+	}
+
+	userDn := strings.ReplaceAll(d.UserDn, "{username}", ldap.EscapeDN(username))
+	if err := conn.Bind(userDn, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrLdapInvalidCredentials
+		}
+		return nil, fmt.Errorf("%w: %v", ErrLdapUnavailable, err)
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		d.BaseDn, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 10, false,
+		strings.ReplaceAll(d.UserFilter, "{username}", ldap.EscapeFilter(username)),
+		[]string{d.EmailAttribute, d.DisplayNameAttribute, d.GroupAttribute},
+		nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLdapUnavailable, err)
+	}
+	if len(result.Entries) != 1 {
+		logger.LogError("ldap user filter must match exactly one entry",
+			zap.String("username", username), zap.Int("entries", len(result.Entries)))
+		return nil, ErrLdapInvalidCredentials
+	}
+	entry := result.Entries[0]
+
+	var groups []string
+	for _, group := range entry.GetAttributeValues(d.GroupAttribute) {
+		groups = append(groups, strings.ToLower(group))
+	}
+	level := grantedUserLevel(groups, d.RoleMapping, d.DefaultRole)
+	if level == "" {
+		if len(d.RoleMapping) != 0 {
+			return nil, ErrLdapNoUserLevel
+		}
+		level = "USER"
+	}
+
+	return d.syncUser(tx, username, entry, level)
+}
+
+// syncUser creates the user on its first login and updates the display name,
+// email and user level of known users. Users are bound to the directory url
+// and the DN of their entry, accounts of other identity providers with the
+// same username are never taken over.
+func (d *LdapDirectory) syncUser(tx *gorm.DB, username string, entry *ldap.Entry, level string) (*models.User, error) {
+	displayName := entry.GetAttributeValue(d.DisplayNameAttribute)
+	if displayName == "" {
+		displayName = username
+	}
+	var email *string
+	if value := entry.GetAttributeValue(d.EmailAttribute); value != "" {
+		email = &value
+	}
+
+	var user models.User
+	err := tx.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where(models.User{ExternalIssuer: &d.Url, ExternalSubject: &entry.DN}).First(&user).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = d.bindUser(tx, username, entry.DN, &user)
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			user = models.User{
+				UserName:        &username,
+				UserEmail:       email,
+				DisplayName:     &displayName,
+				UserLevel:       &level,
+				ExternalIssuer:  &d.Url,
+				ExternalSubject: &entry.DN,
+			}
+			if err := tx.Create(&user).Error; err != nil {
+				if errors.Is(err, gorm.ErrDuplicatedKey) {
+					return ErrLdapUserExists
+				}
+				return err
+			}
+			logger.LogInfo("Provisioned user from ldap directory",
+				zap.String("username", username), zap.String("user_level", level))
+			return utils.AddChangelogsForUser(tx, user.Id, &user, &models.User{})
+		}
+		if err != nil {
+			return err
+		}
+
+		if user.Active != nil && !*user.Active {
+			return ErrLdapUserDeactivated
+		}
+
+		oldUser := user
+		user.DisplayName = &displayName
+		user.UserEmail = email
+		user.UserLevel = &level
+		if err := tx.Model(&user).Select("display_name", "user_email", "user_level").Updates(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return ErrLdapUserExists
+			}
+			return err
+		}
+		return utils.AddChangelogsForUser(tx, user.Id, &user, &oldUser)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// bindUser binds the user named username to the directory entry dn. Only
+// users of LicenseDB without a password, identity provider or scim
+// provisioning are bound, which are users that logged in with the directory
+// before the binding was recorded. It returns gorm.ErrRecordNotFound if no
+// user has the username and ErrLdapUserExists if the user can not be bound.
+func (d *LdapDirectory) bindUser(tx *gorm.DB, username, dn string, user *models.User) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(models.User{UserName: &username}).First(user).Error; err != nil {
+		return err
+	}
+	if user.ExternalIssuer != nil || user.ExternalId != nil || user.AnonymisedAt != nil ||
+		(user.UserPassword != nil && *user.UserPassword != "") {
+		return ErrLdapUserExists
+	}
+	// Service accounts only act through their oidc clients
+	var serviceAccounts int64
+	if err := tx.Model(&models.ServiceAccount{}).Where(&models.ServiceAccount{UserId: user.Id}).Count(&serviceAccounts).Error; err != nil {
+		return err
+	}
+	if serviceAccounts != 0 {
+		return ErrLdapUserExists
+	}
+
+	user.ExternalIssuer = &d.Url
+	user.ExternalSubject = &dn
+	if err := tx.Model(user).Select("external_issuer", "external_subject").Updates(user).Error; err != nil {
+		return err
+	}
+	logger.LogInfo("Bound user to ldap directory entry", zap.String("username", username), zap.String("dn", dn))
+	return nil
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,445 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lestrrat-go/httprc/v3"
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jws"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	logger "github.com/fossology/LicenseDb/pkg/log"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+)
+
+// ErrOidcProviderUnavailable is returned when the keys of an issuer can not be
+// fetched, i.e. the failure is not caused by the token itself.
+var ErrOidcProviderUnavailable = errors.New("oidc provider keys unavailable")
+
+// OidcIssuer is an OIDC identity provider whose tokens are trusted.
+type OidcIssuer struct {
+	// Issuer must match the iss claim of the tokens
+	Issuer string `yaml:"issuer"`
+	// JwksUri is the URL for retrieving keys for token verification
+	JwksUri string `yaml:"jwks_uri"`
+	// SigningAlg is used for keys without an alg field (ex. AzureAD)
+	SigningAlg string `yaml:"signing_alg"`
+	// UsernameClaim, EmailClaim and DisplayNameClaim name the token fields
+	// mapped to the user
+	UsernameClaim    string `yaml:"username_claim"`
+	EmailClaim       string `yaml:"email_claim"`
+	DisplayNameClaim string `yaml:"display_name_claim"`
+	// Audience, if not empty, lists the accepted values of the aud claim
+	Audience []string `yaml:"audience"`
+	// ClientToUserMapperClaim is the claim holding the client id in tokens of
+	// the client credentials flow, see OidcClient
+	ClientToUserMapperClaim string `yaml:"client_to_user_mapper_claim"`
+	// RolesClaim names the claim listing the groups or roles of the user.
+	// Nested claims are separated by dots, e.g. realm_access.roles
+	RolesClaim string `yaml:"roles_claim"`
+	// RoleMapping maps a user level to the groups granting it. The highest
+	// granted level wins and is re-synced on every request.
+	RoleMapping map[string][]string `yaml:"role_mapping"`
+	// DefaultRole is the level of users matching none of the groups. If empty,
+	// such users are rejected while a roles claim is configured.
+	DefaultRole string `yaml:"default_role"`
+	// ProvisionUsers creates unknown users on their first request
+	ProvisionUsers bool `yaml:"provision_users"`
+}
+
+var (
+	// ErrOidcUserNotFound is returned for unknown users of issuers which do not
+	// provision users.
+	ErrOidcUserNotFound = errors.New("user not found")
+	// ErrOidcNoUserLevel is returned if the roles claim grants no user level.
+	ErrOidcNoUserLevel = errors.New("no user level granted by the identity provider")
+	// ErrOidcIncompatibleToken is returned if a configured claim is missing.
+	ErrOidcIncompatibleToken = errors.New("incompatible token format")
+	// ErrOidcUserDeactivated is returned for deactivated users, before their
+	// user level is synced.
+	ErrOidcUserDeactivated = errors.New("user is deactivated")
+	// ErrOidcUserExists is returned if a user can not be provisioned because
+	// its username or email belongs to another account.
+	ErrOidcUserExists = errors.New("username or email taken by another account")
+)
+
+// userLevelRank orders the user levels by their privileges.
+var userLevelRank = map[string]int{
+	"USER":        1,
+	"ADMIN":       2,
+	"SUPER_ADMIN": 3,
+}
+
+// grantedUserLevel returns the highest user level the role mapping grants to
+// one of the groups, or the default level if none matches.
+func grantedUserLevel(groups []string, roleMapping map[string][]string, defaultLevel string) string {
+	level := defaultLevel
+	for mappedLevel, mappedGroups := range roleMapping {
+		if userLevelRank[mappedLevel] <= userLevelRank[level] {
+			continue
+		}
+		if slices.ContainsFunc(groups, func(group string) bool { return slices.Contains(mappedGroups, group) }) {
+			level = mappedLevel
+		}
+	}
+	return level
+}
+
+type oidcIssuersConfig struct {
+	Issuers []OidcIssuer `yaml:"issuers"`
+}
+
+// oidcIssuers maps the iss claim to the trusted issuer.
+var oidcIssuers = map[string]*OidcIssuer{}
+
+// LoadOidcIssuers reads the trusted issuers from the yaml file referenced by
+// OIDC_ISSUERS_FILE. Without that file, a single issuer is configured from the
+// OIDC_* environment variables, if set.
+func LoadOidcIssuers() error {
+	var issuers []OidcIssuer
+	if path := os.Getenv("OIDC_ISSUERS_FILE"); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read oidc issuers file: %w", err)
+		}
+		var config oidcIssuersConfig
+		if err := yaml.Unmarshal(content, &config); err != nil {
+			return fmt.Errorf("failed to parse oidc issuers file: %w", err)
+		}
+		issuers = config.Issuers
+	} else if os.Getenv("OIDC_ISSUER") != "" {
+		issuer := OidcIssuer{
+			Issuer:                  os.Getenv("OIDC_ISSUER"),
+			JwksUri:                 os.Getenv("JWKS_URI"),
+			SigningAlg:              os.Getenv("OIDC_SIGNING_ALG"),
+			UsernameClaim:           os.Getenv("OIDC_USERNAME_KEY"),
+			EmailClaim:              os.Getenv("OIDC_EMAIL_KEY"),
+			DisplayNameClaim:        os.Getenv("OIDC_DISPLAYNAME_KEY"),
+			ClientToUserMapperClaim: os.Getenv("OIDC_CLIENT_TO_USER_MAPPER_CLAIM"),
+		}
+		if os.Getenv("OIDC_AUDIENCE") != "" {
+			issuer.Audience = []string{os.Getenv("OIDC_AUDIENCE")}
+		}
+		if os.Getenv("OIDC_ROLE_MAPPING") != "" {
+			if err := json.Unmarshal([]byte(os.Getenv("OIDC_ROLE_MAPPING")), &issuer.RoleMapping); err != nil {
+				return fmt.Errorf("failed to parse OIDC_ROLE_MAPPING: %w", err)
+			}
+		}
+		issuer.RolesClaim = os.Getenv("OIDC_ROLES_CLAIM")
+		issuer.DefaultRole = os.Getenv("OIDC_DEFAULT_ROLE")
+		issuer.ProvisionUsers, _ = strconv.ParseBool(os.Getenv("OIDC_PROVISION_USERS"))
+		issuers = append(issuers, issuer)
+	}
+
+	loaded := map[string]*OidcIssuer{}
+	for i := range issuers {
+		issuer := issuers[i]
+		if issuer.Issuer == "" || issuer.JwksUri == "" || issuer.UsernameClaim == "" {
+			return fmt.Errorf("oidc issuer %q requires issuer, jwks_uri and username_claim", issuer.Issuer)
+		}
+		if issuer.SigningAlg != "" {
+			if _, ok := jwa.LookupSignatureAlgorithm(issuer.SigningAlg); !ok {
+				return fmt.Errorf("oidc issuer %q has unknown signing_alg %q", issuer.Issuer, issuer.SigningAlg)
+			}
+		}
+		if len(issuer.RoleMapping) != 0 && issuer.RolesClaim == "" {
+			return fmt.Errorf("oidc issuer %q has a role_mapping but no roles_claim", issuer.Issuer)
+		}
+		for level := range issuer.RoleMapping {
+			if _, ok := userLevelRank[level]; !ok {
+				return fmt.Errorf("oidc issuer %q maps to unknown user level %q", issuer.Issuer, level)
+			}
+		}
+		if _, ok := userLevelRank[issuer.DefaultRole]; issuer.DefaultRole != "" && !ok {
+			return fmt.Errorf("oidc issuer %q has unknown default_role %q", issuer.Issuer, issuer.DefaultRole)
+		}
+		if issuer.ProvisionUsers && issuer.EmailClaim == "" {
+			return fmt.Errorf("oidc issuer %q provisions users but has no email_claim", issuer.Issuer)
+		}
+		if _, ok := loaded[issuer.Issuer]; ok {
+			return fmt.Errorf("oidc issuer %q is configured more than once", issuer.Issuer)
+		}
+		loaded[issuer.Issuer] = &issuer
+	}
+	oidcIssuers = loaded
+	return nil
+}
+
+// RegisterOidcIssuers creates the JWKS cache and registers the keys of every
+// trusted issuer.
+func RegisterOidcIssuers(ctx context.Context) error {
+	if len(oidcIssuers) == 0 {
+		return nil
+	}
+	cache, err := jwk.NewCache(ctx, httprc.NewClient())
+	if err != nil {
+		return fmt.Errorf("failed to create jwk.Cache: %w", err)
+	}
+	for _, issuer := range oidcIssuers {
+		if cache.IsRegistered(ctx, issuer.JwksUri) {
+			continue
+		}
+		if err := cache.Register(ctx, issuer.JwksUri); err != nil {
+			return fmt.Errorf("failed to register JWKS URI of %q: %w", issuer.Issuer, err)
+		}
+		logger.LogInfo("Registered oidc issuer", zap.String("issuer", issuer.Issuer), zap.String("jwks_uri", issuer.JwksUri))
+	}
+	Jwks = cache
+	return nil
+}
+
+// LookupOidcIssuer returns the trusted issuer matching the iss claim.
+func LookupOidcIssuer(iss string) (*OidcIssuer, bool) {
+	issuer, ok := oidcIssuers[iss]
+	return issuer, ok
+}
+
+// Verify checks the signature of the token against the keys of the issuer and
+// validates its claims, including issuer and audience.
+func (i *OidcIssuer) Verify(tokenString string) (jwt.Token, error) {
+	if Jwks == nil {
+		return nil, fmt.Errorf("%w: jwk cache not initialized", ErrOidcProviderUnavailable)
+	}
+	keyset, err := Jwks.Lookup(context.Background(), i.JwksUri)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOidcProviderUnavailable, err)
+	}
+
+	msg, err := jws.Parse([]byte(tokenString))
+	if err != nil || len(msg.Signatures()) != 1 {
+		return nil, errors.New("token parsing failed")
+	}
+	kid, ok := msg.Signatures()[0].ProtectedHeaders().KeyID()
+	if !ok {
+		return nil, errors.New("token has no kid header")
+	}
+	key, ok := keyset.LookupKeyID(kid)
+	if !ok {
+		return nil, fmt.Errorf("no key with kid %q", kid)
+	}
+
+	var alg jwa.SignatureAlgorithm
+	if i.SigningAlg != "" {
+		alg, _ = jwa.LookupSignatureAlgorithm(i.SigningAlg)
+	} else {
+		keyAlg, ok := key.Algorithm()
+		if !ok {
+			return nil, errors.New("key has no alg field")
+		}
+		if alg, ok = jwa.LookupSignatureAlgorithm(keyAlg.String()); !ok {
+			return nil, fmt.Errorf("unsupported key alg %q", keyAlg.String())
+		}
+	}
+
+	token, err := jwt.Parse(
+		[]byte(tokenString),
+		jwt.WithKey(alg, key),
+		jwt.WithValidate(true),
+		jwt.WithIssuer(i.Issuer),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(i.Audience) != 0 {
+		audience, _ := token.Audience()
+		if !slices.ContainsFunc(audience, func(aud string) bool { return slices.Contains(i.Audience, aud) }) {
+			return nil, errors.New("token audience not accepted")
+		}
+	}
+	return token, nil
+}
+
+// ResolveUser returns the user a verified token belongs to. Users are bound to
+// the issuer and the sub claim of their tokens, never to their username, so
+// that tokens of one issuer can not act as accounts of another issuer or as
+// local accounts. Users created before this binding are bound on their first
+// login, see bindLegacyUser. Unknown users are created if the issuer
+// provisions users, and the user level of active users is re-synced from the
+// roles claim so that group changes at the identity provider take effect.
+func (i *OidcIssuer) ResolveUser(tx *gorm.DB, token jwt.Token) (*models.User, error) {
+	subject, ok := token.Subject()
+	if !ok || subject == "" {
+		return nil, ErrOidcIncompatibleToken
+	}
+
+	level, err := i.UserLevel(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	err = tx.Where(models.User{ExternalIssuer: &i.Issuer, ExternalSubject: &subject}).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = i.bindLegacyUser(tx, token, subject, &user)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if !i.ProvisionUsers {
+			return nil, ErrOidcUserNotFound
+		}
+		return i.provisionUser(tx, token, subject, level)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Active != nil && !*user.Active {
+		return nil, ErrOidcUserDeactivated
+	}
+
+	if i.RolesClaim != "" && (user.UserLevel == nil || *user.UserLevel != level) {
+		err := tx.Transaction(func(tx *gorm.DB) error {
+			oldUser := user
+			user.UserLevel = &level
+			if err := tx.Model(&user).Update("user_level", level).Error; err != nil {
+				return err
+			}
+			return utils.AddChangelogsForUser(tx, user.Id, &user, &oldUser)
+		})
+		if err != nil {
+			return nil, err
+		}
+		logger.LogInfo("Synced user level from oidc roles claim",
+			zap.String("username", *user.UserName), zap.String("user_level", level))
+	}
+	return &user, nil
+}
+
+// bindLegacyUser binds the user named by the username claim of a token to the
+// issuer and subject of the token, if the user was created by an oidc token
+// before users were bound to their identity, or provisioned through SCIM
+// without SCIM_OIDC_ISSUER. Only active users without a password,
+// without a bound identity and not acting for a service account qualify, so
+// local, ldap and other bound accounts are never taken over. It returns
+// gorm.ErrRecordNotFound if there is no such user.
+func (i *OidcIssuer) bindLegacyUser(tx *gorm.DB, token jwt.Token, subject string, user *models.User) error {
+	var username string
+	if err := token.Get(i.UsernameClaim, &username); err != nil || username == "" {
+		return gorm.ErrRecordNotFound
+	}
+	return tx.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_name = ? AND external_issuer IS NULL AND COALESCE(user_password, '') = '' AND anonymised_at IS NULL AND active", username).
+			Where("NOT EXISTS (SELECT 1 FROM service_accounts WHERE service_accounts.user_id = users.id)").
+			First(user).Error
+		if err != nil {
+			return err
+		}
+		user.ExternalIssuer = &i.Issuer
+		user.ExternalSubject = &subject
+		if err := tx.Model(user).Select("external_issuer", "external_subject").Updates(user).Error; err != nil {
+			return err
+		}
+		logger.LogInfo("Bound user to oidc identity on first login",
+			zap.String("issuer", i.Issuer), zap.String("username", username))
+		return nil
+	})
+}
+
+// UserLevel returns the user level granted by the roles claim of the token.
+// Without a roles claim configured, new users get the default role or USER.
+func (i *OidcIssuer) UserLevel(token jwt.Token) (string, error) {
+	level := i.DefaultRole
+	if i.RolesClaim != "" {
+		level = grantedUserLevel(claimValues(token, i.RolesClaim), i.RoleMapping, i.DefaultRole)
+		if level == "" {
+			return "", ErrOidcNoUserLevel
+		}
+	}
+	if level == "" {
+		level = "USER"
+	}
+	return level, nil
+}
+
+// provisionUser creates the user of a token on its first request.
+func (i *OidcIssuer) provisionUser(tx *gorm.DB, token jwt.Token, subject, level string) (*models.User, error) {
+	var username, email, displayName string
+	if err := token.Get(i.UsernameClaim, &username); err != nil || username == "" {
+		return nil, ErrOidcIncompatibleToken
+	}
+	if err := token.Get(i.EmailClaim, &email); err != nil || email == "" {
+		return nil, ErrOidcIncompatibleToken
+	}
+	if i.DisplayNameClaim == "" || token.Get(i.DisplayNameClaim, &displayName) != nil || displayName == "" {
+		displayName = username
+	}
+
+	user := models.User{
+		UserName:        &username,
+		UserEmail:       &email,
+		DisplayName:     &displayName,
+		UserLevel:       &level,
+		ExternalIssuer:  &i.Issuer,
+		ExternalSubject: &subject,
+	}
+	err := tx.Transaction(func(tx *gorm.DB) error {
+		// A concurrent first request may have created the user meanwhile
+		result := tx.Where(models.User{ExternalIssuer: &i.Issuer, ExternalSubject: &subject}).FirstOrCreate(&user)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return utils.AddChangelogsForUser(tx, user.Id, &user, &models.User{})
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, ErrOidcUserExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Active != nil && !*user.Active {
+		return nil, ErrOidcUserDeactivated
+	}
+	logger.LogInfo("Provisioned user from oidc token",
+		zap.String("issuer", i.Issuer), zap.String("username", username), zap.String("user_level", level))
+	return &user, nil
+}
+
+// claimValues reads a string or list of strings claim. Nested claims are
+// addressed by dot separated names.
+func claimValues(token jwt.Token, name string) []string {
+	path := strings.Split(name, ".")
+	var value interface{}
+	if err := token.Get(path[0], &value); err != nil {
+		return nil
+	}
+	for _, key := range path[1:] {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				values = append(values, str)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jws"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+	"go.uber.org/zap"
+
+	logger "github.com/fossology/LicenseDb/pkg/log"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+// accessTokenType is the typ header of access tokens.
+const accessTokenType = "at+jwt"
+
+// signingKey is the key of the token signing keys, all other keys of the
+// directory are only used for verification.
+var signingKey jwk.Key
+
+// publicSigningKeys holds the public part of all keys of the directory. They
+// are published as JWKS so that other services can verify our tokens.
+var publicSigningKeys = jwk.NewSet()
+
+// LoadSigningKeys loads the PEM encoded RS256 or EdDSA keys of the directory
+// set in TOKEN_SIGNING_KEYS_DIR. The file name without extension is used as
+// kid. Tokens are signed with the key named by TOKEN_SIGNING_KEY_ID, by default
+// the private key with the last kid in alphabetical order, so that naming keys
+// by date rotates them. Public-only key files keep retired keys verifiable.
+//
+// Only access tokens are signed with these keys. Without a key directory,
+// they are signed with the HS256 secret API_SECRET. Refresh tokens are always
+// signed with REFRESH_TOKEN_SECRET.
+func LoadSigningKeys() error {
+	signingKey = nil
+	publicSigningKeys = jwk.NewSet()
+
+	dir := os.Getenv("TOKEN_SIGNING_KEYS_DIR")
+	if dir == "" {
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return err
+	}
+	slices.Sort(files)
+
+	privateKeys := map[string]jwk.Key{}
+	var lastKid string
+	for _, file := range files {
+		kid := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		key, err := jwk.ParseKey(data, jwk.WithPEM(true))
+		if err != nil {
+			return fmt.Errorf("failed to parse signing key %s: %w", file, err)
+		}
+
+		alg, err := signingKeyAlgorithm(key)
+		if err != nil {
+			return fmt.Errorf("signing key %s: %w", file, err)
+		}
+		if err := key.Set(jwk.KeyIDKey, kid); err != nil {
+			return err
+		}
+		if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+			return err
+		}
+		if err := key.Set(jwk.KeyUsageKey, jwk.ForSignature); err != nil {
+			return err
+		}
+
+		publicKey, err := key.PublicKey()
+		if err != nil {
+			return fmt.Errorf("signing key %s: %w", file, err)
+		}
+		if err := publicSigningKeys.AddKey(publicKey); err != nil {
+			return err
+		}
+
+		if isPrivateKey(key) {
+			privateKeys[kid] = key
+			lastKid = kid
+		}
+	}
+
+	kid := os.Getenv("TOKEN_SIGNING_KEY_ID")
+	if kid == "" {
+		kid = lastKid
+	}
+	key, ok := privateKeys[kid]
+	if !ok {
+		return fmt.Errorf("no private signing key with kid '%s' found in %s", kid, dir)
+	}
+	signingKey = key
+
+	logger.LogInfo("Loaded token signing keys",
+		zap.Int("keys", publicSigningKeys.Len()),
+		zap.String("signing_kid", kid),
+	)
+	return nil
+}
+
+// signAccessToken signs an access token issued by LicenseDB with the active
+// signing key or, if no keys are configured, with the HS256 secret
+// API_SECRET. Access tokens are the only tokens signed with the published
+// keys, and are typed at+jwt (RFC 9068) so that verifiers can tell them apart.
+func signAccessToken(token jwt.Token) ([]byte, error) {
+	headers := jws.NewHeaders()
+	if err := headers.Set(jws.TypeKey, accessTokenType); err != nil {
+		return nil, err
+	}
+	if signingKey != nil {
+		alg, _ := signingKey.Algorithm()
+		return jwt.Sign(token, jwt.WithKey(alg, signingKey, jws.WithProtectedHeaders(headers)))
+	}
+	return jwt.Sign(token, jwt.WithKey(jwa.HS256(), []byte(os.Getenv("API_SECRET")), jws.WithProtectedHeaders(headers)))
+}
+
+// signInternalToken signs refresh and mfa tokens, which only LicenseDB
+// verifies, with a HS256 secret and never with the published keys.
+func signInternalToken(token jwt.Token, secret string) ([]byte, error) {
+	return jwt.Sign(token, jwt.WithKey(jwa.HS256(), []byte(secret)))
+}
+
+// VerifyAccessToken verifies the signature and the at+jwt type of an access
+// token issued by LicenseDB. Once signing keys are configured, HS256 access
+// tokens are no longer accepted.
+func VerifyAccessToken(tokenString string) error {
+	var message jws.Message
+	var key jws.VerifyOption = jws.WithKey(jwa.HS256(), []byte(os.Getenv("API_SECRET")))
+	if signingKey != nil {
+		key = jws.WithKeySet(publicSigningKeys, jws.WithRequireKid(true))
+	}
+	if _, err := jws.Verify([]byte(tokenString), key, jws.WithMessage(&message)); err != nil {
+		return err
+	}
+	for _, signature := range message.Signatures() {
+		if typ, ok := signature.ProtectedHeaders().Type(); !ok || typ != accessTokenType {
+			return errors.New("token is no access token")
+		}
+	}
+	return nil
+}
+
+// verifyInternalToken verifies the signature of a refresh or mfa token.
+func verifyInternalToken(tokenString string, secret string) error {
+	_, err := jws.Verify([]byte(tokenString), jws.WithKey(jwa.HS256(), []byte(secret)))
+	return err
+}
+
+// GetJwks publishes the public keys used for signing access tokens.
+//
+// Served at /.well-known/jwks.json. The set is empty while tokens are signed
+// with HS256.
+func GetJwks(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, publicSigningKeys)
+}
+
+// GetOpenIdConfiguration publishes the OpenID discovery document of the
+// issuer of access tokens, pointing verifiers to the published keys.
+//
+// Served at /.well-known/openid-configuration.
+func GetOpenIdConfiguration(c *gin.Context) {
+	issuer := os.Getenv("DEFAULT_ISSUER")
+	baseUrl := strings.TrimSuffix(issuer, "/")
+	var algorithms []string
+	for i := 0; i < publicSigningKeys.Len(); i++ {
+		key, _ := publicSigningKeys.Key(i)
+		if alg, ok := key.Algorithm(); ok && !slices.Contains(algorithms, alg.String()) {
+			algorithms = append(algorithms, alg.String())
+		}
+	}
+	if len(algorithms) == 0 {
+		algorithms = []string{jwa.HS256().String()}
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, models.OpenIdConfiguration{
+		Issuer:                           issuer,
+		JwksUri:                          baseUrl + "/.well-known/jwks.json",
+		TokenEndpoint:                    baseUrl + "/api/v1/login",
+		ResponseTypesSupported:           []string{"token"},
+		SubjectTypesSupported:            []string{"public"},
+		IdTokenSigningAlgValuesSupported: algorithms,
+	})
+}
+
+// signingKeyAlgorithm returns the signature algorithm of RSA and Ed25519 keys.
+func signingKeyAlgorithm(key jwk.Key) (jwa.SignatureAlgorithm, error) {
+	switch k := key.(type) {
+	case jwk.RSAPrivateKey, jwk.RSAPublicKey:
+		return jwa.RS256(), nil
+	case jwk.OKPPrivateKey:
+		if crv, ok := k.Crv(); ok && crv == jwa.Ed25519() {
+			return jwa.EdDSA(), nil
+		}
+	case jwk.OKPPublicKey:
+		if crv, ok := k.Crv(); ok && crv == jwa.Ed25519() {
+			return jwa.EdDSA(), nil
+		}
+	}
+	return jwa.EmptySignatureAlgorithm(), fmt.Errorf("unsupported key type %s, use RSA or Ed25519", key.KeyType())
+}
+
+func isPrivateKey(key jwk.Key) bool {
+	switch key.(type) {
+	case jwk.RSAPrivateKey, jwk.OKPPrivateKey:
+		return true
+	}
+	return false
+}
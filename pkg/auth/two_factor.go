@@ -0,0 +1,990 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	logger "github.com/fossology/LicenseDb/pkg/log"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+	"github.com/fossology/LicenseDb/pkg/validations"
+)
+
+const (
+	// TOTP parameters as per RFC 6238, matching the defaults of common
+	// authenticator apps.
+	totpPeriod      = 30
+	totpDigits      = 6
+	totpSkew        = 1
+	totpSecretBytes = 20
+
+	DEFAULT_TOTP_ISSUER = "LicenseDB"
+
+	recoveryCodeCount = 10
+
+	// Types of the short-lived tokens handed out by the first login step.
+	mfaTokenTypeVerify = "mfa"
+	mfaTokenTypeEnroll = "mfa_enroll"
+	mfaTokenLifespan   = 5 * time.Minute
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+var errSecondFactorInvalid = errors.New("invalid two-factor authentication code")
+
+// LoginTwoFactor completes a login started at /login by verifying the second
+// factor.
+//
+//	@Summary		Complete login with second factor
+//	@Description	Exchange the mfa token returned by login and a TOTP or recovery code for JWT tokens.
+//	@Description	If the login required an enrollment, the code must come from the newly enrolled secret
+//	@Description	and the response additionally lists the recovery codes.
+//	@Id				LoginTwoFactor
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			login	body		models.TwoFactorLogin			true	"MFA token and code"
+//	@Success		200		{object}	models.TwoFactorLoginResponse	"JWT token"
+//	@Failure		400		{object}	models.LicenseError				"Invalid json body"
+//	@Failure		401		{object}	models.LicenseError				"Invalid token or code"
+//	@Router			/login/2fa [post]
+func LoginTwoFactor(c *gin.Context) {
+	var input models.TwoFactorLogin
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	user, tokenType, err := parseMfaToken(input.MfaToken)
+	if err != nil {
+		logger.LogWarn("Invalid mfa token", zap.Error(err))
+		unauthorized(c, err.Error())
+		return
+	}
+
+	var recoveryCodes []string
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		if tokenType == mfaTokenTypeEnroll {
+			codes, err := completeEnrollment(tx, user, user.Id, input.Code)
+			recoveryCodes = codes
+			return err
+		}
+		if user.TotpEnabled == nil || !*user.TotpEnabled {
+			return errSecondFactorInvalid
+		}
+		return verifySecondFactor(tx, user, input.Code)
+	})
+	if err != nil {
+		if errors.Is(err, errSecondFactorInvalid) {
+			unauthorized(c, err.Error())
+			return
+		}
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to verify two-factor authentication code",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	token, err := generateToken(*user)
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to generate token",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	res := models.TwoFactorLoginResponse{
+		Status: http.StatusOK,
+		Data: models.TwoFactorLoginResult{
+			Tokens:        *token,
+			RecoveryCodes: recoveryCodes,
+		},
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// LoginTwoFactorEnroll starts the enrollment of a user who has to set up
+// two-factor authentication before being able to log in.
+//
+//	@Summary		Enroll second factor during login
+//	@Description	Generate a TOTP secret for a user whose login requires an enrollment. Complete the login at /login/2fa.
+//	@Id				LoginTwoFactorEnroll
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			login	body		models.TwoFactorLoginEnroll			true	"MFA token"
+//	@Success		200		{object}	models.TwoFactorEnrollmentResponse	"TOTP secret"
+//	@Failure		400		{object}	models.LicenseError					"Invalid json body"
+//	@Failure		401		{object}	models.LicenseError					"Invalid token"
+//	@Router			/login/2fa/enroll [post]
+func LoginTwoFactorEnroll(c *gin.Context) {
+	var input models.TwoFactorLoginEnroll
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	user, tokenType, err := parseMfaToken(input.MfaToken)
+	if err != nil || tokenType != mfaTokenTypeEnroll {
+		logger.LogWarn("Invalid mfa enrollment token", zap.Error(err))
+		unauthorized(c, "invalid mfa token")
+		return
+	}
+
+	startEnrollment(c, user)
+}
+
+// EnrollTwoFactor generates a new TOTP secret for the logged in user.
+//
+//	@Summary		Start two-factor enrollment
+//	@Description	Generate a TOTP secret and provisioning uri for the logged in user. The secret only becomes active
+//	@Description	after a code generated from it is confirmed at /users/2fa/verify.
+//	@Id				EnrollTwoFactor
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.TwoFactorEnrollmentResponse
+//	@Failure		404	{object}	models.LicenseError	"User not found"
+//	@Failure		409	{object}	models.LicenseError	"Two-factor authentication already enabled"
+//	@Security		ApiKeyAuth
+//	@Router			/users/2fa/enroll [post]
+func EnrollTwoFactor(c *gin.Context) {
+	user, ok := loggedInLocalUser(c)
+	if !ok {
+		return
+	}
+
+	startEnrollment(c, user)
+}
+
+// ConfirmTwoFactor enables two-factor authentication for the logged in user.
+//
+//	@Summary		Confirm two-factor enrollment
+//	@Description	Enable two-factor authentication by confirming a code generated from the pending secret.
+//	@Description	Returns the recovery codes, which are shown only once.
+//	@Id				ConfirmTwoFactor
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body		models.TwoFactorCode	true	"TOTP code"
+//	@Success		200		{object}	models.TwoFactorRecoveryCodesResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid json body or no pending enrollment"
+//	@Failure		401		{object}	models.LicenseError	"Invalid code"
+//	@Security		ApiKeyAuth
+//	@Router			/users/2fa/verify [post]
+func ConfirmTwoFactor(c *gin.Context) {
+	var input models.TwoFactorCode
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	user, ok := loggedInLocalUser(c)
+	if !ok {
+		return
+	}
+
+	if user.TotpSecret == nil || (user.TotpEnabled != nil && *user.TotpEnabled) {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "no pending two-factor enrollment",
+			Error:     "start an enrollment at /users/2fa/enroll first",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	var recoveryCodes []string
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		codes, err := completeEnrollment(tx, user, user.Id, input.Code)
+		recoveryCodes = codes
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errSecondFactorInvalid) {
+			unauthorized(c, err.Error())
+			return
+		}
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to enable two-factor authentication",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	res := models.TwoFactorRecoveryCodesResponse{
+		Status: http.StatusOK,
+		Data:   models.TwoFactorRecoveryCodes{RecoveryCodes: recoveryCodes},
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the logged in user.
+//
+//	@Summary		Regenerate recovery codes
+//	@Description	Invalidate all recovery codes of the logged in user and generate new ones.
+//	@Id				RegenerateRecoveryCodes
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body		models.TwoFactorCode	true	"TOTP or recovery code"
+//	@Success		200		{object}	models.TwoFactorRecoveryCodesResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid json body or two-factor authentication not enabled"
+//	@Failure		401		{object}	models.LicenseError	"Invalid code"
+//	@Security		ApiKeyAuth
+//	@Router			/users/2fa/recovery-codes [post]
+func RegenerateRecoveryCodes(c *gin.Context) {
+	var input models.TwoFactorCode
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	user, ok := loggedInLocalUser(c)
+	if !ok {
+		return
+	}
+
+	if user.TotpEnabled == nil || !*user.TotpEnabled {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "two-factor authentication is not enabled",
+			Error:     "two-factor authentication is not enabled",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	var recoveryCodes []string
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := verifySecondFactor(tx, user, input.Code); err != nil {
+			return err
+		}
+		codes, err := generateRecoveryCodes(tx, user.Id)
+		recoveryCodes = codes
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errSecondFactorInvalid) {
+			unauthorized(c, err.Error())
+			return
+		}
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to generate recovery codes",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	res := models.TwoFactorRecoveryCodesResponse{
+		Status: http.StatusOK,
+		Data:   models.TwoFactorRecoveryCodes{RecoveryCodes: recoveryCodes},
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// DisableTwoFactor turns off two-factor authentication for the logged in user.
+//
+//	@Summary		Disable two-factor authentication
+//	@Description	Disable two-factor authentication for the logged in user. Not allowed when the policy of the user level requires it.
+//	@Id				DisableTwoFactor
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body	models.TwoFactorCode	true	"TOTP or recovery code"
+//	@Success		204
+//	@Failure		400	{object}	models.LicenseError	"Invalid json body or two-factor authentication not enabled"
+//	@Failure		401	{object}	models.LicenseError	"Invalid code"
+//	@Failure		403	{object}	models.LicenseError	"Two-factor authentication is mandatory"
+//	@Security		ApiKeyAuth
+//	@Router			/users/2fa [delete]
+func DisableTwoFactor(c *gin.Context) {
+	var input models.TwoFactorCode
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	user, ok := loggedInLocalUser(c)
+	if !ok {
+		return
+	}
+
+	if user.TotpEnabled == nil || !*user.TotpEnabled {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "two-factor authentication is not enabled",
+			Error:     "two-factor authentication is not enabled",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	required, err := isTwoFactorRequired(db.DB, user.UserLevel)
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to fetch two-factor policy",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+	if required {
+		er := models.LicenseError{
+			Status:    http.StatusForbidden,
+			Message:   "two-factor authentication is mandatory for your user level",
+			Error:     "two-factor authentication cannot be disabled",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusForbidden, er)
+		return
+	}
+
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := verifySecondFactor(tx, user, input.Code); err != nil {
+			return err
+		}
+		return resetTwoFactor(tx, c.MustGet("userId").(uuid.UUID), user)
+	})
+	if err != nil {
+		if errors.Is(err, errSecondFactorInvalid) {
+			unauthorized(c, err.Error())
+			return
+		}
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to disable two-factor authentication",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResetUserTwoFactor removes the second factor of a user, e.g. after the
+// device and the recovery codes got lost.
+//
+//	@Summary		Reset two-factor authentication of a user
+//	@Description	Remove the TOTP secret and recovery codes of a user. If the policy requires two-factor authentication,
+//	@Description	the user has to enroll again on the next login.
+//	@Id				ResetUserTwoFactor
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path	string	true	"username of the user"
+//	@Success		204
+//	@Failure		404	{object}	models.LicenseError	"User not found"
+//	@Security		ApiKeyAuth
+//	@Router			/users/{username}/2fa [delete]
+func ResetUserTwoFactor(c *gin.Context) {
+	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		username := c.Param("username")
+		userId := c.MustGet("userId").(uuid.UUID)
+
+		active := true
+		if err := tx.Where(models.User{UserName: &username, Active: &active}).First(&user).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   "no user with such username exists",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return nil
+		}
+
+		if err := resetTwoFactor(tx, userId, &user); err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to reset two-factor authentication",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	})
+}
+
+// GetTwoFactorPolicies lists for which user levels two-factor authentication
+// is mandatory.
+//
+//	@Summary		Get two-factor policies
+//	@Description	Get the two-factor authentication policy of every user level
+//	@Id				GetTwoFactorPolicies
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.TwoFactorPolicyResponse
+//	@Failure		500	{object}	models.LicenseError	"Failed to fetch policies"
+//	@Security		ApiKeyAuth
+//	@Router			/users/2fa/policies [get]
+func GetTwoFactorPolicies(c *gin.Context) {
+	var policies []models.TwoFactorPolicy
+	if err := db.DB.Order("user_level").Find(&policies).Error; err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to fetch two-factor policies",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	res := models.TwoFactorPolicyResponse{
+		Status: http.StatusOK,
+		Data:   policies,
+		Meta: &models.PaginationMeta{
+			ResourceCount: len(policies),
+		},
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateTwoFactorPolicy makes two-factor authentication mandatory or optional
+// for a user level.
+//
+//	@Summary		Update a two-factor policy
+//	@Description	Require or stop requiring two-factor authentication for local accounts of a user level.
+//	@Description	Users of the level without a second factor are asked to enroll on their next login.
+//	@Id				UpdateTwoFactorPolicy
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			policy	body		models.TwoFactorPolicy	true	"Policy of a user level"
+//	@Success		200		{object}	models.TwoFactorPolicyResponse
+//	@Failure		400		{object}	models.LicenseError	"Invalid json body"
+//	@Failure		404		{object}	models.LicenseError	"Policy not found"
+//	@Security		ApiKeyAuth
+//	@Router			/users/2fa/policies [patch]
+func UpdateTwoFactorPolicy(c *gin.Context) {
+	var input models.TwoFactorPolicy
+	if err := c.ShouldBindJSON(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "invalid json body",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	if err := validations.Validate.Struct(&input); err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusBadRequest,
+			Message:   "can not update policy with these field values",
+			Error:     fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusBadRequest, er)
+		return
+	}
+
+	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+		userId := c.MustGet("userId").(uuid.UUID)
+
+		var oldPolicy models.TwoFactorPolicy
+		if err := tx.Where(models.TwoFactorPolicy{UserLevel: input.UserLevel}).First(&oldPolicy).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   "no policy exists for this user level",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return nil
+		}
+
+		newPolicy := oldPolicy
+		newPolicy.Required = input.Required
+		if err := tx.Model(&newPolicy).Update("required", *newPolicy.Required).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusInternalServerError,
+				Message:   "Failed to update two-factor policy",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusInternalServerError, er)
+			return err
+		}
+
+		var changes []models.ChangeLog
+		utils.AddChangelog("Required", oldPolicy.Required, newPolicy.Required, &changes)
+		if len(changes) != 0 {
+			audit := models.Audit{
+				UserId:     userId,
+				TypeId:     newPolicy.Id,
+				Timestamp:  time.Now(),
+				Type:       "TWO_FACTOR_POLICY",
+				ChangeLogs: changes,
+			}
+			if err := tx.Create(&audit).Error; err != nil {
+				er := models.LicenseError{
+					Status:    http.StatusInternalServerError,
+					Message:   "Failed to update two-factor policy",
+					Error:     err.Error(),
+					Path:      c.Request.URL.Path,
+					Timestamp: time.Now().Format(time.RFC3339),
+				}
+				c.JSON(http.StatusInternalServerError, er)
+				return err
+			}
+		}
+
+		res := models.TwoFactorPolicyResponse{
+			Status: http.StatusOK,
+			Data:   []models.TwoFactorPolicy{newPolicy},
+			Meta: &models.PaginationMeta{
+				ResourceCount: 1,
+			},
+		}
+		c.JSON(http.StatusOK, res)
+		return nil
+	})
+}
+
+// issueTwoFactorChallenge answers the first login step of a user who needs a
+// second factor with a short-lived mfa token instead of the JWT tokens.
+func issueTwoFactorChallenge(c *gin.Context, user models.User, tokenType string) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(mfaTokenLifespan)
+	token, err := jwt.NewBuilder().
+		Issuer(os.Getenv("DEFAULT_ISSUER")).
+		IssuedAt(now).
+		NotBefore(now).
+		Expiration(expiresAt).
+		Claim("sub", user.Id.String()).
+		Claim("type", tokenType).
+		Build()
+	if err == nil {
+		var signed []byte
+		signed, err = signInternalToken(token, os.Getenv("API_SECRET"))
+		if err == nil {
+			res := models.TwoFactorChallengeResponse{
+				Status: http.StatusAccepted,
+				Data: models.TwoFactorChallenge{
+					MfaToken:           string(signed),
+					ExpiresAt:          expiresAt.Format(time.RFC3339),
+					EnrollmentRequired: tokenType == mfaTokenTypeEnroll,
+				},
+			}
+			c.JSON(http.StatusAccepted, res)
+			return
+		}
+	}
+
+	logger.LogError("Failed to generate mfa token", zap.Error(err))
+	er := models.LicenseError{
+		Status:    http.StatusInternalServerError,
+		Message:   "Failed to generate token",
+		Error:     err.Error(),
+		Path:      c.Request.URL.Path,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	c.JSON(http.StatusInternalServerError, er)
+}
+
+// parseMfaToken verifies a token issued by issueTwoFactorChallenge and returns
+// the active user it was issued for along with the token type.
+func parseMfaToken(tokenString string) (*models.User, string, error) {
+	token, err := jwt.Parse(
+		[]byte(tokenString),
+		jwt.WithKey(jwa.HS256(), []byte(os.Getenv("API_SECRET"))),
+		jwt.WithValidate(true),
+		jwt.WithIssuer(os.Getenv("DEFAULT_ISSUER")),
+	)
+	if err != nil {
+		return nil, "", errors.New("token verification failed")
+	}
+
+	var tokenType string
+	if err := token.Get("type", &tokenType); err != nil ||
+		(tokenType != mfaTokenTypeVerify && tokenType != mfaTokenTypeEnroll) {
+		return nil, "", errors.New("invalid token type (expected 'mfa')")
+	}
+
+	var sub string
+	if err := token.Get("sub", &sub); err != nil {
+		return nil, "", errors.New("missing subject claim")
+	}
+	userId, err := uuid.Parse(sub)
+	if err != nil {
+		return nil, "", errors.New("invalid user ID in token")
+	}
+
+	active := true
+	var user models.User
+	if err := db.DB.Where(models.User{Id: userId, Active: &active}).First(&user).Error; err != nil {
+		return nil, "", errors.New("user not found")
+	}
+	return &user, tokenType, nil
+}
+
+// loggedInLocalUser fetches the user of the request. It writes the error
+// response and returns false if the user can not be found.
+func loggedInLocalUser(c *gin.Context) (*models.User, bool) {
+	userId := c.MustGet("userId").(uuid.UUID)
+
+	active := true
+	var user models.User
+	if err := db.DB.Where(models.User{Id: userId, Active: &active}).First(&user).Error; err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusNotFound,
+			Message:   "no user with such username exists",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusNotFound, er)
+		return nil, false
+	}
+	return &user, true
+}
+
+// startEnrollment stores a new, not yet enabled, TOTP secret for the user and
+// writes it to the response.
+func startEnrollment(c *gin.Context, user *models.User) {
+	if user.TotpEnabled != nil && *user.TotpEnabled {
+		er := models.LicenseError{
+			Status:    http.StatusConflict,
+			Message:   "two-factor authentication is already enabled",
+			Error:     "two-factor authentication is already enabled",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusConflict, er)
+		return
+	}
+
+	secret, err := generateTotpSecret()
+	if err == nil {
+		err = db.DB.Model(user).Updates(map[string]interface{}{"totp_secret": secret, "totp_last_step": nil}).Error
+	}
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to start two-factor enrollment",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	res := models.TwoFactorEnrollmentResponse{
+		Status: http.StatusOK,
+		Data: models.TwoFactorEnrollment{
+			Secret:          secret,
+			ProvisioningUri: totpProvisioningUri(secret, *user.UserName),
+		},
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// completeEnrollment enables the pending TOTP secret of the user after
+// checking a code generated from it and returns new recovery codes.
+func completeEnrollment(tx *gorm.DB, user *models.User, actorId uuid.UUID, code string) ([]string, error) {
+	if user.TotpSecret == nil || (user.TotpEnabled != nil && *user.TotpEnabled) {
+		return nil, errSecondFactorInvalid
+	}
+	if err := verifyTotp(tx, user, code); err != nil {
+		return nil, err
+	}
+
+	oldUser := *user
+	enabled := true
+	user.TotpEnabled = &enabled
+	if err := tx.Model(user).Update("totp_enabled", true).Error; err != nil {
+		return nil, err
+	}
+	if err := utils.AddChangelogsForUser(tx, actorId, user, &oldUser); err != nil {
+		return nil, err
+	}
+
+	return generateRecoveryCodes(tx, user.Id)
+}
+
+// resetTwoFactor removes the TOTP secret and recovery codes of the user.
+func resetTwoFactor(tx *gorm.DB, actorId uuid.UUID, user *models.User) error {
+	oldUser := *user
+	if err := tx.Model(user).Updates(map[string]interface{}{
+		"totp_secret":    nil,
+		"totp_enabled":   false,
+		"totp_last_step": nil,
+	}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where(models.UserRecoveryCode{UserId: user.Id}).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	disabled := false
+	user.TotpEnabled = &disabled
+	return utils.AddChangelogsForUser(tx, actorId, user, &oldUser)
+}
+
+// isTwoFactorRequired tells whether the policy of the user level makes two-factor
+// authentication mandatory.
+func isTwoFactorRequired(tx *gorm.DB, userLevel *string) (bool, error) {
+	if userLevel == nil {
+		return false, nil
+	}
+	var policy models.TwoFactorPolicy
+	err := tx.Where(models.TwoFactorPolicy{UserLevel: *userLevel}).Limit(1).Find(&policy).Error
+	if err != nil {
+		return false, err
+	}
+	return policy.Required != nil && *policy.Required, nil
+}
+
+// verifySecondFactor accepts either a TOTP code or an unused recovery code.
+func verifySecondFactor(tx *gorm.DB, user *models.User, code string) error {
+	code = normalizeCode(code)
+	if len(code) == totpDigits {
+		return verifyTotp(tx, user, code)
+	}
+	return useRecoveryCode(tx, user.Id, code)
+}
+
+// verifyTotp checks the code against the secret of the user. The last accepted
+// time step is persisted so that a code can not be replayed.
+func verifyTotp(tx *gorm.DB, user *models.User, code string) error {
+	if user.TotpSecret == nil {
+		return errSecondFactorInvalid
+	}
+	secret, err := totpEncoding.DecodeString(*user.TotpSecret)
+	if err != nil {
+		return err
+	}
+
+	code = normalizeCode(code)
+	currentStep := time.Now().Unix() / totpPeriod
+	for step := currentStep - totpSkew; step <= currentStep+totpSkew; step++ {
+		if user.TotpLastStep != nil && step <= *user.TotpLastStep {
+			continue
+		}
+		if !hmac.Equal([]byte(totpCode(secret, step)), []byte(code)) {
+			continue
+		}
+
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND (totp_last_step IS NULL OR totp_last_step < ?)", user.Id, step).
+			Update("totp_last_step", step)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errSecondFactorInvalid
+		}
+		user.TotpLastStep = &step
+		return nil
+	}
+	return errSecondFactorInvalid
+}
+
+// useRecoveryCode marks the matching unused recovery code of the user as used.
+func useRecoveryCode(tx *gorm.DB, userId uuid.UUID, code string) error {
+	var recoveryCodes []models.UserRecoveryCode
+	if err := tx.Where("user_id = ? AND used_at IS NULL", userId).Find(&recoveryCodes).Error; err != nil {
+		return err
+	}
+
+	for _, recoveryCode := range recoveryCodes {
+		if bcrypt.CompareHashAndPassword([]byte(recoveryCode.CodeHash), []byte(code)) != nil {
+			continue
+		}
+		result := tx.Model(&recoveryCode).Where("used_at IS NULL").Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errSecondFactorInvalid
+		}
+		return nil
+	}
+	return errSecondFactorInvalid
+}
+
+// generateRecoveryCodes replaces the recovery codes of the user and returns the
+// new codes in clear text.
+func generateRecoveryCodes(tx *gorm.DB, userId uuid.UUID) ([]string, error) {
+	if err := tx.Where(models.UserRecoveryCode{UserId: userId}).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		encoded := strings.ToLower(totpEncoding.EncodeToString(raw))
+		code := encoded[:4] + "-" + encoded[4:]
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(normalizeCode(code)), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.Create(&models.UserRecoveryCode{UserId: userId, CodeHash: string(hash)}).Error; err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// normalizeCode strips the separators users tend to type along with a code.
+func normalizeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
+
+func generateTotpSecret() (string, error) {
+	secret := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// totpCode computes the HOTP value (RFC 4226) of the secret for a time step.
+func totpCode(secret []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+// totpProvisioningUri builds the otpauth uri understood by authenticator apps.
+func totpProvisioningUri(secret, username string) string {
+	issuer := os.Getenv("TOTP_ISSUER")
+	if issuer == "" {
+		issuer = DEFAULT_TOTP_ISSUER
+	}
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + username,
+		RawQuery: query.Encode(),
+	}
+	return uri.String()
+}
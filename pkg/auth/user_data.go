@@ -0,0 +1,355 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/fossology/LicenseDb/pkg/utils"
+)
+
+// anonymisedValue replaces personal data in the change logs of anonymised users.
+const anonymisedValue = "[anonymised]"
+
+// ExportUserData bundles all data stored about a user.
+//
+//	@Summary		Export user data
+//	@Description	Export the profile of a user together with its oidc clients, owned service accounts, created
+//	@Description	licenses, scans, teams and all audits made by or about the user. Admins can export any user, other users only
+//	@Description	themselves.
+//	@Id				ExportUserData
+//	@Tags			Users
+//	@Produce		json
+//	@Param			username	path		string	true	"Username of the user"
+//	@Success		200			{object}	models.UserDataExport
+//	@Failure		403			{object}	models.LicenseError	"Not allowed to export this user"
+//	@Failure		404			{object}	models.LicenseError	"User not found"
+//	@Failure		500			{object}	models.LicenseError	"Failed to export user data"
+//	@Security		ApiKeyAuth
+//	@Router			/users/{username}/data-export [get]
+func ExportUserData(c *gin.Context) {
+	username := c.Param("username")
+	var user models.User
+	if err := db.DB.Where(models.User{UserName: &username}).First(&user).Error; err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusNotFound,
+			Message:   "no user with such username exists",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusNotFound, er)
+		return
+	}
+
+	isAdmin := slices.Contains([]string{"ADMIN", "SUPER_ADMIN"}, c.GetString("role"))
+	if !isAdmin && user.Id != c.MustGet("userId").(uuid.UUID) {
+		er := models.LicenseError{
+			Status:    http.StatusForbidden,
+			Message:   "You do not have the necessary permissions to access this resource",
+			Error:     "users can only export their own data",
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusForbidden, er)
+		return
+	}
+
+	export, err := collectUserData(db.DB, user)
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to export user data",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	fileName := strings.Map(func(r rune) rune {
+		if r == '+' || r == ':' {
+			return '_'
+		}
+		return r
+	}, fmt.Sprintf("user-data-export-%s.json", time.Now().Format(time.RFC3339)))
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+	c.JSON(http.StatusOK, export)
+}
+
+// collectUserData gathers the data linked to a user from all tables
+// referencing it.
+func collectUserData(tx *gorm.DB, user models.User) (*models.UserDataExport, error) {
+	export := models.UserDataExport{
+		ExportedAt: time.Now(),
+		User: models.UserDataExportProfile{
+			User:            user,
+			Active:          user.Active != nil && *user.Active,
+			ExternalId:      user.ExternalId,
+			AnonymisedAt:    user.AnonymisedAt,
+			ExternalIssuer:  user.ExternalIssuer,
+			ExternalSubject: user.ExternalSubject,
+		},
+		OidcClients:     []models.UserDataExportClient{},
+		ServiceAccounts: []models.ServiceAccountDTO{},
+		Licenses:        []models.UserDataExportLicense{},
+		Scans:           []models.ScanDTO{},
+		Teams:           []models.UserDataExportTeam{},
+		Audits:          []models.UserDataExportAudit{},
+	}
+
+	var clients []models.OidcClient
+	if err := tx.Where(&models.OidcClient{UserId: user.Id}).Order("add_date").Find(&clients).Error; err != nil {
+		return nil, err
+	}
+	for _, client := range clients {
+		export.OidcClients = append(export.OidcClients, models.UserDataExportClient{
+			ClientId: client.ClientId,
+			AddDate:  client.AddDate,
+		})
+	}
+
+	var serviceAccounts []models.ServiceAccount
+	memberTeams := tx.Table("team_members").Select("team_id").Where("user_id = ?", user.Id)
+	if err := tx.Preload("User").Preload("OwnerTeam").Where("owner_team_id IN (?)", memberTeams).
+		Order("created_at").Find(&serviceAccounts).Error; err != nil {
+		return nil, err
+	}
+	for i := range serviceAccounts {
+		var serviceAccountClients []models.OidcClient
+		if err := tx.Where(&models.OidcClient{UserId: serviceAccounts[i].UserId}).Find(&serviceAccountClients).Error; err != nil {
+			return nil, err
+		}
+		export.ServiceAccounts = append(export.ServiceAccounts, serviceAccounts[i].ConvertToServiceAccountDTO(serviceAccountClients))
+	}
+
+	var licenses []models.LicenseDB
+	if err := tx.Select("rf_id", "rf_shortname", "rf_add_date").Where(&models.LicenseDB{UserId: user.Id}).
+		Order("rf_add_date").Find(&licenses).Error; err != nil {
+		return nil, err
+	}
+	for _, license := range licenses {
+		export.Licenses = append(export.Licenses, models.UserDataExportLicense{
+			Id:        license.Id,
+			Shortname: license.Shortname,
+			AddDate:   license.AddDate,
+		})
+	}
+
+	var scans []models.Scan
+	if err := tx.Where(&models.Scan{CreatedBy: user.Id}).Order("created_at").Find(&scans).Error; err != nil {
+		return nil, err
+	}
+	for i := range scans {
+		export.Scans = append(export.Scans, scans[i].ConvertToScanDTO())
+	}
+
+	var teams []models.Team
+	if err := tx.Where("id IN (?)", memberTeams).Order("name").Find(&teams).Error; err != nil {
+		return nil, err
+	}
+	for _, team := range teams {
+		export.Teams = append(export.Teams, models.UserDataExportTeam{Id: team.Id, Name: team.Name})
+	}
+
+	var audits []models.Audit
+	if err := tx.Preload("ChangeLogs").
+		Where("user_id = ? OR impersonator_id = ? OR (type = ? AND type_id = ?)", user.Id, user.Id, "USER", user.Id).
+		Order("timestamp").Find(&audits).Error; err != nil {
+		return nil, err
+	}
+	for _, audit := range audits {
+		relation := "SUBJECT"
+		if audit.UserId == user.Id {
+			relation = "ACTOR"
+		} else if audit.ImpersonatorId != nil && *audit.ImpersonatorId == user.Id {
+			relation = "IMPERSONATOR"
+		}
+		export.Audits = append(export.Audits, models.UserDataExportAudit{
+			Id:         audit.Id,
+			Relation:   relation,
+			Type:       audit.Type,
+			TypeId:     audit.TypeId,
+			Timestamp:  audit.Timestamp,
+			ChangeLogs: audit.ChangeLogs,
+		})
+	}
+
+	var recoveryCodes []models.UserRecoveryCode
+	if err := tx.Where(&models.UserRecoveryCode{UserId: user.Id}).Find(&recoveryCodes).Error; err != nil {
+		return nil, err
+	}
+	export.RecoveryCodes.Total = len(recoveryCodes)
+	for _, code := range recoveryCodes {
+		if code.UsedAt == nil {
+			export.RecoveryCodes.Unused++
+		}
+	}
+
+	return &export, nil
+}
+
+// AnonymiseUser scrubs the personal data of a user.
+//
+//	@Summary		Anonymise user
+//	@Description	Replace the name of the user by a pseudonym, remove its email, credentials and oidc clients and
+//	@Description	deactivate it. The user row stays as pseudonymous placeholder, so audits and licenses keep
+//	@Description	referencing it. Names and emails in the change logs of the user are scrubbed as well.
+//	@Id				AnonymiseUser
+//	@Tags			Users
+//	@Produce		json
+//	@Param			username	path		string	true	"Username of the user to anonymise"
+//	@Success		200			{object}	models.UserResponse
+//	@Failure		400			{object}	models.LicenseError	"Own user or service account"
+//	@Failure		404			{object}	models.LicenseError	"User not found"
+//	@Failure		409			{object}	models.LicenseError	"User is already anonymised"
+//	@Failure		500			{object}	models.LicenseError	"Failed to anonymise user"
+//	@Security		ApiKeyAuth
+//	@Router			/users/{username}/anonymise [post]
+func AnonymiseUser(c *gin.Context) {
+	userId := c.MustGet("userId").(uuid.UUID)
+	username := c.Param("username")
+
+	var user models.User
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(models.User{UserName: &username}).First(&user).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   "no user with such username exists",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return errUserDataHandled
+		}
+
+		if status, message := anonymiseUserConflict(tx, user, userId); status != 0 {
+			er := models.LicenseError{
+				Status:    status,
+				Message:   message,
+				Error:     message,
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(status, er)
+			return errUserDataHandled
+		}
+
+		oldUser := user
+		now := time.Now()
+		pseudonym := "anonymised-" + user.Id.String()
+		if err := tx.Model(&user).Updates(map[string]interface{}{
+			"user_name":        pseudonym,
+			"display_name":     "Anonymised user",
+			"user_email":       nil,
+			"user_password":    nil,
+			"totp_secret":      nil,
+			"totp_enabled":     false,
+			"totp_last_step":   nil,
+			"external_id":      nil,
+			"external_issuer":  nil,
+			"external_subject": nil,
+			"subscribed":       false,
+			"active":           false,
+			"anonymised_at":    now,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(&models.OidcClient{UserId: user.Id}).Delete(&models.OidcClient{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(&models.UserRecoveryCode{UserId: user.Id}).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		// Earlier changes of the user recorded its name and email
+		scrub := gorm.Expr("CASE WHEN old_value IS NULL OR old_value = '' THEN old_value ELSE ? END", anonymisedValue)
+		scrubUpdated := gorm.Expr("CASE WHEN updated_value IS NULL OR updated_value = '' THEN updated_value ELSE ? END", anonymisedValue)
+		userAudits := tx.Model(&models.Audit{}).Select("id").Where(&models.Audit{Type: "USER", TypeId: user.Id})
+		if err := tx.Model(&models.ChangeLog{}).
+			Where("field IN ?", []string{"UserName", "DisplayName", "UserEmail"}).
+			Where("audit_id IN (?)", userAudits).
+			Updates(map[string]interface{}{"old_value": scrub, "updated_value": scrubUpdated}).Error; err != nil {
+			return err
+		}
+
+		var changes []models.ChangeLog
+		inactive := false
+		anonymisedAt := now.Format(time.RFC3339)
+		utils.AddChangelog("Active", oldUser.Active, &inactive, &changes)
+		utils.AddChangelog("AnonymisedAt", nil, &anonymisedAt, &changes)
+		audit := models.Audit{
+			UserId:     userId,
+			TypeId:     user.Id,
+			Timestamp:  now,
+			Type:       "USER",
+			ChangeLogs: changes,
+		}
+		if err := tx.Create(&audit).Error; err != nil {
+			return err
+		}
+
+		return tx.Where(models.User{Id: user.Id}).First(&user).Error
+	})
+	if errors.Is(err, errUserDataHandled) {
+		return
+	}
+	if err != nil {
+		er := models.LicenseError{
+			Status:    http.StatusInternalServerError,
+			Message:   "Failed to anonymise user",
+			Error:     err.Error(),
+			Path:      c.Request.URL.Path,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}
+		c.JSON(http.StatusInternalServerError, er)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.UserResponse{
+		Data:   []models.User{user},
+		Status: http.StatusOK,
+		Meta: &models.PaginationMeta{
+			ResourceCount: 1,
+		},
+	})
+}
+
+// errUserDataHandled rolls back the transaction after the response has been
+// written.
+var errUserDataHandled = errors.New("response already written")
+
+// anonymiseUserConflict returns the status and message if the user can not be
+// anonymised.
+func anonymiseUserConflict(tx *gorm.DB, user models.User, actorId uuid.UUID) (int, string) {
+	if user.AnonymisedAt != nil {
+		return http.StatusConflict, "user is already anonymised"
+	}
+	if user.Id == actorId {
+		return http.StatusBadRequest, "can not anonymise yourself"
+	}
+	var serviceAccounts int64
+	if err := tx.Model(&models.ServiceAccount{}).Where(&models.ServiceAccount{UserId: user.Id}).Count(&serviceAccounts).Error; err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	if serviceAccounts != 0 {
+		return http.StatusBadRequest, "service accounts hold no personal data, delete them instead"
+	}
+	return 0, ""
+}
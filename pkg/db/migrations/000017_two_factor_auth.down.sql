@@ -0,0 +1,12 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+DROP TABLE IF EXISTS two_factor_policies;
+DROP TABLE IF EXISTS user_recovery_codes;
+
+ALTER TABLE users DROP COLUMN IF EXISTS totp_last_step;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_enabled;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_secret;
+COMMIT;
@@ -0,0 +1,32 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_secret TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_last_step BIGINT;
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id        UUID NOT NULL PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id   UUID NOT NULL,
+    code_hash TEXT NOT NULL,
+    used_at   TIMESTAMPTZ,
+    CONSTRAINT fk_user_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_user_recovery_codes_user_id ON user_recovery_codes(user_id);
+
+CREATE TABLE IF NOT EXISTS two_factor_policies (
+    id         UUID    NOT NULL PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_level TEXT    NOT NULL UNIQUE,
+    required   BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+INSERT INTO two_factor_policies (user_level) VALUES
+    ('USER'),
+    ('ADMIN'),
+    ('SUPER_ADMIN')
+ON CONFLICT (user_level) DO NOTHING;
+COMMIT;
@@ -0,0 +1,6 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+-- Identifier of the user in the provisioning client, see SCIM externalId
+ALTER TABLE users ADD COLUMN IF NOT EXISTS external_id TEXT;
@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+DROP TABLE IF EXISTS service_accounts;
+
+UPDATE users SET user_email = user_name || '@service-account.invalid' WHERE user_email IS NULL;
+ALTER TABLE users ALTER COLUMN user_email SET NOT NULL;
+COMMIT;
@@ -0,0 +1,24 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- Service accounts act through a user row without password and email, so that
+-- audits, licenses and oidc clients can reference them like any other user
+ALTER TABLE users ALTER COLUMN user_email DROP NOT NULL;
+
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id          UUID NOT NULL PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id     UUID NOT NULL,
+    description TEXT,
+    owner_id    UUID NOT NULL,
+    expires_at  TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uni_service_accounts_user_id UNIQUE (user_id),
+    CONSTRAINT fk_service_accounts_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_service_accounts_owner FOREIGN KEY (owner_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS idx_service_accounts_owner_id ON service_accounts(owner_id);
+COMMIT;
@@ -0,0 +1,9 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+DROP INDEX IF EXISTS idx_audits_impersonator_id;
+ALTER TABLE audits DROP CONSTRAINT IF EXISTS fk_audits_impersonator;
+ALTER TABLE audits DROP COLUMN IF EXISTS impersonator_id;
+COMMIT;
@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+-- Super admin who made the change while impersonating the audit's user
+ALTER TABLE audits ADD COLUMN IF NOT EXISTS impersonator_id UUID;
+ALTER TABLE audits ADD CONSTRAINT fk_audits_impersonator FOREIGN KEY (impersonator_id) REFERENCES users(id);
+CREATE INDEX IF NOT EXISTS idx_audits_impersonator_id ON audits(impersonator_id) WHERE impersonator_id IS NOT NULL;
+COMMIT;
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+ALTER TABLE users DROP COLUMN IF EXISTS anonymised_at;
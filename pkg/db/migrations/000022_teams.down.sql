@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+ALTER TABLE obligations DROP COLUMN IF EXISTS steward_team_id;
+ALTER TABLE license_dbs DROP COLUMN IF EXISTS steward_team_id;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
+COMMIT;
@@ -0,0 +1,33 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+CREATE TABLE IF NOT EXISTS teams (
+    id                 UUID NOT NULL PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name               TEXT NOT NULL,
+    description        TEXT,
+    steward_only_edits BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at         TIMESTAMPTZ
+);
+-- Deleted teams are kept for their audits, their names can be reused
+CREATE UNIQUE INDEX IF NOT EXISTS uni_teams_name ON teams(name) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    PRIMARY KEY (team_id, user_id),
+    CONSTRAINT fk_team_members_team FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE,
+    CONSTRAINT fk_team_members_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_team_members_user_id ON team_members(user_id);
+
+ALTER TABLE license_dbs ADD COLUMN IF NOT EXISTS steward_team_id UUID;
+ALTER TABLE license_dbs ADD CONSTRAINT fk_license_dbs_steward_team FOREIGN KEY (steward_team_id) REFERENCES teams(id);
+CREATE INDEX IF NOT EXISTS idx_license_dbs_steward_team_id ON license_dbs(steward_team_id) WHERE steward_team_id IS NOT NULL;
+
+ALTER TABLE obligations ADD COLUMN IF NOT EXISTS steward_team_id UUID;
+ALTER TABLE obligations ADD CONSTRAINT fk_obligations_steward_team FOREIGN KEY (steward_team_id) REFERENCES teams(id);
+CREATE INDEX IF NOT EXISTS idx_obligations_steward_team_id ON obligations(steward_team_id) WHERE steward_team_id IS NOT NULL;
+COMMIT;
@@ -0,0 +1,13 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+ALTER TABLE obligations DROP COLUMN IF EXISTS review_reminded_at;
+ALTER TABLE obligations DROP COLUMN IF EXISTS last_reviewed_at;
+ALTER TABLE obligations DROP COLUMN IF EXISTS review_interval_days;
+
+ALTER TABLE license_dbs DROP COLUMN IF EXISTS review_reminded_at;
+ALTER TABLE license_dbs DROP COLUMN IF EXISTS last_reviewed_at;
+ALTER TABLE license_dbs DROP COLUMN IF EXISTS review_interval_days;
+COMMIT;
@@ -0,0 +1,15 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+-- Items with a positive review interval are overdue once the interval passed
+-- since their last review, or right away if they were never reviewed
+ALTER TABLE license_dbs ADD COLUMN IF NOT EXISTS review_interval_days INTEGER;
+ALTER TABLE license_dbs ADD COLUMN IF NOT EXISTS last_reviewed_at TIMESTAMPTZ;
+ALTER TABLE license_dbs ADD COLUMN IF NOT EXISTS review_reminded_at TIMESTAMPTZ;
+
+ALTER TABLE obligations ADD COLUMN IF NOT EXISTS review_interval_days INTEGER;
+ALTER TABLE obligations ADD COLUMN IF NOT EXISTS last_reviewed_at TIMESTAMPTZ;
+ALTER TABLE obligations ADD COLUMN IF NOT EXISTS review_reminded_at TIMESTAMPTZ;
+COMMIT;
@@ -0,0 +1,11 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+DROP INDEX IF EXISTS idx_obligations_search_vector;
+ALTER TABLE obligations DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_license_dbs_search_vector;
+ALTER TABLE license_dbs DROP COLUMN IF EXISTS search_vector;
+COMMIT;
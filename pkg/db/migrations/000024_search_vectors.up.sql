@@ -0,0 +1,22 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+-- Weighted search vectors, names and ids rank above notes and license texts
+ALTER TABLE license_dbs ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(rf_shortname, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(rf_spdx_id, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(rf_fullname, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(rf_notes, '')), 'C') ||
+    setweight(to_tsvector('english', coalesce(rf_text, '')), 'D')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_license_dbs_search_vector ON license_dbs USING gin (search_vector);
+
+ALTER TABLE obligations ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(topic, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(comment, '')), 'C') ||
+    setweight(to_tsvector('english', coalesce(text, '')), 'D')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_obligations_search_vector ON obligations USING gin (search_vector);
+COMMIT;
@@ -0,0 +1,22 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+CREATE TABLE IF NOT EXISTS scans (
+    id          UUID NOT NULL PRIMARY KEY DEFAULT uuid_generate_v4(),
+    status      TEXT NOT NULL,
+    file_name   TEXT NOT NULL,
+    size        BIGINT NOT NULL,
+    error       TEXT,
+    result      JSONB,
+    created_by  UUID NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at  TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    CONSTRAINT fk_scans_created_by FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_scans_created_by ON scans(created_by);
+-- Scans which did not finish are failed on startup
+CREATE INDEX IF NOT EXISTS idx_scans_unfinished ON scans(status) WHERE finished_at IS NULL;
+COMMIT;
@@ -0,0 +1,17 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+CREATE TABLE IF NOT EXISTS license_aliases (
+    id         UUID NOT NULL PRIMARY KEY DEFAULT uuid_generate_v4(),
+    license_id UUID NOT NULL,
+    alias      TEXT NOT NULL,
+    namespace  TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_license_aliases_license FOREIGN KEY (license_id) REFERENCES license_dbs(rf_id) ON DELETE CASCADE
+);
+-- An alias names a single license within its namespace, regardless of case
+CREATE UNIQUE INDEX IF NOT EXISTS uni_license_aliases_namespace_alias ON license_aliases(namespace, lower(alias));
+CREATE INDEX IF NOT EXISTS idx_license_aliases_license_id ON license_aliases(license_id);
+COMMIT;
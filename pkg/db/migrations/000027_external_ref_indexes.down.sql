@@ -0,0 +1,8 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+DROP INDEX IF EXISTS idx_obligations_external_ref;
+DROP INDEX IF EXISTS idx_license_dbs_external_ref;
+COMMIT;
@@ -0,0 +1,9 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+-- Serve the containment (@>) queries of external_ref equality conditions
+CREATE INDEX IF NOT EXISTS idx_license_dbs_external_ref ON license_dbs USING GIN (external_ref jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_obligations_external_ref ON obligations USING GIN (external_ref jsonb_path_ops);
+COMMIT;
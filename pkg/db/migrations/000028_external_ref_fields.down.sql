@@ -0,0 +1,7 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+DROP TABLE IF EXISTS external_ref_fields;
+COMMIT;
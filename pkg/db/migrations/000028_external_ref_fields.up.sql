@@ -0,0 +1,23 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+CREATE TABLE IF NOT EXISTS external_ref_fields (
+    id         UUID NOT NULL PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entity     TEXT NOT NULL,
+    name       TEXT NOT NULL,
+    type       TEXT NOT NULL,
+    label      TEXT NOT NULL,
+    required   BOOLEAN NOT NULL DEFAULT FALSE,
+    enum       TEXT[],
+    regex      TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT external_ref_fields_entity CHECK (entity IN ('LICENSE', 'OBLIGATION')),
+    CONSTRAINT external_ref_fields_type CHECK (type IN ('string', 'int', 'number', 'boolean', 'date'))
+);
+-- Deleted fields are kept for their audits, their names can be reused
+CREATE UNIQUE INDEX IF NOT EXISTS uni_external_ref_fields_entity_name ON external_ref_fields(entity, name) WHERE deleted_at IS NULL;
+COMMIT;
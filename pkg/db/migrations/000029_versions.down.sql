@@ -0,0 +1,19 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+DROP TRIGGER IF EXISTS obligation_categories_version ON obligation_categories;
+DROP TRIGGER IF EXISTS obligation_classifications_version ON obligation_classifications;
+DROP TRIGGER IF EXISTS obligation_types_version ON obligation_types;
+DROP TRIGGER IF EXISTS obligations_version ON obligations;
+DROP TRIGGER IF EXISTS license_dbs_version ON license_dbs;
+
+ALTER TABLE obligation_categories DROP COLUMN IF EXISTS version;
+ALTER TABLE obligation_classifications DROP COLUMN IF EXISTS version;
+ALTER TABLE obligation_types DROP COLUMN IF EXISTS version;
+ALTER TABLE obligations DROP COLUMN IF EXISTS version;
+ALTER TABLE license_dbs DROP COLUMN IF EXISTS version;
+
+DROP FUNCTION IF EXISTS increase_version();
+COMMIT;
@@ -0,0 +1,35 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+-- Versions of rows for entity tags, increased with every update of a row
+CREATE OR REPLACE FUNCTION increase_version() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE license_dbs ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE obligations ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE obligation_types ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE obligation_classifications ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE obligation_categories ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+DROP TRIGGER IF EXISTS license_dbs_version ON license_dbs;
+CREATE TRIGGER license_dbs_version BEFORE UPDATE ON license_dbs
+    FOR EACH ROW EXECUTE FUNCTION increase_version();
+DROP TRIGGER IF EXISTS obligations_version ON obligations;
+CREATE TRIGGER obligations_version BEFORE UPDATE ON obligations
+    FOR EACH ROW EXECUTE FUNCTION increase_version();
+DROP TRIGGER IF EXISTS obligation_types_version ON obligation_types;
+CREATE TRIGGER obligation_types_version BEFORE UPDATE ON obligation_types
+    FOR EACH ROW EXECUTE FUNCTION increase_version();
+DROP TRIGGER IF EXISTS obligation_classifications_version ON obligation_classifications;
+CREATE TRIGGER obligation_classifications_version BEFORE UPDATE ON obligation_classifications
+    FOR EACH ROW EXECUTE FUNCTION increase_version();
+DROP TRIGGER IF EXISTS obligation_categories_version ON obligation_categories;
+CREATE TRIGGER obligation_categories_version BEFORE UPDATE ON obligation_categories
+    FOR EACH ROW EXECUTE FUNCTION increase_version();
+COMMIT;
@@ -0,0 +1,7 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+DROP INDEX IF EXISTS idx_users_external_identity;
+ALTER TABLE users DROP COLUMN IF EXISTS external_subject;
+ALTER TABLE users DROP COLUMN IF EXISTS external_issuer;
@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+-- Identity of users of external OIDC issuers, the iss and sub claims of their
+-- tokens. Users created by oidc tokens before, without a password, are bound
+-- to the identity of the token of their first login.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS external_issuer TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS external_subject TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_external_identity ON users (external_issuer, external_subject);
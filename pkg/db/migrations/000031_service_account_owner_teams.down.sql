@@ -0,0 +1,19 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+-- Service accounts are handed to a member of their owner team, or to
+-- themselves if the team has no members
+ALTER TABLE service_accounts ADD COLUMN IF NOT EXISTS owner_id UUID;
+UPDATE service_accounts s SET owner_id = COALESCE(
+    (SELECT m.user_id FROM team_members m WHERE m.team_id = s.owner_team_id ORDER BY m.user_id LIMIT 1),
+    s.user_id
+);
+ALTER TABLE service_accounts ALTER COLUMN owner_id SET NOT NULL;
+ALTER TABLE service_accounts ADD CONSTRAINT fk_service_accounts_owner FOREIGN KEY (owner_id) REFERENCES users(id);
+CREATE INDEX IF NOT EXISTS idx_service_accounts_owner_id ON service_accounts(owner_id);
+
+DROP INDEX IF EXISTS idx_service_accounts_owner_team_id;
+ALTER TABLE service_accounts DROP COLUMN IF EXISTS owner_team_id;
+COMMIT;
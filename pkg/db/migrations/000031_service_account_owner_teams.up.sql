@@ -0,0 +1,34 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+-- Service accounts are owned by a team instead of a single user. The accounts
+-- of each owner are moved to a team of that owner named after them.
+ALTER TABLE service_accounts ADD COLUMN IF NOT EXISTS owner_team_id UUID;
+
+INSERT INTO teams (name, description)
+SELECT DISTINCT 'service-accounts-' || u.user_name, 'Owners of the service accounts of ' || u.user_name
+FROM service_accounts s JOIN users u ON u.id = s.owner_id
+WHERE NOT EXISTS (
+    SELECT 1 FROM teams t WHERE t.name = 'service-accounts-' || u.user_name AND t.deleted_at IS NULL
+);
+
+INSERT INTO team_members (team_id, user_id)
+SELECT DISTINCT t.id, u.id
+FROM service_accounts s
+JOIN users u ON u.id = s.owner_id
+JOIN teams t ON t.name = 'service-accounts-' || u.user_name AND t.deleted_at IS NULL
+ON CONFLICT DO NOTHING;
+
+UPDATE service_accounts s SET owner_team_id = t.id
+FROM users u, teams t
+WHERE u.id = s.owner_id AND t.name = 'service-accounts-' || u.user_name AND t.deleted_at IS NULL;
+
+ALTER TABLE service_accounts ALTER COLUMN owner_team_id SET NOT NULL;
+ALTER TABLE service_accounts ADD CONSTRAINT fk_service_accounts_owner_team FOREIGN KEY (owner_team_id) REFERENCES teams(id);
+CREATE INDEX IF NOT EXISTS idx_service_accounts_owner_team_id ON service_accounts(owner_team_id);
+
+DROP INDEX IF EXISTS idx_service_accounts_owner_id;
+ALTER TABLE service_accounts DROP COLUMN IF EXISTS owner_id;
+COMMIT;
@@ -0,0 +1,11 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+BEGIN;
+DELETE FROM change_logs WHERE audit_id IN (
+    SELECT id FROM audits WHERE user_id = '5c1a0000-0000-4000-8000-000000000001'
+);
+DELETE FROM audits WHERE user_id = '5c1a0000-0000-4000-8000-000000000001';
+DELETE FROM users WHERE id = '5c1a0000-0000-4000-8000-000000000001';
+COMMIT;
@@ -0,0 +1,9 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+-- Changes of SCIM provisioning clients are audited as this user. It is
+-- deactivated and has no password, so it can not log in.
+INSERT INTO users (id, user_name, display_name, user_level, active)
+VALUES ('5c1a0000-0000-4000-8000-000000000001', 'scim-provisioning', 'SCIM provisioning', 'USER', false)
+ON CONFLICT (id) DO NOTHING;
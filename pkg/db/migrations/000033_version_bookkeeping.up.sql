@@ -0,0 +1,15 @@
+-- SPDX-FileCopyrightText: 2026 FOSSology contributors
+--
+-- SPDX-License-Identifier: GPL-2.0-only
+
+-- Versions only increase if user-editable columns change. Review bookkeeping
+-- and the generated search vectors do not change the entity tag.
+CREATE OR REPLACE FUNCTION increase_version() RETURNS TRIGGER AS $$
+BEGIN
+    IF to_jsonb(NEW) - 'version' - 'search_vector' - 'last_reviewed_at' - 'review_reminded_at'
+        IS DISTINCT FROM to_jsonb(OLD) - 'version' - 'search_vector' - 'last_reviewed_at' - 'review_reminded_at' THEN
+        NEW.version := OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
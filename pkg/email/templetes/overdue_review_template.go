@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package templates
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+func OverdueReviewEmailTemplate(userName string, items []string, timestamp time.Time) (string, string) {
+	subject := fmt.Sprintf("%d item(s) overdue for review – %s", len(items), timestamp.Format("Jan 2, 2006"))
+
+	var list strings.Builder
+	for _, item := range items {
+		fmt.Fprintf(&list, "<li>%s</li>", html.EscapeString(item))
+	}
+
+	body := fmt.Sprintf(`
+		<!DOCTYPE html>
+		<html lang="en">
+		<head>
+			<meta charset="UTF-8">
+			<title>Overdue Reviews</title>
+			<style>
+				body {
+					font-family: Arial, sans-serif;
+					line-height: 1.6;
+					color: #333;
+					background-color: #f7f7f7;
+					padding: 20px;
+				}
+				.container {
+					max-width: 600px;
+					margin: auto;
+					background: #ffffff;
+					padding: 20px;
+					border-radius: 8px;
+					box-shadow: 0 2px 5px rgba(0, 0, 0, 0.1);
+				}
+				h2 {
+					color: #E65100;
+				}
+				.footer {
+					margin-top: 30px;
+					font-size: 12px;
+					color: #888;
+				}
+				.items {
+					background-color: #f1f1f1;
+					padding: 10px 10px 10px 30px;
+					border-radius: 5px;
+					margin-top: 10px;
+				}
+			</style>
+		</head>
+		<body>
+			<div class="container">
+				<h2>Reviews Overdue</h2>
+				<p>Dear %s,</p>
+
+				<p>As of <em>%s</em>, the following licenses and obligations are overdue for their periodic review:</p>
+
+				<ul class="items">%s</ul>
+
+				<p>Please review them and record the review in LicenseDB.</p>
+
+				<p>Regards,<br><strong>LicenseDB Team</strong></p>
+
+				<div class="footer">
+					This is an automated message. Please do not reply directly to this email.<br>
+					Need help? Contact <a href="mailto:support@licensedb.org">support@licensedb.org</a>
+				</div>
+			</div>
+		</body>
+		</html>
+	`, userName, timestamp.Format("Monday, Jan 2, 2006 at 15:04 MST"), list.String())
+
+	return subject, body
+}
@@ -59,3 +59,8 @@ func NotifyImportSummary(to, userName, importType string, total, success, failed
 	)
 	Email.enqueueAsync(EmailData{To: admins, Subject: adminSubject, HTML: adminHTML})
 }
+
+func NotifyOverdueReviews(to []string, userName string, items []string) {
+	subject, html := templates.OverdueReviewEmailTemplate(userName, items, time.Now())
+	Email.enqueueAsync(EmailData{To: to, Subject: subject, HTML: html})
+}
@@ -0,0 +1,339 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+// Package filter parses the filter expressions of listings and compiles them
+// to parameterised SQL conditions.
+//
+// Expressions follow RSQL: comparisons of the form field operator value are
+// combined with ";" or "and", which binds tighter, and "," or "or", and can
+// be grouped with parentheses, e.g.
+//
+//	risk=ge=3;(source=in=(spdx,fossology),fullname=like="GNU General")
+//
+// Values are quoted with ' or " when they contain spaces or any of the
+// reserved characters "'(),;=!<>, with \ escaping the quote inside quotes.
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxComparisons bounds the size of the SQL an expression compiles to
+	maxComparisons = 50
+	// maxDepth bounds the nesting of parentheses
+	maxDepth = 10
+)
+
+// Operators of comparisons, with their RSQL aliases.
+const (
+	OpEqual        = "=="
+	OpNotEqual     = "!="
+	OpLess         = "=lt="
+	OpLessEqual    = "=le="
+	OpGreater      = "=gt="
+	OpGreaterEqual = "=ge="
+	OpIn           = "=in="
+	OpNotIn        = "=out="
+	OpLike         = "=like="
+	OpNull         = "=null="
+)
+
+var operatorAliases = map[string]string{
+	"<":  OpLess,
+	"<=": OpLessEqual,
+	">":  OpGreater,
+	">=": OpGreaterEqual,
+}
+
+var operators = []string{OpEqual, OpNotEqual, OpLess, OpLessEqual, OpGreater, OpGreaterEqual, OpIn, OpNotIn, OpLike, OpNull}
+
+// Error is a syntax or semantic error of a filter expression.
+type Error struct {
+	// Pos is the byte offset of the error in the expression, or -1 if the
+	// expression was not parsed from text
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	if e.Pos < 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// Expr is a node of a parsed filter expression: either a comparison or the
+// conjunction or disjunction of its operands.
+type Expr struct {
+	// Or combines the operands with OR instead of AND
+	Or       bool
+	Operands []*Expr
+
+	Comparison *Comparison
+}
+
+// References reports whether the expression has a comparison of field. It is
+// false for a nil expression.
+func (e *Expr) References(field string) bool {
+	if e == nil {
+		return false
+	}
+	if e.Comparison != nil {
+		return e.Comparison.Field == field
+	}
+	for _, operand := range e.Operands {
+		if operand.References(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// Comparison compares a field with one or more values.
+type Comparison struct {
+	Field    string
+	Operator string
+	Values   []string
+	// Pos is the byte offset of the comparison in the expression, or -1
+	Pos int
+}
+
+// Parse parses a filter expression.
+func Parse(s string) (*Expr, error) {
+	p := &parser{s: s}
+	expr, err := p.or(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected '%c'", p.s[p.pos])
+	}
+	return expr, nil
+}
+
+type parser struct {
+	s           string
+	pos         int
+	comparisons int
+}
+
+func (p *parser) errorf(format string, args ...any) *Error {
+	return &Error{Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// keyword consumes the logical operator word if it is next.
+func (p *parser) keyword(word string) bool {
+	end := p.pos + len(word)
+	if end >= len(p.s) || !strings.EqualFold(p.s[p.pos:end], word) {
+		return false
+	}
+	if next := p.s[end]; !unicode.IsSpace(rune(next)) && next != '(' {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *parser) or(depth int) (*Expr, error) {
+	return p.logical(depth, true)
+}
+
+// logical parses the operands joined by the OR or AND operators.
+func (p *parser) logical(depth int, or bool) (*Expr, error) {
+	symbol, word := byte(';'), "and"
+	operand := func() (*Expr, error) { return p.primary(depth) }
+	if or {
+		symbol, word = ',', "or"
+		operand = func() (*Expr, error) { return p.logical(depth, false) }
+	}
+
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	expr := &Expr{Or: or, Operands: []*Expr{first}}
+	for {
+		p.skipSpace()
+		if p.pos < len(p.s) && p.s[p.pos] == symbol {
+			p.pos++
+		} else if !p.keyword(word) {
+			break
+		}
+		p.skipSpace()
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		expr.Operands = append(expr.Operands, next)
+	}
+	if len(expr.Operands) == 1 {
+		return first, nil
+	}
+	return expr, nil
+}
+
+func (p *parser) primary(depth int) (*Expr, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return nil, p.errorf("expected a comparison")
+	}
+	if p.s[p.pos] == '(' {
+		if depth == maxDepth {
+			return nil, p.errorf("expression is nested more than %d levels", maxDepth)
+		}
+		p.pos++
+		expr, err := p.or(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.pos >= len(p.s) || p.s[p.pos] != ')' {
+			return nil, p.errorf("expected ')'")
+		}
+		p.pos++
+		return expr, nil
+	}
+	comparison, err := p.comparison()
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{Comparison: comparison}, nil
+}
+
+func (p *parser) comparison() (*Comparison, error) {
+	p.comparisons++
+	if p.comparisons > maxComparisons {
+		return nil, p.errorf("expression has more than %d comparisons", maxComparisons)
+	}
+
+	c := &Comparison{Pos: p.pos}
+	start := p.pos
+	for p.pos < len(p.s) && isSelectorChar(p.s[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos {
+		return nil, p.errorf("expected a field name")
+	}
+	c.Field = p.s[start:p.pos]
+
+	p.skipSpace()
+	op, err := p.operator()
+	if err != nil {
+		return nil, err
+	}
+	c.Operator = op
+
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == '(' {
+		p.pos++
+		for {
+			p.skipSpace()
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			c.Values = append(c.Values, value)
+			p.skipSpace()
+			if p.pos < len(p.s) && p.s[p.pos] == ',' {
+				p.pos++
+				continue
+			}
+			if p.pos < len(p.s) && p.s[p.pos] == ')' {
+				p.pos++
+				break
+			}
+			return nil, p.errorf("expected ',' or ')' in the list of values")
+		}
+	} else {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		c.Values = []string{value}
+	}
+	return c, nil
+}
+
+func (p *parser) operator() (string, error) {
+	rest := p.s[p.pos:]
+	switch {
+	case strings.HasPrefix(rest, "=="), strings.HasPrefix(rest, "!="),
+		strings.HasPrefix(rest, "<="), strings.HasPrefix(rest, ">="):
+		p.pos += 2
+		return alias(rest[:2]), nil
+	case strings.HasPrefix(rest, "<"), strings.HasPrefix(rest, ">"):
+		p.pos++
+		return alias(rest[:1]), nil
+	case strings.HasPrefix(rest, "="):
+		end := strings.IndexByte(rest[1:], '=')
+		if end > 0 {
+			op := strings.ToLower(rest[:end+2])
+			for _, known := range operators {
+				if op == known {
+					p.pos += end + 2
+					return op, nil
+				}
+			}
+		}
+	}
+	return "", p.errorf("expected one of the operators %s, <, <=, >, >=", strings.Join(operators, ", "))
+}
+
+func alias(op string) string {
+	if canonical, ok := operatorAliases[op]; ok {
+		return canonical
+	}
+	return op
+}
+
+func (p *parser) value() (string, error) {
+	if p.pos >= len(p.s) {
+		return "", p.errorf("expected a value")
+	}
+	if quote := p.s[p.pos]; quote == '"' || quote == '\'' {
+		var b strings.Builder
+		p.pos++
+		for p.pos < len(p.s) {
+			ch := p.s[p.pos]
+			switch {
+			case ch == '\\' && p.pos+1 < len(p.s):
+				b.WriteByte(p.s[p.pos+1])
+				p.pos += 2
+			case ch == quote:
+				p.pos++
+				return b.String(), nil
+			default:
+				b.WriteByte(ch)
+				p.pos++
+			}
+		}
+		return "", p.errorf("unterminated quoted value")
+	}
+	start := p.pos
+	for p.pos < len(p.s) && !isReserved(p.s[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos {
+		return "", p.errorf("expected a value")
+	}
+	return p.s[start:p.pos], nil
+}
+
+func isSelectorChar(ch byte) bool {
+	return ch == '_' || ch == '.' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9'
+}
+
+func isReserved(ch byte) bool {
+	return strings.IndexByte("\"'(),;=!<>", ch) >= 0 || unicode.IsSpace(rune(ch))
+}
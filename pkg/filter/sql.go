@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind is the type of the values of a field.
+type Kind int
+
+const (
+	String Kind = iota
+	Integer
+	Float
+	Boolean
+	// Time fields take RFC 3339 timestamps or dates, which compare with
+	// the date of the field
+	Time
+)
+
+func (k Kind) String() string {
+	return [...]string{"string", "integer", "number", "boolean", "timestamp or date"}[k]
+}
+
+// Field is a field that filter expressions can compare.
+type Field struct {
+	Kind Kind
+	// Column is the SQL expression of the field. It is never taken from
+	// the expression, so it may be any trusted SQL.
+	Column string
+	// Exists, if set, is the SQL of a correlated subquery of related rows
+	// with a %s in place of the condition on Column, which makes the
+	// comparison hold if any related row satisfies it.
+	Exists string
+	// Document and Key, if set, are the jsonb column and key the value of
+	// the field is taken from. Equality is then tested by containment in
+	// the document, which GIN indexes on the document serve.
+	Document string
+	Key      string
+}
+
+// JSONField returns the field of the value of key in the jsonb column
+// document, cast to the SQL type of kind.
+func JSONField(kind Kind, document, key string) Field {
+	column := fmt.Sprintf("(%s->>'%s')", document, strings.ReplaceAll(key, "'", "''"))
+	switch kind {
+	case Integer:
+		column += "::bigint"
+	case Float:
+		column += "::double precision"
+	case Boolean:
+		column += "::boolean"
+	case Time:
+		column += "::timestamptz"
+	}
+	return Field{Kind: kind, Column: column, Document: document, Key: key}
+}
+
+// Fields are the fields of a listing by name.
+type Fields map[string]Field
+
+// Compile parses a filter expression and compiles it to an SQL condition
+// with ? placeholders for its arguments. Fields unknown to fields, operators
+// unsupported by a field and values not of the kind of their field are
+// rejected with an *Error.
+func (fields Fields) Compile(s string) (string, []any, error) {
+	expr, err := Parse(s)
+	if err != nil {
+		return "", nil, err
+	}
+	return fields.CompileExpr(expr)
+}
+
+// CompileExpr compiles a parsed filter expression like Compile.
+func (fields Fields) CompileExpr(expr *Expr) (string, []any, error) {
+	var b strings.Builder
+	var args []any
+	if err := fields.compile(expr, &b, &args); err != nil {
+		return "", nil, err
+	}
+	return b.String(), args, nil
+}
+
+func (fields Fields) compile(expr *Expr, b *strings.Builder, args *[]any) error {
+	if expr.Comparison != nil {
+		return fields.compileComparison(expr.Comparison, b, args)
+	}
+	joiner := " AND "
+	if expr.Or {
+		joiner = " OR "
+	}
+	b.WriteByte('(')
+	for i, operand := range expr.Operands {
+		if i != 0 {
+			b.WriteString(joiner)
+		}
+		if err := fields.compile(operand, b, args); err != nil {
+			return err
+		}
+	}
+	b.WriteByte(')')
+	return nil
+}
+
+func (fields Fields) compileComparison(c *Comparison, b *strings.Builder, args *[]any) error {
+	field, ok := fields[c.Field]
+	if !ok {
+		return &Error{Pos: c.Pos, Msg: fmt.Sprintf("unknown field '%s', expected one of %s", c.Field, strings.Join(fields.names(), ", "))}
+	}
+	errorf := func(format string, a ...any) error {
+		return &Error{Pos: c.Pos, Msg: fmt.Sprintf("field '%s': ", c.Field) + fmt.Sprintf(format, a...)}
+	}
+
+	if len(c.Values) > 1 && c.Operator != OpIn && c.Operator != OpNotIn {
+		return errorf("operator %s takes a single value", c.Operator)
+	}
+
+	column := field.Column
+	var condition string
+	var values []any
+	switch c.Operator {
+	case OpNull:
+		isNull, err := strconv.ParseBool(c.Values[0])
+		if err != nil {
+			return errorf("operator %s takes true or false", c.Operator)
+		}
+		condition = column + " IS NOT NULL"
+		if isNull {
+			condition = column + " IS NULL"
+		}
+	case OpLike:
+		if field.Kind != String {
+			return errorf("operator %s applies to strings only", c.Operator)
+		}
+		condition = column + " ILIKE ?"
+		values = []any{"%" + likeEscaper.Replace(c.Values[0]) + "%"}
+	default:
+		if field.Kind == Time && isDate(c.Values) {
+			column = "(" + column + ")::date"
+		}
+		for _, raw := range c.Values {
+			value, err := convert(field.Kind, raw)
+			if err != nil {
+				return errorf("'%s' is not a valid %s", raw, field.Kind)
+			}
+			values = append(values, value)
+		}
+		switch c.Operator {
+		case OpEqual:
+			condition = column + " = ?"
+			if field.Document != "" && field.Kind != Time {
+				document, err := json.Marshal(map[string]any{field.Key: values[0]})
+				if err != nil {
+					return errorf("'%s' is not a valid %s", c.Values[0], field.Kind)
+				}
+				condition = field.Document + " @> ?::jsonb"
+				values = []any{string(document)}
+			}
+		case OpNotEqual:
+			condition = column + " <> ?"
+		case OpLess, OpLessEqual, OpGreater, OpGreaterEqual:
+			if field.Kind == String || field.Kind == Boolean {
+				return errorf("operator %s applies to numbers and timestamps only", c.Operator)
+			}
+			condition = column + " " + map[string]string{OpLess: "<", OpLessEqual: "<=", OpGreater: ">", OpGreaterEqual: ">="}[c.Operator] + " ?"
+		case OpIn:
+			condition = column + " IN ?"
+			values = []any{values}
+		case OpNotIn:
+			condition = column + " NOT IN ?"
+			values = []any{values}
+		}
+	}
+
+	if field.Exists != "" {
+		condition = fmt.Sprintf(field.Exists, condition)
+	}
+	b.WriteString(condition)
+	*args = append(*args, values...)
+	return nil
+}
+
+func (fields Fields) names() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func isDate(values []string) bool {
+	for _, value := range values {
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func convert(kind Kind, value string) (any, error) {
+	switch kind {
+	case Integer:
+		return strconv.ParseInt(value, 10, 64)
+	case Float:
+		return strconv.ParseFloat(value, 64)
+	case Boolean:
+		return strconv.ParseBool(value)
+	case Time:
+		if _, err := time.Parse(time.DateOnly, value); err == nil {
+			return value, nil
+		}
+		return time.Parse(time.RFC3339, value)
+	}
+	return value, nil
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package matching
+
+// Index finds the templates sharing the most words with a text without
+// comparing the text with every template.
+type Index struct {
+	required []int
+	byWord   map[string][]int
+}
+
+// NewIndex indexes the required words of templates. Templates may be nil.
+func NewIndex(templates []*Template) *Index {
+	ix := &Index{required: make([]int, len(templates)), byWord: map[string][]int{}}
+	for i, t := range templates {
+		if t == nil {
+			continue
+		}
+		ix.required[i] = len(t.required)
+		for word := range t.required {
+			ix.byWord[word] = append(ix.byWord[word], i)
+		}
+	}
+	return ix
+}
+
+// Containments returns the containment of each indexed template in the given
+// words, see Template.Containment.
+func (ix *Index) Containments(words map[string]struct{}) []float64 {
+	found := make([]int, len(ix.required))
+	for word := range words {
+		for _, i := range ix.byWord[word] {
+			found[i]++
+		}
+	}
+	containments := make([]float64, len(ix.required))
+	for i, required := range ix.required {
+		if required != 0 {
+			containments[i] = float64(found[i]) / float64(required)
+		}
+	}
+	return containments
+}
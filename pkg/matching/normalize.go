@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+// Package matching identifies licenses in arbitrary text following the SPDX
+// License Matching Guidelines (https://spdx.github.io/spdx-spec/v2.3/license-matching-guidelines-and-templates/).
+package matching
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Word is a normalized word of a text along with its position in the text,
+// counted in runes.
+type Word struct {
+	Text  string
+	Start int
+	End   int
+}
+
+var (
+	// Copyright notices are ignored (guideline 11), but not the wrapped
+	// lines of sentences about copyright notices
+	copyrightLine = regexp.MustCompile(`(?i)^\s*(?:portions\s+)?(?:copyright\s*(?:\(c\)|©|\d{4}|<year>|\[yyyy\]|\{yyyy\})|\(c\)|©)`)
+	// Comment markers of license headers in source code are ignored
+	commentMarker = regexp.MustCompile(`^\s*(?:/\*+|\*+/?|//+|#+|--|;+|%+)`)
+	// Bullets and numbering of list items are ignored (guideline 7)
+	listItem = regexp.MustCompile(`(?i)^\s*(?:[*•·‣◦\-–—]|\(\w{1,5}\)|\d+(?:\.\d+)*[.)]?|(?:[a-z]|[ivxlc]{1,5})[.)])\s+`)
+)
+
+// equivalentWords are varietal spellings which are considered equal
+// (guideline 8), along with http and https urls (guideline 9).
+var equivalentWords = map[string]string{
+	"acknowledgement":  "acknowledgment",
+	"acknowledgements": "acknowledgments",
+	"analogue":         "analog",
+	"authorisation":    "authorization",
+	"authorised":       "authorized",
+	"behaviour":        "behavior",
+	"cancelled":        "canceled",
+	"centre":           "center",
+	"favour":           "favor",
+	"favourable":       "favorable",
+	"https":            "http",
+	"initialise":       "initialize",
+	"licence":          "license",
+	"licences":         "licenses",
+	"memorise":         "memorize",
+	"modelled":         "modeled",
+	"organisation":     "organization",
+	"organise":         "organize",
+	"practise":         "practice",
+	"recognise":        "recognize",
+	"sublicence":       "sublicense",
+	"utilise":          "utilize",
+	"whilst":           "while",
+	"wilful":           "willful",
+}
+
+// Words splits a text into normalized words. Words are lower cased (guideline
+// 4) and punctuation and whitespace are dropped (guidelines 5 and 6), as are
+// copyright notices and the bullets of list items. Repeated words such as
+// "the the" count once.
+func Words(text string) []Word {
+	var words []Word
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		lineStart := offset
+		offset += len([]rune(line))
+		skip := 0
+		if loc := commentMarker.FindStringIndex(line); loc != nil {
+			skip = loc[1]
+		}
+		if loc := listItem.FindStringIndex(line[skip:]); loc != nil {
+			skip += loc[1]
+		}
+		if copyrightLine.MatchString(line[skip:]) {
+			continue
+		}
+		words = appendWords(words, []rune(line), lineStart, len([]rune(line[:skip])))
+	}
+	return words
+}
+
+// appendWords appends the words of a line starting at the rune offset start
+// of the text, skipping its first runes.
+func appendWords(words []Word, line []rune, start, skip int) []Word {
+	for i := skip; i < len(line); {
+		if isMarker(line[i]) {
+			words = append(words, Word{Text: string(line[i]), Start: start + i, End: start + i + 1})
+			i++
+			continue
+		}
+		if !isWordRune(line[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(line) && isWordRune(line[j]) {
+			j++
+		}
+		word := strings.ToLower(string(line[i:j]))
+		if equivalent, ok := equivalentWords[word]; ok {
+			word = equivalent
+		}
+		if len(words) == 0 || words[len(words)-1].Text != word {
+			words = append(words, Word{Text: word, Start: start + i, End: start + j})
+		}
+		i = j
+	}
+	return words
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
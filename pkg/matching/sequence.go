@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package matching
+
+import "sort"
+
+const (
+	// Shorter matching passages are considered coincidental
+	minBlockWords = 3
+	// Matching passages separated by at most this many words form one portion
+	maxPortionGapWords = 3
+)
+
+// block is a passage of a.size words which are the same in a at a.a and b
+// at b.b.
+type block struct {
+	a, b, size int
+}
+
+// matchingBlocks returns the passages which are the same in a and b, ordered
+// by their position. Like the Ratcliff/Obershelp algorithm it takes the
+// longest common passage and recurses into the parts before and after it.
+func matchingBlocks(a, b []string) []block {
+	positions := map[string][]int{}
+	for j, word := range b {
+		positions[word] = append(positions[word], j)
+	}
+	lengths := make([]int, len(b)+1)
+	next := make([]int, len(b)+1)
+
+	longest := func(alo, ahi, blo, bhi int) block {
+		best := block{a: alo, b: blo}
+		var touched, nextTouched []int
+		for i := alo; i < ahi; i++ {
+			nextTouched = nextTouched[:0]
+			for _, j := range positions[a[i]] {
+				if j < blo {
+					continue
+				}
+				if j >= bhi {
+					break
+				}
+				k := lengths[j] + 1
+				next[j+1] = k
+				nextTouched = append(nextTouched, j+1)
+				if k > best.size {
+					best = block{a: i - k + 1, b: j - k + 1, size: k}
+				}
+			}
+			for _, j := range touched {
+				lengths[j] = 0
+			}
+			for _, j := range nextTouched {
+				lengths[j], next[j] = next[j], 0
+			}
+			touched, nextTouched = nextTouched, touched
+		}
+		for _, j := range touched {
+			lengths[j] = 0
+		}
+		return best
+	}
+
+	var blocks []block
+	queue := [][4]int{{0, len(a), 0, len(b)}}
+	for len(queue) != 0 {
+		r := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		m := longest(r[0], r[1], r[2], r[3])
+		if m.size == 0 {
+			continue
+		}
+		blocks = append(blocks, m)
+		if r[0] < m.a && r[2] < m.b {
+			queue = append(queue, [4]int{r[0], m.a, r[2], m.b})
+		}
+		if m.a+m.size < r[1] && m.b+m.size < r[3] {
+			queue = append(queue, [4]int{m.a + m.size, r[1], m.b + m.size, r[3]})
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].a < blocks[j].a })
+	return blocks
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package matching
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	spdxTag = regexp.MustCompile(`SPDX-License-Identifier:[ \t]*([^\r\n]*)`)
+	// Comment closers and string delimiters trailing the tag are not part of
+	// its expression
+	spdxTagEnd      = regexp.MustCompile(`\s*(?:\*+/|-->|\*\)|["'` + "`" + `;,]+)+\s*$`)
+	spdxLicenseId   = regexp.MustCompile(`[A-Za-z0-9][A-Za-z0-9.\-+:]*`)
+	spdxExpressions = map[string]bool{"AND": true, "OR": true, "WITH": true}
+)
+
+// Identifier is an SPDX-License-Identifier tag of a text.
+type Identifier struct {
+	// Line is the line of the tag, starting at 1
+	Line int
+	// Expression is the SPDX license expression of the tag
+	Expression string
+	// Ids are the license and exception ids of the expression
+	Ids []string
+}
+
+// Identifiers returns the SPDX-License-Identifier tags of a text.
+func Identifiers(text string) []Identifier {
+	var identifiers []Identifier
+	for i, line := range strings.Split(text, "\n") {
+		groups := spdxTag.FindStringSubmatch(line)
+		if groups == nil {
+			continue
+		}
+		expression := strings.TrimSpace(spdxTagEnd.ReplaceAllString(groups[1], ""))
+		if expression == "" {
+			continue
+		}
+		identifier := Identifier{Line: i + 1, Expression: expression}
+		for _, id := range spdxLicenseId.FindAllString(expression, -1) {
+			if !spdxExpressions[strings.ToUpper(id)] {
+				identifier.Ids = append(identifier.Ids, id)
+			}
+		}
+		identifiers = append(identifiers, identifier)
+	}
+	return identifiers
+}
@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package matching
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Markers standing in for the markup of SPDX license templates while a
+// template is split into words.
+const (
+	markerVar           = '\uE000'
+	markerBeginOptional = '\uE001'
+	markerEndOptional   = '\uE002'
+)
+
+// Replaceable text may be at most this many words long, unless its match
+// pattern allows less.
+const maxReplaceableWords = 300
+
+var (
+	templateMarkup    = regexp.MustCompile(`(?s)<<(var|beginOptional|endOptional)(.*?)>>`)
+	templateAttribute = regexp.MustCompile(`(\w+)="((?:[^"\\]|\\.)*)"`)
+	boundedLength     = regexp.MustCompile(`\{(\d+),(\d+)\}`)
+)
+
+func isMarker(r rune) bool {
+	return r == markerVar || r == markerBeginOptional || r == markerEndOptional
+}
+
+// Template is a license text which may contain the replaceable
+// (<<var;...>>) and optional (<<beginOptional>>...<<endOptional>>) sections
+// of SPDX license templates.
+type Template struct {
+	// words are the words of the template without its markup
+	words []templateWord
+	// required are the distinct words not in optional sections
+	required map[string]struct{}
+	// exact matches the words of texts which are exactly the template
+	exact *regexp.Regexp
+}
+
+type templateWord struct {
+	text     string
+	optional bool
+}
+
+// Portion is a part of a text, counted in runes.
+type Portion struct {
+	Start int
+	End   int
+}
+
+// Result is the result of matching a text against a template.
+type Result struct {
+	// Exact is set if the text contains the whole template
+	Exact bool
+	// Coverage is the percentage of required template words found in the text
+	Coverage float64
+	// Portions are the parts of the text matching the template
+	Portions []Portion
+}
+
+// Compile compiles a license text or SPDX license template. A title of the
+// license at the beginning of the text is optional.
+func Compile(text string, titles ...string) *Template {
+	var replaceable []int
+	text = templateMarkup.ReplaceAllStringFunc(text, func(markup string) string {
+		groups := templateMarkup.FindStringSubmatch(markup)
+		switch groups[1] {
+		case "var":
+			replaceable = append(replaceable, replaceableWords(groups[2]))
+			return " " + string(markerVar) + " "
+		case "beginOptional":
+			return " " + string(markerBeginOptional) + " "
+		default:
+			return " " + string(markerEndOptional) + " "
+		}
+	})
+
+	t := &Template{required: map[string]struct{}{}}
+	var pattern strings.Builder
+	pattern.WriteString(" ")
+	depth, vars := 0, 0
+	for _, word := range optionalTitle(Words(text), titles) {
+		switch []rune(word.Text)[0] {
+		case markerVar:
+			fmt.Fprintf(&pattern, `(?:\S+ ){0,%d}`, replaceable[vars])
+			vars++
+		case markerBeginOptional:
+			pattern.WriteString("(?:")
+			depth++
+		case markerEndOptional:
+			if depth > 0 {
+				pattern.WriteString(")?")
+				depth--
+			}
+		default:
+			pattern.WriteString(regexp.QuoteMeta(word.Text) + " ")
+			t.words = append(t.words, templateWord{text: word.Text, optional: depth > 0})
+			if depth == 0 {
+				t.required[word.Text] = struct{}{}
+			}
+		}
+	}
+	pattern.WriteString(strings.Repeat(")?", depth))
+	if len(t.required) == 0 {
+		return t
+	}
+
+	// Templates too large for the regexp engine can only be matched
+	// approximately
+	t.exact, _ = regexp.Compile(pattern.String())
+	return t
+}
+
+// optionalTitle makes the longest of the titles which the words begin with
+// optional.
+func optionalTitle(words []Word, titles []string) []Word {
+	title := 0
+	for _, t := range titles {
+		titleWords := Words(t)
+		if len(titleWords) <= title || len(titleWords) > len(words) {
+			continue
+		}
+		matches := true
+		for i, word := range titleWords {
+			matches = matches && words[i].Text == word.Text
+		}
+		if matches {
+			title = len(titleWords)
+		}
+	}
+	if title == 0 {
+		return words
+	}
+	optional := make([]Word, 0, len(words)+2)
+	optional = append(optional, Word{Text: string(markerBeginOptional)})
+	optional = append(optional, words[:title]...)
+	optional = append(optional, Word{Text: string(markerEndOptional)})
+	return append(optional, words[title:]...)
+}
+
+// replaceableWords returns how many words the replaceable text with the given
+// attributes may have.
+func replaceableWords(attributes string) int {
+	limit := maxReplaceableWords
+	for _, attribute := range templateAttribute.FindAllStringSubmatch(attributes, -1) {
+		switch attribute[1] {
+		case "match":
+			// A word takes at least two characters with its separator
+			if bounds := boundedLength.FindStringSubmatch(attribute[2]); bounds != nil {
+				if chars, err := strconv.Atoi(bounds[2]); err == nil && chars/2+1 < limit {
+					limit = chars/2 + 1
+				}
+			}
+		case "original":
+			if words := 2*len(Words(attribute[2])) + 10; words > limit {
+				limit = min(words, maxReplaceableWords)
+			}
+		}
+	}
+	return limit
+}
+
+// Containment is the share of the distinct required words of the template
+// which are in the given words. A text can only contain the template exactly
+// if it contains all of them.
+func (t *Template) Containment(words map[string]struct{}) float64 {
+	if len(t.required) == 0 {
+		return 0
+	}
+	found := 0
+	for word := range t.required {
+		if _, ok := words[word]; ok {
+			found++
+		}
+	}
+	return float64(found) / float64(len(t.required))
+}
+
+// Match matches the words of a text against the template. A text containing
+// the template exactly has full coverage, otherwise the coverage is the share
+// of required template words in passages of the text that match the template.
+func (t *Template) Match(text []Word) Result {
+	if t.exact != nil {
+		joined, offsets := joinWords(text)
+		if locations := t.exact.FindAllStringIndex(joined, -1); len(locations) != 0 {
+			result := Result{Exact: true, Coverage: 100}
+			for _, location := range locations {
+				first, last := wordRange(offsets, location[0], location[1])
+				if first <= last {
+					result.Portions = append(result.Portions, Portion{Start: text[first].Start, End: text[last].End})
+				}
+			}
+			return result
+		}
+	}
+
+	required := 0
+	b := make([]string, len(t.words))
+	for i, word := range t.words {
+		b[i] = word.text
+		if !word.optional {
+			required++
+		}
+	}
+	if required == 0 {
+		return Result{}
+	}
+	a := make([]string, len(text))
+	for i, word := range text {
+		a[i] = word.Text
+	}
+
+	result := Result{}
+	matched := 0
+	for _, block := range matchingBlocks(a, b) {
+		if block.size < minBlockWords && block.size != len(b) {
+			continue
+		}
+		for _, word := range t.words[block.b : block.b+block.size] {
+			if !word.optional {
+				matched++
+			}
+		}
+		portion := Portion{Start: text[block.a].Start, End: text[block.a+block.size-1].End}
+		if n := len(result.Portions); n != 0 && portionGap(text, result.Portions[n-1], block.a) <= maxPortionGapWords {
+			result.Portions[n-1].End = portion.End
+		} else {
+			result.Portions = append(result.Portions, portion)
+		}
+	}
+	result.Coverage = 100 * float64(matched) / float64(required)
+	return result
+}
+
+// joinWords joins words with single spaces, with a leading and a trailing
+// space, and returns the byte offsets of the words.
+func joinWords(words []Word) (string, []int) {
+	var joined strings.Builder
+	offsets := make([]int, len(words))
+	joined.WriteString(" ")
+	for i, word := range words {
+		offsets[i] = joined.Len()
+		joined.WriteString(word.Text + " ")
+	}
+	return joined.String(), offsets
+}
+
+// wordRange returns the first and last word in the byte range of a joined
+// text. The range starts with the space before its first word.
+func wordRange(offsets []int, start, end int) (int, int) {
+	first, last := len(offsets), -1
+	for i, offset := range offsets {
+		if offset > start && first == len(offsets) {
+			first = i
+		}
+		if offset < end {
+			last = i
+		}
+	}
+	return first, last
+}
+
+// portionGap counts the words between a portion and a word.
+func portionGap(text []Word, portion Portion, word int) int {
+	gap := 0
+	for i := word - 1; i >= 0 && text[i].End > portion.End; i-- {
+		gap++
+	}
+	return gap
+}
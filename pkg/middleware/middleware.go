@@ -8,11 +8,10 @@
 package middleware
 
 import (
-	"bytes"
-	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"math"
 	"net/http"
 	"os"
 	"strconv"
@@ -25,10 +24,10 @@ import (
 	"github.com/fossology/LicenseDb/pkg/models"
 	"github.com/fossology/LicenseDb/pkg/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/lestrrat-go/jwx/v3/jwa"
-	"github.com/lestrrat-go/jwx/v3/jws"
+	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/v3/jwt"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 // AuthenticationMiddleware is a middleware function for user authentication.
@@ -53,13 +52,19 @@ func AuthenticationMiddleware() gin.HandlerFunc {
 
 		iss, _ := unverfiedParsedToken.Issuer()
 		if iss == os.Getenv("DEFAULT_ISSUER") {
-			_, err := jws.Verify([]byte(tokenString), jws.WithKey(jwa.HS256(), []byte(os.Getenv("API_SECRET"))))
-			if err != nil {
+			if err := auth.VerifyAccessToken(tokenString); err != nil {
 				logger.LogError("error verifying token", zap.Error(err))
 				unauthorized(c, "token verification failed")
 				return
 			}
 
+			// Only access tokens carry no type, mfa tokens must not grant access
+			var tokenType string
+			if err = unverfiedParsedToken.Get("type", &tokenType); err == nil {
+				unauthorized(c, "invalid token type")
+				return
+			}
+
 			var userData map[string]interface{}
 			if err = unverfiedParsedToken.Get("user", &userData); err != nil {
 				logger.LogError("error parsing token user data", zap.Error(err))
@@ -89,52 +94,43 @@ func AuthenticationMiddleware() gin.HandlerFunc {
 				unauthorized(c, "user not found. please check your credentials.")
 				return
 			}
-			c.Set("userId", user.Id)
-			c.Set("role", *user.UserLevel)
-		} else if iss == os.Getenv("OIDC_ISSUER") {
 
-			if auth.Jwks == nil {
-				logger.LogError("oidc environment variables not configured properly")
-				internalServerError(c)
-				return
+			// Impersonation tokens name the super admin acting as the user
+			var actor map[string]interface{}
+			if err = unverfiedParsedToken.Get("act", &actor); err == nil {
+				impersonatorId, err := uuid.Parse(fmt.Sprint(actor["sub"]))
+				if err != nil {
+					unauthorized(c, "incompatible token format")
+					return
+				}
+				superAdmin := "SUPER_ADMIN"
+				var impersonator models.User
+				if err := db.DB.Where(models.User{Id: impersonatorId, Active: &active, UserLevel: &superAdmin}).First(&impersonator).Error; err != nil {
+					logger.LogError("impersonator is no active super admin", zap.String("impersonator", impersonatorId.String()), zap.Error(err))
+					unauthorized(c, "impersonation is no longer permitted")
+					return
+				}
+				c.Set("impersonatorId", impersonator.Id)
 			}
-			keyset, err := auth.Jwks.Lookup(context.Background(), os.Getenv("JWKS_URI"))
+			c.Set("userId", user.Id)
+			c.Set("role", *user.UserLevel)
+		} else if issuer, ok := auth.LookupOidcIssuer(iss); ok {
+			parsedToken, err := issuer.Verify(tokenString)
 			if err != nil {
-				logger.LogError("failed jwk cache lookup from oidc provider", zap.Error(err))
-				internalServerError(c)
-				return
-			}
-			keyOptions := jws.WithKeySet(keyset)
-			keyError := true
-			if kid, err := utils.GetKid(tokenString); err == nil {
-				if key, ok := keyset.LookupKeyID(kid); ok {
-					if os.Getenv("OIDC_SIGNING_ALG") != "" {
-						if alg, ok := jwa.LookupSignatureAlgorithm(os.Getenv("OIDC_SIGNING_ALG")); ok {
-							if err = key.Set("alg", alg); err == nil {
-								keyError = false
-							}
-						}
-					} else if _, ok := key.Algorithm(); ok {
-						keyError = false
-					}
+				if errors.Is(err, auth.ErrOidcProviderUnavailable) {
+					logger.LogError("failed jwk cache lookup from oidc provider", zap.String("issuer", iss), zap.Error(err))
+					internalServerError(c)
+					return
 				}
-			}
-			if keyError {
-				logger.LogError("token verification failed due to invalid alg header key field")
-				unauthorized(c, "token verification failed")
-				return
-			}
-
-			if _, err = jws.Verify([]byte(tokenString), keyOptions); err != nil {
-				logger.LogError("token verification failed", zap.Error(err))
+				logger.LogError("token verification failed", zap.String("issuer", iss), zap.Error(err))
 				unauthorized(c, "token verification failed")
 				return
 			}
 
 			isClientCredentialsFlow := false
 			var oidcClientToUserMapper string
-			if os.Getenv("OIDC_CLIENT_TO_USER_MAPPER_CLAIM") != "" {
-				if err := unverfiedParsedToken.Get(os.Getenv("OIDC_CLIENT_TO_USER_MAPPER_CLAIM"), &oidcClientToUserMapper); err == nil {
+			if issuer.ClientToUserMapperClaim != "" {
+				if err := parsedToken.Get(issuer.ClientToUserMapperClaim, &oidcClientToUserMapper); err == nil {
 					isClientCredentialsFlow = true
 				}
 			}
@@ -148,24 +144,37 @@ func AuthenticationMiddleware() gin.HandlerFunc {
 					return
 				}
 				user = oidcClient.User
-			} else {
-				if os.Getenv("OIDC_USERNAME_KEY") == "" {
-					logger.LogError("oidc environment variables not configured properly")
-					internalServerError(c)
+
+				var serviceAccount models.ServiceAccount
+				err := db.DB.Where(&models.ServiceAccount{UserId: user.Id}).First(&serviceAccount).Error
+				if err == nil && serviceAccount.Expired() {
+					unauthorized(c, "service account expired")
 					return
-				}
-				var username string
-				if err = unverfiedParsedToken.Get(os.Getenv("OIDC_USERNAME_KEY"), &username); err != nil {
-					logger.LogError("error parsing oidc username", zap.Error(err))
-					unauthorized(c, "incompatible token format")
+				} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+					logger.LogError("error fetching service account", zap.Error(err))
+					internalServerError(c)
 					return
 				}
-
-				if err := db.DB.Where(models.User{UserName: &username}).First(&user).Error; err != nil {
-					logger.LogError("error finding user", zap.Error(err))
-					unauthorized(c, "User not found")
+			} else {
+				resolvedUser, err := issuer.ResolveUser(db.DB, parsedToken)
+				if err != nil {
+					logger.LogError("error resolving oidc user", zap.String("issuer", iss), zap.Error(err))
+					if errors.Is(err, auth.ErrOidcUserNotFound) ||
+						errors.Is(err, auth.ErrOidcNoUserLevel) ||
+						errors.Is(err, auth.ErrOidcIncompatibleToken) ||
+						errors.Is(err, auth.ErrOidcUserDeactivated) ||
+						errors.Is(err, auth.ErrOidcUserExists) {
+						unauthorized(c, err.Error())
+					} else {
+						internalServerError(c)
+					}
 					return
 				}
+				user = *resolvedUser
+			}
+			if user.Active != nil && !*user.Active {
+				unauthorized(c, "user is deactivated")
+				return
 			}
 			c.Set("userId", user.Id)
 			c.Set("role", *user.UserLevel)
@@ -178,6 +187,49 @@ func AuthenticationMiddleware() gin.HandlerFunc {
 	}
 }
 
+// ScimAuthenticationMiddleware authenticates SCIM provisioning clients with the
+// bearer token configured in SCIM_BEARER_TOKEN. The SCIM api is disabled if no
+// token is configured.
+func ScimAuthenticationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		expected := os.Getenv("SCIM_BEARER_TOKEN")
+		token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if expected == "" || !found ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(expected)) != 1 {
+			c.Header("Content-Type", "application/scim+json")
+			c.JSON(http.StatusUnauthorized, models.ScimError{
+				Schemas: []string{models.ScimErrorSchema},
+				Status:  strconv.Itoa(http.StatusUnauthorized),
+				Detail:  "invalid or missing SCIM bearer token",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// DenyImpersonationMiddleware rejects requests made with an impersonation
+// token, so that super admins can not manage users or credentials as someone
+// else.
+func DenyImpersonationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, impersonated := c.Get("impersonatorId"); impersonated {
+			logger.LogError("access denied while impersonating a user")
+			c.JSON(http.StatusForbidden, models.LicenseError{
+				Status:    http.StatusForbidden,
+				Message:   "This resource is not available while impersonating a user",
+				Error:     "access denied while impersonating a user",
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
 // RoleBasedAccessMiddleware is a middleware function for giving role based access to apis.
 func RoleBasedAccessMiddleware(roles []string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -210,8 +262,8 @@ func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Disposition")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, If-Match, If-None-Match")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Disposition, ETag")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
@@ -223,7 +275,8 @@ func CORSMiddleware() gin.HandlerFunc {
 	}
 }
 
-// PaginationMiddleware handles pagination requests and responses.
+// PaginationMiddleware parses the page, limit and cursor of requests for
+// handlers of listings to paginate their responses.
 func PaginationMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var page models.PaginationInput
@@ -235,6 +288,9 @@ func PaginationMiddleware() gin.HandlerFunc {
 		if err == nil {
 			page.Limit = parsedLimit
 		}
+		if cursor, ok := c.GetQuery("cursor"); ok {
+			page.Cursor = &cursor
+		}
 
 		if page.Page == 0 {
 			page.Page = utils.DefaultPage
@@ -244,129 +300,12 @@ func PaginationMiddleware() gin.HandlerFunc {
 			page.Limit = utils.DefaultLimit
 		}
 
-		// Create a custom writer to capture and process response body
-		buffer := new(bytes.Buffer)
-		writer := &bodyWriter{body: buffer, ResponseWriter: c.Writer}
-		c.Writer = writer
-
 		// Set the pagination information for routes who need it
 		c.Set("page", page)
 		c.Next()
-
-		// Get the pagination information from route after processing
-		metaValue, paginationExists := c.Get("paginationMeta")
-
-		// Handle only 200 responses with paginationMeta
-		if paginationExists && c.Writer.Status() == 200 {
-			originalBody := writer.body.Bytes()
-			var metaObject *models.PaginationMeta
-
-			// Try the body with different possible unmarshalling before failing
-			var licenseRes models.LicenseResponse
-			var obligationRes models.ObligationResponse
-			var auditRes models.AuditResponse
-			var userRes models.UserResponse
-			isLicenseRes := false
-			isObligationRes := false
-			isAuditRes := false
-			isUserRes := false
-			responseModel, _ := c.Get("responseModel")
-			switch responseModel.(type) {
-			case *models.LicenseResponse:
-				err = json.Unmarshal(originalBody, &licenseRes)
-				isLicenseRes = true
-				metaObject = licenseRes.Meta
-			case *models.ObligationResponse:
-				err = json.Unmarshal(originalBody, &obligationRes)
-				isObligationRes = true
-				metaObject = &obligationRes.Meta
-			case *models.AuditResponse:
-				err = json.Unmarshal(originalBody, &auditRes)
-				isAuditRes = true
-				metaObject = auditRes.Meta
-			case *models.UserResponse:
-				err = json.Unmarshal(originalBody, &userRes)
-				isUserRes = true
-				metaObject = userRes.Meta
-			default:
-				err = fmt.Errorf("unknown response model type")
-			}
-			if err != nil {
-				logger.LogError("error processing response model", zap.Error(err))
-				internalServerError(c)
-				return
-			}
-
-			paginationMeta := metaValue.(models.PaginationMeta)
-
-			// Get the query params from the request
-			params := c.Request.URL.Query()
-
-			metaObject.Page = page.Page
-			metaObject.Limit = page.Limit
-			metaObject.ResourceCount = paginationMeta.ResourceCount
-			metaObject.TotalPages = int64(math.Ceil(float64(paginationMeta.ResourceCount) / float64(page.Limit)))
-
-			// Can go next
-			if metaObject.Page < metaObject.TotalPages {
-				params.Set("page", strconv.FormatInt(int64(metaObject.Page+1), 10))
-				c.Request.URL.RawQuery = params.Encode()
-				metaObject.Next = c.Request.URL.String()
-			}
-
-			// Can go previous
-			if metaObject.Page > 1 {
-				params.Set("page", strconv.FormatInt(int64(metaObject.Page-1), 10))
-				c.Request.URL.RawQuery = params.Encode()
-				metaObject.Previous = c.Request.URL.String()
-			}
-
-			// Marshal the new body
-			var newBody []byte
-			if isLicenseRes {
-				newBody, err = json.Marshal(licenseRes)
-			} else if isObligationRes {
-				newBody, err = json.Marshal(obligationRes)
-			} else if isAuditRes {
-				newBody, err = json.Marshal(auditRes)
-			} else if isUserRes {
-				newBody, err = json.Marshal(userRes)
-			}
-			if err != nil {
-				logger.LogError("error marshalling response body", zap.Error(err))
-				internalServerError(c)
-				return
-			}
-			_, err = c.Writer.WriteString(string(newBody))
-			if err != nil {
-				logger.LogError("error writing response body", zap.Error(err))
-				internalServerError(c)
-				return
-			}
-
-		} else {
-			// Write the original body for non-paginated responses
-			_, err := c.Writer.WriteString(writer.body.String())
-			if err != nil {
-				logger.LogError("error writing response body", zap.Error(err))
-				internalServerError(c)
-				return
-			}
-		}
 	}
 }
 
-// bodyWriter is a custom writer to capture and process response body.
-type bodyWriter struct {
-	gin.ResponseWriter
-	body *bytes.Buffer
-}
-
-// Write is a custom write function to capture and process response body.
-func (w bodyWriter) Write(b []byte) (int, error) {
-	return w.body.Write(b)
-}
-
 func unauthorized(c *gin.Context, msg string) {
 	c.JSON(http.StatusUnauthorized, models.LicenseError{
 		Status:    http.StatusUnauthorized,
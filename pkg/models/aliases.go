@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Namespaces of license aliases, naming the source of an alias.
+const (
+	// AliasNamespaceSpdx are deprecated and alternative SPDX ids such as GPL-2.0+
+	AliasNamespaceSpdx = "SPDX"
+	// AliasNamespaceFossology are FOSSology shortnames
+	AliasNamespaceFossology = "FOSSOLOGY"
+	// AliasNamespaceScancode are ScanCode license keys
+	AliasNamespaceScancode = "SCANCODE"
+	// AliasNamespaceVendor are names used by vendors and other tools
+	AliasNamespaceVendor = "VENDOR"
+	// AliasNamespaceMerged are the names of licenses merged into another
+	// license
+	AliasNamespaceMerged = "MERGED"
+)
+
+// LicenseAlias is an alternative name of a license.
+type LicenseAlias struct {
+	Id        uuid.UUID `gorm:"type:uuid;primary_key;column:id;default:uuid_generate_v4()"`
+	LicenseId uuid.UUID `gorm:"type:uuid;column:license_id"`
+	Alias     string    `gorm:"column:alias"`
+	Namespace string    `gorm:"column:namespace"`
+	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP"`
+}
+
+func (LicenseAlias) TableName() string {
+	return "license_aliases"
+}
+
+// LicenseAliasDTO is the api representation of a license alias.
+type LicenseAliasDTO struct {
+	Id        uuid.UUID `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Alias     string    `json:"alias" example:"GPL-2.0+"`
+	Namespace string    `json:"namespace" enums:"SPDX,FOSSOLOGY,SCANCODE,VENDOR,MERGED" example:"SPDX"`
+	CreatedAt time.Time `json:"created_at" example:"2026-01-01T00:00:00Z"`
+}
+
+// LicenseAliasInput is the input for adding an alias to a license.
+type LicenseAliasInput struct {
+	Alias     string `json:"alias" validate:"required" example:"GPL-2.0+"`
+	Namespace string `json:"namespace" validate:"required,oneof=SPDX FOSSOLOGY SCANCODE VENDOR MERGED" example:"SPDX"`
+}
+
+// LicenseAliasUpdate is the input for updating an alias of a license.
+type LicenseAliasUpdate struct {
+	Alias     *string `json:"alias" validate:"omitempty,min=1" example:"GPL-2.0+"`
+	Namespace *string `json:"namespace" validate:"omitempty,oneof=SPDX FOSSOLOGY SCANCODE VENDOR MERGED" example:"SPDX"`
+}
+
+// LicenseAliasesResponse represents the response format for license aliases.
+type LicenseAliasesResponse struct {
+	Status int               `json:"status" example:"200"`
+	Data   []LicenseAliasDTO `json:"data"`
+	Meta   *PaginationMeta   `json:"paginationmeta"`
+}
+
+// LicenseResolution is the canonical license of a name, along with what the
+// name matched.
+type LicenseResolution struct {
+	License   LicenseResponseDTO `json:"license"`
+	MatchedBy string             `json:"matched_by" enums:"SPDX_ID,SHORTNAME,ALIAS" example:"ALIAS"`
+	Alias     *LicenseAliasDTO   `json:"alias,omitempty"`
+}
+
+// LicenseResolutionResponse represents the response format for resolved
+// license names.
+type LicenseResolutionResponse struct {
+	Status int               `json:"status" example:"200"`
+	Data   LicenseResolution `json:"data"`
+}
+
+// ConvertToLicenseAliasDTO converts a license alias to its api
+// representation.
+func (a *LicenseAlias) ConvertToLicenseAliasDTO() LicenseAliasDTO {
+	return LicenseAliasDTO{
+		Id:        a.Id,
+		Alias:     a.Alias,
+		Namespace: a.Namespace,
+		CreatedAt: a.CreatedAt,
+	}
+}
@@ -12,6 +12,7 @@ type ObligationCategory struct {
 	Id       uuid.UUID `gorm:"type:uuid;primary_key;column:id;default:uuid_generate_v4()" json:"-"`
 	Category string    `gorm:"column:category" validate:"required,uppercase" example:"GENERAL" json:"category"`
 	Active   *bool     `gorm:"column:active;default:true" json:"-"`
+	Version  int64     `gorm:"column:version;default:1" json:"version" example:"1"`
 }
 
 func (ObligationCategory) TableName() string {
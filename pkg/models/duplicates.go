@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import "github.com/google/uuid"
+
+// DuplicateMember is a license or obligation of a duplicate cluster. Name is
+// the shortname of licenses and the topic of obligations.
+type DuplicateMember struct {
+	Id     uuid.UUID `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Name   string    `json:"name" example:"MIT"`
+	SpdxId *string   `json:"spdx_id,omitempty" example:"MIT"`
+}
+
+// DuplicatePair are two members of a duplicate cluster whose texts are
+// similar.
+type DuplicatePair struct {
+	A          uuid.UUID `json:"a" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	B          uuid.UUID `json:"b" example:"a0c91e6b-7dec-11d0-a765-00f81d4fae6f" swaggertype:"string"`
+	Similarity float64   `json:"similarity" example:"0.97"`
+}
+
+// DuplicateCluster are licenses or obligations whose texts are similar,
+// directly or through other members of the cluster. Licenses are suggested
+// to be merged into the suggested survivor, which prefers SPDX ids over
+// LicenseRef ids.
+type DuplicateCluster struct {
+	Members             []DuplicateMember `json:"members"`
+	Pairs               []DuplicatePair   `json:"pairs"`
+	SuggestedSurvivorId *uuid.UUID        `json:"suggested_survivor_id,omitempty" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+}
+
+// DuplicateClustersResponse represents the response format for duplicate
+// clusters.
+type DuplicateClustersResponse struct {
+	Status int                `json:"status" example:"200"`
+	Data   []DuplicateCluster `json:"data"`
+	Meta   *PaginationMeta    `json:"paginationmeta"`
+}
+
+// LicenseMergeInput is the input for merging a duplicate into a license.
+type LicenseMergeInput struct {
+	DuplicateId uuid.UUID `json:"duplicate_id" validate:"required" example:"a0c91e6b-7dec-11d0-a765-00f81d4fae6f" swaggertype:"string"`
+}
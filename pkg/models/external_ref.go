@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// The schema extensions are (un)marshalled with the values of the fields
+// defined at runtime, kept in Extra, next to the generated fields.
+
+func (e LicenseDBSchemaExtension) MarshalJSON() ([]byte, error) {
+	type generated LicenseDBSchemaExtension
+	return marshalExtension(generated(e), e.Extra)
+}
+
+func (e *LicenseDBSchemaExtension) UnmarshalJSON(data []byte) error {
+	type generated LicenseDBSchemaExtension
+	if err := json.Unmarshal(data, (*generated)(e)); err != nil {
+		return err
+	}
+	extra, err := unmarshalExtra(data, reflect.TypeOf(e).Elem())
+	e.Extra = extra
+	return err
+}
+
+func (e ObligationSchemaExtension) MarshalJSON() ([]byte, error) {
+	type generated ObligationSchemaExtension
+	return marshalExtension(generated(e), e.Extra)
+}
+
+func (e *ObligationSchemaExtension) UnmarshalJSON(data []byte) error {
+	type generated ObligationSchemaExtension
+	if err := json.Unmarshal(data, (*generated)(e)); err != nil {
+		return err
+	}
+	extra, err := unmarshalExtra(data, reflect.TypeOf(e).Elem())
+	e.Extra = extra
+	return err
+}
+
+// ExternalRefMap returns the keys and values of a schema extension or of any
+// other JSON object.
+func ExternalRefMap(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func marshalExtension(generated any, extra map[string]any) ([]byte, error) {
+	data, err := json.Marshal(generated)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, ok := values[key]; ok || value == nil {
+			continue
+		}
+		if values[key], err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(values)
+}
+
+// unmarshalExtra returns the values of the keys of data which are not fields
+// of the extension struct t.
+func unmarshalExtra(data []byte, t reflect.Type) (map[string]any, error) {
+	var values map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return nil, err
+	}
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		delete(values, name)
+	}
+	for key, value := range values {
+		if value == nil {
+			delete(values, key)
+		}
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values, nil
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// Entities whose external_ref can be extended with fields.
+const (
+	ExternalRefEntityLicense    = "LICENSE"
+	ExternalRefEntityObligation = "OBLIGATION"
+)
+
+// Types of the values of external_ref fields.
+const (
+	ExternalRefTypeString  = "string"
+	ExternalRefTypeInt     = "int"
+	ExternalRefTypeNumber  = "number"
+	ExternalRefTypeBoolean = "boolean"
+	// ExternalRefTypeDate values are dates or RFC 3339 timestamps
+	ExternalRefTypeDate = "date"
+	// The following types are only generated from external_ref_fields.yaml
+	ExternalRefTypeTimestamp = "timestamp"
+	ExternalRefTypeURL       = "url"
+	// ExternalRefTypeArray values are arrays of strings
+	ExternalRefTypeArray  = "array"
+	ExternalRefTypeObject = "object"
+)
+
+// ExternalRefField is a key of the external_ref of licenses or obligations.
+// The fields generated from external_ref_fields.yaml are built in, others are
+// defined at runtime and stored in the database.
+type ExternalRefField struct {
+	Id       uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;column:id;default:uuid_generate_v4()" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Entity   string         `json:"entity" gorm:"column:entity" example:"LICENSE"`
+	Name     string         `json:"name" gorm:"column:name" example:"export_control"`
+	Type     string         `json:"type" gorm:"column:type" example:"string"`
+	Label    string         `json:"label" gorm:"column:label" example:"Export control classification"`
+	Required bool           `json:"required" gorm:"column:required" example:"false"`
+	Enum     pq.StringArray `json:"enum,omitempty" gorm:"column:enum;type:text[]" swaggertype:"array,string" example:"EAR99,5D002"`
+	Regex    *string        `json:"regex,omitempty" gorm:"column:regex" example:"^[0-9A-Z]+$"`
+	// Min and Max bound numbers, the length of strings and the number of
+	// items of arrays. Fields are the fields of objects. They are only set on
+	// builtin fields
+	Min    *float64           `json:"min,omitempty" gorm:"-" example:"1"`
+	Max    *float64           `json:"max,omitempty" gorm:"-" example:"10"`
+	Fields []ExternalRefField `json:"fields,omitempty" gorm:"-"`
+	// Builtin fields are generated from external_ref_fields.yaml and cannot
+	// be changed through the api
+	Builtin   bool           `json:"builtin" gorm:"-" example:"false"`
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP" example:"2026-01-01T00:00:00Z"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP" example:"2026-01-01T00:00:00Z"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"column:deleted_at"`
+}
+
+func (ExternalRefField) TableName() string {
+	return "external_ref_fields"
+}
+
+// ExternalRefFieldCreate is the input for defining an external_ref field.
+type ExternalRefFieldCreate struct {
+	Entity   string   `json:"entity" validate:"required,oneof=LICENSE OBLIGATION" example:"LICENSE"`
+	Name     string   `json:"name" validate:"required,max=64" example:"export_control"`
+	Type     string   `json:"type" validate:"required,oneof=string int number boolean date" example:"string"`
+	Label    string   `json:"label" validate:"required" example:"Export control classification"`
+	Required bool     `json:"required" example:"false"`
+	Enum     []string `json:"enum" validate:"omitempty,dive,required" example:"EAR99,5D002"`
+	Regex    *string  `json:"regex" example:"^[0-9A-Z]+$"`
+}
+
+// ExternalRefFieldUpdate is the input for updating an external_ref field. The
+// entity, name and type of a field cannot be changed.
+type ExternalRefFieldUpdate struct {
+	Label    *string   `json:"label" validate:"omitempty,min=1" example:"Export control classification"`
+	Required *bool     `json:"required" example:"true"`
+	Enum     *[]string `json:"enum" validate:"omitempty,dive,required" example:"EAR99,5D002"`
+	Regex    *string   `json:"regex" example:"^[0-9A-Z]+$"`
+}
+
+// ExternalRefFieldResponse represents the response format for external_ref
+// field data.
+type ExternalRefFieldResponse struct {
+	Status int                `json:"status" example:"200"`
+	Data   []ExternalRefField `json:"data"`
+	Meta   *PaginationMeta    `json:"paginationmeta"`
+}
+
+// ExternalRefSchema is the JSON Schema of the external_ref of an entity.
+type ExternalRefSchema struct {
+	Schema               string                               `json:"$schema" example:"https://json-schema.org/draft/2020-12/schema"`
+	Title                string                               `json:"title" example:"License external_ref"`
+	Type                 string                               `json:"type" example:"object"`
+	Properties           map[string]ExternalRefSchemaProperty `json:"properties"`
+	Required             []string                             `json:"required"`
+	AdditionalProperties bool                                 `json:"additionalProperties" example:"false"`
+}
+
+// ExternalRefSchemaProperty is the JSON Schema of an external_ref field.
+type ExternalRefSchemaProperty struct {
+	Type      string   `json:"type" example:"string"`
+	Format    string   `json:"format,omitempty" example:"date"`
+	Title     string   `json:"title" example:"Export control classification"`
+	Enum      []string `json:"enum,omitempty" example:"EAR99,5D002"`
+	Pattern   string   `json:"pattern,omitempty" example:"^[0-9A-Z]+$"`
+	Minimum   *float64 `json:"minimum,omitempty" example:"0"`
+	Maximum   *float64 `json:"maximum,omitempty" example:"10"`
+	MinLength *int     `json:"minLength,omitempty" example:"1"`
+	MaxLength *int     `json:"maxLength,omitempty" example:"64"`
+	MinItems  *int     `json:"minItems,omitempty" example:"1"`
+	MaxItems  *int     `json:"maxItems,omitempty" example:"5"`
+	// Items is the schema of the items of arrays
+	Items *ExternalRefSchemaProperty `json:"items,omitempty"`
+	// Properties and Required are the schema of the fields of objects
+	Properties map[string]ExternalRefSchemaProperty `json:"properties,omitempty"`
+	Required   []string                             `json:"required,omitempty"`
+}
+
+// BuiltinExternalRefFields returns the fields of the external_ref of entity
+// generated from external_ref_fields.yaml.
+func BuiltinExternalRefFields(entity string) []ExternalRefField {
+	var builtin []ExternalRefField
+	switch entity {
+	case ExternalRefEntityLicense:
+		builtin = licenseExternalRefFields
+	case ExternalRefEntityObligation:
+		builtin = obligationExternalRefFields
+	default:
+		return nil
+	}
+
+	fields := slices.Clone(builtin)
+	for i := range fields {
+		fields[i].Entity = entity
+		fields[i].Builtin = true
+	}
+	return fields
+}
@@ -22,24 +22,30 @@ import (
 // The LicenseDB struct represents a license entity with various attributes and properties
 // associated with it. It provides structured storage for license-related information.
 type LicenseDB struct {
-	Id            uuid.UUID                                    `gorm:"primary_key;type:uuid;column:rf_id;default:uuid_generate_v4()"`
-	Shortname     *string                                      `gorm:"column:rf_shortname"`
-	Fullname      *string                                      `gorm:"column:rf_fullname"`
-	Text          *string                                      `gorm:"column:rf_text"`
-	Url           *string                                      `gorm:"column:rf_url;default:''"`
-	AddDate       time.Time                                    `gorm:"column:rf_add_date"`
-	Copyleft      *bool                                        `gorm:"column:rf_copyleft;default:false"`
-	OSIapproved   *bool                                        `gorm:"column:rf_osiapproved;default:false"`
-	Notes         *string                                      `gorm:"column:rf_notes"`
-	TextUpdatable *bool                                        `gorm:"column:rf_text_updatable;default:false"`
-	Active        *bool                                        `gorm:"column:rf_active;default:true"`
-	Source        *string                                      `gorm:"column:rf_source"`
-	SpdxId        *string                                      `gorm:"column:rf_spdx_id"`
-	Risk          *int64                                       `gorm:"column:rf_risk"`
-	ExternalRef   datatypes.JSONType[LicenseDBSchemaExtension] `gorm:"column:external_ref"`
-	Obligations   []Obligation                                 `gorm:"many2many:obligation_licenses;joinForeignKey:license_db_id;joinReferences:obligation_id"`
-	User          User                                         `gorm:"foreignKey:UserId;references:Id"`
-	UserId        uuid.UUID
+	Id                 uuid.UUID                                    `gorm:"primary_key;type:uuid;column:rf_id;default:uuid_generate_v4()"`
+	Shortname          *string                                      `gorm:"column:rf_shortname"`
+	Fullname           *string                                      `gorm:"column:rf_fullname"`
+	Text               *string                                      `gorm:"column:rf_text"`
+	Url                *string                                      `gorm:"column:rf_url;default:''"`
+	AddDate            time.Time                                    `gorm:"column:rf_add_date"`
+	Copyleft           *bool                                        `gorm:"column:rf_copyleft;default:false"`
+	OSIapproved        *bool                                        `gorm:"column:rf_osiapproved;default:false"`
+	Notes              *string                                      `gorm:"column:rf_notes"`
+	TextUpdatable      *bool                                        `gorm:"column:rf_text_updatable;default:false"`
+	Active             *bool                                        `gorm:"column:rf_active;default:true"`
+	Source             *string                                      `gorm:"column:rf_source"`
+	SpdxId             *string                                      `gorm:"column:rf_spdx_id"`
+	Risk               *int64                                       `gorm:"column:rf_risk"`
+	ExternalRef        datatypes.JSONType[LicenseDBSchemaExtension] `gorm:"column:external_ref"`
+	Obligations        []Obligation                                 `gorm:"many2many:obligation_licenses;joinForeignKey:license_db_id;joinReferences:obligation_id"`
+	Aliases            []LicenseAlias                               `gorm:"foreignKey:LicenseId;references:Id"`
+	User               User                                         `gorm:"foreignKey:UserId;references:Id"`
+	UserId             uuid.UUID
+	StewardTeamId      *uuid.UUID `gorm:"type:uuid;column:steward_team_id"`
+	ReviewIntervalDays *int64     `gorm:"column:review_interval_days"`
+	LastReviewedAt     *time.Time `gorm:"column:last_reviewed_at"`
+	ReviewRemindedAt   *time.Time `gorm:"column:review_reminded_at"`
+	Version            int64      `gorm:"column:version;default:1"`
 }
 
 func (LicenseDB) TableName() string {
@@ -89,21 +95,26 @@ func (l *LicenseDB) BeforeUpdate(tx *gorm.DB) (err error) {
 func (l *LicenseDB) ConvertToLicenseResponseDTO() LicenseResponseDTO {
 	var response LicenseResponseDTO
 	response.Id = l.Id
-	response.Shortname = *l.Shortname
-	response.Active = *l.Active
+	response.Shortname = deref(l.Shortname)
+	response.Active = deref(l.Active)
 	response.AddDate = l.AddDate
-	response.Copyleft = *l.Copyleft
+	response.Copyleft = deref(l.Copyleft)
 	response.ExternalRef = l.ExternalRef.Data()
-	response.Fullname = *l.Fullname
-	response.Notes = *l.Notes
-	response.OSIapproved = *l.OSIapproved
-	response.Risk = *l.Risk
-	response.Source = *l.Source
-	response.SpdxId = *l.SpdxId
-	response.Text = *l.Text
-	response.TextUpdatable = *l.TextUpdatable
-	response.Url = *l.Url
+	response.Fullname = deref(l.Fullname)
+	response.Notes = deref(l.Notes)
+	response.OSIapproved = deref(l.OSIapproved)
+	response.Risk = deref(l.Risk)
+	response.Source = deref(l.Source)
+	response.SpdxId = deref(l.SpdxId)
+	response.Text = deref(l.Text)
+	response.TextUpdatable = deref(l.TextUpdatable)
+	response.Url = deref(l.Url)
 	response.User = l.User
+	response.StewardTeamId = l.StewardTeamId
+	response.ReviewIntervalDays = l.ReviewIntervalDays
+	response.Version = l.Version
+	response.LastReviewedAt = l.LastReviewedAt
+	response.ReviewDueAt = reviewDueAt(l.LastReviewedAt, l.ReviewIntervalDays)
 
 	obligations := []uuid.UUID{}
 	for _, o := range l.Obligations {
@@ -111,25 +122,30 @@ func (l *LicenseDB) ConvertToLicenseResponseDTO() LicenseResponseDTO {
 	}
 	response.ObligationIds = obligations
 
+	for _, a := range l.Aliases {
+		response.Aliases = append(response.Aliases, a.ConvertToLicenseAliasDTO())
+	}
+
 	return response
 }
 
 // LicenseCreateDTO struct represents the input format for creating a license.
 type LicenseCreateDTO struct {
-	Shortname     string                   `json:"shortname" validate:"required" example:"MIT"`
-	Fullname      string                   `json:"fullname" validate:"required" example:"MIT License"`
-	Text          string                   `json:"text" validate:"required" example:"MIT License Text here"`
-	Url           *string                  `json:"url" example:"https://opensource.org/licenses/MIT"`
-	Copyleft      *bool                    `json:"copyleft" example:"false"`
-	OSIapproved   *bool                    `json:"OSIapproved" example:"false"`
-	Notes         *string                  `json:"notes" example:"This license has been superseded."`
-	TextUpdatable *bool                    `json:"text_updatable" example:"false"`
-	Active        *bool                    `json:"active" example:"true"`
-	Source        *string                  `json:"source" example:"spdx"`
-	SpdxId        string                   `json:"spdx_id" validate:"required,spdxId" example:"MIT"`
-	Risk          *int64                   `json:"risk" validate:"min=0,max=5" example:"1"`
-	ExternalRef   LicenseDBSchemaExtension `json:"external_ref"`
-	ObligationIds []uuid.UUID              `json:"obligation_ids" swaggertype:"array,string" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6,f812jfae-7dbc-11d0-a765-00a0hf06bf6"`
+	Shortname          string                   `json:"shortname" validate:"required" example:"MIT"`
+	Fullname           string                   `json:"fullname" validate:"required" example:"MIT License"`
+	Text               string                   `json:"text" validate:"required" example:"MIT License Text here"`
+	Url                *string                  `json:"url" example:"https://opensource.org/licenses/MIT"`
+	Copyleft           *bool                    `json:"copyleft" example:"false"`
+	OSIapproved        *bool                    `json:"OSIapproved" example:"false"`
+	Notes              *string                  `json:"notes" example:"This license has been superseded."`
+	TextUpdatable      *bool                    `json:"text_updatable" example:"false"`
+	Active             *bool                    `json:"active" example:"true"`
+	Source             *string                  `json:"source" example:"spdx"`
+	SpdxId             string                   `json:"spdx_id" validate:"required,spdxId" example:"MIT"`
+	Risk               *int64                   `json:"risk" validate:"min=0,max=5" example:"1"`
+	ExternalRef        LicenseDBSchemaExtension `json:"external_ref"`
+	ObligationIds      []uuid.UUID              `json:"obligation_ids" swaggertype:"array,string" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6,f812jfae-7dbc-11d0-a765-00a0hf06bf6"`
+	ReviewIntervalDays *int64                   `json:"review_interval_days" validate:"omitempty,min=0" example:"365"`
 }
 
 func (dto *LicenseCreateDTO) ConvertToLicenseDB() LicenseDB {
@@ -148,47 +164,55 @@ func (dto *LicenseCreateDTO) ConvertToLicenseDB() LicenseDB {
 	l.Text = &dto.Text
 	l.TextUpdatable = dto.TextUpdatable
 	l.Url = dto.Url
+	l.ReviewIntervalDays = dto.ReviewIntervalDays
 
 	return l
 }
 
 // LicenseResponseDTO struct represents the format for returning a license in an api request.
 type LicenseResponseDTO struct {
-	Id            uuid.UUID                `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
-	Shortname     string                   `json:"shortname" example:"MIT"`
-	Fullname      string                   `json:"fullname" example:"MIT License"`
-	Text          string                   `json:"text" example:"MIT License Text here"`
-	Url           string                   `json:"url" example:"https://opensource.org/licenses/MIT"`
-	Copyleft      bool                     `json:"copyleft"`
-	OSIapproved   bool                     `json:"OSIapproved"`
-	Notes         string                   `json:"notes" example:"This license has been superseded."`
-	TextUpdatable bool                     `json:"text_updatable"`
-	Active        bool                     `json:"active"`
-	Source        string                   `json:"source"`
-	SpdxId        string                   `json:"spdx_id" example:"MIT"`
-	Risk          int64                    `json:"risk" example:"1"`
-	ExternalRef   LicenseDBSchemaExtension `json:"external_ref"`
-	ObligationIds []uuid.UUID              `json:"obligation_ids"`
-	User          User                     `json:"created_by"`
-	AddDate       time.Time                `json:"add_date"`
+	Id                 uuid.UUID                `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Shortname          string                   `json:"shortname" example:"MIT"`
+	Fullname           string                   `json:"fullname" example:"MIT License"`
+	Text               string                   `json:"text" example:"MIT License Text here"`
+	Url                string                   `json:"url" example:"https://opensource.org/licenses/MIT"`
+	Copyleft           bool                     `json:"copyleft"`
+	OSIapproved        bool                     `json:"OSIapproved"`
+	Notes              string                   `json:"notes" example:"This license has been superseded."`
+	TextUpdatable      bool                     `json:"text_updatable"`
+	Active             bool                     `json:"active"`
+	Source             string                   `json:"source"`
+	SpdxId             string                   `json:"spdx_id" example:"MIT"`
+	Risk               int64                    `json:"risk" example:"1"`
+	ExternalRef        LicenseDBSchemaExtension `json:"external_ref"`
+	ObligationIds      []uuid.UUID              `json:"obligation_ids"`
+	User               User                     `json:"created_by"`
+	AddDate            time.Time                `json:"add_date"`
+	StewardTeamId      *uuid.UUID               `json:"steward_team_id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	ReviewIntervalDays *int64                   `json:"review_interval_days" example:"365"`
+	LastReviewedAt     *time.Time               `json:"last_reviewed_at" example:"2026-01-01T00:00:00Z"`
+	ReviewDueAt        *time.Time               `json:"review_due_at" example:"2027-01-01T00:00:00Z"`
+	Aliases            []LicenseAliasDTO        `json:"aliases,omitempty"`
+	Version            int64                    `json:"version" example:"1"`
 }
 
 // LicenseUpdateDTO struct represents the input format for updating an existing license.
 type LicenseUpdateDTO struct {
-	Shortname     *string                `json:"shortname" example:"MIT"`
-	Fullname      *string                `json:"fullname" example:"MIT License"`
-	Text          *string                `json:"text" example:"MIT License Text here"`
-	Url           *string                `json:"url" example:"https://opensource.org/licenses/MIT"`
-	Copyleft      *bool                  `json:"copyleft" example:"false"`
-	OSIapproved   *bool                  `json:"OSIapproved" example:"false"`
-	Notes         *string                `json:"notes" example:"This license has been superseded."`
-	TextUpdatable *bool                  `json:"text_updatable" example:"false"`
-	Active        *bool                  `json:"active" example:"true"`
-	Source        *string                `json:"source" example:"Source"`
-	SpdxId        *string                `json:"spdx_id" example:"MIT" validate:"omitempty,spdxId"`
-	Risk          *int64                 `json:"risk" validate:"omitempty,min=0,max=5" example:"1"`
-	ExternalRef   map[string]interface{} `json:"external_ref"`
-	ObligationIds *[]uuid.UUID           `json:"obligation_ids"`
+	Shortname          *string                `json:"shortname" example:"MIT"`
+	Fullname           *string                `json:"fullname" example:"MIT License"`
+	Text               *string                `json:"text" example:"MIT License Text here"`
+	Url                *string                `json:"url" example:"https://opensource.org/licenses/MIT"`
+	Copyleft           *bool                  `json:"copyleft" example:"false"`
+	OSIapproved        *bool                  `json:"OSIapproved" example:"false"`
+	Notes              *string                `json:"notes" example:"This license has been superseded."`
+	TextUpdatable      *bool                  `json:"text_updatable" example:"false"`
+	Active             *bool                  `json:"active" example:"true"`
+	Source             *string                `json:"source" example:"Source"`
+	SpdxId             *string                `json:"spdx_id" example:"MIT" validate:"omitempty,spdxId"`
+	Risk               *int64                 `json:"risk" validate:"omitempty,min=0,max=5" example:"1"`
+	ExternalRef        map[string]interface{} `json:"external_ref"`
+	ObligationIds      *[]uuid.UUID           `json:"obligation_ids"`
+	ReviewIntervalDays *int64                 `json:"review_interval_days" validate:"omitempty,min=0" example:"365"`
 }
 
 func (dto *LicenseUpdateDTO) ConvertToLicenseDB() LicenseDB {
@@ -206,6 +230,7 @@ func (dto *LicenseUpdateDTO) ConvertToLicenseDB() LicenseDB {
 	l.Text = dto.Text
 	l.TextUpdatable = dto.TextUpdatable
 	l.Url = dto.Url
+	l.ReviewIntervalDays = dto.ReviewIntervalDays
 
 	return l
 }
@@ -227,6 +252,7 @@ type LicenseImportDTO struct {
 	Risk          *int64                 `json:"risk" validate:"omitempty,min=0,max=5" example:"1"`
 	ExternalRef   map[string]interface{} `json:"external_ref"`
 	ObligationIds *[]uuid.UUID           `json:"obligation_ids"`
+	Aliases       *[]LicenseAliasInput   `json:"aliases" validate:"omitempty,dive"`
 }
 
 func (dto *LicenseImportDTO) ConvertToLicenseDB() LicenseDB {
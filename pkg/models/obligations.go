@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/datatypes"
@@ -34,6 +35,11 @@ type Obligation struct {
 	Classification             *ObligationClassification                     `gorm:"foreignKey:ObligationClassificationId;references:Id"`
 	Category                   *ObligationCategory                           `gorm:"foreignKey:ObligationCategoryId;references:Id"`
 	ExternalRef                datatypes.JSONType[ObligationSchemaExtension] `gorm:"column:external_ref"`
+	StewardTeamId              *uuid.UUID                                    `gorm:"type:uuid;column:steward_team_id"`
+	ReviewIntervalDays         *int64                                        `gorm:"column:review_interval_days"`
+	LastReviewedAt             *time.Time                                    `gorm:"column:last_reviewed_at"`
+	ReviewRemindedAt           *time.Time                                    `gorm:"column:review_reminded_at"`
+	Version                    int64                                         `gorm:"column:version;default:1"`
 }
 
 func (Obligation) TableName() string {
@@ -187,17 +193,22 @@ func (o *Obligation) BeforeUpdate(tx *gorm.DB) (err error) {
 
 func (o *Obligation) ConvertToObligationResponseDTO() ObligationResponseDTO {
 	dto := ObligationResponseDTO{
-		Id:             o.Id,
-		Topic:          *o.Topic,
-		Text:           *o.Text,
-		Active:         *o.Active,
-		TextUpdatable:  *o.TextUpdatable,
-		LicenseIds:     []uuid.UUID{},
-		Type:           o.Type.Type,
-		Classification: o.Classification.Classification,
-		Category:       o.Category.Category,
-		Comment:        o.Comment,
-		ExternalRef:    o.ExternalRef.Data(),
+		Id:                 o.Id,
+		Topic:              deref(o.Topic),
+		Text:               deref(o.Text),
+		Active:             deref(o.Active),
+		TextUpdatable:      deref(o.TextUpdatable),
+		LicenseIds:         []uuid.UUID{},
+		Type:               o.Type.Type,
+		Classification:     o.Classification.Classification,
+		Category:           o.Category.Category,
+		Comment:            o.Comment,
+		ExternalRef:        o.ExternalRef.Data(),
+		StewardTeamId:      o.StewardTeamId,
+		ReviewIntervalDays: o.ReviewIntervalDays,
+		LastReviewedAt:     o.LastReviewedAt,
+		ReviewDueAt:        reviewDueAt(o.LastReviewedAt, o.ReviewIntervalDays),
+		Version:            o.Version,
 	}
 
 	for _, lic := range o.Licenses {
@@ -209,31 +220,37 @@ func (o *Obligation) ConvertToObligationResponseDTO() ObligationResponseDTO {
 
 // ObligationResponseDTO represents an obligation json object.
 type ObligationResponseDTO struct {
-	Id             uuid.UUID                 `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
-	Topic          string                    `json:"topic" example:"copyleft" validate:"required"`
-	Type           string                    `json:"type" example:"RISK" validate:"required"`
-	Text           string                    `json:"text" example:"Source code be made available when distributing the software." validate:"required"`
-	Classification string                    `json:"classification" example:"GREEN" validate:"required"`
-	Comment        *string                   `json:"comment"`
-	Active         bool                      `json:"active"`
-	TextUpdatable  bool                      `json:"text_updatable" example:"true"`
-	LicenseIds     []uuid.UUID               `json:"license_ids" validate:"required" swaggertype:"array,string" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6,f812jfae-7dbc-11d0-a765-00a0hf06bf6"`
-	Category       string                    `json:"category" example:"DISTRIBUTION" validate:"required"`
-	ExternalRef    ObligationSchemaExtension `json:"external_ref"`
+	Id                 uuid.UUID                 `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Topic              string                    `json:"topic" example:"copyleft" validate:"required"`
+	Type               string                    `json:"type" example:"RISK" validate:"required"`
+	Text               string                    `json:"text" example:"Source code be made available when distributing the software." validate:"required"`
+	Classification     string                    `json:"classification" example:"GREEN" validate:"required"`
+	Comment            *string                   `json:"comment"`
+	Active             bool                      `json:"active"`
+	TextUpdatable      bool                      `json:"text_updatable" example:"true"`
+	LicenseIds         []uuid.UUID               `json:"license_ids" validate:"required" swaggertype:"array,string" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6,f812jfae-7dbc-11d0-a765-00a0hf06bf6"`
+	Category           string                    `json:"category" example:"DISTRIBUTION" validate:"required"`
+	ExternalRef        ObligationSchemaExtension `json:"external_ref"`
+	StewardTeamId      *uuid.UUID                `json:"steward_team_id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	ReviewIntervalDays *int64                    `json:"review_interval_days" example:"365"`
+	LastReviewedAt     *time.Time                `json:"last_reviewed_at" example:"2026-01-01T00:00:00Z"`
+	ReviewDueAt        *time.Time                `json:"review_due_at" example:"2027-01-01T00:00:00Z"`
+	Version            int64                     `json:"version" example:"1"`
 }
 
 // ObligationUpdateDTO represents an obligation json object.
 type ObligationUpdateDTO struct {
-	Topic          *string                `json:"topic" example:"copyleft"`
-	Type           *string                `json:"type" example:"RISK"`
-	Text           *string                `json:"text" example:"Source code be made available when distributing the software."`
-	Classification *string                `json:"classification" example:"GREEN"`
-	Comment        *string                `json:"comment"`
-	Active         *bool                  `json:"active"`
-	LicenseIds     *[]uuid.UUID           `json:"license_ids" validate:"required" swaggertype:"array,string" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6,f812jfae-7dbc-11d0-a765-00a0hf06bf6"`
-	TextUpdatable  *bool                  `json:"text_updatable" example:"true"`
-	Category       *string                `json:"category" example:"DISTRIBUTION"`
-	ExternalRef    map[string]interface{} `json:"external_ref"`
+	Topic              *string                `json:"topic" example:"copyleft"`
+	Type               *string                `json:"type" example:"RISK"`
+	Text               *string                `json:"text" example:"Source code be made available when distributing the software."`
+	Classification     *string                `json:"classification" example:"GREEN"`
+	Comment            *string                `json:"comment"`
+	Active             *bool                  `json:"active"`
+	LicenseIds         *[]uuid.UUID           `json:"license_ids" validate:"required" swaggertype:"array,string" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6,f812jfae-7dbc-11d0-a765-00a0hf06bf6"`
+	TextUpdatable      *bool                  `json:"text_updatable" example:"true"`
+	Category           *string                `json:"category" example:"DISTRIBUTION"`
+	ExternalRef        map[string]interface{} `json:"external_ref"`
+	ReviewIntervalDays *int64                 `json:"review_interval_days" validate:"omitempty,min=0" example:"365"`
 }
 
 func (obDto *ObligationUpdateDTO) ConvertToObligation() Obligation {
@@ -253,22 +270,24 @@ func (obDto *ObligationUpdateDTO) ConvertToObligation() Obligation {
 	if obDto.Category != nil {
 		o.Category = &ObligationCategory{Category: *obDto.Category}
 	}
+	o.ReviewIntervalDays = obDto.ReviewIntervalDays
 
 	return o
 }
 
 // ObligationCreateDTO represents an obligation json object.
 type ObligationCreateDTO struct {
-	Topic          string                    `json:"topic" example:"copyleft" validate:"required"`
-	Type           string                    `json:"type" example:"RISK" validate:"required"`
-	Text           string                    `json:"text" example:"Source code be made available when distributing the software." validate:"required"`
-	Classification string                    `json:"classification" example:"GREEN" validate:"required"`
-	Comment        *string                   `json:"comment"`
-	Active         *bool                     `json:"active"`
-	TextUpdatable  *bool                     `json:"text_updatable" example:"true"`
-	LicenseIds     []uuid.UUID               `json:"license_ids" validate:"required" swaggertype:"array,string" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6,f812jfae-7dbc-11d0-a765-00a0hf06bf6"`
-	Category       string                    `json:"category" example:"DISTRIBUTION"`
-	ExternalRef    ObligationSchemaExtension `json:"external_ref"`
+	Topic              string                    `json:"topic" example:"copyleft" validate:"required"`
+	Type               string                    `json:"type" example:"RISK" validate:"required"`
+	Text               string                    `json:"text" example:"Source code be made available when distributing the software." validate:"required"`
+	Classification     string                    `json:"classification" example:"GREEN" validate:"required"`
+	Comment            *string                   `json:"comment"`
+	Active             *bool                     `json:"active"`
+	TextUpdatable      *bool                     `json:"text_updatable" example:"true"`
+	LicenseIds         []uuid.UUID               `json:"license_ids" validate:"required" swaggertype:"array,string" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6,f812jfae-7dbc-11d0-a765-00a0hf06bf6"`
+	Category           string                    `json:"category" example:"DISTRIBUTION"`
+	ExternalRef        ObligationSchemaExtension `json:"external_ref"`
+	ReviewIntervalDays *int64                    `json:"review_interval_days" validate:"omitempty,min=0" example:"365"`
 }
 
 func (dto *ObligationCreateDTO) ConvertToObligation() Obligation {
@@ -279,6 +298,7 @@ func (dto *ObligationCreateDTO) ConvertToObligation() Obligation {
 	o.Comment = dto.Comment
 	o.Active = dto.Active
 	o.TextUpdatable = dto.TextUpdatable
+	o.ReviewIntervalDays = dto.ReviewIntervalDays
 	o.Category = &ObligationCategory{
 		Category: dto.Category,
 	}
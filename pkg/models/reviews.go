@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewInput is the input for recording the review of a license or
+// obligation.
+type ReviewInput struct {
+	Comment *string `json:"comment" example:"Checked against the SPDX text, no changes needed"`
+}
+
+// OverdueReview is a license or obligation which is due for review.
+type OverdueReview struct {
+	Type           string     `json:"type" enums:"LICENSE,OBLIGATION" example:"LICENSE"`
+	Id             uuid.UUID  `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Name           string     `json:"name" example:"GPL-2.0-only"`
+	StewardTeamId  *uuid.UUID `json:"-"`
+	StewardTeam    *string    `json:"steward_team" example:"copyleft-reviewers"`
+	LastReviewedAt *time.Time `json:"last_reviewed_at" example:"2025-01-01T00:00:00Z"`
+	DueAt          *time.Time `json:"due_at" example:"2026-01-01T00:00:00Z"`
+	RemindedAt     *time.Time `json:"reminded_at" example:"2026-01-02T00:00:00Z"`
+}
+
+// OverdueReviewResponse represents the response format for overdue reviews.
+type OverdueReviewResponse struct {
+	Status int             `json:"status" example:"200"`
+	Data   []OverdueReview `json:"data"`
+	Meta   *PaginationMeta `json:"paginationmeta"`
+}
+
+// reviewDueAt returns when an item is due for review. It is nil if no review
+// is scheduled, or if the item was never reviewed and thus is due already.
+func reviewDueAt(lastReviewedAt *time.Time, intervalDays *int64) *time.Time {
+	if lastReviewedAt == nil || intervalDays == nil || *intervalDays <= 0 {
+		return nil
+	}
+	dueAt := lastReviewedAt.AddDate(0, 0, int(*intervalDays))
+	return &dueAt
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// Statuses of scans
+const (
+	ScanQueued    = "QUEUED"
+	ScanRunning   = "RUNNING"
+	ScanCompleted = "COMPLETED"
+	ScanFailed    = "FAILED"
+)
+
+// Scan is a background job detecting the licenses in an uploaded archive.
+type Scan struct {
+	Id         uuid.UUID      `gorm:"type:uuid;primary_key;column:id;default:uuid_generate_v4()"`
+	Status     string         `gorm:"column:status"`
+	FileName   string         `gorm:"column:file_name"`
+	Size       int64          `gorm:"column:size"`
+	Error      *string        `gorm:"column:error"`
+	Result     datatypes.JSON `gorm:"column:result"`
+	CreatedBy  uuid.UUID      `gorm:"type:uuid;column:created_by"`
+	CreatedAt  time.Time      `gorm:"column:created_at;default:CURRENT_TIMESTAMP"`
+	StartedAt  *time.Time     `gorm:"column:started_at"`
+	FinishedAt *time.Time     `gorm:"column:finished_at"`
+}
+
+func (Scan) TableName() string {
+	return "scans"
+}
+
+// ScannedLicense is a catalog license found in a scanned file.
+type ScannedLicense struct {
+	Id        uuid.UUID `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Shortname string    `json:"shortname" example:"MIT"`
+	SpdxId    string    `json:"spdx_id" example:"MIT"`
+}
+
+// ScanIdentifier is an SPDX-License-Identifier tag of a scanned file, along
+// with the catalog licenses of its ids and the ids not in the catalog.
+type ScanIdentifier struct {
+	Line       int              `json:"line" example:"1"`
+	Expression string           `json:"expression" example:"MIT OR Apache-2.0"`
+	Licenses   []ScannedLicense `json:"licenses"`
+	UnknownIds []string         `json:"unknown_ids" example:"LicenseRef-Proprietary"`
+}
+
+// ScanFileFinding are the licenses found in a file of a scanned archive.
+type ScanFileFinding struct {
+	Path         string              `json:"path" example:"src/main.c"`
+	Identifiers  []ScanIdentifier    `json:"identifiers"`
+	ExactMatches []IdentifiedLicense `json:"exact_matches"`
+	NearMatches  []IdentifiedLicense `json:"near_matches"`
+}
+
+// ScanObligation is an active obligation of a license found in a scan.
+type ScanObligation struct {
+	Id             uuid.UUID `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Topic          string    `json:"topic" example:"Provide Copyright Notices"`
+	Type           string    `json:"type" example:"OBLIGATION"`
+	Classification string    `json:"classification" example:"GREEN"`
+}
+
+// ScanLicense is a license found in a scan along with the number of files it
+// was found in and its obligations.
+type ScanLicense struct {
+	ScannedLicense
+	Files       int              `json:"files" example:"3"`
+	Obligations []ScanObligation `json:"obligations"`
+}
+
+// ScanResult are the findings of a scan. Files only lists the files in which
+// licenses or SPDX-License-Identifier tags were found. Binary files and
+// nested archives are skipped.
+type ScanResult struct {
+	FilesScanned int               `json:"files_scanned" example:"120"`
+	FilesSkipped int               `json:"files_skipped" example:"4"`
+	Files        []ScanFileFinding `json:"files"`
+	Licenses     []ScanLicense     `json:"licenses"`
+	UnknownIds   []string          `json:"unknown_ids" example:"LicenseRef-Proprietary"`
+}
+
+// ScanDTO is the api representation of a scan. Its result is set once the
+// scan is completed.
+type ScanDTO struct {
+	Id         uuid.UUID   `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Status     string      `json:"status" enums:"QUEUED,RUNNING,COMPLETED,FAILED" example:"COMPLETED"`
+	FileName   string      `json:"file_name" example:"project-1.0.tar.gz"`
+	Size       int64       `json:"size" example:"524288"`
+	Error      *string     `json:"error,omitempty" example:"invalid zip file: zip: not a valid zip file"`
+	Result     *ScanResult `json:"result,omitempty"`
+	CreatedAt  time.Time   `json:"created_at" example:"2026-01-01T00:00:00Z"`
+	StartedAt  *time.Time  `json:"started_at,omitempty" example:"2026-01-01T00:00:01Z"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty" example:"2026-01-01T00:00:05Z"`
+}
+
+// ScanResponse represents the response format for scan data.
+type ScanResponse struct {
+	Status int     `json:"status" example:"200"`
+	Data   ScanDTO `json:"data"`
+}
+
+// ConvertToScanDTO converts a scan to its api representation.
+func (s *Scan) ConvertToScanDTO() ScanDTO {
+	dto := ScanDTO{
+		Id:         s.Id,
+		Status:     s.Status,
+		FileName:   s.FileName,
+		Size:       s.Size,
+		Error:      s.Error,
+		CreatedAt:  s.CreatedAt,
+		StartedAt:  s.StartedAt,
+		FinishedAt: s.FinishedAt,
+	}
+	if len(s.Result) != 0 {
+		var result ScanResult
+		if err := json.Unmarshal(s.Result, &result); err == nil {
+			dto.Result = &result
+		}
+	}
+	return dto
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// URNs of the SCIM 2.0 schemas (RFC 7643, RFC 7644) used by the provisioning api.
+const (
+	ScimUserSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimListResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimPatchOpSchema      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimErrorSchema        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// ScimActorId is the id of the deactivated user the changes of SCIM
+// provisioning clients are audited as.
+var ScimActorId = uuid.MustParse("5c1a0000-0000-4000-8000-000000000001")
+
+// ScimName is the name of a SCIM user.
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty" example:"Jane Doe"`
+	GivenName  string `json:"givenName,omitempty" example:"Jane"`
+	FamilyName string `json:"familyName,omitempty" example:"Doe"`
+}
+
+// ScimMultiValuedAttribute is an entry of multi-valued attributes like emails
+// or roles.
+type ScimMultiValuedAttribute struct {
+	Value   string `json:"value" example:"jane.doe@example.com"`
+	Type    string `json:"type,omitempty" example:"work"`
+	Primary bool   `json:"primary,omitempty" example:"true"`
+}
+
+// ScimMeta holds the resource metadata of a SCIM resource.
+type ScimMeta struct {
+	ResourceType string `json:"resourceType" example:"User"`
+	Location     string `json:"location,omitempty" example:"/api/v1/scim/v2/Users/f81d4fae-7dec-11d0-a765-00a0c91e6bf6"`
+}
+
+// ScimUser is the SCIM representation of a User. The user level is exposed as
+// role and can be USER or ADMIN.
+type ScimUser struct {
+	Schemas     []string                   `json:"schemas" example:"urn:ietf:params:scim:schemas:core:2.0:User"`
+	Id          string                     `json:"id,omitempty" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6"`
+	ExternalId  string                     `json:"externalId,omitempty" example:"00u1abcd"`
+	UserName    string                     `json:"userName" example:"jdoe"`
+	Name        *ScimName                  `json:"name,omitempty"`
+	DisplayName string                     `json:"displayName,omitempty" example:"Jane Doe"`
+	Emails      []ScimMultiValuedAttribute `json:"emails,omitempty"`
+	Roles       []ScimMultiValuedAttribute `json:"roles,omitempty"`
+	Active      *bool                      `json:"active,omitempty" example:"true"`
+	Meta        *ScimMeta                  `json:"meta,omitempty"`
+}
+
+// ScimListResponse is the response of a SCIM query.
+type ScimListResponse struct {
+	Schemas      []string   `json:"schemas" example:"urn:ietf:params:scim:api:messages:2.0:ListResponse"`
+	TotalResults int64      `json:"totalResults" example:"1"`
+	StartIndex   int        `json:"startIndex" example:"1"`
+	ItemsPerPage int        `json:"itemsPerPage" example:"1"`
+	Resources    []ScimUser `json:"Resources"`
+}
+
+// ScimPatchOperation is a single operation of a SCIM PATCH request.
+type ScimPatchOperation struct {
+	Op    string      `json:"op" example:"replace"`
+	Path  string      `json:"path,omitempty" example:"active"`
+	Value interface{} `json:"value,omitempty" swaggertype:"object"`
+}
+
+// ScimPatchRequest is the body of a SCIM PATCH request.
+type ScimPatchRequest struct {
+	Schemas    []string             `json:"schemas" example:"urn:ietf:params:scim:api:messages:2.0:PatchOp"`
+	Operations []ScimPatchOperation `json:"Operations"`
+}
+
+// ScimError is the error response of the SCIM api.
+type ScimError struct {
+	Schemas  []string `json:"schemas" example:"urn:ietf:params:scim:api:messages:2.0:Error"`
+	Status   string   `json:"status" example:"404"`
+	ScimType string   `json:"scimType,omitempty" example:"uniqueness"`
+	Detail   string   `json:"detail" example:"user not found"`
+}
+
+// ConvertToScimUser converts the user to its SCIM representation.
+func (u *User) ConvertToScimUser(location string) ScimUser {
+	scimUser := ScimUser{
+		Schemas: []string{ScimUserSchema},
+		Id:      u.Id.String(),
+		Active:  u.Active,
+		Meta: &ScimMeta{
+			ResourceType: "User",
+			Location:     fmt.Sprintf("%s/%s", strings.TrimSuffix(location, "/"), u.Id.String()),
+		},
+	}
+	if u.ExternalId != nil {
+		scimUser.ExternalId = *u.ExternalId
+	}
+	if u.UserName != nil {
+		scimUser.UserName = *u.UserName
+	}
+	if u.DisplayName != nil {
+		scimUser.DisplayName = *u.DisplayName
+		scimUser.Name = &ScimName{Formatted: *u.DisplayName}
+	}
+	if u.UserEmail != nil {
+		scimUser.Emails = []ScimMultiValuedAttribute{{Value: *u.UserEmail, Type: "work", Primary: true}}
+	}
+	if u.UserLevel != nil {
+		scimUser.Roles = []ScimMultiValuedAttribute{{Value: *u.UserLevel, Primary: true}}
+	}
+	return scimUser
+}
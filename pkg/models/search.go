@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import "github.com/google/uuid"
+
+// SearchResult is a license or obligation matching a search, with a snippet of
+// its text in which the matched terms are highlighted. The snippet is HTML, the
+// text is escaped and only the highlights are <mark> tags.
+type SearchResult struct {
+	Type           string    `json:"type" enums:"LICENSE,OBLIGATION" example:"LICENSE"`
+	Id             uuid.UUID `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Name           string    `json:"name" example:"MIT"`
+	Risk           *int64    `json:"risk,omitempty" example:"1"`
+	Classification *string   `json:"classification,omitempty" example:"GREEN"`
+	Rank           float64   `json:"rank" example:"0.6079271"`
+	Snippet        string    `json:"snippet" example:"<mark>MIT</mark> License Copyright (c) &lt;year&gt; &lt;copyright holders&gt;"`
+}
+
+// SearchFacet is the number of search results with a certain value.
+type SearchFacet struct {
+	Value string `json:"value" example:"LICENSE"`
+	Count int64  `json:"count" example:"12"`
+}
+
+// SearchFacets are the number of search results by entity type, license risk
+// and obligation classification.
+type SearchFacets struct {
+	Types           []SearchFacet `json:"type"`
+	Risks           []SearchFacet `json:"risk"`
+	Classifications []SearchFacet `json:"classification"`
+}
+
+// SearchResponse represents the response format for search results.
+type SearchResponse struct {
+	Status int             `json:"status" example:"200"`
+	Data   []SearchResult  `json:"data"`
+	Facets SearchFacets    `json:"facets"`
+	Meta   *PaginationMeta `json:"paginationmeta"`
+}
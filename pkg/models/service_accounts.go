@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceAccount is a non-human identity used by automation. It acts through
+// its own user row (without password and email) which carries its name, user
+// level and active state, so audits and oidc clients reference the service
+// account instead of the person who set it up. It is owned by a team, so that
+// it outlives the people maintaining it.
+type ServiceAccount struct {
+	Id          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;column:id;default:uuid_generate_v4()" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	UserId      uuid.UUID  `json:"-" gorm:"type:uuid;column:user_id"`
+	User        User       `json:"-" gorm:"foreignKey:UserId;references:Id"`
+	Description *string    `json:"description" gorm:"column:description" example:"Imports licenses on every release"`
+	OwnerTeamId uuid.UUID  `json:"owner_team_id" gorm:"type:uuid;column:owner_team_id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	OwnerTeam   Team       `json:"-" gorm:"foreignKey:OwnerTeamId;references:Id"`
+	ExpiresAt   *time.Time `json:"expires_at" gorm:"column:expires_at" example:"2027-01-01T00:00:00Z"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP" example:"2026-01-01T00:00:00Z"`
+}
+
+func (ServiceAccount) TableName() string {
+	return "service_accounts"
+}
+
+// Expired tells whether the service account is past its expiry date.
+func (s *ServiceAccount) Expired() bool {
+	return s.ExpiresAt != nil && !s.ExpiresAt.After(time.Now())
+}
+
+// ServiceAccountCreate is the input for creating a service account.
+type ServiceAccountCreate struct {
+	Name        string     `json:"name" validate:"required" example:"release-pipeline"`
+	Description *string    `json:"description" example:"Imports licenses on every release"`
+	UserLevel   string     `json:"user_level" validate:"required,oneof=USER ADMIN" example:"USER"`
+	OwnerTeam   string     `json:"owner_team" validate:"required" example:"release-engineering"`
+	ExpiresAt   *time.Time `json:"expires_at" example:"2027-01-01T00:00:00Z"`
+}
+
+// ServiceAccountUpdate is the input for updating a service account.
+type ServiceAccountUpdate struct {
+	Description *string    `json:"description" example:"Imports licenses on every release"`
+	UserLevel   *string    `json:"user_level" validate:"omitempty,oneof=USER ADMIN" example:"ADMIN"`
+	OwnerTeam   *string    `json:"owner_team" example:"release-engineering"`
+	ExpiresAt   *time.Time `json:"expires_at" example:"2027-01-01T00:00:00Z"`
+	Active      *bool      `json:"active" example:"true"`
+}
+
+// ServiceAccountClient is the input for mapping an oidc client to a service
+// account.
+type ServiceAccountClient struct {
+	ClientId string `json:"client_id" validate:"required" example:"release-pipeline"`
+}
+
+// ServiceAccountDTO is the api representation of a service account.
+type ServiceAccountDTO struct {
+	Id          uuid.UUID  `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	UserId      uuid.UUID  `json:"user_id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Name        string     `json:"name" example:"release-pipeline"`
+	Description *string    `json:"description" example:"Imports licenses on every release"`
+	UserLevel   string     `json:"user_level" example:"USER"`
+	OwnerTeam   string     `json:"owner_team" example:"release-engineering"`
+	ExpiresAt   *time.Time `json:"expires_at" example:"2027-01-01T00:00:00Z"`
+	Expired     bool       `json:"expired" example:"false"`
+	Active      bool       `json:"active" example:"true"`
+	ClientIds   []string   `json:"client_ids" example:"release-pipeline"`
+	CreatedAt   time.Time  `json:"created_at" example:"2026-01-01T00:00:00Z"`
+}
+
+// ServiceAccountResponse represents the response format for service account data.
+type ServiceAccountResponse struct {
+	Status int                 `json:"status" example:"200"`
+	Data   []ServiceAccountDTO `json:"data"`
+	Meta   *PaginationMeta     `json:"paginationmeta"`
+}
+
+// ConvertToServiceAccountDTO converts a service account with preloaded user and
+// owner team to its api representation.
+func (s *ServiceAccount) ConvertToServiceAccountDTO(clients []OidcClient) ServiceAccountDTO {
+	dto := ServiceAccountDTO{
+		Id:          s.Id,
+		UserId:      s.UserId,
+		Description: s.Description,
+		ExpiresAt:   s.ExpiresAt,
+		Expired:     s.Expired(),
+		ClientIds:   []string{},
+		CreatedAt:   s.CreatedAt,
+	}
+	if s.User.UserName != nil {
+		dto.Name = *s.User.UserName
+	}
+	if s.User.UserLevel != nil {
+		dto.UserLevel = *s.User.UserLevel
+	}
+	if s.User.Active != nil {
+		dto.Active = *s.User.Active
+	}
+	dto.OwnerTeam = s.OwnerTeam.Name
+	for _, client := range clients {
+		dto.ClientIds = append(dto.ClientIds, client.ClientId)
+	}
+	return dto
+}
@@ -10,7 +10,7 @@ import "github.com/google/uuid"
 type SimilarLicense struct {
 	Id         uuid.UUID `json:"id" gorm:"column:rf_id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
 	Shortname  *string   `json:"shortname" gorm:"column:rf_shortname" example:"MIT"`
-	Text       *string   `json:"text" gorm:"column:rf_text" example:"MIT License Text here"`
+	Text       *string   `json:"text,omitempty" gorm:"column:rf_text" example:"MIT License Text here"`
 	Similarity float64   `json:"similarity"`
 }
 
@@ -18,11 +18,72 @@ type SimilarLicense struct {
 type SimilarObligation struct {
 	Id         uuid.UUID `gorm:"primary_key;column:id" json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
 	Topic      *string   `gorm:"column:topic" json:"topic" example:"MIT license"`
-	Text       *string   `gorm:"column:text" json:"text"  example:"obligation text here"`
+	Text       *string   `gorm:"column:text" json:"text,omitempty"  example:"obligation text here"`
 	Similarity float64   `json:"similarity"`
 }
 
 // SimilarityRequest represents a request for similarity search
 type SimilarityRequest struct {
 	Text string `json:"text" binding:"required"`
+	// Threshold is the minimum trigram similarity of matches, defaulting to
+	// SIMILARITY_THRESHOLD
+	Threshold *float64 `json:"threshold" binding:"omitempty,gt=0,lte=1" example:"0.8"`
+	// Limit is the maximum number of matches, all matches by default
+	Limit *int `json:"limit" binding:"omitempty,min=1,max=100" example:"10"`
+	// MinLength is the minimum length of the texts of matches, to skip short
+	// texts which are similar to almost anything
+	MinLength *int `json:"min_length" binding:"omitempty,min=0" example:"100"`
+	// OmitText leaves the texts of matches out of the response
+	OmitText bool `json:"omit_text" example:"false"`
+}
+
+// IdentifyRequest is the input for identifying the licenses in a text.
+type IdentifyRequest struct {
+	Text        string   `json:"text" binding:"required" example:"Permission is hereby granted, free of charge, to any person obtaining a copy"`
+	MinCoverage *float64 `json:"min_coverage" validate:"omitempty,min=0,max=100" example:"50"`
+}
+
+// MatchedPortion is a part of the input text matching a license, counted in
+// characters.
+type MatchedPortion struct {
+	Start int `json:"start" example:"0"`
+	End   int `json:"end" example:"1073"`
+}
+
+// IdentifiedLicense is a license found in the input text. Coverage is the
+// percentage of the license text found in the input.
+type IdentifiedLicense struct {
+	Id        uuid.UUID        `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Shortname string           `json:"shortname" example:"MIT"`
+	SpdxId    string           `json:"spdx_id" example:"MIT"`
+	Coverage  float64          `json:"coverage" example:"98.8"`
+	Portions  []MatchedPortion `json:"portions"`
+}
+
+// LicenseIdentification lists the licenses whose text is found exactly in the
+// input and the ones which are found partially.
+type LicenseIdentification struct {
+	ExactMatches []IdentifiedLicense `json:"exact_matches"`
+	NearMatches  []IdentifiedLicense `json:"near_matches"`
+}
+
+// LicenseIdentificationResponse represents the response format for license
+// identification.
+type LicenseIdentificationResponse struct {
+	Status int                   `json:"status" example:"200"`
+	Data   LicenseIdentification `json:"data"`
+}
+
+// LicenseDuplicateError is the error of creating a license whose text is
+// similar to the texts of active licenses.
+type LicenseDuplicateError struct {
+	LicenseError
+	Matches []SimilarLicense `json:"matches"`
+}
+
+// ObligationDuplicateError is the error of creating an obligation whose text
+// is similar to the texts of active obligations.
+type ObligationDuplicateError struct {
+	LicenseError
+	Matches []SimilarObligation `json:"matches"`
 }
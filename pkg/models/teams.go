@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Team is a group of users which can be assigned as steward of licenses and
+// obligations. If StewardOnlyEdits is set, only its members can edit the items
+// it stewards.
+type Team struct {
+	Id               uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;column:id;default:uuid_generate_v4()" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Name             string         `json:"name" gorm:"column:name" example:"copyleft-reviewers"`
+	Description      *string        `json:"description" gorm:"column:description" example:"Legal reviewers of copyleft licenses"`
+	StewardOnlyEdits bool           `json:"steward_only_edits" gorm:"column:steward_only_edits" example:"true"`
+	Members          []User         `json:"-" gorm:"many2many:team_members;joinForeignKey:team_id;joinReferences:user_id"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP" example:"2026-01-01T00:00:00Z"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"column:deleted_at"`
+}
+
+func (Team) TableName() string {
+	return "teams"
+}
+
+// TeamCreate is the input for creating a team.
+type TeamCreate struct {
+	Name             string   `json:"name" validate:"required" example:"copyleft-reviewers"`
+	Description      *string  `json:"description" example:"Legal reviewers of copyleft licenses"`
+	StewardOnlyEdits bool     `json:"steward_only_edits" example:"true"`
+	Members          []string `json:"members" example:"fossy"`
+}
+
+// TeamUpdate is the input for updating a team.
+type TeamUpdate struct {
+	Description      *string `json:"description" example:"Legal reviewers of copyleft licenses"`
+	StewardOnlyEdits *bool   `json:"steward_only_edits" example:"true"`
+}
+
+// TeamMemberInput is the input for adding a user to a team.
+type TeamMemberInput struct {
+	Username string `json:"username" validate:"required" example:"fossy"`
+}
+
+// TeamDTO is the api representation of a team.
+type TeamDTO struct {
+	Id               uuid.UUID `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Name             string    `json:"name" example:"copyleft-reviewers"`
+	Description      *string   `json:"description" example:"Legal reviewers of copyleft licenses"`
+	StewardOnlyEdits bool      `json:"steward_only_edits" example:"true"`
+	Members          []string  `json:"members" example:"fossy"`
+	CreatedAt        time.Time `json:"created_at" example:"2026-01-01T00:00:00Z"`
+}
+
+// TeamResponse represents the response format for team data.
+type TeamResponse struct {
+	Status int             `json:"status" example:"200"`
+	Data   []TeamDTO       `json:"data"`
+	Meta   *PaginationMeta `json:"paginationmeta"`
+}
+
+// ConvertToTeamDTO converts a team with preloaded members to its api
+// representation.
+func (t *Team) ConvertToTeamDTO() TeamDTO {
+	dto := TeamDTO{
+		Id:               t.Id,
+		Name:             t.Name,
+		Description:      t.Description,
+		StewardOnlyEdits: t.StewardOnlyEdits,
+		Members:          []string{},
+		CreatedAt:        t.CreatedAt,
+	}
+	for _, member := range t.Members {
+		if member.UserName != nil {
+			dto.Members = append(dto.Members, *member.UserName)
+		}
+	}
+	return dto
+}
+
+// StewardAssignment is the input for assigning the steward team of a license
+// or obligation. A null team removes the steward.
+type StewardAssignment struct {
+	Team *string `json:"team" example:"copyleft-reviewers"`
+}
+
+// StewardedItems lists the licenses and obligations stewarded by the teams of
+// a user.
+type StewardedItems struct {
+	Licenses    []StewardedLicense    `json:"licenses"`
+	Obligations []StewardedObligation `json:"obligations"`
+}
+
+// StewardedLicense is a license stewarded by a team of the user.
+type StewardedLicense struct {
+	LicensePreview
+	Team string `json:"team" example:"copyleft-reviewers"`
+}
+
+// StewardedObligation is an obligation stewarded by a team of the user.
+type StewardedObligation struct {
+	Id    uuid.UUID `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Topic string    `json:"topic" example:"Provide Copyright Notices"`
+	Team  string    `json:"team" example:"copyleft-reviewers"`
+}
+
+// StewardedItemsResponse represents the response format for stewarded items.
+type StewardedItemsResponse struct {
+	Status int            `json:"status" example:"200"`
+	Data   StewardedItems `json:"data"`
+}
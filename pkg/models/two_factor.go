@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserRecoveryCode stores the hash of a single-use recovery code which can be
+// used instead of a TOTP code when the authenticator device is lost.
+type UserRecoveryCode struct {
+	Id       uuid.UUID  `gorm:"type:uuid;primary_key;column:id;default:uuid_generate_v4()"`
+	UserId   uuid.UUID  `gorm:"type:uuid;column:user_id"`
+	CodeHash string     `gorm:"column:code_hash"`
+	UsedAt   *time.Time `gorm:"column:used_at"`
+}
+
+func (UserRecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
+
+// TwoFactorPolicy tells whether two-factor authentication is mandatory for
+// local accounts of a user level.
+type TwoFactorPolicy struct {
+	Id        uuid.UUID `gorm:"type:uuid;primary_key;column:id;default:uuid_generate_v4()" json:"-"`
+	UserLevel string    `gorm:"column:user_level" json:"user_level" validate:"required,oneof=USER ADMIN SUPER_ADMIN" example:"ADMIN"`
+	Required  *bool     `gorm:"column:required;default:false" json:"required" validate:"required" example:"true"`
+}
+
+func (TwoFactorPolicy) TableName() string {
+	return "two_factor_policies"
+}
+
+// TwoFactorPolicyResponse represents the response format for two-factor policy data.
+type TwoFactorPolicyResponse struct {
+	Status int               `json:"status" example:"200"`
+	Data   []TwoFactorPolicy `json:"data"`
+	Meta   *PaginationMeta   `json:"paginationmeta"`
+}
+
+// TwoFactorCode is the input carrying a TOTP code or a recovery code.
+type TwoFactorCode struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// TwoFactorEnrollment holds the shared secret of a pending enrollment. The
+// provisioning uri is meant to be rendered as a QR code for authenticator apps.
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	ProvisioningUri string `json:"provisioning_uri" example:"otpauth://totp/LicenseDB:fossy?algorithm=SHA1&digits=6&issuer=LicenseDB&period=30&secret=JBSWY3DPEHPK3PXP"`
+}
+
+// TwoFactorEnrollmentResponse represents the response format for an enrollment.
+type TwoFactorEnrollmentResponse ApiResponse[TwoFactorEnrollment]
+
+// TwoFactorRecoveryCodes lists freshly generated recovery codes. They are
+// shown only once and stored hashed.
+type TwoFactorRecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k3v9-2xq7"`
+}
+
+// TwoFactorRecoveryCodesResponse represents the response format for recovery codes.
+type TwoFactorRecoveryCodesResponse ApiResponse[TwoFactorRecoveryCodes]
+
+// TwoFactorChallenge is returned by login when the password was correct but a
+// second factor is needed. The mfa token has to be exchanged at /login/2fa.
+type TwoFactorChallenge struct {
+	MfaToken           string `json:"mfa_token" example:"your_mfa_token_here"`
+	ExpiresAt          string `json:"expires_at" example:"2026-02-10T15:11:14Z"`
+	EnrollmentRequired bool   `json:"enrollment_required" example:"false"`
+}
+
+// TwoFactorChallengeResponse represents the response format for a login challenge.
+type TwoFactorChallengeResponse ApiResponse[TwoFactorChallenge]
+
+// TwoFactorLogin is the input of the second login step.
+type TwoFactorLogin struct {
+	MfaToken string `json:"mfa_token" binding:"required" example:"your_mfa_token_here"`
+	Code     string `json:"code" binding:"required" example:"123456"`
+}
+
+// TwoFactorLoginEnroll is the input to start an enrollment during login.
+type TwoFactorLoginEnroll struct {
+	MfaToken string `json:"mfa_token" binding:"required" example:"your_mfa_token_here"`
+}
+
+// TwoFactorLoginResult carries the issued tokens, and the recovery codes when
+// the login also completed an enrollment.
+type TwoFactorLoginResult struct {
+	Tokens
+	RecoveryCodes []string `json:"recovery_codes,omitempty" example:"k3v9-2xq7"`
+}
+
+// TwoFactorLoginResponse represents the response format for the second login step.
+type TwoFactorLoginResponse ApiResponse[TwoFactorLoginResult]
@@ -9,6 +9,7 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -27,12 +28,16 @@ type PaginationMeta struct {
 	Limit         int64  `json:"limit,omitempty" example:"10"`
 	Next          string `json:"next,omitempty" example:"/api/v1/licenses?limit=10&page=11"`
 	Previous      string `json:"previous,omitempty" example:"/api/v1/licenses?limit=10&page=9"`
+	// NextCursor is the cursor of the next page of listings paginated by cursor
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJjIjoicmZfc2hvcnRuYW1lIn0"`
 }
 
 // The PaginationInput struct represents the input required for pagination.
 type PaginationInput struct {
 	Page  int64 `json:"page" example:"10"`
 	Limit int64 `json:"limit" example:"10"`
+	// Cursor is set when paginating by cursor, and empty for the first page
+	Cursor *string `json:"cursor,omitempty" example:"eyJjIjoicmZfc2hvcnRuYW1lIn0"`
 }
 
 // PaginationParse interface processes the pagination input.
@@ -51,6 +56,20 @@ func (p PaginationInput) GetLimit() int64 {
 	return p.Limit
 }
 
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// deref returns the value p points to, or the zero value for columns which
+// were not selected.
+func deref[T any](p *T) T {
+	var v T
+	if p != nil {
+		v = *p
+	}
+	return v
+}
+
 // The LicenseError struct represents an error response related to license operations.
 // It provides information about the encountered error, including details such as
 // status, error message, error type, path, and timestamp.
@@ -64,14 +83,21 @@ type LicenseError struct {
 
 // User struct is representation of user information.
 type User struct {
-	Id           uuid.UUID `json:"id" gorm:"primary_key;type:uuid;column:id;default:uuid_generate_v4()" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
-	UserName     *string   `json:"user_name" gorm:"column:user_name" example:"fossy"`
-	DisplayName  *string   `json:"display_name" gorm:"column:display_name" example:"fossy"`
-	UserEmail    *string   `json:"user_email" gorm:"column:user_email" example:"fossy@org.com"`
-	UserLevel    *string   `json:"user_level" gorm:"column:user_level" example:"USER"`
-	UserPassword *string   `json:"-" gorm:"column:user_password"`
-	Active       *bool     `json:"-" gorm:"column:active;default:true"`
-	Subscribed   *bool     `json:"subscribed" gorm:"column:subscribed;default:false"`
+	Id              uuid.UUID  `json:"id" gorm:"primary_key;type:uuid;column:id;default:uuid_generate_v4()" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	UserName        *string    `json:"user_name" gorm:"column:user_name" example:"fossy"`
+	DisplayName     *string    `json:"display_name" gorm:"column:display_name" example:"fossy"`
+	UserEmail       *string    `json:"user_email" gorm:"column:user_email" example:"fossy@org.com"`
+	UserLevel       *string    `json:"user_level" gorm:"column:user_level" example:"USER"`
+	UserPassword    *string    `json:"-" gorm:"column:user_password"`
+	Active          *bool      `json:"-" gorm:"column:active;default:true"`
+	Subscribed      *bool      `json:"subscribed" gorm:"column:subscribed;default:false"`
+	TotpSecret      *string    `json:"-" gorm:"column:totp_secret"`
+	TotpEnabled     *bool      `json:"totp_enabled" gorm:"column:totp_enabled;default:false"`
+	TotpLastStep    *int64     `json:"-" gorm:"column:totp_last_step"`
+	ExternalId      *string    `json:"-" gorm:"column:external_id"`
+	AnonymisedAt    *time.Time `json:"-" gorm:"column:anonymised_at"`
+	ExternalIssuer  *string    `json:"-" gorm:"column:external_issuer"`
+	ExternalSubject *string    `json:"-" gorm:"column:external_subject"`
 }
 
 func (User) TableName() string {
@@ -123,36 +149,57 @@ func (u *User) BeforeUpdate(tx *gorm.DB) (err error) {
 }
 
 type UserCreate struct {
-	Id           uuid.UUID `json:"-"`
-	UserName     *string   `json:"user_name" validate:"required" example:"fossy"`
-	DisplayName  *string   `json:"display_name" validate:"required" example:"fossy"`
-	UserEmail    *string   `json:"user_email" validate:"required,email" example:"fossy@org.com"`
-	UserLevel    *string   `json:"user_level" validate:"required,oneof=USER ADMIN" example:"ADMIN"`
-	UserPassword *string   `json:"user_password" example:"fossy"`
-	Active       *bool     `json:"-"`
-	Subscribed   *bool     `json:"-"`
+	Id              uuid.UUID  `json:"-"`
+	UserName        *string    `json:"user_name" validate:"required" example:"fossy"`
+	DisplayName     *string    `json:"display_name" validate:"required" example:"fossy"`
+	UserEmail       *string    `json:"user_email" validate:"required,email" example:"fossy@org.com"`
+	UserLevel       *string    `json:"user_level" validate:"required,oneof=USER ADMIN" example:"ADMIN"`
+	UserPassword    *string    `json:"user_password" example:"fossy"`
+	Active          *bool      `json:"-"`
+	Subscribed      *bool      `json:"-"`
+	TotpSecret      *string    `json:"-"`
+	TotpEnabled     *bool      `json:"-"`
+	TotpLastStep    *int64     `json:"-"`
+	ExternalId      *string    `json:"-"`
+	AnonymisedAt    *time.Time `json:"-"`
+	ExternalIssuer  *string    `json:"-"`
+	ExternalSubject *string    `json:"-"`
 }
 
 type UserUpdate struct {
-	Id           uuid.UUID `json:"-"`
-	UserName     *string   `json:"user_name" example:"fossy"`
-	DisplayName  *string   `json:"display_name" example:"fossy"`
-	UserEmail    *string   `json:"user_email" validate:"omitempty,email"`
-	UserLevel    *string   `json:"user_level" validate:"omitempty,oneof=USER ADMIN" example:"ADMIN"`
-	UserPassword *string   `json:"user_password"`
-	Active       *bool     `json:"active"`
-	Subscribed   *bool     `json:"-"`
+	Id              uuid.UUID  `json:"-"`
+	UserName        *string    `json:"user_name" example:"fossy"`
+	DisplayName     *string    `json:"display_name" example:"fossy"`
+	UserEmail       *string    `json:"user_email" validate:"omitempty,email"`
+	UserLevel       *string    `json:"user_level" validate:"omitempty,oneof=USER ADMIN" example:"ADMIN"`
+	UserPassword    *string    `json:"user_password"`
+	Active          *bool      `json:"active"`
+	Subscribed      *bool      `json:"-"`
+	TotpSecret      *string    `json:"-"`
+	TotpEnabled     *bool      `json:"-"`
+	TotpLastStep    *int64     `json:"-"`
+	ExternalId      *string    `json:"-"`
+	AnonymisedAt    *time.Time `json:"-"`
+	ExternalIssuer  *string    `json:"-"`
+	ExternalSubject *string    `json:"-"`
 }
 
 type ProfileUpdate struct {
-	Id           uuid.UUID `json:"-"`
-	UserName     *string   `json:"-"`
-	DisplayName  *string   `json:"display_name" example:"fossy"`
-	UserEmail    *string   `json:"user_email" validate:"omitempty,email"`
-	UserLevel    *string   `json:"-"`
-	UserPassword *string   `json:"user_password"`
-	Active       *bool     `json:"-"`
-	Subscribed   *bool     `json:"subscribed" example:"false"`
+	Id              uuid.UUID  `json:"-"`
+	UserName        *string    `json:"-"`
+	DisplayName     *string    `json:"display_name" example:"fossy"`
+	UserEmail       *string    `json:"user_email" validate:"omitempty,email"`
+	UserLevel       *string    `json:"-"`
+	UserPassword    *string    `json:"user_password"`
+	Active          *bool      `json:"-"`
+	Subscribed      *bool      `json:"subscribed" example:"false"`
+	TotpSecret      *string    `json:"-"`
+	TotpEnabled     *bool      `json:"-"`
+	TotpLastStep    *int64     `json:"-"`
+	ExternalId      *string    `json:"-"`
+	AnonymisedAt    *time.Time `json:"-"`
+	ExternalIssuer  *string    `json:"-"`
+	ExternalSubject *string    `json:"-"`
 }
 
 type UserLogin struct {
@@ -181,16 +228,37 @@ type Audit struct {
 	UserId     uuid.UUID   `json:"user_id" gorm:"type:uuid;column:user_id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
 	User       User        `gorm:"foreignKey:UserId;references:Id" json:"user"`
 	Timestamp  time.Time   `json:"timestamp" gorm:"column:timestamp" example:"2023-12-01T18:10:25.00+05:30"`
-	Type       string      `json:"type" gorm:"column:type" enums:"OBLIGATION,LICENSE,USER" example:"LICENSE"`
+	Type       string      `json:"type" gorm:"column:type" enums:"OBLIGATION,LICENSE,USER,TYPE,CLASSIFICATION,CATEGORY,TWO_FACTOR_POLICY,SERVICE_ACCOUNT,IMPERSONATION,TEAM" example:"LICENSE"`
 	TypeId     uuid.UUID   `json:"type_id" gorm:"type:uuid;column:type_id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
 	Entity     interface{} `json:"entity" gorm:"-" swaggertype:"object"`
 	ChangeLogs []ChangeLog `json:"-"`
+
+	// ServiceAccount is set if the user is the identity of a service account
+	ServiceAccount *ServiceAccount `gorm:"foreignKey:UserId;references:UserId" json:"service_account,omitempty"`
+
+	// ImpersonatorId is the super admin who made the change while
+	// impersonating the user
+	ImpersonatorId *uuid.UUID `json:"impersonator_id,omitempty" gorm:"type:uuid;column:impersonator_id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Impersonator   *User      `gorm:"foreignKey:ImpersonatorId;references:Id" json:"impersonator,omitempty"`
 }
 
 func (Audit) TableName() string {
 	return "audits"
 }
 
+// BeforeCreate marks audits created while a super admin impersonates the user.
+// The authentication middleware stores the impersonator as "impersonatorId" in
+// the gin context, which reaches the hook through db.DB.WithContext(c).
+func (a *Audit) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ImpersonatorId != nil || tx.Statement.Context == nil {
+		return
+	}
+	if impersonatorId, ok := tx.Statement.Context.Value("impersonatorId").(uuid.UUID); ok {
+		a.ImpersonatorId = &impersonatorId
+	}
+	return
+}
+
 // ChangeLog struct represents a change entity with certain attributes and properties
 type ChangeLog struct {
 	Id           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
@@ -221,9 +289,10 @@ type AuditResponse struct {
 
 // ObligationType represents one of the possible of obligation type values
 type ObligationType struct {
-	Id     uuid.UUID `gorm:"type:uuid;primary_key;column:id;default:uuid_generate_v4()" json:"-"`
-	Type   string    `gorm:"column:type" validate:"required,uppercase" example:"PERMISSION" json:"type"`
-	Active *bool     `gorm:"column:active;default:true" json:"-"`
+	Id      uuid.UUID `gorm:"type:uuid;primary_key;column:id;default:uuid_generate_v4()" json:"-"`
+	Type    string    `gorm:"column:type" validate:"required,uppercase" example:"PERMISSION" json:"type"`
+	Active  *bool     `gorm:"column:active;default:true" json:"-"`
+	Version int64     `gorm:"column:version;default:1" json:"version" example:"1"`
 }
 
 func (ObligationType) TableName() string {
@@ -243,6 +312,7 @@ type ObligationClassification struct {
 	Classification string    `gorm:"column:classification" validate:"required,uppercase" example:"GREEN" json:"classification"`
 	Color          string    `gorm:"column:color" validate:"required,hexcolor" example:"#00FF00" json:"color"`
 	Active         *bool     `gorm:"column:active;default:true" json:"-"`
+	Version        int64     `gorm:"column:version;default:1" json:"version" example:"1"`
 }
 
 func (ObligationClassification) TableName() string {
@@ -332,6 +402,7 @@ type Dashboard struct {
 	ObligationsCount             int64                     `json:"obligations_count" example:"7"`
 	UsersCount                   int64                     `json:"users_count" example:"5"`
 	LicenseChangesSinceLastMonth int64                     `json:"monthly_license_changes_count" example:"6"`
+	OverdueReviewsCount          int64                     `json:"overdue_reviews_count" example:"3"`
 	RiskLicenseFrequency         []RiskLicenseCount        `json:"risk_license_frequency"`
 	CategoryObligationFrequency  []CategoryObligationCount `json:"category_obligation_frequency"`
 }
@@ -361,4 +432,28 @@ type RefreshToken struct {
 // TokenResponse represents the response structure for token generation API.
 type TokenResponse ApiResponse[Tokens]
 
+// ImpersonationInput is the reason a super admin gives for impersonating a
+// user. It is kept in the audit trail.
+type ImpersonationInput struct {
+	Reason string `json:"reason" validate:"required" example:"User reports they can not edit obligation MIT-notice"`
+}
+
+// OpenIdConfiguration is the OpenID discovery document of the token issuer.
+type OpenIdConfiguration struct {
+	Issuer                           string   `json:"issuer" example:"http://localhost:8080"`
+	JwksUri                          string   `json:"jwks_uri" example:"http://localhost:8080/.well-known/jwks.json"`
+	TokenEndpoint                    string   `json:"token_endpoint" example:"http://localhost:8080/api/v1/login"`
+	ResponseTypesSupported           []string `json:"response_types_supported" example:"token"`
+	SubjectTypesSupported            []string `json:"subject_types_supported" example:"public"`
+	IdTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported" example:"RS256"`
+}
+
+// SparseResponse is the response of listings shaped by the fields and
+// expand query parameters, whose objects have the requested keys only.
+type SparseResponse struct {
+	Status int                          `json:"status" example:"200"`
+	Data   []map[string]json.RawMessage `json:"data" swaggertype:"array,object"`
+	Meta   *PaginationMeta              `json:"paginationmeta"`
+}
+
 // can add all other response structures in similar manner
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserDataExport bundles the personal data of a user and everything linked to
+// it, as required for a data subject access request.
+type UserDataExport struct {
+	ExportedAt      time.Time               `json:"exported_at" example:"2026-01-01T00:00:00Z"`
+	User            UserDataExportProfile   `json:"user"`
+	OidcClients     []UserDataExportClient  `json:"oidc_clients"`
+	ServiceAccounts []ServiceAccountDTO     `json:"owned_service_accounts"`
+	Licenses        []UserDataExportLicense `json:"created_licenses"`
+	Scans           []ScanDTO               `json:"scans"`
+	Teams           []UserDataExportTeam    `json:"teams"`
+	Audits          []UserDataExportAudit   `json:"audits"`
+	RecoveryCodes   UserDataExportRecovery  `json:"recovery_codes"`
+}
+
+// UserDataExportProfile is the stored profile of the user, including the
+// fields not shown by the other user endpoints.
+type UserDataExportProfile struct {
+	User
+	Active          bool       `json:"active" example:"true"`
+	ExternalId      *string    `json:"external_id" example:"00u1abcd"`
+	AnonymisedAt    *time.Time `json:"anonymised_at" example:"2026-01-01T00:00:00Z"`
+	ExternalIssuer  *string    `json:"external_issuer" example:"https://login.example.com"`
+	ExternalSubject *string    `json:"external_subject" example:"248289761001"`
+}
+
+// UserDataExportClient is an oidc client mapped to the user.
+type UserDataExportClient struct {
+	ClientId string    `json:"client_id" example:"release-pipeline"`
+	AddDate  time.Time `json:"add_date" example:"2023-12-01T18:10:25.00+05:30"`
+}
+
+// UserDataExportLicense is a license created by the user.
+type UserDataExportLicense struct {
+	Id        uuid.UUID `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Shortname *string   `json:"shortname" example:"MIT"`
+	AddDate   time.Time `json:"add_date" example:"2023-12-01T18:10:25.00+05:30"`
+}
+
+// UserDataExportTeam is a team the user is a member of.
+type UserDataExportTeam struct {
+	Id   uuid.UUID `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Name string    `json:"name" example:"copyleft-reviewers"`
+}
+
+// UserDataExportAudit is an audit made by, made while impersonating, or
+// describing a change of the user, along with its change logs.
+type UserDataExportAudit struct {
+	Id         uuid.UUID   `json:"id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Relation   string      `json:"relation" enums:"ACTOR,IMPERSONATOR,SUBJECT" example:"ACTOR"`
+	Type       string      `json:"type" example:"LICENSE"`
+	TypeId     uuid.UUID   `json:"type_id" example:"f81d4fae-7dec-11d0-a765-00a0c91e6bf6" swaggertype:"string"`
+	Timestamp  time.Time   `json:"timestamp" example:"2023-12-01T18:10:25.00+05:30"`
+	ChangeLogs []ChangeLog `json:"change_logs"`
+}
+
+// UserDataExportRecovery summarises the two-factor recovery codes of the user.
+// The codes themselves are only stored as hashes.
+type UserDataExportRecovery struct {
+	Total  int `json:"total" example:"10"`
+	Unused int `json:"unused" example:"8"`
+}
@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+// Keyset is the order of a listing which can be paginated by cursor: a sort
+// column and the primary key to break ties, both in the same direction.
+type Keyset struct {
+	// Table qualifies the columns in queries joining other tables
+	Table    string
+	Column   string
+	IdColumn string
+	Desc     bool
+}
+
+func (k Keyset) qualified(column string) string {
+	if k.Table == "" {
+		return column
+	}
+	return k.Table + "." + column
+}
+
+// cursor is the position after the last row of a page, given to clients
+// base64 encoded. The sort column and direction are kept to reject cursors
+// of differently sorted listings.
+type cursor struct {
+	Column string `json:"c"`
+	Desc   bool   `json:"d,omitempty"`
+	Value  any    `json:"v"`
+	Id     any    `json:"i"`
+}
+
+// schemas caches the parsed schemas of the rows of paginated listings.
+var schemas sync.Map
+
+// Paginator keeps the pagination of a listing from limiting its query to
+// building the metadata of its response.
+type Paginator struct {
+	Input  models.PaginationInput
+	c      *gin.Context
+	keyset *Keyset
+	count  int64
+}
+
+// PreparePaginateResponse counts the rows of query and limits it to the
+// requested page. Listings which can be paginated by cursor pass their
+// keyset, by which the query is ordered, and continue after the cursor if one
+// is given. Other listings are ordered by their handler and only paginated by
+// page. It responds with 400 Bad Request and returns an error if the cursor
+// is invalid or not supported.
+func PreparePaginateResponse(c *gin.Context, query *gorm.DB, keyset *Keyset) (*Paginator, error) {
+	pageVar, exists := c.Get("page")
+	if !exists {
+		pageVar = models.PaginationInput{
+			Page:  DefaultPage,
+			Limit: DefaultLimit,
+		}
+	}
+	p := &Paginator{Input: pageVar.(models.PaginationInput), c: c, keyset: keyset}
+
+	query.Count(&p.count)
+
+	if keyset != nil {
+		direction := ""
+		if keyset.Desc {
+			direction = " desc"
+		}
+		query.Order(keyset.qualified(keyset.Column) + direction).Order(keyset.qualified(keyset.IdColumn) + direction)
+	}
+
+	if p.Input.Cursor == nil {
+		query.Offset(int(p.Input.GetOffset())).Limit(int(p.Input.GetLimit()))
+		return p, nil
+	}
+
+	if keyset == nil {
+		return nil, paginationError(c, errors.New("this listing cannot be paginated by cursor"))
+	}
+	if *p.Input.Cursor != "" {
+		var position cursor
+		data, err := base64.RawURLEncoding.DecodeString(*p.Input.Cursor)
+		if err == nil {
+			err = json.Unmarshal(data, &position)
+		}
+		if err == nil && (position.Column != keyset.Column || position.Desc != keyset.Desc || position.Value == nil || position.Id == nil) {
+			err = errors.New("cursor of a differently sorted listing")
+		}
+		if err != nil {
+			return nil, paginationError(c, fmt.Errorf("invalid cursor: %w", err))
+		}
+		operator := ">"
+		if keyset.Desc {
+			operator = "<"
+		}
+		query.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", keyset.qualified(keyset.Column), keyset.qualified(keyset.IdColumn), operator),
+			position.Value, position.Id)
+	}
+	query.Limit(int(p.Input.GetLimit()))
+	return p, nil
+}
+
+// Meta returns the pagination metadata of the response listing rows, a
+// slice of the models queried. Pages by cursor link to the next page while
+// they are full, pages by number to the previous and next page.
+func (p *Paginator) Meta(rows any) *models.PaginationMeta {
+	meta := &models.PaginationMeta{
+		ResourceCount: int(p.count),
+		Limit:         p.Input.Limit,
+	}
+	params := p.c.Request.URL.Query()
+	link := func() string {
+		u := *p.c.Request.URL
+		u.RawQuery = params.Encode()
+		return u.String()
+	}
+
+	if p.Input.Cursor != nil {
+		list := reflect.Indirect(reflect.ValueOf(rows))
+		if list.Len() == 0 || list.Len() < int(p.Input.Limit) {
+			return meta
+		}
+		next, err := p.cursorAfter(list.Index(list.Len() - 1))
+		if err != nil {
+			return meta
+		}
+		meta.NextCursor = next
+		params.Del("page")
+		params.Set("cursor", next)
+		meta.Next = link()
+		return meta
+	}
+
+	meta.Page = p.Input.Page
+	meta.TotalPages = int64(math.Ceil(float64(p.count) / float64(p.Input.Limit)))
+
+	// Can go next
+	if meta.Page < meta.TotalPages {
+		params.Set("page", strconv.FormatInt(meta.Page+1, 10))
+		meta.Next = link()
+	}
+
+	// Can go previous
+	if meta.Page > 1 {
+		params.Set("page", strconv.FormatInt(meta.Page-1, 10))
+		meta.Previous = link()
+	}
+	return meta
+}
+
+// cursorAfter returns the cursor of the position after row.
+func (p *Paginator) cursorAfter(row reflect.Value) (string, error) {
+	s, err := schema.Parse(row.Addr().Interface(), &schemas, db.DB.NamingStrategy)
+	if err != nil {
+		return "", err
+	}
+	column, id := s.LookUpField(p.keyset.Column), s.LookUpField(p.keyset.IdColumn)
+	if column == nil || id == nil {
+		return "", fmt.Errorf("no column %s or %s in %s", p.keyset.Column, p.keyset.IdColumn, s.Name)
+	}
+	position := cursor{Column: p.keyset.Column, Desc: p.keyset.Desc}
+	position.Value, _ = column.ValueOf(p.c, row)
+	position.Id, _ = id.ValueOf(p.c, row)
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func paginationError(c *gin.Context, err error) error {
+	er := models.LicenseError{
+		Status:    http.StatusBadRequest,
+		Message:   "invalid pagination",
+		Error:     err.Error(),
+		Path:      c.Request.URL.Path,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	c.JSON(http.StatusBadRequest, er)
+	return err
+}
@@ -13,13 +13,18 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"maps"
+	"math"
 	"net/http"
+	"net/url"
 	"os"
 	"reflect"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -79,34 +84,6 @@ func VerifyPassword(inputPassword, dbPassword string) error {
 	return bcrypt.CompareHashAndPassword([]byte(dbPassword), []byte(inputPassword))
 }
 
-// PreparePaginateResponse prepares the pagination response for the API.
-// It gets the count of total rows and sets the pagination parameters, also
-// updates the query limit and offset and update the "paginationMeta" and
-// "responseModel" in gin.Context for middleware to process.
-func PreparePaginateResponse(c *gin.Context, query *gorm.DB,
-	responseModel interface{}) models.PaginationInput {
-	var totalRows int64
-	query.Count(&totalRows)
-
-	pageVar, exists := c.Get("page")
-	if !exists {
-		pageVar = models.PaginationInput{
-			Page:  DefaultPage,
-			Limit: DefaultLimit,
-		}
-	}
-	pagination := pageVar.(models.PaginationInput)
-
-	query.Offset(int(pagination.GetOffset())).Limit(int(pagination.GetLimit()))
-
-	var paginationMeta models.PaginationMeta
-	paginationMeta.ResourceCount = int(totalRows)
-
-	c.Set("paginationMeta", paginationMeta)
-	c.Set("responseModel", responseModel)
-	return pagination
-}
-
 // LicenseImportStatusCode is internally used for checking status of a license import
 type LicenseImportStatusCode int
 
@@ -119,7 +96,7 @@ const (
 	IMPORT_LICENSE_UPDATE_OBLIGATION_ASSOCIATION_FAILED
 )
 
-func InsertOrUpdateLicenseOnImport(lic *models.LicenseImportDTO, userId uuid.UUID) (string, LicenseImportStatusCode) {
+func InsertOrUpdateLicenseOnImport(tx *gorm.DB, lic *models.LicenseImportDTO, userId uuid.UUID) (string, LicenseImportStatusCode) {
 	var message string
 	var importStatus LicenseImportStatusCode
 
@@ -129,7 +106,7 @@ func InsertOrUpdateLicenseOnImport(lic *models.LicenseImportDTO, userId uuid.UUI
 		return message, importStatus
 	}
 
-	_ = db.DB.Transaction(func(tx *gorm.DB) error {
+	_ = tx.Transaction(func(tx *gorm.DB) error {
 		license := lic.ConvertToLicenseDB()
 		/*
 			We can have the following situations here:
@@ -141,9 +118,14 @@ func InsertOrUpdateLicenseOnImport(lic *models.LicenseImportDTO, userId uuid.UUI
 		*/
 		if lic.Id != nil {
 			var newLicense, oldLicense models.LicenseDB
-			if err := tx.Where(models.LicenseDB{Id: *lic.Id}).Preload("User").Preload("Obligations").First(&oldLicense).Error; err != nil {
+			if err := tx.Where(models.LicenseDB{Id: *lic.Id}).Preload("User").Preload("Obligations").Preload("Aliases").First(&oldLicense).Error; err != nil {
 				if errors.Is(err, gorm.ErrRecordNotFound) {
 					// case 1(b)
+					if err := CheckExternalRef(tx, models.ExternalRefEntityLicense, lic.ExternalRef, nil); err != nil {
+						message = fmt.Sprintf("failed to import license: %s", err.Error())
+						importStatus = IMPORT_FAILED
+						return errors.New(message)
+					}
 					license.UserId = userId
 					if err := tx.Omit("Obligations").Create(&license).Error; err != nil {
 						message = fmt.Sprintf("failed to import license: %s", err.Error())
@@ -165,7 +147,15 @@ func InsertOrUpdateLicenseOnImport(lic *models.LicenseImportDTO, userId uuid.UUI
 						}
 					}
 
-					if err := tx.Preload("User").Preload("Obligations").First(&license).Error; err != nil {
+					if lic.Aliases != nil {
+						if err := ReplaceLicenseAliases(tx, license.Id, *lic.Aliases); err != nil {
+							message = fmt.Sprintf("failed to import license: %s", err.Error())
+							importStatus = IMPORT_FAILED
+							return errors.New(message)
+						}
+					}
+
+					if err := tx.Preload("User").Preload("Obligations").Preload("Aliases").First(&license).Error; err != nil {
 						message = fmt.Sprintf("failed to create license: %s", err.Error())
 						importStatus = IMPORT_FAILED
 						return errors.New(message)
@@ -201,6 +191,12 @@ func InsertOrUpdateLicenseOnImport(lic *models.LicenseImportDTO, userId uuid.UUI
 					}
 				}
 
+				if err := CheckExternalRef(tx, models.ExternalRefEntityLicense, lic.ExternalRef, oldLicense.ExternalRef.Data()); err != nil {
+					message = fmt.Sprintf("failed to import license: %s", err.Error())
+					importStatus = IMPORT_FAILED
+					return errors.New(message)
+				}
+
 				// Overwrite values of existing keys, add new key value pairs and remove keys with null values.
 				if err := tx.Model(models.LicenseDB{}).Where(models.LicenseDB{Id: oldLicense.Id}).UpdateColumn("external_ref", gorm.Expr("jsonb_strip_nulls(COALESCE(external_ref, '{}'::jsonb) || ?)", lic.ExternalRef)).Error; err != nil {
 					message = fmt.Sprintf("failed to update license: %s", err.Error())
@@ -228,7 +224,15 @@ func InsertOrUpdateLicenseOnImport(lic *models.LicenseImportDTO, userId uuid.UUI
 					}
 				}
 
-				if err := tx.Preload("User").Preload("Obligations").Where(models.LicenseDB{Id: oldLicense.Id}).First(&newLicense).Error; err != nil {
+				if lic.Aliases != nil {
+					if err := ReplaceLicenseAliases(tx, oldLicense.Id, *lic.Aliases); err != nil {
+						message = fmt.Sprintf("failed to update license: %s", err.Error())
+						importStatus = IMPORT_FAILED
+						return errors.New(message)
+					}
+				}
+
+				if err := tx.Preload("User").Preload("Obligations").Preload("Aliases").Where(models.LicenseDB{Id: oldLicense.Id}).First(&newLicense).Error; err != nil {
 					message = fmt.Sprintf("failed to update license: %s", err.Error())
 					importStatus = IMPORT_FAILED
 					return errors.New(message)
@@ -250,6 +254,11 @@ func InsertOrUpdateLicenseOnImport(lic *models.LicenseImportDTO, userId uuid.UUI
 			}
 		} else {
 			// Case 2
+			if err := CheckExternalRef(tx, models.ExternalRefEntityLicense, lic.ExternalRef, nil); err != nil {
+				message = fmt.Sprintf("failed to import license: %s", err.Error())
+				importStatus = IMPORT_FAILED
+				return errors.New(message)
+			}
 			license.UserId = userId
 			if err := tx.Omit("Obligations").Create(&license).Error; err != nil {
 				message = fmt.Sprintf("failed to import license: %s", err.Error())
@@ -271,7 +280,15 @@ func InsertOrUpdateLicenseOnImport(lic *models.LicenseImportDTO, userId uuid.UUI
 				}
 			}
 
-			if err := tx.Preload("User").Preload("Obligations").First(&license).Error; err != nil {
+			if lic.Aliases != nil {
+				if err := ReplaceLicenseAliases(tx, license.Id, *lic.Aliases); err != nil {
+					message = fmt.Sprintf("failed to import license: %s", err.Error())
+					importStatus = IMPORT_FAILED
+					return errors.New(message)
+				}
+			}
+
+			if err := tx.Preload("User").Preload("Obligations").Preload("Aliases").First(&license).Error; err != nil {
 				message = fmt.Sprintf("failed to create license: %s", err.Error())
 				importStatus = IMPORT_FAILED
 				return errors.New(message)
@@ -326,6 +343,11 @@ func PerformObligationMapActions(tx *gorm.DB, userId uuid.UUID, obligation *mode
 		}
 	}
 
+	var oldLicenseIds []uuid.UUID
+	if err := tx.Table("obligation_licenses").Where("obligation_id = ?", obligation.Id).Pluck("license_db_id", &oldLicenseIds).Error; err != nil {
+		return append(errs, err)
+	}
+
 	// Use a local model copy so association replacement does not mutate caller state
 	// that may be used for changelog old/new comparisons.
 	obligationModel := models.Obligation{Id: obligation.Id}
@@ -333,6 +355,10 @@ func PerformObligationMapActions(tx *gorm.DB, userId uuid.UUID, obligation *mode
 		errs = append(errs, err)
 	}
 
+	if err := IncreaseVersions(tx, &models.LicenseDB{}, "rf_id", changedIds(oldLicenseIds, newLicenseAssociations, func(l models.LicenseDB) uuid.UUID { return l.Id })); err != nil {
+		errs = append(errs, err)
+	}
+
 	return errs
 }
 
@@ -350,6 +376,11 @@ func PerformLicenseMapActions(tx *gorm.DB, userId uuid.UUID, license *models.Lic
 		}
 	}
 
+	var oldObligationIds []uuid.UUID
+	if err := tx.Table("obligation_licenses").Where("license_db_id = ?", license.Id).Pluck("obligation_id", &oldObligationIds).Error; err != nil {
+		return append(errs, err)
+	}
+
 	// Use a local model copy so association replacement does not mutate the caller's
 	// pre-update license instance used later for changelog old/new comparisons.
 	licenseModel := models.LicenseDB{Id: license.Id}
@@ -357,9 +388,296 @@ func PerformLicenseMapActions(tx *gorm.DB, userId uuid.UUID, license *models.Lic
 		errs = append(errs, err)
 	}
 
+	if err := IncreaseVersions(tx, &models.Obligation{}, "id", changedIds(oldObligationIds, newObligationAssociations, func(o models.Obligation) uuid.UUID { return o.Id })); err != nil {
+		errs = append(errs, err)
+	}
+
 	return errs
 }
 
+// changedIds returns the ids which are only in one of the old ids and the ids of the new rows
+func changedIds[T any](oldIds []uuid.UUID, newRows []T, id func(T) uuid.UUID) []uuid.UUID {
+	var changed []uuid.UUID
+	newIds := make([]uuid.UUID, 0, len(newRows))
+	for _, row := range newRows {
+		newIds = append(newIds, id(row))
+		if !slices.Contains(oldIds, id(row)) {
+			changed = append(changed, id(row))
+		}
+	}
+	for _, oldId := range oldIds {
+		if !slices.Contains(newIds, oldId) {
+			changed = append(changed, oldId)
+		}
+	}
+	return changed
+}
+
+// IncreaseVersions increases the versions of the rows of model whose idColumn is one of ids, for
+// changes of their relations which do not update the rows themselves. Updates of rows increase
+// their versions in the database.
+func IncreaseVersions(tx *gorm.DB, model any, idColumn string, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Model(model).Where(idColumn+" IN ?", ids).UpdateColumn("version", gorm.Expr("version + 1")).Error
+}
+
+// ReplaceLicenseAliases replaces the aliases of a license with the provided aliases
+func ReplaceLicenseAliases(tx *gorm.DB, licenseId uuid.UUID, aliases []models.LicenseAliasInput) error {
+	if err := tx.Where(&models.LicenseAlias{LicenseId: licenseId}).Delete(&models.LicenseAlias{}).Error; err != nil {
+		return err
+	}
+	for _, a := range aliases {
+		alias := models.LicenseAlias{
+			LicenseId: licenseId,
+			Alias:     strings.TrimSpace(a.Alias),
+			Namespace: a.Namespace,
+		}
+		if err := tx.Create(&alias).Error; err != nil {
+			return fmt.Errorf("unable to add alias '%s:%s': %s", a.Namespace, a.Alias, err.Error())
+		}
+	}
+	return nil
+}
+
+// aliasesToStr lists the aliases of a license as sorted namespace:alias pairs
+func aliasesToStr(aliases []models.LicenseAlias) string {
+	s := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		s = append(s, a.Namespace+":"+a.Alias)
+	}
+	slices.Sort(s)
+	return strings.Join(s, ", ")
+}
+
+// ExternalRefFields returns the built in fields of the external_ref of entity
+// followed by the fields defined at runtime.
+func ExternalRefFields(tx *gorm.DB, entity string) ([]models.ExternalRefField, error) {
+	var defined []models.ExternalRefField
+	if err := tx.Where(&models.ExternalRefField{Entity: entity}).Order("name").Find(&defined).Error; err != nil {
+		return nil, err
+	}
+	return append(models.BuiltinExternalRefFields(entity), defined...), nil
+}
+
+// ExternalRefError is an error of the external_ref values written to an
+// entity.
+type ExternalRefError struct {
+	Msg string
+}
+
+func (e *ExternalRefError) Error() string {
+	return e.Msg
+}
+
+// CheckExternalRef validates the external_ref values written to an entity of
+// entity, given as schema extension or map, against its fields, with current
+// the external_ref of the entity if it exists already. Invalid values are
+// reported with an *ExternalRefError.
+func CheckExternalRef(tx *gorm.DB, entity string, written, current any) error {
+	fields, err := ExternalRefFields(tx, entity)
+	if err != nil {
+		return err
+	}
+	values, err := models.ExternalRefMap(written)
+	if err != nil {
+		return &ExternalRefError{Msg: err.Error()}
+	}
+	var currentValues map[string]any
+	if current != nil {
+		if currentValues, err = models.ExternalRefMap(current); err != nil {
+			return err
+		}
+	}
+	return ValidateExternalRef(fields, values, currentValues)
+}
+
+// ValidateExternalRef checks the external_ref values written to an entity
+// against its fields like CheckExternalRef. Only the keys written are checked, so values of deleted
+// fields can be kept. Required fields are checked against the current values
+// with the written ones applied, unless nothing is written to an existing
+// entity. The current values of new entities are nil.
+func ValidateExternalRef(fields []models.ExternalRefField, values, current map[string]any) error {
+	byName := make(map[string]models.ExternalRefField, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		field, ok := byName[key]
+		if !ok {
+			return &ExternalRefError{Msg: fmt.Sprintf("external_ref key '%s' is not defined", key)}
+		}
+		if values[key] == nil {
+			continue
+		}
+		if err := checkExternalRefValue(field, values[key]); err != nil {
+			return err
+		}
+	}
+
+	if values == nil && current != nil {
+		return nil
+	}
+	merged := maps.Clone(current)
+	if merged == nil {
+		merged = map[string]any{}
+	}
+	maps.Copy(merged, values)
+	for _, field := range fields {
+		if field.Required && merged[field.Name] == nil {
+			return &ExternalRefError{Msg: fmt.Sprintf("external_ref key '%s' is required", field.Name)}
+		}
+	}
+	return nil
+}
+
+func checkExternalRefValue(field models.ExternalRefField, value any) error {
+	invalid := &ExternalRefError{Msg: fmt.Sprintf("external_ref key '%s' takes a value of type %s", field.Name, field.Type)}
+	outOfBounds := func(n float64, what string) error {
+		if field.Min != nil && n < *field.Min {
+			return &ExternalRefError{Msg: fmt.Sprintf("external_ref key '%s' takes a %s of at least %v", field.Name, what, *field.Min)}
+		}
+		if field.Max != nil && n > *field.Max {
+			return &ExternalRefError{Msg: fmt.Sprintf("external_ref key '%s' takes a %s of at most %v", field.Name, what, *field.Max)}
+		}
+		return nil
+	}
+	switch field.Type {
+	case models.ExternalRefTypeString, models.ExternalRefTypeURL:
+		s, ok := value.(string)
+		if !ok {
+			return invalid
+		}
+		if field.Type == models.ExternalRefTypeURL {
+			if u, err := url.ParseRequestURI(s); err != nil || u.Scheme == "" || u.Host == "" {
+				return invalid
+			}
+		}
+		if len(field.Enum) != 0 && !slices.Contains(field.Enum, s) {
+			return &ExternalRefError{Msg: fmt.Sprintf("external_ref key '%s' takes one of %s", field.Name, strings.Join(field.Enum, ", "))}
+		}
+		if field.Regex != nil {
+			if matched, err := regexp.MatchString(*field.Regex, s); err != nil || !matched {
+				return &ExternalRefError{Msg: fmt.Sprintf("external_ref key '%s' must match %s", field.Name, *field.Regex)}
+			}
+		}
+		return outOfBounds(float64(utf8.RuneCountInString(s)), "length")
+	case models.ExternalRefTypeInt:
+		var n float64
+		switch v := value.(type) {
+		case float64:
+			if v != math.Trunc(v) {
+				return invalid
+			}
+			n = v
+		case json.Number:
+			i, err := v.Int64()
+			if err != nil {
+				return invalid
+			}
+			n = float64(i)
+		default:
+			return invalid
+		}
+		return outOfBounds(n, "value")
+	case models.ExternalRefTypeNumber:
+		var n float64
+		switch v := value.(type) {
+		case float64:
+			n = v
+		case json.Number:
+			var err error
+			if n, err = v.Float64(); err != nil {
+				return invalid
+			}
+		default:
+			return invalid
+		}
+		return outOfBounds(n, "value")
+	case models.ExternalRefTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return invalid
+		}
+	case models.ExternalRefTypeDate:
+		s, ok := value.(string)
+		if !ok {
+			return invalid
+		}
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				return invalid
+			}
+		}
+	case models.ExternalRefTypeTimestamp:
+		s, ok := value.(string)
+		if !ok {
+			return invalid
+		}
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return invalid
+		}
+	case models.ExternalRefTypeArray:
+		items, ok := value.([]any)
+		if !ok {
+			return invalid
+		}
+		item := field
+		item.Type = models.ExternalRefTypeString
+		item.Min, item.Max = nil, nil
+		for _, v := range items {
+			if err := checkExternalRefValue(item, v); err != nil {
+				return err
+			}
+		}
+		return outOfBounds(float64(len(items)), "number of items")
+	case models.ExternalRefTypeObject:
+		values, ok := value.(map[string]any)
+		if !ok {
+			return invalid
+		}
+		if err := ValidateExternalRef(field.Fields, values, nil); err != nil {
+			return &ExternalRefError{Msg: fmt.Sprintf("external_ref key '%s': %s", field.Name, err.Error())}
+		}
+	}
+	return nil
+}
+
+// ExternalRefJSON returns the JSON of the value of an external_ref field for
+// changelogs, or nil if it is not set.
+func ExternalRefJSON(value reflect.Value) *string {
+	if value.IsZero() {
+		return nil
+	}
+	data, _ := json.Marshal(value.Interface())
+	s := string(data)
+	return &s
+}
+
+// AddExternalRefChangelogs adds changelogs for the changed values of the
+// external_ref fields defined at runtime.
+func AddExternalRefChangelogs(oldExtra, newExtra map[string]any, changes *[]models.ChangeLog) {
+	keys := slices.Collect(maps.Keys(oldExtra))
+	for key := range newExtra {
+		if _, ok := oldExtra[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	toStr := func(value any) *string {
+		if value == nil {
+			return nil
+		}
+		data, _ := json.Marshal(value)
+		s := string(data)
+		return &s
+	}
+	for _, key := range keys {
+		AddChangelog(fmt.Sprintf("External Reference %s", key), toStr(oldExtra[key]), toStr(newExtra[key]), changes)
+	}
+}
+
 func AddChangelogForObligationType(tx *gorm.DB, userId uuid.UUID, oldObType, newObType *models.ObligationType) error {
 	var changes []models.ChangeLog
 	AddChangelog("Active", oldObType.Active, newObType.Active, &changes)
@@ -471,13 +789,13 @@ const (
 	CREATED
 )
 
-func CreateObType(obType *models.ObligationType, userId uuid.UUID) (ObligationFieldCreateStatusCode, error) {
+func CreateObType(tx *gorm.DB, obType *models.ObligationType, userId uuid.UUID) (ObligationFieldCreateStatusCode, error) {
 	if err := validations.Validate.Struct(obType); err != nil {
 		return VALIDATION_FAILED, err
 	}
 
 	var status ObligationFieldCreateStatusCode
-	err := db.DB.Transaction(func(tx *gorm.DB) error {
+	err := tx.Transaction(func(tx *gorm.DB) error {
 		result := tx.Where(&models.ObligationType{Type: obType.Type}).FirstOrCreate(&obType)
 		if result.Error != nil {
 			status = CREATE_FAILED
@@ -506,13 +824,13 @@ func CreateObType(obType *models.ObligationType, userId uuid.UUID) (ObligationFi
 	return status, err
 }
 
-func CreateObClassification(obClassification *models.ObligationClassification, userId uuid.UUID) (ObligationFieldCreateStatusCode, error) {
+func CreateObClassification(tx *gorm.DB, obClassification *models.ObligationClassification, userId uuid.UUID) (ObligationFieldCreateStatusCode, error) {
 	if err := validations.Validate.Struct(obClassification); err != nil {
 		return VALIDATION_FAILED, err
 	}
 
 	var status ObligationFieldCreateStatusCode
-	err := db.DB.Transaction(func(tx *gorm.DB) error {
+	err := tx.Transaction(func(tx *gorm.DB) error {
 		result := tx.Where(&models.ObligationClassification{Classification: obClassification.Classification}).FirstOrCreate(&obClassification)
 		if result.Error != nil {
 			status = CREATE_FAILED
@@ -541,13 +859,13 @@ func CreateObClassification(obClassification *models.ObligationClassification, u
 	return status, err
 }
 
-func CreateObCategory(obCategory *models.ObligationCategory, userId uuid.UUID) (ObligationFieldCreateStatusCode, error) {
+func CreateObCategory(tx *gorm.DB, obCategory *models.ObligationCategory, userId uuid.UUID) (ObligationFieldCreateStatusCode, error) {
 	if err := validations.Validate.Struct(obCategory); err != nil {
 		return VALIDATION_FAILED, err
 	}
 
 	var status ObligationFieldCreateStatusCode
-	err := db.DB.Transaction(func(tx *gorm.DB) error {
+	err := tx.Transaction(func(tx *gorm.DB) error {
 		result := tx.Where(&models.ObligationCategory{Category: obCategory.Category}).FirstOrCreate(&obCategory)
 		if result.Error != nil {
 			status = CREATE_FAILED
@@ -603,7 +921,7 @@ func Populatedb(datafile string) {
 			log.Printf("%s%s: %s%s", red, *result.Shortname, fmt.Sprintf("field '%s' failed validation: %s\n", err.(validator.ValidationErrors)[0].Field(), err.(validator.ValidationErrors)[0].Tag()), reset)
 			continue
 		}
-		_, _ = InsertOrUpdateLicenseOnImport(&result, user.Id)
+		_, _ = InsertOrUpdateLicenseOnImport(db.DB, &result, user.Id)
 	}
 
 	DEFAULT_OBLIGATION_TYPES := []*models.ObligationType{
@@ -614,7 +932,7 @@ func Populatedb(datafile string) {
 	}
 
 	for _, obType := range DEFAULT_OBLIGATION_TYPES {
-		status, err := CreateObType(obType, user.Id)
+		status, err := CreateObType(db.DB, obType, user.Id)
 
 		if status == CREATED || status == CONFLICT {
 			green := "\033[32m"
@@ -635,7 +953,7 @@ func Populatedb(datafile string) {
 	}
 
 	for _, obClassification := range DEFAULT_OBLIGATION_CLASSIFICATIONS {
-		status, err := CreateObClassification(obClassification, user.Id)
+		status, err := CreateObClassification(db.DB, obClassification, user.Id)
 
 		if status == CREATED || status == CONFLICT {
 			green := "\033[32m"
@@ -658,7 +976,7 @@ func Populatedb(datafile string) {
 	}
 
 	for _, obCategory := range DEFAULT_OBLIGATION_CATEGORIES {
-		status, err := CreateObCategory(obCategory, user.Id)
+		status, err := CreateObCategory(db.DB, obCategory, user.Id)
 
 		if status == CREATED || status == CONFLICT {
 			green := "\033[32m"
@@ -672,26 +990,34 @@ func Populatedb(datafile string) {
 	}
 }
 
-// SetSimilarityThreshold parses the env var and sets the threshold in Postgres.
-func SetSimilarityThreshold() {
+// DefaultSimilarityThreshold is the pg_trgm similarity threshold of similarity
+// searches that do not set one, read from SIMILARITY_THRESHOLD.
+func DefaultSimilarityThreshold() float64 {
 	defaultThreshold := 0.7
 	thresholdStr := os.Getenv("SIMILARITY_THRESHOLD")
-
-	threshold := defaultThreshold
-	if thresholdStr != "" {
-		if parsed, err := strconv.ParseFloat(thresholdStr, 64); err == nil {
-			threshold = parsed
-		} else {
-			log.Printf("Invalid SIMILARITY_THRESHOLD '%s', using default %.1f", thresholdStr, defaultThreshold)
-		}
-	} else {
-		log.Printf("SIMILARITY_THRESHOLD not set, using default %.1f", defaultThreshold)
+	if thresholdStr == "" {
+		return defaultThreshold
 	}
-
-	query := fmt.Sprintf("SET pg_trgm.similarity_threshold = %f", threshold)
-	if err := db.DB.Exec(query).Error; err != nil {
-		log.Println("Failed to set similarity threshold:", err)
+	threshold, err := strconv.ParseFloat(thresholdStr, 64)
+	if err != nil || threshold <= 0 || threshold > 1 {
+		log.Printf("Invalid SIMILARITY_THRESHOLD '%s', using default %.1f", thresholdStr, defaultThreshold)
+		return defaultThreshold
 	}
+	return threshold
+}
+
+// WithSimilarityThreshold runs fn in a transaction whose pg_trgm similarity
+// threshold, used by the % operator, is set to threshold. The setting is local
+// to the transaction, so it applies to the queries of fn and does not leak to
+// other requests sharing the pooled connection.
+func WithSimilarityThreshold(tx *gorm.DB, threshold float64, fn func(tx *gorm.DB) error) error {
+	return tx.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT set_config('pg_trgm.similarity_threshold', ?, true)",
+			strconv.FormatFloat(threshold, 'f', -1, 64)).Error; err != nil {
+			return err
+		}
+		return fn(tx)
+	})
 }
 
 // GetAuditEntity is an utility function to fetch obligation or license associated with an audit
@@ -764,7 +1090,7 @@ func GetAuditEntity(c *gin.Context, audit *models.Audit) error {
 			c.JSON(http.StatusNotFound, er)
 			return err
 		}
-	case "USER":
+	case "USER", "IMPERSONATION":
 		audit.Entity = &models.User{}
 		if err := db.DB.Where(&models.User{Id: audit.TypeId}).First(&audit.Entity).Error; err != nil {
 			er := models.LicenseError{
@@ -777,6 +1103,62 @@ func GetAuditEntity(c *gin.Context, audit *models.Audit) error {
 			c.JSON(http.StatusNotFound, er)
 			return err
 		}
+	case "SERVICE_ACCOUNT":
+		var serviceAccount models.ServiceAccount
+		if err := db.DB.Preload("User").Preload("OwnerTeam").Where(&models.ServiceAccount{Id: audit.TypeId}).First(&serviceAccount).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   "service account corresponding with this audit does not exist",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return err
+		}
+		audit.Entity = serviceAccount.ConvertToServiceAccountDTO(nil)
+	case "TEAM":
+		var team models.Team
+		// Deleted teams are kept for their audits
+		if err := db.DB.Unscoped().Preload("Members").Where(&models.Team{Id: audit.TypeId}).First(&team).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   "team corresponding with this audit does not exist",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return err
+		}
+		audit.Entity = team.ConvertToTeamDTO()
+	case "EXTERNAL_REF_FIELD":
+		audit.Entity = &models.ExternalRefField{}
+		// Deleted fields are kept for their audits
+		if err := db.DB.Unscoped().Where(&models.ExternalRefField{Id: audit.TypeId}).First(audit.Entity).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   "external_ref field corresponding with this audit does not exist",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return err
+		}
+	case "TWO_FACTOR_POLICY":
+		audit.Entity = &models.TwoFactorPolicy{}
+		if err := db.DB.Where(&models.TwoFactorPolicy{Id: audit.TypeId}).First(&audit.Entity).Error; err != nil {
+			er := models.LicenseError{
+				Status:    http.StatusNotFound,
+				Message:   "two-factor policy corresponding with this audit does not exist",
+				Error:     err.Error(),
+				Path:      c.Request.URL.Path,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			c.JSON(http.StatusNotFound, er)
+			return err
+		}
 	default:
 		// no action
 	}
@@ -823,9 +1205,10 @@ func AddChangelog[T any](fieldName string, oldValue, newValue *T, changes *[]mod
 	}
 }
 
-// AddChangelogsForLicense adds changelogs for the updated fields on license update
+// AddChangelogsForLicense adds changelogs for the updated fields on license update,
+// along with any further changes to record in the same audit
 func AddChangelogsForLicense(tx *gorm.DB, userId uuid.UUID,
-	newLicense, oldLicense *models.LicenseDB) error {
+	newLicense, oldLicense *models.LicenseDB, extraChanges ...models.ChangeLog) error {
 	uuidsToStr := func(ids []models.Obligation) string {
 		if len(ids) == 0 {
 			return ""
@@ -850,12 +1233,19 @@ func AddChangelogsForLicense(tx *gorm.DB, userId uuid.UUID,
 	AddChangelog("Source", oldLicense.Source, newLicense.Source, &changes)
 	AddChangelog("Spdx Id", oldLicense.SpdxId, newLicense.SpdxId, &changes)
 	AddChangelog("Risk", oldLicense.Risk, newLicense.Risk, &changes)
+	AddChangelog("Steward Team Id", oldLicense.StewardTeamId, newLicense.StewardTeamId, &changes)
+	AddChangelog("Review Interval Days", oldLicense.ReviewIntervalDays, newLicense.ReviewIntervalDays, &changes)
 
 	oldVal := uuidsToStr(oldLicense.Obligations)
 	newVal := uuidsToStr(newLicense.Obligations)
 
 	AddChangelog("Obligation Ids", &oldVal, &newVal, &changes)
 
+	oldAliases := aliasesToStr(oldLicense.Aliases)
+	newAliases := aliasesToStr(newLicense.Aliases)
+
+	AddChangelog("Aliases", &oldAliases, &newAliases, &changes)
+
 	oldLicenseExternalRef := oldLicense.ExternalRef.Data()
 	oldExternalRefVal := reflect.ValueOf(oldLicenseExternalRef)
 	typesOf := oldExternalRefVal.Type()
@@ -867,7 +1257,7 @@ func AddChangelogsForLicense(tx *gorm.DB, userId uuid.UUID,
 		fieldName := typesOf.Field(i).Name
 
 		switch typesOf.Field(i).Type.String() {
-		case "*boolean":
+		case "*bool":
 			oldFieldPtr, _ := oldExternalRefVal.Field(i).Interface().(*bool)
 			newFieldPtr, _ := newExternalRefVal.Field(i).Interface().(*bool)
 			AddChangelog(fmt.Sprintf("External Reference %s", fieldName), oldFieldPtr, newFieldPtr, &changes)
@@ -875,13 +1265,22 @@ func AddChangelogsForLicense(tx *gorm.DB, userId uuid.UUID,
 			oldFieldPtr, _ := oldExternalRefVal.Field(i).Interface().(*string)
 			newFieldPtr, _ := newExternalRefVal.Field(i).Interface().(*string)
 			AddChangelog(fmt.Sprintf("External Reference %s", fieldName), oldFieldPtr, newFieldPtr, &changes)
-		case "*int":
-			oldFieldPtr, _ := oldExternalRefVal.Field(i).Interface().(*int)
-			newFieldPtr, _ := newExternalRefVal.Field(i).Interface().(*int)
+		case "*int64":
+			oldFieldPtr, _ := oldExternalRefVal.Field(i).Interface().(*int64)
+			newFieldPtr, _ := newExternalRefVal.Field(i).Interface().(*int64)
 			AddChangelog(fmt.Sprintf("External Reference %s", fieldName), oldFieldPtr, newFieldPtr, &changes)
+		default:
+			if typesOf.Field(i).Tag.Get("json") == "-" {
+				continue
+			}
+			AddChangelog(fmt.Sprintf("External Reference %s", fieldName), ExternalRefJSON(oldExternalRefVal.Field(i)), ExternalRefJSON(newExternalRefVal.Field(i)), &changes)
 		}
 	}
 
+	AddExternalRefChangelogs(oldLicenseExternalRef.Extra, newLicenseExternalRef.Extra, &changes)
+
+	changes = append(changes, extraChanges...)
+
 	if len(changes) != 0 {
 		var user models.User
 		if err := tx.Where(models.User{Id: userId}).First(&user).Error; err != nil {
@@ -914,6 +1313,7 @@ func AddChangelogsForUser(tx *gorm.DB, userId uuid.UUID,
 	AddChangelog("UserEmail", oldUser.UserEmail, newUser.UserEmail, &changes)
 	AddChangelog("UserLevel", oldUser.UserLevel, newUser.UserLevel, &changes)
 	AddChangelog("Active", oldUser.Active, newUser.Active, &changes)
+	AddChangelog("TotpEnabled", oldUser.TotpEnabled, newUser.TotpEnabled, &changes)
 
 	if len(changes) != 0 {
 		var user models.User
@@ -936,3 +1336,78 @@ func AddChangelogsForUser(tx *gorm.DB, userId uuid.UUID,
 
 	return nil
 }
+
+// AddChangelogsForServiceAccount adds changelogs for the updated fields of a
+// service account. Changes of its name, user level and active state are logged
+// for its user.
+func AddChangelogsForServiceAccount(tx *gorm.DB, userId uuid.UUID,
+	newServiceAccount, oldServiceAccount *models.ServiceAccount) error {
+	var changes []models.ChangeLog
+
+	AddChangelog("Description", oldServiceAccount.Description, newServiceAccount.Description, &changes)
+	var oldOwnerTeamId, newOwnerTeamId *uuid.UUID
+	if oldServiceAccount.OwnerTeamId != uuid.Nil {
+		oldOwnerTeamId = &oldServiceAccount.OwnerTeamId
+	}
+	if newServiceAccount.OwnerTeamId != uuid.Nil {
+		newOwnerTeamId = &newServiceAccount.OwnerTeamId
+	}
+	AddChangelog("OwnerTeamId", oldOwnerTeamId, newOwnerTeamId, &changes)
+	AddChangelog("ExpiresAt", oldServiceAccount.ExpiresAt, newServiceAccount.ExpiresAt, &changes)
+
+	if len(changes) != 0 {
+		audit := models.Audit{
+			UserId:     userId,
+			TypeId:     newServiceAccount.Id,
+			Timestamp:  time.Now(),
+			Type:       "SERVICE_ACCOUNT",
+			ChangeLogs: changes,
+		}
+
+		if err := tx.Create(&audit).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// overdueReviewCondition matches active items whose review interval has passed
+// since their last review. Items with an interval which were never reviewed are
+// due right away.
+const overdueReviewCondition = "%[1]s.%[2]s AND %[1]s.review_interval_days > 0 AND " +
+	"(%[1]s.last_reviewed_at IS NULL OR %[1]s.last_reviewed_at + %[1]s.review_interval_days * INTERVAL '1 day' < ?)"
+
+// OverdueReviews lists the licenses and obligations which are due for review
+// at the given time, together with their steward team.
+func OverdueReviews(tx *gorm.DB, now time.Time) ([]models.OverdueReview, error) {
+	var licenses, obligations []models.OverdueReview
+
+	if err := tx.Model(&models.LicenseDB{}).
+		Select("'LICENSE' AS type, license_dbs.rf_id AS id, license_dbs.rf_shortname AS name, "+
+			"license_dbs.steward_team_id, teams.name AS steward_team, license_dbs.last_reviewed_at, "+
+			"license_dbs.last_reviewed_at + license_dbs.review_interval_days * INTERVAL '1 day' AS due_at, "+
+			"license_dbs.review_reminded_at AS reminded_at").
+		Joins("LEFT JOIN teams ON teams.id = license_dbs.steward_team_id").
+		Where(fmt.Sprintf(overdueReviewCondition, "license_dbs", "rf_active"), now).
+		Order("license_dbs.rf_shortname").
+		Scan(&licenses).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(&models.Obligation{}).
+		Select("'OBLIGATION' AS type, obligations.id, obligations.topic AS name, "+
+			"obligations.steward_team_id, teams.name AS steward_team, obligations.last_reviewed_at, "+
+			"obligations.last_reviewed_at + obligations.review_interval_days * INTERVAL '1 day' AS due_at, "+
+			"obligations.review_reminded_at AS reminded_at").
+		Joins("LEFT JOIN teams ON teams.id = obligations.steward_team_id").
+		Where(fmt.Sprintf(overdueReviewCondition, "obligations", "active"), now).
+		Order("obligations.topic").
+		Scan(&obligations).Error; err != nil {
+		return nil, err
+	}
+
+	overdue := make([]models.OverdueReview, 0, len(licenses)+len(obligations))
+	overdue = append(overdue, licenses...)
+	return append(overdue, obligations...), nil
+}
@@ -6,18 +6,41 @@
 package validations
 
 import (
+	"regexp"
+	"sync"
+
 	"github.com/github/go-spdx/v2/spdxexp"
 	"github.com/go-playground/validator/v10"
 )
 
 var Validate *validator.Validate
 
+// regexps caches the compiled regular expressions of regexp validations.
+var regexps sync.Map
+
 func spdxId(fl validator.FieldLevel) bool {
 	valid, _ := spdxexp.ValidateLicenses([]string{fl.Field().String()})
 	return valid
 }
 
+// matchesRegexp checks that a string matches the regular expression given as
+// parameter, with commas and pipes escaped as 0x2C and 0x7C.
+func matchesRegexp(fl validator.FieldLevel) bool {
+	re, ok := regexps.Load(fl.Param())
+	if !ok {
+		compiled, err := regexp.Compile(fl.Param())
+		if err != nil {
+			return false
+		}
+		re, _ = regexps.LoadOrStore(fl.Param(), compiled)
+	}
+	return re.(*regexp.Regexp).MatchString(fl.Field().String())
+}
+
 func RegisterValidations() error {
 	Validate = validator.New(validator.WithRequiredStructEnabled())
-	return Validate.RegisterValidation("spdxId", spdxId)
+	if err := Validate.RegisterValidation("spdxId", spdxId); err != nil {
+		return err
+	}
+	return Validate.RegisterValidation("regexp", matchesRegexp)
 }
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestLicenseAliases(t *testing.T) {
+	loginAs(t, "admin")
+
+	w := makeRequest("POST", "/licenses", models.LicenseCreateDTO{
+		Shortname: "Alias-Test-1.0",
+		Fullname:  "Alias Test License 1.0",
+		Text:      "Alias Test License text",
+		SpdxId:    "LicenseRef-Alias-Test-1.0",
+		Risk:      ptr(int64(1)),
+	}, true)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var licenseRes models.LicenseResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &licenseRes); err != nil {
+		t.Fatalf("Error unmarshalling JSON: %v", err)
+	}
+	licenseId := licenseRes.Data[0].Id
+	aliasesPath := "/licenses/" + licenseId.String() + "/aliases"
+
+	var aliasId string
+	t.Run("add alias", func(t *testing.T) {
+		w := makeRequest("POST", aliasesPath, models.LicenseAliasInput{Alias: "alias-test-vendor", Namespace: models.AliasNamespaceVendor}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var res models.LicenseAliasesResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, "alias-test-vendor", res.Data[0].Alias)
+		aliasId = res.Data[0].Id.String()
+	})
+
+	t.Run("add alias already in namespace", func(t *testing.T) {
+		w := makeRequest("POST", aliasesPath, models.LicenseAliasInput{Alias: "ALIAS-TEST-VENDOR", Namespace: models.AliasNamespaceVendor}, true)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("add alias with unknown namespace", func(t *testing.T) {
+		w := makeRequest("POST", aliasesPath, models.LicenseAliasInput{Alias: "alias-test", Namespace: "UNKNOWN"}, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("list aliases", func(t *testing.T) {
+		w := makeRequest("GET", aliasesPath, nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.LicenseAliasesResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Len(t, res.Data, 1)
+	})
+
+	t.Run("resolve alias", func(t *testing.T) {
+		w := makeRequest("GET", "/licenses/resolve?name=ALIAS-TEST-VENDOR", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.LicenseResolutionResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, licenseId, res.Data.License.Id)
+		assert.Equal(t, "ALIAS", res.Data.MatchedBy)
+		assert.NotNil(t, res.Data.Alias)
+	})
+
+	t.Run("resolve spdx id", func(t *testing.T) {
+		w := makeRequest("GET", "/licenses/resolve?name=licenseref-alias-test-1.0", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.LicenseResolutionResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, licenseId, res.Data.License.Id)
+		assert.Equal(t, "SPDX_ID", res.Data.MatchedBy)
+	})
+
+	t.Run("resolve unknown name", func(t *testing.T) {
+		w := makeRequest("GET", "/licenses/resolve?name=no-such-license", nil, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("resolve with unknown namespace", func(t *testing.T) {
+		w := makeRequest("GET", "/licenses/resolve?name=alias-test-vendor&namespace=UNKNOWN", nil, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("update alias namespace", func(t *testing.T) {
+		w := makeRequest("PATCH", aliasesPath+"/"+aliasId, models.LicenseAliasUpdate{Namespace: ptr(models.AliasNamespaceScancode)}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = makeRequest("GET", "/licenses/resolve?name=alias-test-vendor&namespace=VENDOR", nil, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = makeRequest("GET", "/licenses/resolve?name=alias-test-vendor&namespace=SCANCODE", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("delete alias", func(t *testing.T) {
+		w := makeRequest("DELETE", aliasesPath+"/"+aliasId, nil, true)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = makeRequest("DELETE", aliasesPath+"/"+aliasId, nil, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("alias changes are audited", func(t *testing.T) {
+		var changes int64
+		db.DB.Model(&models.ChangeLog{}).
+			Where("audit_id IN (?)", db.DB.Model(&models.Audit{}).Select("id").Where(&models.Audit{Type: "LICENSE", TypeId: licenseId})).
+			Where(&models.ChangeLog{Field: "Aliases"}).
+			Count(&changes)
+		assert.Equal(t, int64(3), changes)
+	})
+}
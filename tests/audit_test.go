@@ -26,7 +26,7 @@ func TestGetAuditsAndChangelog(t *testing.T) {
 		SpdxId:    "LicenseRef-MIT3",
 		Risk:      ptr(int64(2)),
 	}
-	_ = makeRequest("POST", "/licenses", license, true)
+	_ = makeRequest("POST", "/licenses?force=true", license, true)
 
 	var audit models.Audit
 
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestDuplicates(t *testing.T) {
+	loginAs(t, "admin")
+
+	const text = "The Merge Test License grants every recipient of the work the right to study, adapt and share it " +
+		"for any purpose, provided that each copy carries this grant unchanged, that modified versions name the " +
+		"party who modified them and the date of the modification, and that no recipient is prevented from " +
+		"exercising the rights granted by this license by technical measures applied to the work."
+
+	w := makeRequest("POST", "/obligations", models.ObligationCreateDTO{
+		Topic:          "test-topic-merge",
+		Type:           "RIGHT",
+		Text:           "Share the work under the Merge Test License",
+		Classification: "GREEN",
+		Active:         ptr(true),
+		TextUpdatable:  ptr(false),
+		Category:       "GENERAL",
+	}, true)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var obligationRes models.ObligationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &obligationRes); err != nil {
+		t.Fatalf("Error unmarshalling JSON: %v", err)
+	}
+	obligationId := obligationRes.Data[0].Id
+
+	createLicense := func(t *testing.T, path string, license models.LicenseCreateDTO) uuid.UUID {
+		w := makeRequest("POST", path, license, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var res models.LicenseResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		return res.Data[0].Id
+	}
+	survivorId := createLicense(t, "/licenses", models.LicenseCreateDTO{
+		Shortname: "Merge-Test-1.0",
+		Fullname:  "Merge Test License 1.0",
+		Text:      text,
+		SpdxId:    "LicenseRef-Merge-Test-1.0",
+		Risk:      ptr(int64(2)),
+	})
+	duplicate := models.LicenseCreateDTO{
+		Shortname:     "LicenseRef-fossology-Merge-Test",
+		Fullname:      "Merge Test License",
+		Text:          text + " ",
+		SpdxId:        "LicenseRef-fossology-Merge-Test",
+		Risk:          ptr(int64(2)),
+		ObligationIds: []uuid.UUID{obligationId},
+	}
+
+	t.Run("create duplicate without force", func(t *testing.T) {
+		w := makeRequest("POST", "/licenses", duplicate, true)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var res models.LicenseDuplicateError
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.True(t, slices.ContainsFunc(res.Matches, func(m models.SimilarLicense) bool { return m.Id == survivorId }))
+	})
+
+	t.Run("create duplicate obligation without force", func(t *testing.T) {
+		w := makeRequest("POST", "/obligations", models.ObligationCreateDTO{
+			Topic:          "test-topic-merge-duplicate",
+			Type:           "RIGHT",
+			Text:           "Share the work under the Merge Test License",
+			Classification: "GREEN",
+			Active:         ptr(true),
+			TextUpdatable:  ptr(false),
+			Category:       "GENERAL",
+		}, true)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	duplicateId := createLicense(t, "/licenses?force=true", duplicate)
+
+	t.Run("forced duplicate is audited", func(t *testing.T) {
+		var decisions int64
+		db.DB.Model(&models.ChangeLog{}).
+			Where("audit_id IN (?)", db.DB.Model(&models.Audit{}).Select("id").Where(&models.Audit{Type: "LICENSE", TypeId: duplicateId})).
+			Where(&models.ChangeLog{Field: "Duplicate Check"}).
+			Count(&decisions)
+		assert.Equal(t, int64(1), decisions)
+	})
+
+	t.Run("report clusters similar licenses", func(t *testing.T) {
+		w := makeRequest("GET", "/licenses/duplicates?threshold=0.95", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.DuplicateClustersResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+
+		var cluster *models.DuplicateCluster
+		for i := range res.Data {
+			if slices.ContainsFunc(res.Data[i].Members, func(m models.DuplicateMember) bool { return m.Id == survivorId }) {
+				cluster = &res.Data[i]
+			}
+		}
+		if assert.NotNil(t, cluster) {
+			assert.True(t, slices.ContainsFunc(cluster.Members, func(m models.DuplicateMember) bool { return m.Id == duplicateId }))
+			assert.NotEmpty(t, cluster.Pairs)
+			assert.NotNil(t, cluster.SuggestedSurvivorId)
+		}
+	})
+
+	t.Run("report duplicate obligations", func(t *testing.T) {
+		w := makeRequest("GET", "/obligations/duplicates", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		w := makeRequest("GET", "/licenses/duplicates?threshold=2", nil, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("merge license into itself", func(t *testing.T) {
+		w := makeRequest("POST", "/licenses/"+survivorId.String()+"/merge", models.LicenseMergeInput{DuplicateId: survivorId}, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("merge unknown license", func(t *testing.T) {
+		w := makeRequest("POST", "/licenses/"+survivorId.String()+"/merge", models.LicenseMergeInput{DuplicateId: uuid.New()}, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("merge duplicate", func(t *testing.T) {
+		w := makeRequest("POST", "/licenses/"+survivorId.String()+"/merge", models.LicenseMergeInput{DuplicateId: duplicateId}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.LicenseResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Contains(t, res.Data[0].ObligationIds, obligationId)
+
+		var duplicate models.LicenseDB
+		if err := db.DB.Preload("Obligations").Where(&models.LicenseDB{Id: duplicateId}).First(&duplicate).Error; err != nil {
+			t.Fatalf("Failed to fetch license: %v", err)
+		}
+		assert.False(t, *duplicate.Active)
+		assert.Empty(t, duplicate.Obligations)
+
+		var aliases []models.LicenseAlias
+		db.DB.Where(&models.LicenseAlias{LicenseId: survivorId}).Find(&aliases)
+		if assert.Len(t, aliases, 1) {
+			assert.Equal(t, "LicenseRef-fossology-Merge-Test", aliases[0].Alias)
+			assert.Equal(t, models.AliasNamespaceMerged, aliases[0].Namespace)
+		}
+
+		// Only the audit of the merge stays with the duplicate
+		var duplicateAudits int64
+		db.DB.Model(&models.Audit{}).Where(&models.Audit{Type: "LICENSE", TypeId: duplicateId}).Count(&duplicateAudits)
+		assert.Equal(t, int64(1), duplicateAudits)
+
+		var merges int64
+		db.DB.Model(&models.ChangeLog{}).
+			Where("audit_id IN (?)", db.DB.Model(&models.Audit{}).Select("id").Where(&models.Audit{Type: "LICENSE", TypeId: survivorId})).
+			Where(&models.ChangeLog{Field: "Merged License"}).
+			Count(&merges)
+		assert.Equal(t, int64(1), merges)
+	})
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/api"
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func conditionalRequest(method, path string, body interface{}, header, etag string) *httptest.ResponseRecorder {
+	reqBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, baseURL+path, bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+AuthToken)
+	req.Header.Set(header, etag)
+	w := httptest.NewRecorder()
+	api.Router().ServeHTTP(w, req)
+	return w
+}
+
+func TestETags(t *testing.T) {
+	loginAs(t, "admin")
+
+	w := makeRequest("POST", "/licenses?force=true", models.LicenseCreateDTO{
+		Shortname: "ETag-Test-1.0",
+		Fullname:  "ETag Test License 1.0",
+		Text:      "ETag Test License text",
+		SpdxId:    "LicenseRef-ETag-Test-1.0",
+		Risk:      ptr(int64(1)),
+	}, true)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.LicenseResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Error unmarshalling JSON: %v", err)
+	}
+	path := "/licenses/" + created.Data[0].Id.String()
+
+	w = makeRequest("GET", path, nil, true)
+	assert.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, fmt.Sprintf("%q", fmt.Sprint(created.Data[0].Version)), etag)
+
+	t.Run("not modified", func(t *testing.T) {
+		w := conditionalRequest("GET", path, nil, "If-None-Match", etag)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.Bytes())
+
+		w = conditionalRequest("GET", path, nil, "If-None-Match", `"0"`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("update with current etag", func(t *testing.T) {
+		w := conditionalRequest("PATCH", path, models.LicenseUpdateDTO{Notes: ptr("first edit")}, "If-Match", etag)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+		// The second edit was based on the version before the first one
+		w = conditionalRequest("PATCH", path, models.LicenseUpdateDTO{Notes: ptr("second edit")}, "If-Match", etag)
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+		w = makeRequest("GET", path, nil, true)
+		var res models.LicenseResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, "first edit", res.Data[0].Notes)
+		etag = w.Header().Get("ETag")
+	})
+
+	t.Run("alias changes the etag", func(t *testing.T) {
+		w := makeRequest("POST", path+"/aliases", models.LicenseAliasInput{Alias: "etag-alias", Namespace: models.AliasNamespaceVendor}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		w = conditionalRequest("GET", path, nil, "If-None-Match", etag)
+		assert.Equal(t, http.StatusOK, w.Code)
+		etag = w.Header().Get("ETag")
+	})
+
+	t.Run("reviews and reminders keep the etag", func(t *testing.T) {
+		w := makeRequest("POST", path+"/review", models.ReviewInput{Comment: ptr("etag review")}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = conditionalRequest("GET", path, nil, "If-None-Match", etag)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+
+		// Reminders only record when they were sent
+		if err := db.DB.Model(&models.LicenseDB{}).Where(&models.LicenseDB{Id: created.Data[0].Id}).
+			UpdateColumn("review_reminded_at", time.Now()).Error; err != nil {
+			t.Fatalf("Failed to record reminder: %v", err)
+		}
+		w = conditionalRequest("GET", path, nil, "If-None-Match", etag)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("update without if-match", func(t *testing.T) {
+		w := makeRequest("PATCH", path, models.LicenseUpdateDTO{Notes: ptr("third edit")}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("obligation", func(t *testing.T) {
+		w := makeRequest("POST", "/obligations?force=true", models.ObligationCreateDTO{
+			Topic:          "etag-topic",
+			Type:           "RIGHT",
+			Text:           "ETag obligation text",
+			Classification: "GREEN",
+			Category:       "GENERAL",
+		}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var res models.ObligationResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		obligationPath := "/obligations/" + res.Data[0].Id.String()
+
+		w = makeRequest("GET", obligationPath, nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		obligationETag := w.Header().Get("ETag")
+		assert.NotEmpty(t, obligationETag)
+
+		w = conditionalRequest("PATCH", obligationPath, models.ObligationUpdateDTO{Comment: ptr("edited")}, "If-Match", obligationETag)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = conditionalRequest("DELETE", obligationPath, nil, "If-Match", obligationETag)
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		w = conditionalRequest("DELETE", obligationPath, nil, "If-Match", "*")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("obligation type", func(t *testing.T) {
+		w := makeRequest("POST", "/obligations/types", models.ObligationType{Type: "ETAG"}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		w = makeRequest("GET", "/obligations/types?active=true", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.ObligationTypeResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		var version int64
+		for _, obType := range res.Data {
+			if obType.Type == "ETAG" {
+				version = obType.Version
+			}
+		}
+
+		w = conditionalRequest("DELETE", "/obligations/types/ETAG", nil, "If-Match", fmt.Sprintf(`"%d"`, version+1))
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		w = conditionalRequest("DELETE", "/obligations/types/ETAG", nil, "If-Match", fmt.Sprintf(`"%d"`, version))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
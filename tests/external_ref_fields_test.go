@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestExternalRefFields(t *testing.T) {
+	loginAs(t, "admin")
+
+	w := makeRequest("POST", "/external-ref-fields", models.ExternalRefFieldCreate{
+		Entity: "LICENSE",
+		Name:   "export_control",
+		Type:   "string",
+		Label:  "Export control classification",
+		Enum:   []string{"EAR99", "5D002"},
+	}, true)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var fieldRes models.ExternalRefFieldResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &fieldRes); err != nil {
+		t.Fatalf("Error unmarshalling JSON: %v", err)
+	}
+	field := fieldRes.Data[0]
+	assert.Equal(t, "export_control", field.Name)
+	assert.False(t, field.Builtin)
+
+	t.Run("list fields", func(t *testing.T) {
+		w := makeRequest("GET", "/external-ref-fields?entity=LICENSE", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.ExternalRefFieldResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		names := map[string]bool{}
+		for _, f := range res.Data {
+			names[f.Name] = f.Builtin
+		}
+		assert.Equal(t, map[string]bool{"license_suffix": true, "license_explanation": true, "export_control": false}, names)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		w := makeRequest("POST", "/external-ref-fields", models.ExternalRefFieldCreate{
+			Entity: "LICENSE",
+			Name:   "export_control",
+			Type:   "int",
+			Label:  "Duplicate",
+		}, true)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = makeRequest("POST", "/external-ref-fields", models.ExternalRefFieldCreate{
+			Entity: "LICENSE",
+			Name:   "license_suffix",
+			Type:   "string",
+			Label:  "Builtin",
+		}, true)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		w := makeRequest("POST", "/external-ref-fields", models.ExternalRefFieldCreate{
+			Entity: "LICENSE",
+			Name:   "Export Control",
+			Type:   "string",
+			Label:  "Invalid name",
+		}, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = makeRequest("POST", "/external-ref-fields", models.ExternalRefFieldCreate{
+			Entity: "LICENSE",
+			Name:   "review_count",
+			Type:   "int",
+			Label:  "Enum on int",
+			Enum:   []string{"1"},
+		}, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		w := makeRequest("GET", "/licenses/external-ref-schema", nil, false)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var schema models.ExternalRefSchema
+		if err := json.Unmarshal(w.Body.Bytes(), &schema); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, "object", schema.Type)
+		assert.False(t, schema.AdditionalProperties)
+		if assert.Contains(t, schema.Properties, "export_control") {
+			assert.Equal(t, []string{"EAR99", "5D002"}, schema.Properties["export_control"].Enum)
+		}
+		assert.Contains(t, schema.Properties, "license_suffix")
+	})
+
+	createLicense := func(extra map[string]any) int {
+		w := makeRequest("POST", "/licenses?force=true", models.LicenseCreateDTO{
+			Shortname: "External-Ref-Fields-1.0",
+			Fullname:  "External Ref Fields License 1.0",
+			Text:      "External Ref Fields License text",
+			SpdxId:    "LicenseRef-External-Ref-Fields-1.0",
+			Risk:      ptr(int64(2)),
+			ExternalRef: models.LicenseDBSchemaExtension{
+				Extra: extra,
+			},
+		}, true)
+		return w.Code
+	}
+
+	t.Run("invalid values", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, createLicense(map[string]any{"export_control": "EAR100"}))
+		assert.Equal(t, http.StatusBadRequest, createLicense(map[string]any{"export_control": 99}))
+		assert.Equal(t, http.StatusBadRequest, createLicense(map[string]any{"undefined_key": "x"}))
+	})
+
+	t.Run("valid value", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, createLicense(map[string]any{"export_control": "EAR99"}))
+
+		w := makeRequest("GET", "/licenses?filter="+url.QueryEscape("external_ref.export_control==EAR99"), nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.LicenseResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		if assert.Len(t, res.Data, 1) {
+			assert.Equal(t, "External-Ref-Fields-1.0", res.Data[0].Shortname)
+			assert.Equal(t, "EAR99", res.Data[0].ExternalRef.Extra["export_control"])
+		}
+	})
+
+	t.Run("update field", func(t *testing.T) {
+		w := makeRequest("PATCH", "/external-ref-fields/"+field.Id.String(), models.ExternalRefFieldUpdate{
+			Label: ptr("Export control"),
+		}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.ExternalRefFieldResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, "Export control", res.Data[0].Label)
+		assert.Equal(t, []string{"EAR99", "5D002"}, []string(res.Data[0].Enum))
+	})
+
+	t.Run("delete field", func(t *testing.T) {
+		w := makeRequest("DELETE", "/external-ref-fields/"+field.Id.String(), nil, true)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = makeRequest("GET", "/licenses?filter="+url.QueryEscape("external_ref.export_control==EAR99"), nil, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = makeRequest("DELETE", "/external-ref-fields/"+field.Id.String(), nil, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/validations"
+)
+
+// generatedExtension has the fields generated for generatorConfig, to check
+// that the generated tags validate values as configured.
+type generatedExtension struct {
+	ExportCode   *string  `json:"export_code,omitempty" validate:"omitempty,regexp=^[A-Z]{2}[0-9]{10x2C3}(-[a-z]+0x7C0x2Cx)?$"`
+	Distribution *string  `json:"distribution,omitempty" validate:"required,oneof=internal public"`
+	Platforms    []string `enums:"linux,windows" json:"platforms,omitempty" validate:"omitempty,max=2,dive,oneof=linux windows"`
+}
+
+const generatorConfig = `
+license:
+  fields:
+    - name: "export_code"
+      type: "string"
+      struct_field_name: "ExportCode"
+      regex: "^[A-Z]{2}[0-9]{1,3}(-[a-z]+|,x)?$"
+    - name: "distribution"
+      type: "enum"
+      struct_field_name: "Distribution"
+      required: true
+      values: ["internal", "public"]
+    - name: "platforms"
+      type: "array"
+      struct_field_name: "Platforms"
+      values: ["linux", "windows"]
+      max: 2
+obligation:
+  fields: []
+`
+
+// runGenerator runs the external_ref struct generator on config and returns
+// the generated file and the output of the generator.
+func runGenerator(t *testing.T, config string) (string, string, error) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "external_ref_fields.yaml")
+	structFile := filepath.Join(dir, "external_ref_structs.go")
+	if err := os.WriteFile(configFile, []byte(config), 0o600); err != nil {
+		t.Fatalf("Error writing config: %v", err)
+	}
+	out, err := exec.Command("go", "run", "../cmd/laas/gen_external_ref_schema.go",
+		"-config", configFile, "-out", structFile).CombinedOutput()
+	generated, _ := os.ReadFile(structFile)
+	return string(generated), string(out), err
+}
+
+func TestExternalRefGenerator(t *testing.T) {
+	t.Run("generates validations", func(t *testing.T) {
+		generated, out, err := runGenerator(t, generatorConfig)
+		if !assert.NoError(t, err, out) {
+			return
+		}
+		fields := reflect.TypeOf(generatedExtension{})
+		for i := range fields.NumField() {
+			field := fields.Field(i)
+			assert.Contains(t, generated, string(field.Tag), field.Name)
+		}
+	})
+
+	t.Run("validates regex and enum fields", func(t *testing.T) {
+		valid := []generatedExtension{
+			{Distribution: ptr("internal")},
+			{ExportCode: ptr("EU12"), Distribution: ptr("public")},
+			{ExportCode: ptr("US123-ear"), Distribution: ptr("public"), Platforms: []string{"linux"}},
+			{ExportCode: ptr("US1,x"), Distribution: ptr("internal"), Platforms: []string{"linux", "windows"}},
+		}
+		for _, ext := range valid {
+			assert.NoError(t, validations.Validate.Struct(&ext))
+		}
+
+		invalid := []generatedExtension{
+			{},
+			{Distribution: ptr("private")},
+			{ExportCode: ptr("eu12"), Distribution: ptr("public")},
+			{ExportCode: ptr("US1234"), Distribution: ptr("public")},
+			{ExportCode: ptr("US1|x"), Distribution: ptr("public")},
+			{Distribution: ptr("public"), Platforms: []string{"macos"}},
+			{Distribution: ptr("public"), Platforms: []string{"linux", "windows", "linux"}},
+		}
+		for _, ext := range invalid {
+			assert.Error(t, validations.Validate.Struct(&ext))
+		}
+	})
+
+	t.Run("rejects constraints not applying to the type", func(t *testing.T) {
+		fields := map[string]struct{ field, err string }{
+			"enum without values":   {`{name: "f", struct_field_name: "F", type: "enum"}`, "enum fields need values"},
+			"values on int":         {`{name: "f", struct_field_name: "F", type: "int", values: ["1"]}`, "values do not apply to int fields"},
+			"regex on boolean":      {`{name: "f", struct_field_name: "F", type: "boolean", regex: "^t"}`, "regex does not apply to boolean fields"},
+			"min on date":           {`{name: "f", struct_field_name: "F", type: "date", min: 1}`, "min and max do not apply to date fields"},
+			"object without fields": {`{name: "f", struct_field_name: "F", type: "object"}`, "object fields need fields"},
+			"fields on string":      {`{name: "f", struct_field_name: "F", type: "string", fields: [{name: "g", struct_field_name: "G", type: "string"}]}`, "fields do not apply to string fields"},
+			"enum value with space": {`{name: "f", struct_field_name: "F", type: "enum", values: ["a b"]}`, "enum value 'a b' must not be empty or contain spaces or commas"},
+			"invalid regex":         {`{name: "f", struct_field_name: "F", type: "string", regex: "("}`, "error parsing regexp"},
+			"unknown type":          {`{name: "f", struct_field_name: "F", type: "uuid"}`, "type uuid in external_ref_fields.yaml is not supported"},
+		}
+		for name, tc := range fields {
+			t.Run(name, func(t *testing.T) {
+				_, out, err := runGenerator(t, "license:\n  fields:\n    - "+tc.field+"\n")
+				assert.Error(t, err)
+				assert.Contains(t, out, tc.err)
+			})
+		}
+	})
+}
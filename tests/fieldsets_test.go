@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldsAndExpand(t *testing.T) {
+	loginAs(t, "admin")
+
+	list := func(t *testing.T, path string) []map[string]json.RawMessage {
+		w := makeRequest("GET", path, nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res struct {
+			Data []map[string]json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		return res.Data
+	}
+
+	t.Run("license fields", func(t *testing.T) {
+		data := list(t, "/licenses?fields=shortname,spdx_id&limit=5")
+		assert.NotEmpty(t, data)
+		for _, l := range data {
+			assert.ElementsMatch(t, []string{"id", "shortname", "spdx_id"}, keys(l))
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		w := makeRequest("GET", "/licenses?fields=shortname,secret", nil, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = makeRequest("GET", "/obligations?expand=user", nil, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("expand obligations", func(t *testing.T) {
+		data := list(t, "/licenses?fields=shortname&expand=obligations&limit=1000")
+		for _, l := range data {
+			assert.NotContains(t, l, "created_by")
+			var obligations []map[string]any
+			assert.NoError(t, json.Unmarshal(l["obligations"], &obligations))
+			for _, o := range obligations {
+				assert.Contains(t, o, "topic")
+				assert.Contains(t, o, "text")
+				assert.NotContains(t, o, "license_ids")
+			}
+		}
+	})
+
+	t.Run("expand nothing", func(t *testing.T) {
+		data := list(t, "/licenses?expand=&limit=1")
+		if assert.NotEmpty(t, data) {
+			assert.NotContains(t, data[0], "created_by")
+			assert.Contains(t, data[0], "text")
+			assert.Contains(t, data[0], "obligation_ids")
+		}
+	})
+
+	t.Run("obligations", func(t *testing.T) {
+		data := list(t, "/obligations?fields=topic,classification&expand=licenses&limit=5")
+		for _, o := range data {
+			assert.ElementsMatch(t, []string{"id", "topic", "classification", "licenses"}, keys(o))
+			var licenses []map[string]any
+			assert.NoError(t, json.Unmarshal(o["licenses"], &licenses))
+			for _, l := range licenses {
+				assert.Contains(t, l, "shortname")
+				assert.NotContains(t, l, "obligation_ids")
+			}
+		}
+	})
+}
+
+func keys(object map[string]json.RawMessage) []string {
+	var keys []string
+	for key := range object {
+		keys = append(keys, key)
+	}
+	return keys
+}
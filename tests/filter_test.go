@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestFilters(t *testing.T) {
+	loginAs(t, "admin")
+
+	w := makeRequest("POST", "/obligations?force=true", models.ObligationCreateDTO{
+		Topic:          "test-topic-filter",
+		Type:           "RISK",
+		Text:           "Obligation of the filter test license",
+		Classification: "RED",
+		Active:         ptr(true),
+		TextUpdatable:  ptr(false),
+		Category:       "GENERAL",
+	}, true)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var obligationRes models.ObligationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &obligationRes); err != nil {
+		t.Fatalf("Error unmarshalling JSON: %v", err)
+	}
+
+	w = makeRequest("POST", "/licenses?force=true", models.LicenseCreateDTO{
+		Shortname:     "Filter-Test-1.0",
+		Fullname:      "Filter Test Public License 1.0",
+		Text:          "Filter Test Public License text",
+		SpdxId:        "LicenseRef-Filter-Test-1.0",
+		Source:        ptr("filter-test"),
+		Risk:          ptr(int64(4)),
+		ObligationIds: []uuid.UUID{obligationRes.Data[0].Id},
+		ExternalRef: models.LicenseDBSchemaExtension{
+			LicenseSuffix: ptr("filter-v2"),
+		},
+	}, true)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	filterLicenses := func(t *testing.T, filter string) (int, []models.LicenseResponseDTO) {
+		w := makeRequest("GET", "/licenses?filter="+url.QueryEscape(filter), nil, true)
+		var res models.LicenseResponse
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatalf("Error unmarshalling JSON: %v", err)
+			}
+		}
+		return w.Code, res.Data
+	}
+
+	t.Run("risk range", func(t *testing.T) {
+		code, licenses := filterLicenses(t, "risk=ge=3;risk<=4")
+		assert.Equal(t, http.StatusOK, code)
+		assert.NotEmpty(t, licenses)
+		for _, l := range licenses {
+			assert.GreaterOrEqual(t, l.Risk, int64(3))
+			assert.LessOrEqual(t, l.Risk, int64(4))
+		}
+	})
+
+	t.Run("grouped conditions", func(t *testing.T) {
+		code, licenses := filterLicenses(t, `source=in=(filter-test,none);(fullname=like="test public",obligation.classification==GREEN)`)
+		assert.Equal(t, http.StatusOK, code)
+		if assert.Len(t, licenses, 1) {
+			assert.Equal(t, "Filter-Test-1.0", licenses[0].Shortname)
+		}
+	})
+
+	t.Run("has obligation of classification", func(t *testing.T) {
+		code, licenses := filterLicenses(t, "obligation.classification==RED and shortname==Filter-Test-1.0")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, licenses, 1)
+
+		code, licenses = filterLicenses(t, "obligation.classification==GREEN and shortname==Filter-Test-1.0")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, licenses)
+	})
+
+	t.Run("add date range", func(t *testing.T) {
+		code, licenses := filterLicenses(t, "add_date=ge=2000-01-01;shortname==Filter-Test-1.0")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, licenses, 1)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		code, _ := filterLicenses(t, "colour==red")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		code, _ := filterLicenses(t, "risk=gt=high")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("invalid syntax", func(t *testing.T) {
+		code, _ := filterLicenses(t, "(risk==1")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("external ref key", func(t *testing.T) {
+		code, licenses := filterLicenses(t, "external_ref.license_suffix==filter-v2")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, licenses, 1)
+	})
+
+	externalRefLicenses := func(t *testing.T, query string) (int, []models.LicenseResponseDTO) {
+		w := makeRequest("GET", "/licenses?externalRef="+url.QueryEscape(query), nil, true)
+		var res models.LicenseResponse
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatalf("Error unmarshalling JSON: %v", err)
+			}
+		}
+		return w.Code, res.Data
+	}
+
+	t.Run("external ref operators", func(t *testing.T) {
+		code, licenses := externalRefLicenses(t, `{"license_suffix":"filter-v2"}`)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, licenses, 1)
+
+		code, licenses = externalRefLicenses(t, `{"license_suffix":{"in":["filter-v1","filter-v2"],"contains":"FILTER"}}`)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, licenses, 1)
+
+		code, licenses = externalRefLicenses(t, `{"license_suffix":{"exists":true,"ne":"filter-v2"}}`)
+		assert.Equal(t, http.StatusOK, code)
+		for _, l := range licenses {
+			assert.NotEqual(t, "Filter-Test-1.0", l.Shortname)
+		}
+	})
+
+	t.Run("external ref unknown key", func(t *testing.T) {
+		code, _ := externalRefLicenses(t, `{"license_suffix' = '' OR '1'='1":"x"}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("external ref invalid operator", func(t *testing.T) {
+		code, _ := externalRefLicenses(t, `{"license_suffix":{"gt":"a"}}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		code, _ = externalRefLicenses(t, `{"license_suffix":{"matches":"a"}}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("filter obligations", func(t *testing.T) {
+		w := makeRequest("GET", "/obligations?filter="+url.QueryEscape("classification==RED;license.spdx_id==LicenseRef-Filter-Test-1.0"), nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.ObligationResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		if assert.Len(t, res.Data, 1) {
+			assert.Equal(t, "test-topic-filter", res.Data[0].Topic)
+		}
+	})
+
+	t.Run("filter obligations keeps active default", func(t *testing.T) {
+		w := makeRequest("POST", "/obligations?force=true", models.ObligationCreateDTO{
+			Topic:          "test-topic-filter-inactive",
+			Type:           "RISK",
+			Text:           "Inactive obligation of the filter test",
+			Classification: "YELLOW",
+			Active:         ptr(false),
+			TextUpdatable:  ptr(false),
+			Category:       "GENERAL",
+		}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		filterTopics := func(t *testing.T, query string) []string {
+			w := makeRequest("GET", "/obligations?"+query, nil, true)
+			assert.Equal(t, http.StatusOK, w.Code)
+			var res models.ObligationResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatalf("Error unmarshalling JSON: %v", err)
+			}
+			var topics []string
+			for _, o := range res.Data {
+				topics = append(topics, o.Topic)
+			}
+			return topics
+		}
+
+		filter := url.QueryEscape("topic=like=test-topic-filter")
+		assert.Equal(t, []string{"test-topic-filter"}, filterTopics(t, "filter="+filter))
+		assert.ElementsMatch(t, []string{"test-topic-filter", "test-topic-filter-inactive"}, filterTopics(t, "active=&filter="+filter))
+		assert.Equal(t, []string{"test-topic-filter-inactive"}, filterTopics(t, "filter="+url.QueryEscape("topic=like=test-topic-filter;active==false")))
+	})
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestIdentifyLicense(t *testing.T) {
+	var license models.LicenseDB
+	if err := db.DB.Where(&models.LicenseDB{Shortname: ptr("MIT")}).First(&license).Error; err != nil {
+		t.Fatalf("Failed to fetch license: %v", err)
+	}
+
+	identify := func(t *testing.T, req models.IdentifyRequest) models.LicenseIdentification {
+		w := makeRequest("POST", "/licenses/identify", req, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.LicenseIdentificationResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		return res.Data
+	}
+
+	t.Run("exact match ignores trivial differences", func(t *testing.T) {
+		text := "/*\n * Copyright (c) 2026 Jane Doe\n *\n * " +
+			strings.ReplaceAll(strings.ToUpper(*license.Text), "\n", "\n * ") + "\n */\n"
+		res := identify(t, models.IdentifyRequest{Text: text})
+
+		var mit *models.IdentifiedLicense
+		for i := range res.ExactMatches {
+			if res.ExactMatches[i].Id == license.Id {
+				mit = &res.ExactMatches[i]
+			}
+		}
+		if assert.NotNil(t, mit) {
+			assert.Equal(t, float64(100), mit.Coverage)
+			assert.Len(t, mit.Portions, 1)
+		}
+	})
+
+	t.Run("near match reports coverage", func(t *testing.T) {
+		words := strings.Fields(*license.Text)
+		text := strings.Join(words[:len(words)*2/3], " ")
+		res := identify(t, models.IdentifyRequest{Text: text, MinCoverage: ptr(float64(40))})
+
+		for _, exact := range res.ExactMatches {
+			assert.NotEqual(t, license.Id, exact.Id)
+		}
+		found := false
+		for _, near := range res.NearMatches {
+			if near.Id == license.Id {
+				found = true
+				assert.Less(t, near.Coverage, float64(100))
+				assert.GreaterOrEqual(t, near.Coverage, float64(40))
+				assert.NotEmpty(t, near.Portions)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("follows license changes", func(t *testing.T) {
+		text := "Permission is granted to identify this cache test license, provided that every copy " +
+			"keeps the cache test notice and mentions the original authors of the software."
+		w := makeRequest("POST", "/licenses?force=true", models.LicenseCreateDTO{
+			Shortname:     "Identify-Cache-Test-1.0",
+			Fullname:      "Identify Cache Test License 1.0",
+			Text:          text,
+			SpdxId:        "LicenseRef-Identify-Cache-Test-1.0",
+			Risk:          ptr(int64(1)),
+			TextUpdatable: ptr(true),
+		}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var res models.LicenseResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		created := res.Data[0]
+
+		exactly := func(t *testing.T, text string) bool {
+			for _, exact := range identify(t, models.IdentifyRequest{Text: text}).ExactMatches {
+				if exact.Id == created.Id {
+					return true
+				}
+			}
+			return false
+		}
+		assert.True(t, exactly(t, text))
+
+		updated := text + " The notice may not be removed."
+		w = makeRequest("PATCH", "/licenses/"+created.Id.String(), models.LicenseUpdateDTO{Text: ptr(updated)}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, exactly(t, text))
+		assert.True(t, exactly(t, updated))
+
+		w = makeRequest("PATCH", "/licenses/"+created.Id.String(), models.LicenseUpdateDTO{Active: ptr(false)}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, exactly(t, updated))
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		w := makeRequest("POST", "/licenses/identify", models.IdentifyRequest{Text: "MIT", MinCoverage: ptr(float64(120))}, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = makeRequest("POST", "/licenses/identify", models.IdentifyRequest{}, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("text too large", func(t *testing.T) {
+		w := makeRequest("POST", "/licenses/identify", models.IdentifyRequest{Text: strings.Repeat("word ", 1<<18)}, true)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestImpersonation(t *testing.T) {
+	loginAs(t, "superadmin")
+	superAdminToken := AuthToken
+	t.Cleanup(func() {
+		AuthToken = superAdminToken
+		audits := db.DB.Model(&models.Audit{}).Select("id").
+			Where("impersonator_id IS NOT NULL OR type = ?", "IMPERSONATION")
+		db.DB.Where("audit_id IN (?)", audits).Delete(&models.ChangeLog{})
+		db.DB.Where("impersonator_id IS NOT NULL OR type = ?", "IMPERSONATION").Delete(&models.Audit{})
+		db.DB.Where(&models.ObligationType{Type: "IMPERSONATED"}).Delete(&models.ObligationType{})
+	})
+
+	var tokens models.Tokens
+	t.Run("impersonate user", func(t *testing.T) {
+		w := makeRequest("POST", "/users/fossy_admin/impersonate", models.ImpersonationInput{Reason: "Reproduce missing edit permission"}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res models.TokenResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		tokens = res.Data
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.Empty(t, tokens.RefreshToken)
+	})
+
+	t.Run("reason is required", func(t *testing.T) {
+		w := makeRequest("POST", "/users/fossy_admin/impersonate", models.ImpersonationInput{}, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("can not impersonate yourself", func(t *testing.T) {
+		w := makeRequest("POST", "/users/fossy_superadmin/impersonate", models.ImpersonationInput{Reason: "test"}, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("only super admins can impersonate", func(t *testing.T) {
+		loginAs(t, "admin")
+		defer func() { AuthToken = superAdminToken }()
+		w := makeRequest("POST", "/users/fossy_superadmin/impersonate", models.ImpersonationInput{Reason: "test"}, true)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("token acts as the user", func(t *testing.T) {
+		AuthToken = tokens.AccessToken
+		defer func() { AuthToken = superAdminToken }()
+
+		w := makeRequest("GET", "/users/profile", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.UserResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, "fossy_admin", *res.Data[0].UserName)
+	})
+
+	t.Run("user management is blocked", func(t *testing.T) {
+		AuthToken = tokens.AccessToken
+		defer func() { AuthToken = superAdminToken }()
+
+		assert.Equal(t, http.StatusForbidden, makeRequest("GET", "/users", nil, true).Code)
+		assert.Equal(t, http.StatusForbidden, makeRequest("PATCH", "/users", models.ProfileUpdate{DisplayName: ptr("impersonated")}, true).Code)
+		assert.Equal(t, http.StatusForbidden, makeRequest("GET", "/oidcClients", nil, true).Code)
+		assert.Equal(t, http.StatusForbidden, makeRequest("GET", "/service-accounts", nil, true).Code)
+		assert.Equal(t, http.StatusForbidden, makeRequest("POST", "/teams", models.TeamCreate{Name: "impersonated"}, true).Code)
+		assert.Equal(t, http.StatusForbidden, makeRequest("DELETE", "/teams/impersonated", nil, true).Code)
+		assert.Equal(t, http.StatusForbidden, makeRequest("DELETE", "/external-ref-fields/"+uuid.NewString(), nil, true).Code)
+	})
+
+	t.Run("audits record the impersonator", func(t *testing.T) {
+		AuthToken = tokens.AccessToken
+		w := makeRequest("POST", "/obligations/types", models.ObligationType{Type: "IMPERSONATED", Active: ptr(true)}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		w = makeRequest("DELETE", "/obligations/types/IMPERSONATED", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		AuthToken = superAdminToken
+
+		w = makeRequest("GET", "/audits?impersonated=true", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.AuditResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		if assert.NotEmpty(t, res.Data) {
+			audit := res.Data[0]
+			assert.Equal(t, "TYPE", audit.Type)
+			assert.Equal(t, "fossy_admin", *audit.User.UserName)
+			if assert.NotNil(t, audit.Impersonator) {
+				assert.Equal(t, "fossy_superadmin", *audit.Impersonator.UserName)
+			}
+		}
+	})
+}
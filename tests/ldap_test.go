@@ -0,0 +1,280 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/fossology/LicenseDb/pkg/auth"
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+// testLdapEntry is a user of the test directory.
+type testLdapEntry struct {
+	password   string
+	attributes map[string][]string
+}
+
+// testLdapServer is an in-process stand-in for an LDAP directory. It accepts
+// simple binds of its entries and answers every search with the bound entry.
+type testLdapServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	entries  map[string]testLdapEntry
+}
+
+func newTestLdapServer(t *testing.T) *testLdapServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start ldap server: %v", err)
+	}
+	server := &testLdapServer{listener: listener, entries: map[string]testLdapEntry{}}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *testLdapServer) url() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *testLdapServer) setEntry(dn string, entry testLdapEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[dn] = entry
+}
+
+func (s *testLdapServer) serve(conn net.Conn) {
+	defer conn.Close()
+	var boundDn string
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageId, _ := packet.Children[0].Value.(int64)
+		request := packet.Children[1]
+
+		switch request.Tag {
+		case ldapBindRequest:
+			dn, _ := request.Children[1].Value.(string)
+			password := request.Children[2].Data.String()
+			s.mu.Lock()
+			entry, ok := s.entries[dn]
+			s.mu.Unlock()
+			code := ldapResultSuccess
+			if !ok || entry.password != password {
+				code = ldapResultInvalidCredentials
+			} else {
+				boundDn = dn
+			}
+			s.respond(conn, messageId, ldapBindResponse, ldapResult(code)...)
+		case ldapSearchRequest:
+			s.mu.Lock()
+			entry, ok := s.entries[boundDn]
+			s.mu.Unlock()
+			if ok {
+				attributes := ber.NewSequence("attributes")
+				for name, values := range entry.attributes {
+					attribute := ber.NewSequence("attribute")
+					attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+					set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "values")
+					for _, value := range values {
+						set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "value"))
+					}
+					attribute.AppendChild(set)
+					attributes.AppendChild(attribute)
+				}
+				s.respond(conn, messageId, ldapSearchResultEntry,
+					ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, boundDn, "objectName"),
+					attributes)
+			}
+			s.respond(conn, messageId, ldapSearchResultDone, ldapResult(ldapResultSuccess)...)
+		case ldapUnbindRequest:
+			return
+		}
+	}
+}
+
+func (s *testLdapServer) respond(conn net.Conn, messageId int64, tag ber.Tag, children ...*ber.Packet) {
+	envelope := ber.NewSequence("LDAP Response")
+	envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageId, "MessageID"))
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	for _, child := range children {
+		response.AppendChild(child)
+	}
+	envelope.AppendChild(response)
+	_, _ = conn.Write(envelope.Bytes())
+}
+
+const (
+	ldapBindRequest       ber.Tag = 0
+	ldapBindResponse      ber.Tag = 1
+	ldapUnbindRequest     ber.Tag = 2
+	ldapSearchRequest     ber.Tag = 3
+	ldapSearchResultEntry ber.Tag = 4
+	ldapSearchResultDone  ber.Tag = 5
+
+	ldapResultSuccess            int64 = 0
+	ldapResultInvalidCredentials int64 = 49
+)
+
+func ldapResult(code int64) []*ber.Packet {
+	return []*ber.Packet{
+		ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "resultCode"),
+		ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"),
+		ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "diagnosticMessage"),
+	}
+}
+
+func TestLdapLogin(t *testing.T) {
+	const (
+		aliceDn  = "uid=ldap-alice,ou=people,dc=example,dc=org"
+		carolDn  = "uid=ldap-carol,ou=people,dc=example,dc=org"
+		adminsDn = "cn=LicenseDB-Admins,ou=groups,dc=example,dc=org"
+	)
+	directory := newTestLdapServer(t)
+	directory.setEntry(aliceDn, testLdapEntry{
+		password: "secret",
+		attributes: map[string][]string{
+			"mail":        {"alice@example.org"},
+			"displayName": {"Alice Directory"},
+			"memberOf":    {adminsDn, "cn=developers,ou=groups,dc=example,dc=org"},
+		},
+	})
+
+	t.Setenv("LDAP_URL", directory.url())
+	t.Setenv("LDAP_USER_DN", "uid={username},ou=people,dc=example,dc=org")
+	t.Setenv("LDAP_BASE_DN", "dc=example,dc=org")
+	t.Setenv("LDAP_ROLE_MAPPING", `{"ADMIN":["cn=licensedb-admins,ou=groups,dc=example,dc=org"]}`)
+	t.Setenv("LDAP_DEFAULT_ROLE", "USER")
+	if err := auth.LoadLdapDirectory(); err != nil {
+		t.Fatalf("Failed to configure ldap login: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Unsetenv("LDAP_URL")
+		_ = auth.LoadLdapDirectory()
+
+		for _, username := range []string{"ldap-alice", "ldap-carol"} {
+			var user models.User
+			if err := db.DB.Where(&models.User{UserName: &username}).First(&user).Error; err == nil {
+				audits := db.DB.Model(&models.Audit{}).Select("id").Where(&models.Audit{UserId: user.Id})
+				db.DB.Where("audit_id IN (?)", audits).Delete(&models.ChangeLog{})
+				db.DB.Where(&models.Audit{UserId: user.Id}).Delete(&models.Audit{})
+				db.DB.Unscoped().Delete(&user)
+			}
+		}
+	})
+
+	login := func(username, password string) int {
+		return makeRequest("POST", "/login", models.UserLogin{Username: username, Userpassword: password}, false).Code
+	}
+	directoryUser := func(t *testing.T) models.User {
+		var user models.User
+		if err := db.DB.Where(&models.User{UserName: ptr("ldap-alice")}).First(&user).Error; err != nil {
+			t.Fatalf("Failed to fetch user: %v", err)
+		}
+		return user
+	}
+
+	t.Run("first login creates the user", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, login("ldap-alice", "secret"))
+
+		user := directoryUser(t)
+		assert.Equal(t, "Alice Directory", *user.DisplayName)
+		assert.Equal(t, "alice@example.org", *user.UserEmail)
+		assert.Equal(t, "ADMIN", *user.UserLevel)
+		assert.Nil(t, user.UserPassword)
+		assert.Equal(t, directory.url(), *user.ExternalIssuer)
+		assert.Equal(t, aliceDn, *user.ExternalSubject)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, login("ldap-alice", "wrong"))
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, login("ldap-bob", "secret"))
+		err := db.DB.Where(&models.User{UserName: ptr("ldap-bob")}).First(&models.User{}).Error
+		assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	})
+
+	t.Run("group changes are synced on login", func(t *testing.T) {
+		directory.setEntry(aliceDn, testLdapEntry{
+			password: "secret",
+			attributes: map[string][]string{
+				"mail":        {"alice.directory@example.org"},
+				"displayName": {"Alice Directory"},
+				"memberOf":    {"cn=developers,ou=groups,dc=example,dc=org"},
+			},
+		})
+		assert.Equal(t, http.StatusOK, login("ldap-alice", "secret"))
+
+		user := directoryUser(t)
+		assert.Equal(t, "alice.directory@example.org", *user.UserEmail)
+		assert.Equal(t, "USER", *user.UserLevel)
+	})
+
+	t.Run("oidc users are not taken over", func(t *testing.T) {
+		carol := models.User{
+			UserName:        ptr("ldap-carol"),
+			DisplayName:     ptr("Carol Oidc"),
+			UserEmail:       ptr("carol@login.example.com"),
+			UserLevel:       ptr("ADMIN"),
+			ExternalIssuer:  ptr("https://login.example.com"),
+			ExternalSubject: ptr("carol-subject"),
+		}
+		if err := db.DB.Create(&carol).Error; err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		directory.setEntry(carolDn, testLdapEntry{
+			password: "secret",
+			attributes: map[string][]string{
+				"mail":        {"carol@example.org"},
+				"displayName": {"Carol Directory"},
+			},
+		})
+
+		assert.Equal(t, http.StatusUnauthorized, login("ldap-carol", "secret"))
+
+		var user models.User
+		if err := db.DB.First(&user, "id = ?", carol.Id).Error; err != nil {
+			t.Fatalf("Failed to fetch user: %v", err)
+		}
+		assert.Equal(t, "carol@login.example.com", *user.UserEmail)
+		assert.Equal(t, "ADMIN", *user.UserLevel)
+		assert.Equal(t, "https://login.example.com", *user.ExternalIssuer)
+		assert.Equal(t, "carol-subject", *user.ExternalSubject)
+	})
+
+	t.Run("local accounts keep their password", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, login("fossy_admin", "fossy"))
+	})
+
+	t.Run("deactivated user", func(t *testing.T) {
+		user := directoryUser(t)
+		db.DB.Model(&user).Update("active", false)
+		assert.Equal(t, http.StatusUnauthorized, login("ldap-alice", "secret"))
+	})
+}
@@ -312,7 +312,7 @@ func TestImportLicenses(t *testing.T) {
 				ObligationExplanation: ptr("this is a test explaination to test the external ref functionality"),
 			},
 		}
-		wObligation := makeRequest("POST", "/obligations", dto, true)
+		wObligation := makeRequest("POST", "/obligations?force=true", dto, true)
 		assert.Equal(t, http.StatusCreated, wObligation.Code, "Failed to create test obligation")
 
 		var obligationRes models.ObligationResponse
@@ -376,7 +376,7 @@ func TestImportLicenses(t *testing.T) {
 			SpdxId:    "LicenseRef-MIT1-UpdateExisting",
 			Risk:      ptr(int64(2)),
 		}
-		wLicense := makeRequest("POST", "/licenses", license, true)
+		wLicense := makeRequest("POST", "/licenses?force=true", license, true)
 		assert.Equal(t, http.StatusCreated, wLicense.Code)
 		var licenseRes models.LicenseResponse
 		if err := json.Unmarshal(wLicense.Body.Bytes(), &licenseRes); err != nil {
@@ -401,7 +401,7 @@ func TestImportLicenses(t *testing.T) {
 				ObligationExplanation: ptr("this is a test explaination to test the external ref functionality"),
 			},
 		}
-		wObligation := makeRequest("POST", "/obligations", dto, true)
+		wObligation := makeRequest("POST", "/obligations?force=true", dto, true)
 		assert.Equal(t, http.StatusCreated, wObligation.Code, "Failed to create test obligation")
 
 		var obligationRes models.ObligationResponse
@@ -584,6 +584,39 @@ func TestGetSimilarLicenses(t *testing.T) {
 		}
 	})
 
+	t.Run("findSimilarWithThresholdAndLimit", func(t *testing.T) {
+		similarityReq := models.SimilarityRequest{
+			Text:      "MIT License\n\nCopyright (c) <year> <copyright holders>\n\nPermission is hereby granted",
+			Threshold: ptr(0.05),
+			Limit:     ptr(1),
+			MinLength: ptr(10),
+			OmitText:  true,
+		}
+
+		w := makeRequest("POST", "/licenses/similarity", similarityReq, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res models.ApiResponse[[]models.SimilarLicense]
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Errorf("Error unmarshalling JSON: %v", err)
+			return
+		}
+		if assert.Len(t, res.Data, 1) {
+			assert.Nil(t, res.Data[0].Text)
+			assert.GreaterOrEqual(t, res.Data[0].Similarity, 0.05)
+		}
+	})
+
+	t.Run("findSimilarWithInvalidThreshold", func(t *testing.T) {
+		similarityReq := models.SimilarityRequest{
+			Text:      "MIT License",
+			Threshold: ptr(1.5),
+		}
+
+		w := makeRequest("POST", "/licenses/similarity", similarityReq, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
 	t.Run("findSimilarWithEmptyText", func(t *testing.T) {
 		similarityReq := models.SimilarityRequest{
 			Text: "",
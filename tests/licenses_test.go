@@ -27,7 +27,7 @@ func TestCreateLicense(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		w := makeRequest("POST", "/licenses", license, true)
+		w := makeRequest("POST", "/licenses?force=true", license, true)
 		assert.Equal(t, http.StatusCreated, w.Code)
 		var res models.LicenseResponse
 		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
@@ -100,7 +100,7 @@ func TestCreateLicense(t *testing.T) {
 				ObligationExplanation: ptr("this is a test explaination to test the external ref functionality"),
 			},
 		}
-		wObligation := makeRequest("POST", "/obligations", dto, true)
+		wObligation := makeRequest("POST", "/obligations?force=true", dto, true)
 		assert.Equal(t, http.StatusCreated, wObligation.Code, "Failed to create test obligation")
 
 		var obligationRes models.ObligationResponse
@@ -121,7 +121,7 @@ func TestCreateLicense(t *testing.T) {
 			ObligationIds: []uuid.UUID{createdObligationID},
 		}
 		// Create the license
-		wLicense := makeRequest("POST", "/licenses", licenseWithObligation, true)
+		wLicense := makeRequest("POST", "/licenses?force=true", licenseWithObligation, true)
 		assert.Equal(t, http.StatusCreated, wLicense.Code, "Failed to create license with obligations")
 
 		var res models.LicenseResponse
@@ -149,7 +149,7 @@ func TestGetLicense(t *testing.T) {
 		Active:        true,
 		SpdxId:        "LicenseRef-MITE",
 	}
-	w := makeRequest("POST", "/licenses", license, true)
+	w := makeRequest("POST", "/licenses?force=true", license, true)
 	var res models.LicenseResponse
 	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
 		t.Errorf("Error unmarshalling response: %v", err)
@@ -294,7 +294,7 @@ func TestUpdateLicense(t *testing.T) {
 				ObligationExplanation: ptr("this is a test explaination to test the external ref functionality"),
 			},
 		}
-		wObligation := makeRequest("POST", "/obligations", dto, true)
+		wObligation := makeRequest("POST", "/obligations?force=true", dto, true)
 		assert.Equal(t, http.StatusCreated, wObligation.Code, "Failed to create test obligation")
 
 		var obligationRes models.ObligationResponse
@@ -139,7 +139,7 @@ func TestGetObligationAudits(t *testing.T) {
 		TextUpdatable:  ptr(false),
 		Category:       "GENERAL",
 	}
-	createW := makeRequest("POST", "/obligations", dto, true)
+	createW := makeRequest("POST", "/obligations?force=true", dto, true)
 	assert.Equal(t, http.StatusCreated, createW.Code)
 
 	var createRes models.ObligationResponse
@@ -449,6 +449,16 @@ func TestGetSimilarObligations(t *testing.T) {
 		}
 	})
 
+	t.Run("findSimilarWithInvalidLimit", func(t *testing.T) {
+		similarityReq := models.SimilarityRequest{
+			Text:  "You must include the copyright notice",
+			Limit: ptr(0),
+		}
+
+		w := makeRequest("POST", "/obligations/similarity", similarityReq, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
 	t.Run("findSimilarWithEmptyText", func(t *testing.T) {
 		similarityReq := models.SimilarityRequest{
 			Text: "",
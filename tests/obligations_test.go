@@ -276,7 +276,7 @@ func TestDeleteObligation(t *testing.T) {
 }
 
 func assertObligationCreated(t *testing.T, dto models.ObligationCreateDTO) uuid.UUID {
-	w := makeRequest("POST", "/obligations", dto, true)
+	w := makeRequest("POST", "/obligations?force=true", dto, true)
 	if w.Code != http.StatusCreated {
 		t.Fatalf("Expected 201 Created, got %d", w.Code)
 	}
@@ -0,0 +1,419 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fossology/LicenseDb/pkg/auth"
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+// testOidcProvider serves the public key set of a signing key like an OIDC
+// provider would.
+type testOidcProvider struct {
+	issuer string
+	key    jwk.Key
+	server *httptest.Server
+}
+
+func newTestOidcProvider(t *testing.T, issuer, kid string) *testOidcProvider {
+	rawKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	key, err := jwk.Import(rawKey)
+	if err != nil {
+		t.Fatalf("Failed to import key: %v", err)
+	}
+	_ = key.Set(jwk.KeyIDKey, kid)
+	_ = key.Set(jwk.AlgorithmKey, jwa.RS256())
+
+	set := jwk.NewSet()
+	_ = set.AddKey(key)
+	publicSet, err := jwk.PublicSetOf(set)
+	if err != nil {
+		t.Fatalf("Failed to build public key set: %v", err)
+	}
+	keys, _ := json.Marshal(publicSet)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(keys)
+	}))
+	t.Cleanup(server.Close)
+
+	return &testOidcProvider{issuer: issuer, key: key, server: server}
+}
+
+func (p *testOidcProvider) token(t *testing.T, issuer string, claims map[string]interface{}) string {
+	builder := jwt.NewBuilder().
+		Issuer(issuer).
+		IssuedAt(time.Now()).
+		Expiration(time.Now().Add(time.Hour))
+	for name, value := range claims {
+		builder = builder.Claim(name, value)
+	}
+	token, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Failed to build token: %v", err)
+	}
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256(), p.key))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return string(signed)
+}
+
+// useOidcIssuers trusts the issuers of the yaml config for the duration of
+// the test.
+func useOidcIssuers(t *testing.T, config string) {
+	configDir := t.TempDir()
+	configFile := filepath.Join(configDir, "oidc_issuers.yaml")
+	if err := os.WriteFile(configFile, []byte(config), 0o600); err != nil {
+		t.Fatalf("Failed to write oidc issuers file: %v", err)
+	}
+
+	t.Setenv("OIDC_ISSUERS_FILE", configFile)
+	if err := auth.LoadOidcIssuers(); err != nil {
+		t.Fatalf("Failed to load oidc issuers: %v", err)
+	}
+	if err := auth.RegisterOidcIssuers(context.Background()); err != nil {
+		t.Fatalf("Failed to register oidc issuers: %v", err)
+	}
+	t.Cleanup(func() {
+		emptyConfig := filepath.Join(configDir, "empty.yaml")
+		_ = os.WriteFile(emptyConfig, []byte("issuers: []\n"), 0o600)
+		_ = os.Setenv("OIDC_ISSUERS_FILE", emptyConfig)
+		_ = auth.LoadOidcIssuers()
+		auth.Jwks = nil
+	})
+}
+
+func TestMultipleOidcIssuers(t *testing.T) {
+	employees := newTestOidcProvider(t, "https://login.example.com", "employees-key")
+	partners := newTestOidcProvider(t, "https://partners.example.org", "partners-key")
+
+	config := fmt.Sprintf(`issuers:
+  - issuer: %q
+    jwks_uri: %q
+    username_claim: "employee_id"
+    email_claim: "mail"
+    display_name_claim: "display_name"
+  - issuer: %q
+    jwks_uri: %q
+    username_claim: "preferred_username"
+    audience: ["licensedb"]
+    client_to_user_mapper_claim: "client_id"
+`, employees.issuer, employees.server.URL, partners.issuer, partners.server.URL)
+	useOidcIssuers(t, config)
+
+	usernames := []string{"oidc-employee", "oidc-partner", "oidc-local", "oidc-legacy"}
+	db.DB.Unscoped().Where("user_name IN ?", usernames).Delete(&models.User{})
+	partner := models.User{
+		UserName:        ptr("oidc-partner"),
+		DisplayName:     ptr("oidc-partner"),
+		UserEmail:       ptr("oidc-partner@example.org"),
+		UserLevel:       ptr("USER"),
+		ExternalIssuer:  ptr(partners.issuer),
+		ExternalSubject: ptr("partner-subject"),
+	}
+	if err := db.DB.Create(&partner).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	local := models.User{
+		UserName:     ptr("oidc-local"),
+		DisplayName:  ptr("oidc-local"),
+		UserEmail:    ptr("oidc-local@example.com"),
+		UserLevel:    ptr("ADMIN"),
+		UserPassword: ptr("local-password-hash"),
+	}
+	if err := db.DB.Create(&local).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	// A user created by an oidc token before users were bound to their identity
+	legacy := models.User{
+		UserName:    ptr("oidc-legacy"),
+		DisplayName: ptr("oidc-legacy"),
+		UserEmail:   ptr("oidc-legacy@example.com"),
+		UserLevel:   ptr("USER"),
+	}
+	if err := db.DB.Create(&legacy).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	t.Cleanup(func() {
+		db.DB.Where(&models.OidcClient{UserId: partner.Id}).Delete(&models.OidcClient{})
+		db.DB.Unscoped().Where("user_name IN ?", usernames).Delete(&models.User{})
+	})
+
+	profile := func(token string) int {
+		previous := AuthToken
+		AuthToken = token
+		defer func() { AuthToken = previous }()
+		return makeRequest("GET", "/users/profile", nil, true).Code
+	}
+
+	t.Run("create user with token of first issuer", func(t *testing.T) {
+		token := employees.token(t, employees.issuer, map[string]interface{}{
+			"sub":          "employee-subject",
+			"employee_id":  "oidc-employee",
+			"mail":         "oidc-employee@example.com",
+			"display_name": "oidc-employee",
+		})
+		previous := AuthToken
+		AuthToken = token
+		w := makeRequest("POST", "/users/oidc", nil, true)
+		AuthToken = previous
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		assert.Equal(t, http.StatusOK, profile(token))
+	})
+
+	t.Run("token of second issuer with audience", func(t *testing.T) {
+		token := partners.token(t, partners.issuer, map[string]interface{}{
+			"sub":                "partner-subject",
+			"preferred_username": "oidc-partner",
+			"aud":                []string{"licensedb"},
+		})
+		assert.Equal(t, http.StatusOK, profile(token))
+	})
+
+	t.Run("token of second issuer with wrong audience", func(t *testing.T) {
+		token := partners.token(t, partners.issuer, map[string]interface{}{
+			"sub":                "partner-subject",
+			"preferred_username": "oidc-partner",
+			"aud":                []string{"another-service"},
+		})
+		assert.Equal(t, http.StatusUnauthorized, profile(token))
+	})
+
+	t.Run("same username of another issuer", func(t *testing.T) {
+		token := partners.token(t, partners.issuer, map[string]interface{}{
+			"sub":                "employee-subject",
+			"preferred_username": "oidc-employee",
+			"aud":                []string{"licensedb"},
+		})
+		assert.Equal(t, http.StatusUnauthorized, profile(token))
+	})
+
+	t.Run("username of local account", func(t *testing.T) {
+		token := partners.token(t, partners.issuer, map[string]interface{}{
+			"sub":                "local-subject",
+			"preferred_username": "oidc-local",
+			"aud":                []string{"licensedb"},
+		})
+		assert.Equal(t, http.StatusUnauthorized, profile(token))
+
+		token = employees.token(t, employees.issuer, map[string]interface{}{
+			"sub":          "local-subject",
+			"employee_id":  "oidc-local",
+			"mail":         "oidc-local@example.org",
+			"display_name": "oidc-local",
+		})
+		previous := AuthToken
+		AuthToken = token
+		w := makeRequest("POST", "/users/oidc", nil, true)
+		AuthToken = previous
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, http.StatusUnauthorized, profile(token))
+	})
+
+	t.Run("user created before the binding logs in", func(t *testing.T) {
+		token := partners.token(t, partners.issuer, map[string]interface{}{
+			"sub":                "legacy-subject",
+			"preferred_username": "oidc-legacy",
+			"aud":                []string{"licensedb"},
+		})
+		assert.Equal(t, http.StatusOK, profile(token))
+
+		if err := db.DB.First(&legacy, "id = ?", legacy.Id).Error; err != nil {
+			t.Fatalf("Failed to find user: %v", err)
+		}
+		assert.Equal(t, partners.issuer, *legacy.ExternalIssuer)
+		assert.Equal(t, "legacy-subject", *legacy.ExternalSubject)
+		assert.Equal(t, http.StatusOK, profile(token))
+
+		// Once bound, other identities with the same username are rejected
+		token = partners.token(t, partners.issuer, map[string]interface{}{
+			"sub":                "another-subject",
+			"preferred_username": "oidc-legacy",
+			"aud":                []string{"licensedb"},
+		})
+		assert.Equal(t, http.StatusUnauthorized, profile(token))
+		token = employees.token(t, employees.issuer, map[string]interface{}{
+			"sub":          "legacy-subject",
+			"employee_id":  "oidc-legacy",
+			"mail":         "oidc-legacy@example.com",
+			"display_name": "oidc-legacy",
+		})
+		assert.Equal(t, http.StatusUnauthorized, profile(token))
+	})
+
+	t.Run("token without sub claim", func(t *testing.T) {
+		token := partners.token(t, partners.issuer, map[string]interface{}{
+			"preferred_username": "oidc-partner",
+			"aud":                []string{"licensedb"},
+		})
+		assert.Equal(t, http.StatusUnauthorized, profile(token))
+	})
+
+	t.Run("client credentials token of second issuer", func(t *testing.T) {
+		if err := db.DB.Create(&models.OidcClient{ClientId: "partner-pipeline", UserId: partner.Id}).Error; err != nil {
+			t.Fatalf("Failed to create oidc client: %v", err)
+		}
+		token := partners.token(t, partners.issuer, map[string]interface{}{
+			"client_id": "partner-pipeline",
+			"aud":       "licensedb",
+		})
+		assert.Equal(t, http.StatusOK, profile(token))
+	})
+
+	t.Run("token signed by key of another issuer", func(t *testing.T) {
+		token := employees.token(t, partners.issuer, map[string]interface{}{
+			"sub":                "partner-subject",
+			"preferred_username": "oidc-partner",
+			"aud":                []string{"licensedb"},
+		})
+		assert.Equal(t, http.StatusUnauthorized, profile(token))
+	})
+
+	t.Run("unknown issuer", func(t *testing.T) {
+		token := employees.token(t, "https://unknown.example.net", map[string]interface{}{
+			"employee_id": "oidc-employee",
+		})
+		assert.Equal(t, http.StatusUnauthorized, profile(token))
+	})
+}
+
+func TestOidcRoleMapping(t *testing.T) {
+	provider := newTestOidcProvider(t, "https://login.example.com", "employees-key")
+	useOidcIssuers(t, fmt.Sprintf(`issuers:
+  - issuer: %q
+    jwks_uri: %q
+    username_claim: "preferred_username"
+    email_claim: "email"
+    display_name_claim: "name"
+    roles_claim: "realm_access.roles"
+    role_mapping:
+      ADMIN: ["licensedb-admins"]
+      USER: ["employees"]
+    provision_users: true
+`, provider.issuer, provider.server.URL))
+
+	usernames := []string{"oidc-jit", "oidc-jit-local"}
+	db.DB.Unscoped().Where("user_name IN ?", usernames).Delete(&models.User{})
+	t.Cleanup(func() {
+		db.DB.Unscoped().Where("user_name IN ?", usernames).Delete(&models.User{})
+	})
+
+	tokenWithRoles := func(roles ...string) string {
+		return provider.token(t, provider.issuer, map[string]interface{}{
+			"sub":                "jit-subject",
+			"preferred_username": "oidc-jit",
+			"email":              "oidc-jit@example.com",
+			"name":               "OIDC JIT",
+			"realm_access":       map[string]interface{}{"roles": roles},
+		})
+	}
+	profile := func(token string) (int, models.UserResponse) {
+		previous := AuthToken
+		AuthToken = token
+		defer func() { AuthToken = previous }()
+		w := makeRequest("GET", "/users/profile", nil, true)
+		var res models.UserResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &res)
+		return w.Code, res
+	}
+
+	t.Run("user is provisioned on first request", func(t *testing.T) {
+		code, res := profile(tokenWithRoles("employees"))
+		assert.Equal(t, http.StatusOK, code)
+		if assert.Len(t, res.Data, 1) {
+			assert.Equal(t, "oidc-jit", *res.Data[0].UserName)
+			assert.Equal(t, "OIDC JIT", *res.Data[0].DisplayName)
+			assert.Equal(t, "USER", *res.Data[0].UserLevel)
+		}
+	})
+
+	t.Run("role is re-synced on group change", func(t *testing.T) {
+		code, res := profile(tokenWithRoles("employees", "licensedb-admins"))
+		assert.Equal(t, http.StatusOK, code)
+		if assert.Len(t, res.Data, 1) {
+			assert.Equal(t, "ADMIN", *res.Data[0].UserLevel)
+		}
+
+		code, res = profile(tokenWithRoles("employees"))
+		assert.Equal(t, http.StatusOK, code)
+		if assert.Len(t, res.Data, 1) {
+			assert.Equal(t, "USER", *res.Data[0].UserLevel)
+		}
+	})
+
+	t.Run("no mapped group", func(t *testing.T) {
+		code, _ := profile(tokenWithRoles("contractors"))
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("deactivated user is not re-synced", func(t *testing.T) {
+		var user models.User
+		if err := db.DB.Where(&models.User{UserName: ptr("oidc-jit")}).First(&user).Error; err != nil {
+			t.Fatalf("Failed to find provisioned user: %v", err)
+		}
+		if err := db.DB.Model(&user).Update("active", false).Error; err != nil {
+			t.Fatalf("Failed to deactivate user: %v", err)
+		}
+
+		code, _ := profile(tokenWithRoles("employees", "licensedb-admins"))
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		if err := db.DB.First(&user, "id = ?", user.Id).Error; err != nil {
+			t.Fatalf("Failed to find provisioned user: %v", err)
+		}
+		assert.Equal(t, "USER", *user.UserLevel)
+	})
+
+	t.Run("provisioning does not adopt a local account", func(t *testing.T) {
+		local := models.User{
+			UserName:     ptr("oidc-jit-local"),
+			DisplayName:  ptr("oidc-jit-local"),
+			UserEmail:    ptr("oidc-jit-local@example.com"),
+			UserLevel:    ptr("USER"),
+			UserPassword: ptr("local-password-hash"),
+		}
+		if err := db.DB.Create(&local).Error; err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+
+		token := provider.token(t, provider.issuer, map[string]interface{}{
+			"sub":                "jit-local-subject",
+			"preferred_username": "oidc-jit-local",
+			"email":              "oidc-jit-local@example.org",
+			"realm_access":       map[string]interface{}{"roles": []string{"licensedb-admins"}},
+		})
+		code, _ := profile(token)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		if err := db.DB.First(&local, "id = ?", local.Id).Error; err != nil {
+			t.Fatalf("Failed to find local user: %v", err)
+		}
+		assert.Equal(t, "USER", *local.UserLevel)
+		assert.Nil(t, local.ExternalSubject)
+	})
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestCursorPagination(t *testing.T) {
+	loginAs(t, "admin")
+
+	listLicenses := func(t *testing.T, query string) models.LicenseResponse {
+		w := makeRequest("GET", "/licenses?"+query, nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.LicenseResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		return res
+	}
+
+	t.Run("licenses", func(t *testing.T) {
+		all := listLicenses(t, "limit=1000&sort_by=fullname&order_by=desc")
+
+		var ids []uuid.UUID
+		res := listLicenses(t, "cursor=&limit=2&sort_by=fullname&order_by=desc")
+		for {
+			for _, l := range res.Data {
+				ids = append(ids, l.Id)
+			}
+			assert.Equal(t, all.Meta.ResourceCount, res.Meta.ResourceCount)
+			if res.Meta.NextCursor == "" || len(ids) > len(all.Data) {
+				break
+			}
+			res = listLicenses(t, "cursor="+url.QueryEscape(res.Meta.NextCursor)+"&limit=2&sort_by=fullname&order_by=desc")
+		}
+
+		var allIds []uuid.UUID
+		for _, l := range all.Data {
+			allIds = append(allIds, l.Id)
+		}
+		assert.Equal(t, allIds, ids)
+	})
+
+	t.Run("next link", func(t *testing.T) {
+		res := listLicenses(t, "cursor=&limit=1")
+		if assert.NotEmpty(t, res.Meta.Next) {
+			next, err := url.Parse(res.Meta.Next)
+			assert.NoError(t, err)
+			assert.Equal(t, res.Meta.NextCursor, next.Query().Get("cursor"))
+			assert.Empty(t, res.Meta.Previous)
+			assert.Zero(t, res.Meta.Page)
+		}
+	})
+
+	t.Run("page compatibility", func(t *testing.T) {
+		res := listLicenses(t, "page=2&limit=1")
+		assert.Equal(t, int64(2), res.Meta.Page)
+		assert.Equal(t, int64(1), res.Meta.Limit)
+		assert.Equal(t, int64(res.Meta.ResourceCount), res.Meta.TotalPages)
+		assert.NotEmpty(t, res.Meta.Previous)
+		assert.Empty(t, res.Meta.NextCursor)
+	})
+
+	t.Run("obligations audits and users", func(t *testing.T) {
+		for _, path := range []string{"/obligations?limit=1", "/audits?limit=1", "/users?active=true&limit=1"} {
+			w := makeRequest("GET", path+"&cursor=", nil, true)
+			assert.Equal(t, http.StatusOK, w.Code, path)
+			var res struct {
+				Data []json.RawMessage     `json:"data"`
+				Meta models.PaginationMeta `json:"paginationmeta"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatalf("Error unmarshalling JSON: %v", err)
+			}
+			if assert.Len(t, res.Data, 1, path) && assert.NotEmpty(t, res.Meta.NextCursor, path) {
+				w = makeRequest("GET", path+"&cursor="+url.QueryEscape(res.Meta.NextCursor), nil, true)
+				assert.Equal(t, http.StatusOK, w.Code, path)
+				var next struct {
+					Data []json.RawMessage `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &next))
+				if assert.NotEmpty(t, next.Data, path) {
+					assert.NotEqual(t, string(res.Data[0]), string(next.Data[0]), path)
+				}
+			}
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		w := makeRequest("GET", "/licenses?cursor=not-a-cursor", nil, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		res := listLicenses(t, "cursor=&limit=1&sort_by=spdx_id")
+		if assert.NotEmpty(t, res.Meta.NextCursor) {
+			w = makeRequest("GET", "/licenses?limit=1&sort_by=fullname&cursor="+url.QueryEscape(res.Meta.NextCursor), nil, true)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("cursor not supported", func(t *testing.T) {
+		w := makeRequest("GET", "/teams?cursor=", nil, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
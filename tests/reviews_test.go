@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestReviews(t *testing.T) {
+	loginAs(t, "admin")
+
+	var license models.LicenseDB
+	if err := db.DB.Where(&models.LicenseDB{Active: ptr(true)}).First(&license).Error; err != nil {
+		t.Fatalf("Failed to fetch license: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.DB.Model(&models.LicenseDB{}).Where(&models.LicenseDB{Id: license.Id}).UpdateColumns(map[string]interface{}{
+			"review_interval_days": nil, "last_reviewed_at": nil, "review_reminded_at": nil,
+		})
+	})
+
+	overdue := func(t *testing.T) bool {
+		w := makeRequest("GET", "/dashboard/overdue-reviews", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.OverdueReviewResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		for _, item := range res.Data {
+			if item.Type == "LICENSE" && item.Id == license.Id {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("never reviewed license is overdue", func(t *testing.T) {
+		w := makeRequest("PATCH", "/licenses/"+license.Id.String(), models.LicenseUpdateDTO{ReviewIntervalDays: ptr(int64(30))}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, overdue(t))
+
+		w = makeRequest("GET", "/dashboard", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.DashboardResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.NotZero(t, res.Data.OverdueReviewsCount)
+	})
+
+	t.Run("review license", func(t *testing.T) {
+		w := makeRequest("POST", "/licenses/"+license.Id.String()+"/review", models.ReviewInput{Comment: ptr("Text checked")}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.LicenseResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.NotNil(t, res.Data[0].LastReviewedAt)
+		assert.NotNil(t, res.Data[0].ReviewDueAt)
+		assert.False(t, overdue(t))
+
+		var comments int64
+		db.DB.Model(&models.ChangeLog{}).
+			Where("audit_id IN (?)", db.DB.Model(&models.Audit{}).Select("id").Where(&models.Audit{Type: "LICENSE", TypeId: license.Id})).
+			Where(&models.ChangeLog{Field: "Review Comment", UpdatedValue: ptr("Text checked")}).
+			Count(&comments)
+		assert.Equal(t, int64(1), comments)
+	})
+
+	t.Run("review unknown license", func(t *testing.T) {
+		w := makeRequest("POST", "/licenses/00000000-0000-0000-0000-000000000000/review", nil, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/api"
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestScan(t *testing.T) {
+	var license models.LicenseDB
+	if err := db.DB.Where(&models.LicenseDB{Shortname: ptr("MIT")}).First(&license).Error; err != nil {
+		t.Fatalf("Failed to fetch license: %v", err)
+	}
+
+	upload := func(t *testing.T, name string, contents []byte) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", name)
+		assert.NoError(t, err)
+		_, err = part.Write(contents)
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+
+		req := httptest.NewRequest("POST", baseURL+"/scan", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+AuthToken)
+		w := httptest.NewRecorder()
+		api.Router().ServeHTTP(w, req)
+		return w
+	}
+
+	finishedScan := func(t *testing.T, w *httptest.ResponseRecorder) models.ScanDTO {
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var res models.ScanResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, "/api/v1/scan/"+res.Data.Id.String(), w.Header().Get("Location"))
+
+		for deadline := time.Now().Add(time.Minute); time.Now().Before(deadline); time.Sleep(100 * time.Millisecond) {
+			w := makeRequest("GET", "/scan/"+res.Data.Id.String(), nil, true)
+			assert.Equal(t, http.StatusOK, w.Code)
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatalf("Error unmarshalling JSON: %v", err)
+			}
+			if res.Data.Status == models.ScanCompleted || res.Data.Status == models.ScanFailed {
+				return res.Data
+			}
+		}
+		t.Fatalf("scan %s did not finish", res.Data.Id)
+		return res.Data
+	}
+
+	t.Run("scanTarball", func(t *testing.T) {
+		var archive bytes.Buffer
+		gz := gzip.NewWriter(&archive)
+		tw := tar.NewWriter(gz)
+		files := map[string]string{
+			"project/LICENSE":    "Copyright (c) 2026 Jane Doe\n\n" + *license.Text,
+			"project/src/main.c": "// SPDX-License-Identifier: MIT OR LicenseRef-Scan-Test\nint main(void) { return 0; }\n",
+			"project/logo.png":   "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR",
+		}
+		for name, contents := range files {
+			assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents))}))
+			_, err := tw.Write([]byte(contents))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, tw.Close())
+		assert.NoError(t, gz.Close())
+
+		scan := finishedScan(t, upload(t, "project.tar.gz", archive.Bytes()))
+		assert.Equal(t, models.ScanCompleted, scan.Status)
+		if !assert.NotNil(t, scan.Result) {
+			return
+		}
+		assert.Equal(t, 2, scan.Result.FilesScanned)
+		assert.Equal(t, 1, scan.Result.FilesSkipped)
+		assert.Equal(t, []string{"LicenseRef-Scan-Test"}, scan.Result.UnknownIds)
+
+		var mit *models.ScanLicense
+		for i := range scan.Result.Licenses {
+			if scan.Result.Licenses[i].Id == license.Id {
+				mit = &scan.Result.Licenses[i]
+			}
+		}
+		if assert.NotNil(t, mit) {
+			assert.Equal(t, 2, mit.Files)
+			assert.NotNil(t, mit.Obligations)
+		}
+
+		for _, file := range scan.Result.Files {
+			switch file.Path {
+			case "project/LICENSE":
+				found := false
+				for _, exact := range file.ExactMatches {
+					found = found || exact.Id == license.Id
+				}
+				assert.True(t, found)
+			case "project/src/main.c":
+				if assert.Len(t, file.Identifiers, 1) {
+					assert.Equal(t, 1, file.Identifiers[0].Line)
+					assert.Equal(t, []string{"LicenseRef-Scan-Test"}, file.Identifiers[0].UnknownIds)
+					if assert.Len(t, file.Identifiers[0].Licenses, 1) {
+						assert.Equal(t, license.Id, file.Identifiers[0].Licenses[0].Id)
+					}
+				}
+			default:
+				t.Errorf("unexpected finding in %s", file.Path)
+			}
+		}
+	})
+
+	t.Run("invalidArchiveFails", func(t *testing.T) {
+		scan := finishedScan(t, upload(t, "broken.zip", []byte("not a zip file")))
+		assert.Equal(t, models.ScanFailed, scan.Status)
+		assert.NotNil(t, scan.Error)
+		assert.Nil(t, scan.Result)
+	})
+
+	t.Run("extractedSizeIsLimited", func(t *testing.T) {
+		t.Setenv("SCAN_MAX_EXTRACTED_MB", "1")
+		assert.NoError(t, api.StartScans())
+		t.Cleanup(func() {
+			_ = os.Unsetenv("SCAN_MAX_EXTRACTED_MB")
+			_ = api.StartScans()
+		})
+
+		// Each file is read at most partially, but their total is limited
+		var archive bytes.Buffer
+		gz := gzip.NewWriter(&archive)
+		tw := tar.NewWriter(gz)
+		zeros := make([]byte, 512<<10)
+		for _, name := range []string{"a.bin", "b.bin", "c.bin"} {
+			assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(zeros))}))
+			_, err := tw.Write(zeros)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, tw.Close())
+		assert.NoError(t, gz.Close())
+
+		scan := finishedScan(t, upload(t, "zeros.tar.gz", archive.Bytes()))
+		assert.Equal(t, models.ScanFailed, scan.Status)
+		if assert.NotNil(t, scan.Error) {
+			assert.Contains(t, *scan.Error, "too large")
+		}
+	})
+
+	t.Run("invalidMinCoverage", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "main.c")
+		assert.NoError(t, err)
+		_, err = part.Write([]byte("int main(void) { return 0; }\n"))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.WriteField("min_coverage", "120"))
+		assert.NoError(t, writer.Close())
+
+		req := httptest.NewRequest("POST", baseURL+"/scan", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+AuthToken)
+		w := httptest.NewRecorder()
+		api.Router().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("scanWithoutFile", func(t *testing.T) {
+		w := makeRequest("POST", "/scan", nil, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("scanWithoutAuth", func(t *testing.T) {
+		w := makeRequest("POST", "/scan", nil, false)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("unknownScan", func(t *testing.T) {
+		w := makeRequest("GET", "/scan/"+uuid.New().String(), nil, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
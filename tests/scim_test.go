@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScimUsers(t *testing.T) {
+	t.Setenv("SCIM_BEARER_TOKEN", "scim-test-token")
+
+	db.DB.Unscoped().Where("user_name IN ?", []string{"scim-jdoe", "scim-local"}).Delete(&models.User{})
+	t.Cleanup(func() {
+		db.DB.Unscoped().Where("user_name IN ?", []string{"scim-jdoe", "scim-local"}).Delete(&models.User{})
+	})
+
+	scimRequest := func(method, path string, body interface{}, token string) *http.Response {
+		previous := AuthToken
+		AuthToken = token
+		defer func() { AuthToken = previous }()
+		return makeRequest(method, "/scim/v2/Users"+path, body, true).Result()
+	}
+	decode := func(t *testing.T, res *http.Response, v interface{}) {
+		if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+	}
+
+	t.Run("wrong token", func(t *testing.T) {
+		res := scimRequest("GET", "", nil, "wrong-token")
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	var created models.ScimUser
+	t.Run("create", func(t *testing.T) {
+		user := models.ScimUser{
+			Schemas:    []string{models.ScimUserSchema},
+			ExternalId: "00u1",
+			UserName:   "scim-jdoe",
+			Name:       &models.ScimName{GivenName: "Jane", FamilyName: "Doe"},
+			Emails:     []models.ScimMultiValuedAttribute{{Value: "scim-jdoe@example.com", Primary: true}},
+			Active:     ptr(true),
+		}
+		res := scimRequest("POST", "", user, "scim-test-token")
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		assert.Equal(t, "application/scim+json", res.Header.Get("Content-Type"))
+
+		decode(t, res, &created)
+		assert.NotEmpty(t, created.Id)
+		assert.Equal(t, "Jane Doe", created.DisplayName)
+		if assert.Len(t, created.Roles, 1) {
+			assert.Equal(t, "USER", created.Roles[0].Value)
+		}
+
+		res = scimRequest("POST", "", user, "scim-test-token")
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("filter by userName", func(t *testing.T) {
+		filter := url.QueryEscape(`userName eq "SCIM-JDOE"`)
+		res := scimRequest("GET", "?filter="+filter, nil, "scim-test-token")
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		var list models.ScimListResponse
+		decode(t, res, &list)
+		assert.Equal(t, int64(1), list.TotalResults)
+		if assert.Len(t, list.Resources, 1) {
+			assert.Equal(t, created.Id, list.Resources[0].Id)
+		}
+
+		res = scimRequest("GET", "?filter="+url.QueryEscape(`title pr`), nil, "scim-test-token")
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		user := created
+		user.DisplayName = "Jane A. Doe"
+		user.Roles = []models.ScimMultiValuedAttribute{{Value: "ADMIN"}}
+		res := scimRequest("PUT", "/"+created.Id, user, "scim-test-token")
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		var replaced models.ScimUser
+		decode(t, res, &replaced)
+		assert.Equal(t, "Jane A. Doe", replaced.DisplayName)
+		if assert.Len(t, replaced.Roles, 1) {
+			assert.Equal(t, "ADMIN", replaced.Roles[0].Value)
+		}
+	})
+
+	t.Run("patch with unsupported role", func(t *testing.T) {
+		patch := models.ScimPatchRequest{
+			Schemas:    []string{models.ScimPatchOpSchema},
+			Operations: []models.ScimPatchOperation{{Op: "replace", Path: "roles", Value: "SUPER_ADMIN"}},
+		}
+		res := scimRequest("PATCH", "/"+created.Id, patch, "scim-test-token")
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("deactivation rejects the user immediately", func(t *testing.T) {
+		loginAs(t, "admin")
+		user := models.UserCreate{
+			UserName:     ptr("scim-local"),
+			UserPassword: ptr("abc123"),
+			UserLevel:    ptr("USER"),
+			DisplayName:  ptr("scim-local"),
+			UserEmail:    ptr("scim-local@example.com"),
+		}
+		w := makeRequest("POST", "/users", user, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		w = makeRequest("POST", "/login", models.UserLogin{Username: "scim-local", Userpassword: "abc123"}, false)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var tokens models.TokenResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &tokens); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+
+		res := scimRequest("GET", "?filter="+url.QueryEscape(`userName eq "scim-local"`), nil, "scim-test-token")
+		var list models.ScimListResponse
+		decode(t, res, &list)
+		if !assert.Len(t, list.Resources, 1) {
+			return
+		}
+
+		patch := models.ScimPatchRequest{
+			Schemas:    []string{models.ScimPatchOpSchema},
+			Operations: []models.ScimPatchOperation{{Op: "Replace", Value: map[string]interface{}{"active": "False"}}},
+		}
+		res = scimRequest("PATCH", "/"+list.Resources[0].Id, patch, "scim-test-token")
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		previous := AuthToken
+		AuthToken = tokens.Data.AccessToken
+		w = makeRequest("GET", "/users/profile", nil, true)
+		AuthToken = previous
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("delete deactivates", func(t *testing.T) {
+		res := scimRequest("DELETE", "/"+created.Id, nil, "scim-test-token")
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+		res = scimRequest("GET", "/"+created.Id, nil, "scim-test-token")
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var user models.ScimUser
+		decode(t, res, &user)
+		if assert.NotNil(t, user.Active) {
+			assert.False(t, *user.Active)
+		}
+	})
+
+	t.Run("changes are audited as the scim actor", func(t *testing.T) {
+		var audits []models.Audit
+		if err := db.DB.Where(&models.Audit{Type: "USER", TypeId: uuid.MustParse(created.Id)}).Find(&audits).Error; err != nil {
+			t.Fatalf("Failed to fetch audits: %v", err)
+		}
+		// Creation, replacement and deactivation
+		assert.GreaterOrEqual(t, len(audits), 3)
+		for _, audit := range audits {
+			assert.Equal(t, models.ScimActorId, audit.UserId)
+		}
+
+		res := scimRequest("GET", "/"+models.ScimActorId.String(), nil, "scim-test-token")
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
+
+func TestScimUsersOidcLogin(t *testing.T) {
+	t.Setenv("SCIM_BEARER_TOKEN", "scim-test-token")
+	provider := newTestOidcProvider(t, "https://login.example.com", "employees-key")
+	useOidcIssuers(t, fmt.Sprintf(`issuers:
+  - issuer: %q
+    jwks_uri: %q
+    username_claim: "preferred_username"
+`, provider.issuer, provider.server.URL))
+
+	usernames := []string{"scim-oidc-mapped", "scim-oidc-bound"}
+	db.DB.Unscoped().Where("user_name IN ?", usernames).Delete(&models.User{})
+	t.Cleanup(func() {
+		db.DB.Unscoped().Where("user_name IN ?", usernames).Delete(&models.User{})
+	})
+
+	createScimUser := func(t *testing.T, userName, externalId string) {
+		previous := AuthToken
+		AuthToken = "scim-test-token"
+		defer func() { AuthToken = previous }()
+		w := makeRequest("POST", "/scim/v2/Users", models.ScimUser{
+			Schemas:    []string{models.ScimUserSchema},
+			ExternalId: externalId,
+			UserName:   userName,
+			Emails:     []models.ScimMultiValuedAttribute{{Value: userName + "@example.com", Primary: true}},
+		}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+	profile := func(t *testing.T, claims map[string]interface{}) (int, models.UserResponse) {
+		previous := AuthToken
+		AuthToken = provider.token(t, provider.issuer, claims)
+		defer func() { AuthToken = previous }()
+		w := makeRequest("GET", "/users/profile", nil, true)
+		var res models.UserResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &res)
+		return w.Code, res
+	}
+
+	t.Run("externalId is the subject of the configured issuer", func(t *testing.T) {
+		t.Setenv("SCIM_OIDC_ISSUER", provider.issuer)
+		createScimUser(t, "scim-oidc-mapped", "00u-mapped")
+
+		code, res := profile(t, map[string]interface{}{"sub": "00u-mapped", "preferred_username": "renamed-at-idp"})
+		assert.Equal(t, http.StatusOK, code)
+		if assert.Len(t, res.Data, 1) {
+			assert.Equal(t, "scim-oidc-mapped", *res.Data[0].UserName)
+		}
+
+		code, _ = profile(t, map[string]interface{}{"sub": "another-subject", "preferred_username": "scim-oidc-mapped"})
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("bound on first login without configured issuer", func(t *testing.T) {
+		createScimUser(t, "scim-oidc-bound", "00u-bound")
+
+		code, res := profile(t, map[string]interface{}{"sub": "bound-subject", "preferred_username": "scim-oidc-bound"})
+		assert.Equal(t, http.StatusOK, code)
+		if assert.Len(t, res.Data, 1) {
+			assert.Equal(t, "scim-oidc-bound", *res.Data[0].UserName)
+		}
+
+		code, _ = profile(t, map[string]interface{}{"sub": "bound-subject", "preferred_username": "renamed-at-idp"})
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
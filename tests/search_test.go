@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestSearch(t *testing.T) {
+	search := func(t *testing.T, query string) models.SearchResponse {
+		w := makeRequest("GET", "/search?"+query, nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.SearchResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		return res
+	}
+
+	t.Run("license names rank first", func(t *testing.T) {
+		res := search(t, "q=MIT")
+		if assert.NotEmpty(t, res.Data) {
+			assert.Equal(t, "LICENSE", res.Data[0].Type)
+			assert.Contains(t, res.Data[0].Name, "MIT")
+		}
+		for i := 1; i < len(res.Data); i++ {
+			assert.GreaterOrEqual(t, res.Data[i-1].Rank, res.Data[i].Rank)
+		}
+		if assert.NotEmpty(t, res.Facets.Types) {
+			assert.Equal(t, "LICENSE", res.Facets.Types[0].Value)
+		}
+	})
+
+	t.Run("paginate and filter", func(t *testing.T) {
+		res := search(t, "q=license&limit=2")
+		assert.LessOrEqual(t, len(res.Data), 2)
+		assert.Equal(t, int64(2), res.Meta.Limit)
+
+		res = search(t, "q=license&type=OBLIGATION")
+		for _, result := range res.Data {
+			assert.Equal(t, "OBLIGATION", result.Type)
+		}
+	})
+
+	t.Run("snippets escape the text", func(t *testing.T) {
+		w := makeRequest("POST", "/licenses?force=true", models.LicenseCreateDTO{
+			Shortname: "Search-Escape-Test-1.0",
+			Fullname:  "Search Escape Test License 1.0",
+			Text:      `Permission is granted to use this escapetest license <script>alert("escapetest")</script> as-is & "quoted".`,
+			SpdxId:    "LicenseRef-Search-Escape-Test-1.0",
+			Risk:      ptr(int64(1)),
+		}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		res := search(t, "q=escapetest&type=LICENSE")
+		if assert.Len(t, res.Data, 1) {
+			snippet := res.Data[0].Snippet
+			assert.NotContains(t, snippet, "<script>")
+			assert.Contains(t, snippet, "&lt;script&gt;")
+			assert.Contains(t, snippet, "<mark>escapetest</mark>")
+			text := strings.NewReplacer("<mark>", "", "</mark>", "").Replace(snippet)
+			assert.NotContains(t, text, "<")
+			assert.NotContains(t, text, `"`)
+		}
+	})
+
+	t.Run("invalid search", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, makeRequest("GET", "/search?q=", nil, true).Code)
+		assert.Equal(t, http.StatusBadRequest, makeRequest("GET", "/search?q=MIT&type=USER", nil, true).Code)
+		assert.Equal(t, http.StatusBadRequest, makeRequest("GET", "/search?q=MIT&risk=high", nil, true).Code)
+	})
+}
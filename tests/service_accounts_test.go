@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceAccounts(t *testing.T) {
+	pipelines := newTestOidcProvider(t, "https://ci.example.com", "ci-key")
+	useOidcIssuers(t, fmt.Sprintf(`issuers:
+  - issuer: %q
+    jwks_uri: %q
+    client_to_user_mapper_claim: "client_id"
+`, pipelines.issuer, pipelines.server.URL))
+
+	loginAs(t, "admin")
+	adminToken := AuthToken
+
+	teamNames := []string{"release-engineering", "platform"}
+	db.DB.Unscoped().Where("name IN ?", teamNames).Delete(&models.Team{})
+	for _, name := range teamNames {
+		if err := db.DB.Create(&models.Team{Name: name}).Error; err != nil {
+			t.Fatalf("Failed to create team: %v", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		var principal models.User
+		if err := db.DB.Where(&models.User{UserName: ptr("release-pipeline")}).First(&principal).Error; err == nil {
+			db.DB.Where(&models.OidcClient{UserId: principal.Id}).Delete(&models.OidcClient{})
+			audits := db.DB.Model(&models.Audit{}).Select("id").Where(&models.Audit{UserId: principal.Id})
+			db.DB.Where("audit_id IN (?)", audits).Delete(&models.ChangeLog{})
+			db.DB.Where(&models.Audit{UserId: principal.Id}).Delete(&models.Audit{})
+			db.DB.Where(&models.ServiceAccount{UserId: principal.Id}).Delete(&models.ServiceAccount{})
+			db.DB.Unscoped().Delete(&principal)
+		}
+		db.DB.Where(&models.ObligationType{Type: "AUTOMATION"}).Delete(&models.ObligationType{})
+		db.DB.Unscoped().Where("name IN ?", teamNames).Delete(&models.Team{})
+	})
+
+	asPipeline := func(method, path string, body interface{}) int {
+		token := pipelines.token(t, pipelines.issuer, map[string]interface{}{
+			"client_id": "release-pipeline-ci",
+		})
+		AuthToken = token
+		defer func() { AuthToken = adminToken }()
+		return makeRequest(method, path, body, true).Code
+	}
+
+	var serviceAccount models.ServiceAccountDTO
+	t.Run("create service account", func(t *testing.T) {
+		w := makeRequest("POST", "/service-accounts", models.ServiceAccountCreate{
+			Name:        "release-pipeline",
+			Description: ptr("Imports licenses on every release"),
+			UserLevel:   "ADMIN",
+			OwnerTeam:   "release-engineering",
+		}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var res models.ServiceAccountResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		serviceAccount = res.Data[0]
+		assert.Equal(t, "release-pipeline", serviceAccount.Name)
+		assert.Equal(t, "release-engineering", serviceAccount.OwnerTeam)
+		assert.True(t, serviceAccount.Active)
+	})
+
+	t.Run("owner team is required", func(t *testing.T) {
+		w := makeRequest("POST", "/service-accounts", models.ServiceAccountCreate{
+			Name:      "ownerless-pipeline",
+			UserLevel: "USER",
+		}, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = makeRequest("POST", "/service-accounts", models.ServiceAccountCreate{
+			Name:      "ownerless-pipeline",
+			UserLevel: "USER",
+			OwnerTeam: "no-such-team",
+		}, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		w := makeRequest("POST", "/service-accounts", models.ServiceAccountCreate{
+			Name:      "release-pipeline",
+			UserLevel: "USER",
+			OwnerTeam: "release-engineering",
+		}, true)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("hand over to another team", func(t *testing.T) {
+		w := makeRequest("PATCH", fmt.Sprintf("/service-accounts/%s", serviceAccount.Id),
+			models.ServiceAccountUpdate{OwnerTeam: ptr("platform")}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res models.ServiceAccountResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, "platform", res.Data[0].OwnerTeam)
+
+		w = makeRequest("DELETE", "/teams/platform", nil, true)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("list service accounts", func(t *testing.T) {
+		w := makeRequest("GET", "/service-accounts?limit=1", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res models.ServiceAccountResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Len(t, res.Data, 1)
+		assert.Equal(t, int64(1), res.Meta.Limit)
+		assert.GreaterOrEqual(t, res.Meta.ResourceCount, 1)
+	})
+
+	t.Run("map oidc client", func(t *testing.T) {
+		w := makeRequest("POST", fmt.Sprintf("/service-accounts/%s/clients", serviceAccount.Id),
+			models.ServiceAccountClient{ClientId: "release-pipeline-ci"}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var res models.ServiceAccountResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, []string{"release-pipeline-ci"}, res.Data[0].ClientIds)
+	})
+
+	t.Run("changes are audited as the service account", func(t *testing.T) {
+		code := asPipeline("POST", "/obligations/types", models.ObligationType{Type: "AUTOMATION", Active: ptr(true)})
+		assert.Equal(t, http.StatusCreated, code)
+		code = asPipeline("DELETE", "/obligations/types/AUTOMATION", nil)
+		assert.Equal(t, http.StatusOK, code)
+
+		w := makeRequest("GET", fmt.Sprintf("/service-accounts/%s/audits", serviceAccount.Id), nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.AuditResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		if assert.NotEmpty(t, res.Data) {
+			assert.Equal(t, serviceAccount.UserId, res.Data[0].UserId)
+			if assert.NotNil(t, res.Data[0].ServiceAccount) {
+				assert.Equal(t, serviceAccount.Id, res.Data[0].ServiceAccount.Id)
+			}
+		}
+
+		w = makeRequest("GET", "/audits?actor=service_account", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		for _, audit := range res.Data {
+			assert.NotNil(t, audit.ServiceAccount)
+		}
+	})
+
+	t.Run("expired service account is rejected", func(t *testing.T) {
+		expired := time.Now().Add(-time.Hour)
+		w := makeRequest("PATCH", fmt.Sprintf("/service-accounts/%s", serviceAccount.Id),
+			models.ServiceAccountUpdate{ExpiresAt: &expired}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		assert.Equal(t, http.StatusUnauthorized, asPipeline("GET", "/users/profile", nil))
+	})
+
+	t.Run("delete service account", func(t *testing.T) {
+		w := makeRequest("DELETE", fmt.Sprintf("/service-accounts/%s", serviceAccount.Id), nil, true)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		assert.Equal(t, http.StatusUnauthorized, asPipeline("GET", "/users/profile", nil))
+	})
+
+	t.Run("service accounts are admin only", func(t *testing.T) {
+		w := makeRequest("GET", "/service-accounts", nil, false)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fossology/LicenseDb/pkg/api"
+	"github.com/fossology/LicenseDb/pkg/auth"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jws"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeSigningKey stores a PKCS #8 PEM encoded private key as <kid>.pem.
+func writeSigningKey(t *testing.T, dir, kid string, key interface{}) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+}
+
+func TestAsymmetricTokenSigning(t *testing.T) {
+	keysDir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	writeSigningKey(t, keysDir, "2026-01", rsaKey)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	writeSigningKey(t, keysDir, "2026-02", edKey)
+
+	t.Setenv("TOKEN_SIGNING_KEYS_DIR", keysDir)
+	if err := auth.LoadSigningKeys(); err != nil {
+		t.Fatalf("Failed to load signing keys: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Unsetenv("TOKEN_SIGNING_KEYS_DIR")
+		_ = auth.LoadSigningKeys()
+	})
+
+	wellKnown := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/.well-known/"+path, nil)
+		w := httptest.NewRecorder()
+		api.Router().ServeHTTP(w, req)
+		return w
+	}
+	login := func() string {
+		w := makeRequest("POST", "/login", models.UserLogin{Username: "fossy_admin", Userpassword: "fossy"}, false)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var tokens models.TokenResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &tokens); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		return tokens.Data.AccessToken
+	}
+	profile := func(token string) int {
+		previous := AuthToken
+		AuthToken = token
+		defer func() { AuthToken = previous }()
+		return makeRequest("GET", "/users/profile", nil, true).Code
+	}
+
+	var keySet jwk.Set
+	t.Run("jwks lists public keys", func(t *testing.T) {
+		w := wellKnown("jwks.json")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		keySet, err = jwk.Parse(w.Body.Bytes())
+		if err != nil {
+			t.Fatalf("Failed to parse jwks: %v", err)
+		}
+		assert.Equal(t, 2, keySet.Len())
+		for i := 0; i < keySet.Len(); i++ {
+			key, _ := keySet.Key(i)
+			_, isRsaPrivate := key.(jwk.RSAPrivateKey)
+			_, isOkpPrivate := key.(jwk.OKPPrivateKey)
+			assert.False(t, isRsaPrivate || isOkpPrivate)
+		}
+	})
+
+	t.Run("only access tokens are signed with the published keys", func(t *testing.T) {
+		w := makeRequest("POST", "/login", models.UserLogin{Username: "fossy_admin", Userpassword: "fossy"}, false)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var tokens models.TokenResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &tokens); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+
+		message, err := jws.Parse([]byte(tokens.Data.AccessToken))
+		if err != nil {
+			t.Fatalf("Failed to parse access token: %v", err)
+		}
+		typ, _ := message.Signatures()[0].ProtectedHeaders().Type()
+		assert.Equal(t, "at+jwt", typ)
+		accessToken, err := jwt.Parse([]byte(tokens.Data.AccessToken), jwt.WithKeySet(keySet))
+		if assert.NoError(t, err) {
+			audience, _ := accessToken.Audience()
+			assert.Equal(t, []string{os.Getenv("DEFAULT_ISSUER")}, audience)
+		}
+
+		_, err = jwt.Parse([]byte(tokens.Data.RefreshToken), jwt.WithKeySet(keySet))
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, profile(tokens.Data.RefreshToken))
+
+		w = makeRequest("POST", "/refresh-token", models.RefreshToken{RefreshToken: tokens.Data.RefreshToken}, false)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("discovery document", func(t *testing.T) {
+		w := wellKnown("openid-configuration")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var config models.OpenIdConfiguration
+		if err := json.Unmarshal(w.Body.Bytes(), &config); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		issuer := os.Getenv("DEFAULT_ISSUER")
+		assert.Equal(t, issuer, config.Issuer)
+		assert.Equal(t, strings.TrimSuffix(issuer, "/")+"/.well-known/jwks.json", config.JwksUri)
+		assert.Equal(t, strings.TrimSuffix(issuer, "/")+"/api/v1/login", config.TokenEndpoint)
+		assert.ElementsMatch(t, []string{"RS256", "EdDSA"}, config.IdTokenSigningAlgValuesSupported)
+	})
+
+	var edToken string
+	t.Run("tokens are signed with the newest key", func(t *testing.T) {
+		edToken = login()
+		_, err := jwt.Parse([]byte(edToken), jwt.WithKeySet(keySet))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, profile(edToken))
+	})
+
+	t.Run("tokens of the previous key stay valid after rotation", func(t *testing.T) {
+		t.Setenv("TOKEN_SIGNING_KEY_ID", "2026-01")
+		if err := auth.LoadSigningKeys(); err != nil {
+			t.Fatalf("Failed to load signing keys: %v", err)
+		}
+
+		rsaToken := login()
+		assert.NotEqual(t, edToken, rsaToken)
+		assert.Equal(t, http.StatusOK, profile(rsaToken))
+		assert.Equal(t, http.StatusOK, profile(edToken))
+	})
+}
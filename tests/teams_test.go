@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestTeams(t *testing.T) {
+	loginAs(t, "admin")
+
+	var license models.LicenseDB
+	if err := db.DB.Where(&models.LicenseDB{Active: ptr(true)}).First(&license).Error; err != nil {
+		t.Fatalf("Failed to fetch license: %v", err)
+	}
+	var obligation models.Obligation
+	if err := db.DB.Where(&models.Obligation{Active: ptr(true)}).First(&obligation).Error; err != nil {
+		t.Fatalf("Failed to fetch obligation: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.DB.Model(&models.LicenseDB{}).Where(&models.LicenseDB{Id: license.Id}).UpdateColumn("steward_team_id", nil)
+		db.DB.Model(&models.Obligation{}).Where(&models.Obligation{Id: obligation.Id}).UpdateColumn("steward_team_id", nil)
+		teams := db.DB.Unscoped().Model(&models.Team{}).Select("id").Where(&models.Team{Name: "copyleft-reviewers"})
+		audits := db.DB.Model(&models.Audit{}).Select("id").Where("type = ? AND type_id IN (?)", "TEAM", teams)
+		db.DB.Where("audit_id IN (?)", audits).Delete(&models.ChangeLog{})
+		db.DB.Where("type = ? AND type_id IN (?)", "TEAM", teams).Delete(&models.Audit{})
+		db.DB.Unscoped().Where(&models.Team{Name: "copyleft-reviewers"}).Delete(&models.Team{})
+	})
+
+	t.Run("create team", func(t *testing.T) {
+		w := makeRequest("POST", "/teams", models.TeamCreate{
+			Name:             "copyleft-reviewers",
+			Description:      ptr("Legal reviewers of copyleft licenses"),
+			StewardOnlyEdits: true,
+		}, true)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		w = makeRequest("POST", "/teams", models.TeamCreate{Name: "copyleft-reviewers"}, true)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = makeRequest("GET", "/teams?limit=1", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.TeamResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, int64(1), res.Meta.Limit)
+	})
+
+	t.Run("assign stewards", func(t *testing.T) {
+		w := makeRequest("PUT", "/licenses/"+license.Id.String()+"/steward", models.StewardAssignment{Team: ptr("copyleft-reviewers")}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = makeRequest("PUT", "/obligations/"+obligation.Id.String()+"/steward", models.StewardAssignment{Team: ptr("copyleft-reviewers")}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = makeRequest("PUT", "/licenses/"+license.Id.String()+"/steward", models.StewardAssignment{Team: ptr("no-such-team")}, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("only stewards can edit", func(t *testing.T) {
+		w := makeRequest("PATCH", "/licenses/"+license.Id.String(), models.LicenseUpdateDTO{Notes: license.Notes}, true)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = makeRequest("DELETE", "/obligations/"+obligation.Id.String(), nil, true)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = makeRequest("POST", "/teams/copyleft-reviewers/members", models.TeamMemberInput{Username: "fossy_admin"}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = makeRequest("PATCH", "/licenses/"+license.Id.String(), models.LicenseUpdateDTO{Notes: license.Notes}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("my stewarded items", func(t *testing.T) {
+		w := makeRequest("GET", "/users/profile/stewarded", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.StewardedItemsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		if assert.Len(t, res.Data.Licenses, 1) {
+			assert.Equal(t, license.Id, res.Data.Licenses[0].Id)
+			assert.Equal(t, "copyleft-reviewers", res.Data.Licenses[0].Team)
+		}
+		assert.Len(t, res.Data.Obligations, 1)
+	})
+
+	t.Run("delete team", func(t *testing.T) {
+		w := makeRequest("DELETE", "/teams/copyleft-reviewers", nil, true)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		makeRequest("PUT", "/licenses/"+license.Id.String()+"/steward", models.StewardAssignment{}, true)
+		makeRequest("PUT", "/obligations/"+obligation.Id.String()+"/steward", models.StewardAssignment{}, true)
+		w = makeRequest("DELETE", "/teams/copyleft-reviewers", nil, true)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w = makeRequest("GET", "/teams/copyleft-reviewers", nil, true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
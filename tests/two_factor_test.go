@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactorAuthentication(t *testing.T) {
+	loginAs(t, "admin")
+
+	db.DB.Unscoped().Where("user_name = ?", "fossy-2fa").Delete(&models.User{})
+	user := models.UserCreate{
+		UserName:     ptr("fossy-2fa"),
+		UserPassword: ptr("abc123"),
+		UserLevel:    ptr("USER"),
+		DisplayName:  ptr("fossy-2fa"),
+		UserEmail:    ptr("fossy-2fa@gmail.com"),
+	}
+	w := makeRequest("POST", "/users", user, true)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	t.Cleanup(func() {
+		db.DB.Unscoped().Where("user_name = ?", "fossy-2fa").Delete(&models.User{})
+	})
+
+	w = makeRequest("POST", "/login", models.UserLogin{Username: "fossy-2fa", Userpassword: "abc123"}, false)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var tokens models.TokenResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &tokens); err != nil {
+		t.Fatalf("Error unmarshalling JSON: %v", err)
+	}
+	AuthToken = tokens.Data.AccessToken
+
+	var secret string
+	var recoveryCodes []string
+
+	t.Run("confirm without enrollment", func(t *testing.T) {
+		w := makeRequest("POST", "/users/2fa/verify", models.TwoFactorCode{Code: "123456"}, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("enroll", func(t *testing.T) {
+		w := makeRequest("POST", "/users/2fa/enroll", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res models.TwoFactorEnrollmentResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.NotEmpty(t, res.Data.Secret)
+		assert.Contains(t, res.Data.ProvisioningUri, "otpauth://totp/")
+		assert.Contains(t, res.Data.ProvisioningUri, "secret="+res.Data.Secret)
+		secret = res.Data.Secret
+	})
+
+	t.Run("confirm with wrong code", func(t *testing.T) {
+		w := makeRequest("POST", "/users/2fa/verify", models.TwoFactorCode{Code: "000000"}, true)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("confirm", func(t *testing.T) {
+		w := makeRequest("POST", "/users/2fa/verify", models.TwoFactorCode{Code: totpCodeNow(t, secret)}, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res models.TwoFactorRecoveryCodesResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Len(t, res.Data.RecoveryCodes, 10)
+		recoveryCodes = res.Data.RecoveryCodes
+	})
+
+	t.Run("enroll again", func(t *testing.T) {
+		w := makeRequest("POST", "/users/2fa/enroll", nil, true)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	var challenge models.TwoFactorChallengeResponse
+	t.Run("login requires second factor", func(t *testing.T) {
+		w := makeRequest("POST", "/login", models.UserLogin{Username: "fossy-2fa", Userpassword: "abc123"}, false)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+
+		if err := json.Unmarshal(w.Body.Bytes(), &challenge); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.NotEmpty(t, challenge.Data.MfaToken)
+		assert.False(t, challenge.Data.EnrollmentRequired)
+	})
+
+	t.Run("mfa token is no access token", func(t *testing.T) {
+		token := AuthToken
+		AuthToken = challenge.Data.MfaToken
+		w := makeRequest("GET", "/users/profile", nil, true)
+		AuthToken = token
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("second factor with wrong code", func(t *testing.T) {
+		login := models.TwoFactorLogin{MfaToken: challenge.Data.MfaToken, Code: "zzzz-zzzz"}
+		w := makeRequest("POST", "/login/2fa", login, false)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("second factor with recovery code", func(t *testing.T) {
+		login := models.TwoFactorLogin{MfaToken: challenge.Data.MfaToken, Code: recoveryCodes[0]}
+		w := makeRequest("POST", "/login/2fa", login, false)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res models.TwoFactorLoginResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.NotEmpty(t, res.Data.AccessToken)
+		assert.Empty(t, res.Data.RecoveryCodes)
+	})
+
+	t.Run("recovery code is single use", func(t *testing.T) {
+		login := models.TwoFactorLogin{MfaToken: challenge.Data.MfaToken, Code: recoveryCodes[0]}
+		w := makeRequest("POST", "/login/2fa", login, false)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		w := makeRequest("DELETE", "/users/2fa", models.TwoFactorCode{Code: recoveryCodes[1]}, true)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = makeRequest("POST", "/login", models.UserLogin{Username: "fossy-2fa", Userpassword: "abc123"}, false)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestTwoFactorPolicy(t *testing.T) {
+	loginAs(t, "superadmin")
+	t.Cleanup(func() {
+		db.DB.Model(&models.TwoFactorPolicy{}).Where("user_level = ?", "ADMIN").Update("required", false)
+		db.DB.Model(&models.User{}).Where("user_name = ?", "fossy_admin").
+			Updates(map[string]interface{}{"totp_secret": nil, "totp_enabled": false, "totp_last_step": nil})
+	})
+
+	t.Run("get policies", func(t *testing.T) {
+		w := makeRequest("GET", "/users/2fa/policies", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res models.TwoFactorPolicyResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Len(t, res.Data, 3)
+	})
+
+	t.Run("invalid user level", func(t *testing.T) {
+		policy := models.TwoFactorPolicy{UserLevel: "GUEST", Required: ptr(true)}
+		w := makeRequest("PATCH", "/users/2fa/policies", policy, true)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("require for admins", func(t *testing.T) {
+		policy := models.TwoFactorPolicy{UserLevel: "ADMIN", Required: ptr(true)}
+		w := makeRequest("PATCH", "/users/2fa/policies", policy, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	var challenge models.TwoFactorChallengeResponse
+	t.Run("admin login requires enrollment", func(t *testing.T) {
+		w := makeRequest("POST", "/login", models.UserLogin{Username: "fossy_admin", Userpassword: "fossy"}, false)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+
+		if err := json.Unmarshal(w.Body.Bytes(), &challenge); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.True(t, challenge.Data.EnrollmentRequired)
+	})
+
+	t.Run("enroll during login", func(t *testing.T) {
+		w := makeRequest("POST", "/login/2fa/enroll", models.TwoFactorLoginEnroll{MfaToken: challenge.Data.MfaToken}, false)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var enrollment models.TwoFactorEnrollmentResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &enrollment); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+
+		login := models.TwoFactorLogin{MfaToken: challenge.Data.MfaToken, Code: totpCodeNow(t, enrollment.Data.Secret)}
+		w = makeRequest("POST", "/login/2fa", login, false)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res models.TwoFactorLoginResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.NotEmpty(t, res.Data.AccessToken)
+		assert.Len(t, res.Data.RecoveryCodes, 10)
+	})
+
+	t.Run("reset by superadmin", func(t *testing.T) {
+		w := makeRequest("DELETE", "/users/fossy_admin/2fa", nil, true)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+// totpCodeNow computes the current RFC 6238 code for a base32 encoded secret.
+func totpCodeNow(t *testing.T, secret string) string {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("Invalid TOTP secret: %v", err)
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(time.Now().Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
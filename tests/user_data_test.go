@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 FOSSology contributors
+//
+// SPDX-License-Identifier: GPL-2.0-only
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossology/LicenseDb/pkg/db"
+	"github.com/fossology/LicenseDb/pkg/models"
+)
+
+func TestUserData(t *testing.T) {
+	loginAs(t, "admin")
+	adminToken := AuthToken
+	db.DB.Unscoped().Where(&models.User{UserName: ptr("gdpr-subject")}).Delete(&models.User{})
+
+	w := makeRequest("POST", "/users", models.UserCreate{
+		UserName:     ptr("gdpr-subject"),
+		UserPassword: ptr("fossy"),
+		UserLevel:    ptr("USER"),
+		DisplayName:  ptr("Gdpr Subject"),
+		UserEmail:    ptr("gdpr-subject@example.org"),
+	}, true)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.UserResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Error unmarshalling JSON: %v", err)
+	}
+	user := created.Data[0]
+
+	w = makeRequest("POST", "/teams", models.TeamCreate{Name: "gdpr-team", Members: []string{"gdpr-subject"}}, true)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	scan := models.Scan{Status: "COMPLETED", FileName: "gdpr-project.tar.gz", Size: 1024, CreatedBy: user.Id}
+	if err := db.DB.Create(&scan).Error; err != nil {
+		t.Fatalf("Failed to create scan: %v", err)
+	}
+	t.Cleanup(func() {
+		AuthToken = adminToken
+		db.DB.Where(&models.Scan{CreatedBy: user.Id}).Delete(&models.Scan{})
+		teams := db.DB.Unscoped().Model(&models.Team{}).Select("id").Where(&models.Team{Name: "gdpr-team"})
+		teamAudits := db.DB.Model(&models.Audit{}).Select("id").Where("type = ? AND type_id IN (?)", "TEAM", teams)
+		db.DB.Where("audit_id IN (?)", teamAudits).Delete(&models.ChangeLog{})
+		db.DB.Where("type = ? AND type_id IN (?)", "TEAM", teams).Delete(&models.Audit{})
+		db.DB.Unscoped().Where(&models.Team{Name: "gdpr-team"}).Delete(&models.Team{})
+		audits := db.DB.Model(&models.Audit{}).Select("id").
+			Where("user_id = ? OR (type = ? AND type_id = ?)", user.Id, "USER", user.Id)
+		db.DB.Where("audit_id IN (?)", audits).Delete(&models.ChangeLog{})
+		db.DB.Where("user_id = ? OR (type = ? AND type_id = ?)", user.Id, "USER", user.Id).Delete(&models.Audit{})
+		db.DB.Unscoped().Delete(&models.User{Id: user.Id})
+	})
+
+	t.Run("admin exports user data", func(t *testing.T) {
+		w := makeRequest("GET", "/users/gdpr-subject/data-export", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+
+		var export models.UserDataExport
+		if err := json.Unmarshal(w.Body.Bytes(), &export); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		assert.Equal(t, "gdpr-subject@example.org", *export.User.UserEmail)
+		assert.True(t, export.User.Active)
+		if assert.Len(t, export.Scans, 1) {
+			assert.Equal(t, scan.Id, export.Scans[0].Id)
+			assert.Equal(t, "gdpr-project.tar.gz", export.Scans[0].FileName)
+		}
+		if assert.Len(t, export.Teams, 1) {
+			assert.Equal(t, "gdpr-team", export.Teams[0].Name)
+		}
+		if assert.NotEmpty(t, export.Audits) {
+			assert.Equal(t, "SUBJECT", export.Audits[0].Relation)
+		}
+	})
+
+	t.Run("users export only their own data", func(t *testing.T) {
+		w := makeRequest("POST", "/login", models.UserLogin{Username: "gdpr-subject", Userpassword: "fossy"}, false)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.TokenResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshalling JSON: %v", err)
+		}
+		AuthToken = res.Data.AccessToken
+		defer func() { AuthToken = adminToken }()
+
+		assert.Equal(t, http.StatusOK, makeRequest("GET", "/users/gdpr-subject/data-export", nil, true).Code)
+		assert.Equal(t, http.StatusForbidden, makeRequest("GET", "/users/fossy_admin/data-export", nil, true).Code)
+		assert.Equal(t, http.StatusForbidden, makeRequest("POST", "/users/fossy_admin/anonymise", nil, true).Code)
+	})
+
+	t.Run("anonymise user", func(t *testing.T) {
+		w := makeRequest("POST", "/users/gdpr-subject/anonymise", nil, true)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var anonymised models.User
+		if err := db.DB.Where(&models.User{Id: user.Id}).First(&anonymised).Error; err != nil {
+			t.Fatalf("Failed to fetch user: %v", err)
+		}
+		assert.Equal(t, "anonymised-"+user.Id.String(), *anonymised.UserName)
+		assert.Nil(t, anonymised.UserEmail)
+		assert.Nil(t, anonymised.UserPassword)
+		assert.False(t, *anonymised.Active)
+		assert.NotNil(t, anonymised.AnonymisedAt)
+
+		var leaked int64
+		db.DB.Model(&models.ChangeLog{}).
+			Where("audit_id IN (?)", db.DB.Model(&models.Audit{}).Select("id").Where(&models.Audit{Type: "USER", TypeId: user.Id})).
+			Where("old_value IN ? OR updated_value IN ?", []string{"gdpr-subject", "Gdpr Subject", "gdpr-subject@example.org"},
+				[]string{"gdpr-subject", "Gdpr Subject", "gdpr-subject@example.org"}).
+			Count(&leaked)
+		assert.Zero(t, leaked)
+	})
+
+	t.Run("anonymised user can not log in", func(t *testing.T) {
+		w := makeRequest("POST", "/login", models.UserLogin{Username: "gdpr-subject", Userpassword: "fossy"}, false)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("anonymised user stays anonymised", func(t *testing.T) {
+		username := "anonymised-" + user.Id.String()
+		assert.Equal(t, http.StatusConflict, makeRequest("POST", "/users/"+username+"/anonymise", nil, true).Code)
+		assert.Equal(t, http.StatusConflict, makeRequest("PATCH", "/users/"+username, models.UserUpdate{Active: ptr(true)}, true).Code)
+	})
+
+	t.Run("can not anonymise yourself", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, makeRequest("POST", "/users/fossy_admin/anonymise", nil, true).Code)
+	})
+}